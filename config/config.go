@@ -14,9 +14,13 @@ type Config struct {
 	Database    DatabaseConfig
 	Supabase    SupabaseConfig
 	Upload      UploadConfig
+	Storage     StorageConfig
+	Download    DownloadConfig
+	Quota       QuotaConfig
 	Logging     LoggingConfig
 	Swagger     SwaggerConfig
 	Performance PerformanceConfig
+	Monitoring  MonitoringConfig
 }
 
 type AppConfig struct {
@@ -44,6 +48,7 @@ type UploadConfig struct {
 	MaxConcurrentProject int
 	MaxConcurrentModul   int
 	MaxQueueModulPerUser int
+	QueueBackend         string
 	IdleTimeout          int
 	CleanupInterval      int
 	PathProduction       string
@@ -52,6 +57,43 @@ type UploadConfig struct {
 	TempPathDevelopment  string
 	TusVersion           string
 	MaxResumeAttempts    int
+	// Limits checked when a project ZIP finishes uploading; 0 disables a limit.
+	ZipMaxUncompressedSize int64
+	ZipMaxCompressionRatio float64
+	ZipMaxEntries          int
+	// ProjectVersionRetention is how many versions of a project are kept; older
+	// versions and their files are pruned. 0 keeps every version.
+	ProjectVersionRetention int
+	// ModulRevisionRetention is how many revisions of a modul are kept; older
+	// revisions and the files only they refer to are pruned. 0 keeps every revision.
+	ModulRevisionRetention int
+}
+
+// StorageConfig selects where finalized project, modul and profile files are kept.
+type StorageConfig struct {
+	Backend        string // STORAGE_BACKEND, "local" (default) or "s3"
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3UsePathStyle bool // required by MinIO and most self-hosted S3 servers
+}
+
+// DownloadConfig limits batch downloads, controls the sweep of leftover temp zips and
+// signs the links stored files are served through.
+type DownloadConfig struct {
+	MaxArchiveSize      int64  // DOWNLOAD_MAX_ARCHIVE_SIZE, combined size of files in one zip, 0 disables the limit
+	TempCleanupInterval int    // DOWNLOAD_TEMP_CLEANUP_INTERVAL, default 3600 (seconds)
+	TempMaxAge          int    // DOWNLOAD_TEMP_MAX_AGE, default 3600 (seconds)
+	URLSecret           string // DOWNLOAD_URL_SECRET, HMAC key for /uploads links, shared by all instances
+	URLTTL              int    // DOWNLOAD_URL_TTL, default 900 (seconds)
+}
+
+// QuotaConfig is the storage quota for users whose role has no quota of its own.
+type QuotaConfig struct {
+	DefaultMaxBytes int64 // QUOTA_DEFAULT_MAX_BYTES, project and modul bytes per user, 0 means unlimited
+	DefaultMaxFiles int   // QUOTA_DEFAULT_MAX_FILES, project and modul files per user, 0 means unlimited
 }
 
 type LoggingConfig struct {
@@ -64,6 +106,10 @@ type SwaggerConfig struct {
 	Enabled bool
 }
 
+type MonitoringConfig struct {
+	PrometheusPath string // MONITORING_PROMETHEUS_PATH, relative to /api/v1/monitoring, default "/prometheus"
+}
+
 type SupabaseConfig struct {
 	URL        string
 	ServiceKey string
@@ -117,22 +163,48 @@ func LoadConfig() (*Config, error) {
 			Name:     getEnv("DB_NAME", "postgres"),
 		},
 		Upload: UploadConfig{
-			MaxSize:              getEnvAsInt64("UPLOAD_MAX_SIZE", 524288000),
-			MaxSizeProject:       getEnvAsInt64("UPLOAD_MAX_SIZE_PROJECT", 524288000),
-			MaxSizeModul:         getEnvAsInt64("UPLOAD_MAX_SIZE_MODUL", 52428800),
-			ChunkSize:            getEnvAsInt64("UPLOAD_CHUNK_SIZE", 1048576),
-			MaxConcurrent:        getEnvAsInt("UPLOAD_MAX_CONCURRENT", 1),
-			MaxConcurrentProject: getEnvAsInt("UPLOAD_MAX_CONCURRENT_PROJECT", 1),
-			MaxConcurrentModul:   getEnvAsInt("UPLOAD_MAX_CONCURRENT_MODUL", 1),
-			MaxQueueModulPerUser: getEnvAsInt("UPLOAD_MAX_QUEUE_MODUL_PER_USER", 5),
-			IdleTimeout:          getEnvAsInt("UPLOAD_IDLE_TIMEOUT", 600),
-			CleanupInterval:      getEnvAsInt("UPLOAD_CLEANUP_INTERVAL", 300),
-			PathProduction:       getEnv("UPLOAD_PATH_PRODUCTION", "/volume1/data-invento/"),
-			PathDevelopment:      getEnv("UPLOAD_PATH_DEVELOPMENT", "./uploads/"),
-			TempPathProduction:   getEnv("UPLOAD_TEMP_PATH_PRODUCTION", "/volume1/data-invento/temp/"),
-			TempPathDevelopment:  getEnv("UPLOAD_TEMP_PATH_DEVELOPMENT", "./uploads/temp/"),
-			TusVersion:           getEnv("TUS_RESUMABLE_VERSION", "1.0.0"),
-			MaxResumeAttempts:    getEnvAsInt("TUS_MAX_RESUME_ATTEMPTS", 10),
+			MaxSize:                 getEnvAsInt64("UPLOAD_MAX_SIZE", 524288000),
+			MaxSizeProject:          getEnvAsInt64("UPLOAD_MAX_SIZE_PROJECT", 524288000),
+			MaxSizeModul:            getEnvAsInt64("UPLOAD_MAX_SIZE_MODUL", 52428800),
+			ChunkSize:               getEnvAsInt64("UPLOAD_CHUNK_SIZE", 1048576),
+			MaxConcurrent:           getEnvAsInt("UPLOAD_MAX_CONCURRENT", 1),
+			MaxConcurrentProject:    getEnvAsInt("UPLOAD_MAX_CONCURRENT_PROJECT", 1),
+			MaxConcurrentModul:      getEnvAsInt("UPLOAD_MAX_CONCURRENT_MODUL", 1),
+			MaxQueueModulPerUser:    getEnvAsInt("UPLOAD_MAX_QUEUE_MODUL_PER_USER", 5),
+			QueueBackend:            getEnv("UPLOAD_QUEUE_BACKEND", "database"),
+			IdleTimeout:             getEnvAsInt("UPLOAD_IDLE_TIMEOUT", 600),
+			CleanupInterval:         getEnvAsInt("UPLOAD_CLEANUP_INTERVAL", 300),
+			PathProduction:          getEnv("UPLOAD_PATH_PRODUCTION", "/volume1/data-invento/"),
+			PathDevelopment:         getEnv("UPLOAD_PATH_DEVELOPMENT", "./uploads/"),
+			TempPathProduction:      getEnv("UPLOAD_TEMP_PATH_PRODUCTION", "/volume1/data-invento/temp/"),
+			TempPathDevelopment:     getEnv("UPLOAD_TEMP_PATH_DEVELOPMENT", "./uploads/temp/"),
+			TusVersion:              getEnv("TUS_RESUMABLE_VERSION", "1.0.0"),
+			MaxResumeAttempts:       getEnvAsInt("TUS_MAX_RESUME_ATTEMPTS", 10),
+			ZipMaxUncompressedSize:  getEnvAsInt64("UPLOAD_ZIP_MAX_UNCOMPRESSED_SIZE", 2147483648),
+			ZipMaxCompressionRatio:  getEnvAsFloat64("UPLOAD_ZIP_MAX_COMPRESSION_RATIO", 100),
+			ZipMaxEntries:           getEnvAsInt("UPLOAD_ZIP_MAX_ENTRIES", 20000),
+			ProjectVersionRetention: getEnvAsInt("UPLOAD_PROJECT_VERSION_RETENTION", 10),
+			ModulRevisionRetention:  getEnvAsInt("UPLOAD_MODUL_REVISION_RETENTION", 10),
+		},
+		Storage: StorageConfig{
+			Backend:        getEnv("STORAGE_BACKEND", "local"),
+			S3Endpoint:     getEnv("S3_ENDPOINT", ""),
+			S3Region:       getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:       getEnv("S3_BUCKET", ""),
+			S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+			S3UsePathStyle: getEnvAsBool("S3_USE_PATH_STYLE", true),
+		},
+		Download: DownloadConfig{
+			MaxArchiveSize:      getEnvAsInt64("DOWNLOAD_MAX_ARCHIVE_SIZE", 2147483648),
+			TempCleanupInterval: getEnvAsInt("DOWNLOAD_TEMP_CLEANUP_INTERVAL", 3600),
+			TempMaxAge:          getEnvAsInt("DOWNLOAD_TEMP_MAX_AGE", 3600),
+			URLSecret:           getEnv("DOWNLOAD_URL_SECRET", ""),
+			URLTTL:              getEnvAsInt("DOWNLOAD_URL_TTL", 900),
+		},
+		Quota: QuotaConfig{
+			DefaultMaxBytes: getEnvAsInt64("QUOTA_DEFAULT_MAX_BYTES", 5368709120),
+			DefaultMaxFiles: getEnvAsInt("QUOTA_DEFAULT_MAX_FILES", 50),
 		},
 		Logging: LoggingConfig{
 			Level:          getEnv("LOG_LEVEL", "INFO"),
@@ -163,6 +235,9 @@ func LoadConfig() (*Config, error) {
 			EnablePprof:            getEnvAsBool("ENABLE_PPROF", false),
 			MemoryWarningThreshold: getEnvAsFloat64("MEMORY_WARNING_THRESHOLD", 0.8),
 		},
+		Monitoring: MonitoringConfig{
+			PrometheusPath: getEnv("MONITORING_PROMETHEUS_PATH", "/prometheus"),
+		},
 	}
 
 	return config, nil
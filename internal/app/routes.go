@@ -16,15 +16,22 @@ import (
 
 // routeDeps holds all dependencies needed for route registration.
 type routeDeps struct {
-	authController      *http.AuthController
-	roleController      *http.RoleController
-	userController      *http.UserController
-	projectController   *http.ProjectController
-	modulController     *http.ModulController
-	tusController       *http.TusController
-	tusModulController  *http.TusModulController
-	statisticController *http.StatisticController
-	healthController    *http.HealthController
+	authController          *http.AuthController
+	roleController          *http.RoleController
+	userController          *http.UserController
+	projectController       *http.ProjectController
+	projectReviewController *http.ProjectReviewController
+	modulController         *http.ModulController
+	tusController           *http.TusController
+	tusModulController      *http.TusModulController
+	statisticController     *http.StatisticController
+	healthController        *http.HealthController
+	metricsController       *http.MetricsController
+	uploadEventsController  *http.UploadEventsController
+	fileController          *http.FileController
+	quotaController         *http.QuotaController
+	classController         *http.ClassController
+	assignmentController    *http.AssignmentController
 
 	supabaseAuthService domain.AuthService
 	userRepo            repo.UserRepository
@@ -44,12 +51,18 @@ func registerRoutes(app *fiber.App, deps routeDeps) {
 	registerUserRoutes(api, deps)
 	registerProjectRoutes(api, deps)
 	registerModulRoutes(api, deps)
+	registerClassRoutes(api, deps)
+	registerAssignmentRoutes(api, deps)
+	registerUploadEventRoutes(api, deps)
 	registerStatisticRoutes(api, deps)
 	registerMonitoringRoutes(api, deps)
 
 	// Top-level health check (no auth)
 	app.Get("/health", deps.healthController.BasicHealthCheck)
 
+	// Stored files, authorized by the link signature instead of a session
+	app.Get("/uploads/*", deps.fileController.ServeSignedFile)
+
 	registerSwaggerRoutes(app, deps)
 }
 
@@ -76,6 +89,8 @@ func registerRoleRoutes(api fiber.Router, deps routeDeps) {
 	role.Delete("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceRole, rbac.ActionDelete, deps.appLogger), deps.roleController.DeleteRole)
 	role.Get("/:id/users", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceRole, rbac.ActionRead, deps.appLogger), deps.userController.GetUsersForRole)
 	role.Post("/:id/users/bulk", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceRole, rbac.ActionUpdate, deps.appLogger), deps.userController.BulkAssignRole)
+	role.Get("/:id/quota", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceRole, rbac.ActionRead, deps.appLogger), deps.quotaController.GetRoleQuota)
+	role.Put("/:id/quota", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceRole, rbac.ActionUpdate, deps.appLogger), deps.quotaController.UpdateRoleQuota)
 }
 
 // registerUserRoutes registers /user and /profile routes with auth + RBAC middleware.
@@ -89,18 +104,31 @@ func registerUserRoutes(api fiber.Router, deps routeDeps) {
 	user.Delete("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceUser, rbac.ActionDelete, deps.appLogger), deps.userController.DeleteUser)
 	user.Get("/:id/files", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceUser, rbac.ActionRead, deps.appLogger), deps.userController.GetUserFiles)
 	user.Post("/:id/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceUser, rbac.ActionDownload, deps.appLogger), deps.userController.DownloadUserFiles)
+	user.Get("/:id/quota", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceUser, rbac.ActionRead, deps.appLogger), deps.quotaController.GetUserQuota)
+	user.Put("/:id/quota", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceUser, rbac.ActionUpdate, deps.appLogger), deps.quotaController.UpdateUserQuota)
 	user.Get("/permissions", deps.userController.GetUserPermissions)
 
 	profile := api.Group("/profile", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
 	profile.Get("/", deps.userController.GetProfile)
 	profile.Put("/", deps.userController.UpdateProfile)
+	profile.Get("/quota", deps.quotaController.GetMyQuota)
 }
 
 // registerProjectRoutes registers /project routes including TUS upload and update groups.
 func registerProjectRoutes(api fiber.Router, deps routeDeps) {
 	project := api.Group("/project", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
 	project.Get("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.GetList)
+	project.Get("/reviews", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProjectReview, rbac.ActionRead, deps.appLogger), deps.projectReviewController.GetQueue)
 	project.Get("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.GetByID)
+	project.Get("/:id/tree", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.GetTree)
+	project.Get("/:id/file", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.GetFile)
+	project.Get("/:id/versions", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.GetVersions)
+	project.Get("/:id/versions/:version/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.DownloadVersion)
+	project.Post("/:id/versions/:version/restore", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.projectController.RestoreVersion)
+	project.Put("/:id/reviewers", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.projectReviewController.AssignReviewers)
+	project.Get("/:id/review", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProjectReview, rbac.ActionRead, deps.appLogger), deps.projectReviewController.GetReview)
+	project.Get("/:id/review/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProjectReview, rbac.ActionRead, deps.appLogger), deps.projectReviewController.Download)
+	project.Post("/:id/review", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProjectReview, rbac.ActionReview, deps.appLogger), deps.projectReviewController.Review)
 	project.Patch("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.projectController.UpdateMetadata)
 	project.Post("/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.projectController.Download)
 	project.Delete("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionDelete, deps.appLogger), deps.projectController.Delete)
@@ -109,9 +137,10 @@ func registerProjectRoutes(api fiber.Router, deps routeDeps) {
 	tusUploadCheck := api.Group("/project/upload", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
 	tusUploadCheck.Get("/check-slot", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.tusController.CheckUploadSlot)
 	tusUploadCheck.Post("/reset-queue", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionCreate, deps.appLogger), deps.tusController.ResetUploadQueue)
+	tusUploadCheck.Get("/:id/events", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.uploadEventsController.StreamProjectUpload)
 
 	// TUS upload (with TUS protocol middleware)
-	tusUpload := api.Group("/project/upload", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper), middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject))
+	tusUpload := api.Group("/project/upload", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper), middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject, "concatenation"))
 	tusUpload.Post("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionCreate, deps.appLogger), deps.tusController.InitiateUpload)
 	tusUpload.Patch("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionCreate, deps.appLogger), deps.tusController.UploadChunk)
 	tusUpload.Head("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.tusController.GetUploadStatus)
@@ -120,24 +149,38 @@ func registerProjectRoutes(api fiber.Router, deps routeDeps) {
 
 	// Project update upload
 	projectUpdate := api.Group("/project/:id", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
-	projectUpdate.Post("/upload", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.tusController.InitiateProjectUpdateUpload)
-	projectUpdate.Patch("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.tusController.UploadProjectUpdateChunk)
-	projectUpdate.Head("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.tusController.GetProjectUpdateUploadStatus)
+	projectUpdate.Post("/upload", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject, "concatenation"), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.tusController.InitiateProjectUpdateUpload)
+	projectUpdate.Patch("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject, "concatenation"), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.tusController.UploadProjectUpdateChunk)
+	projectUpdate.Head("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject, "concatenation"), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.tusController.GetProjectUpdateUploadStatus)
 	projectUpdate.Get("/update/:upload_id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionRead, deps.appLogger), deps.tusController.GetProjectUpdateUploadInfo)
-	projectUpdate.Delete("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.tusController.CancelProjectUpdateUpload)
+	projectUpdate.Delete("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeProject, "concatenation"), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceProject, rbac.ActionUpdate, deps.appLogger), deps.tusController.CancelProjectUpdateUpload)
 }
 
 // registerModulRoutes registers /modul routes including TUS upload and update groups.
 func registerModulRoutes(api fiber.Router, deps routeDeps) {
 	modul := api.Group("/modul", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
 	modul.Get("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.modulController.GetList)
+	modul.Get("/library", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModulLibrary, rbac.ActionRead, deps.appLogger), deps.modulController.GetLibrary)
+	modul.Post("/library/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModulLibrary, rbac.ActionDownload, deps.appLogger), deps.modulController.Download)
+	modul.Get("/reviews", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionReview, deps.appLogger), deps.modulController.GetReviewQueue)
+	modul.Get("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.modulController.GetByID)
 	modul.Patch("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionUpdate, deps.appLogger), deps.modulController.UpdateMetadata)
+	modul.Put("/:id/visibility", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionUpdate, deps.appLogger), deps.modulController.UpdateVisibility)
+	modul.Post("/:id/submit", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionPublish, deps.appLogger), deps.modulController.Submit)
+	modul.Post("/:id/publish", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionPublish, deps.appLogger), deps.modulController.Publish)
+	modul.Post("/:id/withdraw", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionPublish, deps.appLogger), deps.modulController.Withdraw)
+	modul.Post("/:id/archive", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionArchive, deps.appLogger), deps.modulController.Archive)
+	modul.Post("/:id/review", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionReview, deps.appLogger), deps.modulController.Review)
+	modul.Get("/:id/revisions", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.modulController.GetRevisions)
+	modul.Get("/:id/revisions/:revision/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.modulController.DownloadRevision)
+	modul.Post("/:id/revisions/:revision/rollback", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionUpdate, deps.appLogger), deps.modulController.Rollback)
 	modul.Post("/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.modulController.Download)
 	modul.Delete("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionDelete, deps.appLogger), deps.modulController.Delete)
 
 	// TUS modul upload check (no TUS protocol middleware)
 	tusModulCheck := api.Group("/modul/upload", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
 	tusModulCheck.Get("/check-slot", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.tusModulController.CheckUploadSlot)
+	tusModulCheck.Get("/:upload_id/events", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionRead, deps.appLogger), deps.uploadEventsController.StreamModulUpload)
 
 	// TUS modul upload (with TUS protocol middleware)
 	tusModul := api.Group("/modul/upload", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper), middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeModul))
@@ -156,6 +199,43 @@ func registerModulRoutes(api fiber.Router, deps routeDeps) {
 	modulUpdate.Delete("/update/:upload_id", middleware.TusProtocolMiddleware(deps.cfg.Upload.TusVersion, deps.cfg.Upload.MaxSizeModul), middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceModul, rbac.ActionUpdate, deps.appLogger), deps.tusModulController.CancelModulUpdateUpload)
 }
 
+// registerClassRoutes registers /class routes. Managing a class and managing who is
+// enrolled in it are separate RBAC resources.
+func registerClassRoutes(api fiber.Router, deps routeDeps) {
+	class := api.Group("/class", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
+	class.Get("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClass, rbac.ActionRead, deps.appLogger), deps.classController.GetList)
+	class.Post("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClass, rbac.ActionCreate, deps.appLogger), deps.classController.Create)
+	class.Post("/join", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClassEnrollment, rbac.ActionJoin, deps.appLogger), deps.classController.Join)
+	class.Get("/import/template", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClassEnrollment, rbac.ActionCreate, deps.appLogger), deps.classController.GetImportTemplate)
+	class.Get("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClass, rbac.ActionRead, deps.appLogger), deps.classController.GetByID)
+	class.Put("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClass, rbac.ActionUpdate, deps.appLogger), deps.classController.Update)
+	class.Delete("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClass, rbac.ActionDelete, deps.appLogger), deps.classController.Delete)
+	class.Post("/:id/join-code", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClass, rbac.ActionUpdate, deps.appLogger), deps.classController.RegenerateJoinCode)
+	class.Get("/:id/members", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClassEnrollment, rbac.ActionRead, deps.appLogger), deps.classController.GetMembers)
+	class.Post("/:id/members", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClassEnrollment, rbac.ActionCreate, deps.appLogger), deps.classController.AddMembers)
+	class.Post("/:id/members/import", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClassEnrollment, rbac.ActionCreate, deps.appLogger), deps.classController.ImportMembers)
+	class.Delete("/:id/members/:user_id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceClassEnrollment, rbac.ActionDelete, deps.appLogger), deps.classController.RemoveMember)
+}
+
+// registerAssignmentRoutes registers /assignment routes. Mahasiswa submit through the
+// project upload endpoints, so only the submissions of others are guarded here.
+func registerAssignmentRoutes(api fiber.Router, deps routeDeps) {
+	assignment := api.Group("/assignment", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
+	assignment.Get("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignment, rbac.ActionRead, deps.appLogger), deps.assignmentController.GetList)
+	assignment.Post("/", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignment, rbac.ActionCreate, deps.appLogger), deps.assignmentController.Create)
+	assignment.Get("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignment, rbac.ActionRead, deps.appLogger), deps.assignmentController.GetByID)
+	assignment.Put("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignment, rbac.ActionUpdate, deps.appLogger), deps.assignmentController.Update)
+	assignment.Delete("/:id", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignment, rbac.ActionDelete, deps.appLogger), deps.assignmentController.Delete)
+	assignment.Get("/:id/submissions", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignmentSubmission, rbac.ActionRead, deps.appLogger), deps.assignmentController.GetSubmissions)
+	assignment.Get("/:id/submissions/download", middleware.RBACMiddleware(deps.casbinEnforcer, rbac.ResourceAssignmentSubmission, rbac.ActionDownload, deps.appLogger), deps.assignmentController.DownloadSubmissions)
+}
+
+// registerUploadEventRoutes registers the /upload progress stream covering all of a user's uploads.
+func registerUploadEventRoutes(api fiber.Router, deps routeDeps) {
+	uploadEvents := api.Group("/upload", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
+	uploadEvents.Get("/events", deps.uploadEventsController.StreamUserUploads)
+}
+
 // registerStatisticRoutes registers /statistic routes with auth middleware.
 func registerStatisticRoutes(api fiber.Router, deps routeDeps) {
 	statistic := api.Group("/statistic", middleware.SupabaseAuthMiddleware(deps.supabaseAuthService, deps.userRepo, deps.cookieHelper))
@@ -168,6 +248,7 @@ func registerMonitoringRoutes(api fiber.Router, deps routeDeps) {
 	monitoring.Get("/health", deps.healthController.ComprehensiveHealthCheck)
 	monitoring.Get("/metrics", deps.healthController.GetSystemMetrics)
 	monitoring.Get("/status", deps.healthController.GetApplicationStatus)
+	monitoring.Get(deps.cfg.Monitoring.PrometheusPath, deps.metricsController.PrometheusMetrics)
 }
 
 // registerSwaggerRoutes enables the Swagger UI endpoint.
@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"invento-service/config"
@@ -18,6 +20,7 @@ import (
 	"invento-service/internal/usecase/repo"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/metrics"
 	"strings"
@@ -26,6 +29,7 @@ import (
 	_ "invento-service/docs"
 
 	apperrors "invento-service/internal/errors"
+	appmetrics "invento-service/internal/metrics"
 
 	supabaseAuth "invento-service/internal/supabase"
 
@@ -122,8 +126,10 @@ func NewServer(cfg *config.Config, db *gorm.DB) (*fiber.App, error) {
 		appLogger.Info().Msg("pprof profiling enabled at /debug/pprof/*")
 	}
 
-	// Apply middleware in order: RequestID -> Logger -> Recover -> CORS
+	// Apply middleware in order: RequestID -> Metrics -> Logger -> Recover -> CORS
+	httpMetrics := appmetrics.NewHTTPCollector()
 	app.Use(middleware.RequestID())
+	app.Use(httpMetrics.Middleware())
 	app.Use(fiberzerolog.New(fiberzerolog.Config{
 		Logger:   &appLogger,
 		SkipURIs: []string{"/health", "/uploads"},
@@ -154,7 +160,29 @@ func NewServer(cfg *config.Config, db *gorm.DB) (*fiber.App, error) {
 
 	pathResolver := storage.NewPathResolver(cfg)
 	cookieHelper := httputil.NewCookieHelper(cfg)
-	app.Static("/uploads", pathResolver.GetBasePath())
+
+	storageBackend, err := storage.NewBackend(cfg, pathResolver)
+	if err != nil {
+		return nil, fmt.Errorf("storage backend init: %w", err)
+	}
+	storage.SetDefaultBackend(storageBackend)
+	urlSecret, err := downloadURLSecret(cfg, appLogger)
+	if err != nil {
+		return nil, fmt.Errorf("download URL secret: %w", err)
+	}
+	urlSigner := storage.NewURLSigner(urlSecret, time.Duration(cfg.Download.URLTTL)*time.Second)
+	pathResolver.SetURLSigner(urlSigner)
+	fileController := http.NewFileController(urlSigner, storageBackend, pathResolver.GetBasePath(), appLogger)
+	appLogger.Info().Str("backend", cfg.Storage.Backend).Msg("storage backend ready")
+
+	storage.SetMaxArchiveSize(cfg.Download.MaxArchiveSize)
+	tempCleanup := storage.NewTempCleanup(
+		[]string{filepath.Join(pathResolver.GetBasePath(), "temp"), "./uploads/temp"},
+		cfg.Download.TempCleanupInterval,
+		cfg.Download.TempMaxAge,
+		appLogger,
+	)
+	tempCleanup.Start()
 
 	userRepo := repo.NewUserRepository(db)
 	roleRepo := repo.NewRoleRepository(db)
@@ -162,8 +190,11 @@ func NewServer(cfg *config.Config, db *gorm.DB) (*fiber.App, error) {
 	rolePermissionRepo := repo.NewRolePermissionRepository(db)
 	projectRepo := repo.NewProjectRepository(db)
 	modulRepo := repo.NewModulRepository(db, appLogger)
+	classRepo := repo.NewClassRepository(db, appLogger)
+	assignmentRepo := repo.NewAssignmentRepository(db, appLogger)
 	tusUploadRepo := repo.NewTusUploadRepository(db)
 	tusModulUploadRepo := repo.NewTusModulUploadRepository(db)
+	quotaRepo := repo.NewQuotaRepository(db)
 
 	// Initialize Supabase client
 	supabaseClient, err := supabase.NewClient(cfg.Supabase.URL, cfg.Supabase.ServiceKey, nil)
@@ -182,19 +213,26 @@ func NewServer(cfg *config.Config, db *gorm.DB) (*fiber.App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("casbin enforcer init: %w", err)
 	}
+	casbinMetrics := appmetrics.NewCasbinMetrics()
+	casbinEnforcer.SetMetrics(casbinMetrics)
+
 	tusProjectStore := upload.NewTusStore(pathResolver, cfg.Upload.MaxSizeProject)
 	tusModulStore := upload.NewTusStore(pathResolver, cfg.Upload.MaxSizeModul)
-	tusQueue := upload.NewTusQueue(cfg.Upload.MaxConcurrentProject)
-	tusModulQueue := upload.NewTusQueue(cfg.Upload.MaxQueueModulPerUser)
+	tusQueue := newUploadQueue(cfg, db, upload.TusQueueTableProject, cfg.Upload.MaxConcurrentProject, appLogger)
+	tusModulQueue := newUploadQueue(cfg, db, upload.TusQueueTableModul, cfg.Upload.MaxQueueModulPerUser, appLogger)
 	fileManager := storage.NewFileManager(cfg)
 	tusProjectManager := upload.NewTusManager(tusProjectStore, tusQueue, fileManager, cfg, appLogger)
 	tusModulManager := upload.NewTusManager(tusModulStore, tusModulQueue, fileManager, cfg, appLogger)
-
-	if activeIDs, activeErr := tusUploadRepo.GetActiveUploadIDs(context.Background()); activeErr == nil && len(activeIDs) > 0 {
-		tusQueue.LoadFromDB(activeIDs)
-	}
+	tusMetrics := appmetrics.NewTusMetrics()
+	tusProjectManager.SetMetrics(tusMetrics.Project)
+	tusModulManager.SetMetrics(tusMetrics.Modul)
+	progressBus := upload.NewProgressBus()
+	tusProjectManager.SetProgressBus(progressBus)
+	tusModulManager.SetProgressBus(progressBus)
+
+	restoreProjectQueue(cfg, tusUploadRepo, tusQueue, appLogger)
 	if activeIDs, activeErr := tusModulUploadRepo.GetActiveUploadIDs(context.Background()); activeErr == nil && len(activeIDs) > 0 {
-		tusModulQueue.LoadFromDB(activeIDs)
+		tusModulQueue.LoadFromDB(activeIDs, nil)
 	}
 
 	authUsecase, err := usecase.NewAuthUsecase(userRepo, roleRepo, supabaseClient, supabaseServiceKey, cfg, appLogger)
@@ -212,45 +250,131 @@ func NewServer(cfg *config.Config, db *gorm.DB) (*fiber.App, error) {
 
 	projectUsecase := usecase.NewProjectUsecase(projectRepo, fileManager)
 	projectController := http.NewProjectController(projectUsecase, cfg.Supabase.URL, casbinEnforcer)
+	projectReviewUsecase := usecase.NewProjectReviewUsecase(projectRepo, userRepo)
+	projectReviewController := http.NewProjectReviewController(projectReviewUsecase, baseCtrl)
+
+	quotaUsecase := usecase.NewQuotaUsecase(quotaRepo, userRepo, roleRepo, cfg)
+	quotaController := http.NewQuotaController(quotaUsecase, baseCtrl)
 
-	tusUploadUsecase := usecase.NewTusUploadUsecase(tusUploadRepo, projectRepo, projectUsecase, tusProjectManager, fileManager, cfg)
+	assignmentUsecase := usecase.NewAssignmentUsecase(assignmentRepo, classRepo)
+
+	tusUploadUsecase := usecase.NewTusUploadUsecase(tusUploadRepo, projectRepo, projectUsecase, tusProjectManager, fileManager, quotaUsecase, assignmentUsecase, cfg)
 	tusController := http.NewTusController(tusUploadUsecase, cfg, baseCtrl)
 
-	modulUsecase := usecase.NewModulUsecase(modulRepo)
-	tusModulUsecase := usecase.NewTusModulUsecase(tusModulUploadRepo, modulRepo, tusModulManager, fileManager, cfg)
+	modulUsecase := usecase.NewModulUsecase(modulRepo, cfg.Upload.ModulRevisionRetention)
+	tusModulUsecase := usecase.NewTusModulUsecase(tusModulUploadRepo, modulRepo, tusModulManager, fileManager, quotaUsecase, cfg)
 	modulController := http.NewModulController(modulUsecase, cfg, baseCtrl)
 	tusModulController := http.NewTusModulController(tusModulUsecase, cfg, baseCtrl)
+	uploadEventsController := http.NewUploadEventsController(progressBus, tusUploadUsecase, tusModulUsecase, cfg, baseCtrl)
+
+	excelHelper := helper.NewExcelHelper()
+	classUsecase := usecase.NewClassUsecase(classRepo, userRepo, excelHelper)
+	classController := http.NewClassController(classUsecase, excelHelper, baseCtrl)
+	assignmentController := http.NewAssignmentController(assignmentUsecase, baseCtrl)
 
 	tusCleanup := upload.NewTusCleanup(tusUploadRepo, tusModulUploadRepo, tusProjectStore, tusModulStore, cfg.Upload.CleanupInterval, cfg.Upload.IdleTimeout, appLogger)
+	tusCleanup.SetMetrics(tusMetrics)
+	tusCleanup.SetProjectQueue(tusQueue)
+	tusCleanup.SetProgressBus(progressBus)
 	tusCleanup.Start()
 
 	statisticUsecase := usecase.NewStatisticUsecase(userRepo, projectRepo, modulRepo, roleRepo, casbinEnforcer, db)
 	statisticController := http.NewStatisticController(statisticUsecase)
 
-	healthUsecase := usecase.NewHealthUsecase(db, cfg)
+	healthUsecase := usecase.NewHealthUsecase(db, cfg, httpMetrics)
 	healthController := http.NewHealthController(healthUsecase)
 
+	exporterSources := appmetrics.ExporterSources{
+		HTTP:   httpMetrics,
+		Tus:    tusMetrics,
+		Casbin: casbinMetrics,
+		Queues: []appmetrics.NamedQueue{
+			{Name: appmetrics.TusKindProject, Queue: tusQueue},
+			{Name: appmetrics.TusKindModul, Queue: tusModulQueue},
+		},
+	}
+	if sqlDB, dbErr := db.DB(); dbErr == nil {
+		exporterSources.DB = sqlDB
+	}
+	metricsController := http.NewMetricsController(appmetrics.NewExporter(exporterSources))
+
 	registerRoutes(app, routeDeps{
-		authController:      authController,
-		roleController:      roleController,
-		userController:      userController,
-		projectController:   projectController,
-		modulController:     modulController,
-		tusController:       tusController,
-		tusModulController:  tusModulController,
-		statisticController: statisticController,
-		healthController:    healthController,
-		supabaseAuthService: supabaseAuthService,
-		userRepo:            userRepo,
-		cookieHelper:        cookieHelper,
-		casbinEnforcer:      casbinEnforcer,
-		cfg:                 cfg,
-		appLogger:           appLogger,
+		authController:          authController,
+		roleController:          roleController,
+		userController:          userController,
+		projectController:       projectController,
+		projectReviewController: projectReviewController,
+		modulController:         modulController,
+		tusController:           tusController,
+		tusModulController:      tusModulController,
+		statisticController:     statisticController,
+		healthController:        healthController,
+		metricsController:       metricsController,
+		uploadEventsController:  uploadEventsController,
+		fileController:          fileController,
+		quotaController:         quotaController,
+		classController:         classController,
+		assignmentController:    assignmentController,
+		supabaseAuthService:     supabaseAuthService,
+		userRepo:                userRepo,
+		cookieHelper:            cookieHelper,
+		casbinEnforcer:          casbinEnforcer,
+		cfg:                     cfg,
+		appLogger:               appLogger,
 	})
 
 	return app, nil
 }
 
+// newUploadQueue returns the upload queue selected by UPLOAD_QUEUE_BACKEND. The database
+// backend keeps slot accounting in the upload table so it holds across instances.
+func newUploadQueue(cfg *config.Config, db *gorm.DB, table string, maxConcurrent int, appLogger zerolog.Logger) upload.UploadQueue {
+	if cfg.Upload.QueueBackend == "memory" {
+		return upload.NewTusQueue(maxConcurrent)
+	}
+	return upload.NewDBTusQueue(db, table, maxConcurrent, time.Duration(cfg.Upload.IdleTimeout)*time.Second, appLogger)
+}
+
+// restoreProjectQueue rebuilds the project upload queue from the stored upload states.
+// Queued uploads that find a free slot are marked active in the database too, otherwise
+// their chunks would be refused while they hold the slot.
+func restoreProjectQueue(cfg *config.Config, tusUploadRepo repo.TusUploadRepository, queue upload.UploadQueue, appLogger zerolog.Logger) {
+	ctx := context.Background()
+	activeIDs, err := tusUploadRepo.GetActiveUploadIDs(ctx)
+	if err != nil {
+		appLogger.Error().Err(err).Msg("failed to load active project uploads")
+		return
+	}
+	queuedIDs, err := tusUploadRepo.GetQueuedUploadIDs(ctx)
+	if err != nil {
+		appLogger.Error().Err(err).Msg("failed to load queued project uploads")
+		return
+	}
+
+	expiresAt := time.Now().Add(time.Duration(cfg.Upload.IdleTimeout) * time.Second)
+	for _, uploadID := range queue.LoadFromDB(activeIDs, queuedIDs) {
+		if err := tusUploadRepo.Promote(ctx, uploadID, expiresAt); err != nil {
+			appLogger.Error().Err(err).Str("upload_id", uploadID).Msg("failed to promote queued upload")
+		}
+	}
+}
+
+// downloadURLSecret returns DOWNLOAD_URL_SECRET, or a random key for this process when
+// it is unset. Links signed with a random key stop working after a restart and are not
+// accepted by other instances.
+func downloadURLSecret(cfg *config.Config, appLogger zerolog.Logger) (string, error) {
+	if cfg.Download.URLSecret != "" {
+		return cfg.Download.URLSecret, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generate random key: %w", err)
+	}
+	appLogger.Warn().Msg("DOWNLOAD_URL_SECRET is not set, signed download links will not survive a restart")
+	return hex.EncodeToString(key), nil
+}
+
 // startMemoryMonitor starts a background goroutine that periodically checks heap
 // memory usage and logs a warning when it exceeds the threshold percentage of GOMEMLIMIT.
 // Uses runtime/metrics instead of runtime.ReadMemStats to avoid stop-the-world pauses.
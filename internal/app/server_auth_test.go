@@ -151,9 +151,8 @@ func TestServer_ErrorHandler_UploadsPath(t *testing.T) {
 	resp, err := appInstance.Test(req)
 
 	require.NoError(t, err)
-	// Uploads paths should return the raw error, not our custom error handler
-	// This typically returns 404 or 500 depending on Fiber's behavior
-	assert.True(t, resp.StatusCode == fiber.StatusNotFound || resp.StatusCode == fiber.StatusInternalServerError)
+	// Uploads paths are only served through signed links
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
 }
 
 // TestServer_ErrorHandler_TusProtocol tests TUS protocol error handling
@@ -132,6 +132,9 @@ func createTestConfig() *config.Config {
 			EnablePprof:            false,
 			MemoryWarningThreshold: 0.8,
 		},
+		Monitoring: config.MonitoringConfig{
+			PrometheusPath: "/prometheus",
+		},
 	}
 }
 
@@ -106,7 +106,7 @@ func TestServer_Middleware_CORS_Production(t *testing.T) {
 		"Access-Control-Allow-Credentials header should be present")
 }
 
-// TestServer_StaticFileUploads tests that static file serving for uploads is configured
+// TestServer_StaticFileUploads tests that /uploads no longer serves files without a signed link
 func TestServer_StaticFileUploads(t *testing.T) {
 	t.Skip("requires network access to Supabase JWKS endpoint")
 	cfg := createTestConfig()
@@ -121,9 +121,7 @@ func TestServer_StaticFileUploads(t *testing.T) {
 	resp, err := appInstance.Test(req)
 
 	require.NoError(t, err)
-	// Should not be a routing error - file may not exist but route is set up
-	assert.True(t, resp.StatusCode == fiber.StatusNotFound || resp.StatusCode == fiber.StatusInternalServerError,
-		"Uploads route should be configured")
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode, "Unsigned uploads link should be rejected")
 }
 
 // TestServer_LoggerConfiguration tests logger configuration based on environment
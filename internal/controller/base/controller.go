@@ -1,15 +1,18 @@
 package base
 
 import (
+	"bufio"
 	"errors"
 	"invento-service/internal/httputil"
 	"invento-service/internal/middleware"
 	"invento-service/internal/rbac"
+	"invento-service/internal/storage"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	zlog "github.com/rs/zerolog/log"
 )
 
 // BaseController provides common functionality for all HTTP controllers.
@@ -283,6 +286,33 @@ func (bc *BaseController) SendInternalError(c *fiber.Ctx) error {
 	return httputil.SendInternalServerErrorResponse(c)
 }
 
+// SendDownload sends a download as an attachment. A single file goes through
+// httputil.SendStoredFile, so it can be resumed with Range requests; an archive is
+// zipped directly into the response body, so nothing is written to disk. Errors while
+// an archive is streaming can only be logged, because the status line has already
+// been sent by then.
+func (bc *BaseController) SendDownload(c *fiber.Ctx, download *storage.Download) error {
+	if download.IsArchive() {
+		ctx := c.UserContext()
+		c.Type("zip")
+		c.Set(fiber.HeaderContentDisposition, httputil.ContentDisposition(download.FileName))
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := download.WriteArchive(ctx, w); err != nil {
+				zlog.Error().Err(err).Str("file", download.FileName).Msg("failed to stream download archive")
+			}
+		})
+		return nil
+	}
+
+	if err := httputil.SendStoredFile(c, download); err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return bc.SendNotFound(c, "File tidak ditemukan")
+		}
+		return bc.SendInternalError(c)
+	}
+	return nil
+}
+
 // ValidateStruct validates a struct using the validator.
 // Returns true if valid, false if validation fails (response already sent).
 //
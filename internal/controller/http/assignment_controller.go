@@ -0,0 +1,298 @@
+package http
+
+import (
+	"errors"
+	"invento-service/internal/controller/base"
+	"invento-service/internal/dto"
+	"invento-service/internal/httputil"
+	"invento-service/internal/usecase"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AssignmentController struct {
+	*base.BaseController
+	assignmentUsecase usecase.AssignmentUsecase
+}
+
+func NewAssignmentController(assignmentUsecase usecase.AssignmentUsecase, baseCtrl *base.BaseController) *AssignmentController {
+	return &AssignmentController{
+		BaseController:    baseCtrl,
+		assignmentUsecase: assignmentUsecase,
+	}
+}
+
+// GetList handles GET /api/v1/assignment
+//
+// @Summary Get list of assignments
+// @Description Retrieve the assignments of the classes the user teaches or is enrolled in, with the user's own submission status
+// @Tags Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param class_id query int false "Filter by class"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.AssignmentListData} "Assignments retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment [get]
+func (ctrl *AssignmentController) GetList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var params dto.AssignmentListQueryParams
+	if err := c.QueryParser(&params); err != nil {
+		return ctrl.SendBadRequest(c, "Parameter query tidak valid")
+	}
+
+	result, err := ctrl.assignmentUsecase.GetList(ctx, userID, params)
+	if err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Daftar tugas berhasil diambil")
+}
+
+// Create handles POST /api/v1/assignment
+//
+// @Summary Create an assignment
+// @Description Set an assignment for a class the user teaches. Mahasiswa submit it by uploading a project with assignment_id in Upload-Metadata.
+// @Tags Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateAssignmentRequest true "Assignment details"
+// @Success 201 {object} dto.SuccessResponse{data=dto.AssignmentResponse} "Assignment created successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or dates"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment [post]
+func (ctrl *AssignmentController) Create(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var req dto.CreateAssignmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.assignmentUsecase.Create(ctx, userID, req)
+	if err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendCreated(c, result, "Tugas berhasil dibuat")
+}
+
+// GetByID handles GET /api/v1/assignment/:id
+//
+// @Summary Get assignment detail
+// @Description Retrieve an assignment of one of the user's classes. Mahasiswa also get their own submission.
+// @Tags Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AssignmentResponse} "Assignment retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid assignment ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a member of the class"
+// @Failure 404 {object} dto.ErrorResponse "Assignment not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment/{id} [get]
+func (ctrl *AssignmentController) GetByID(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	assignmentID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	result, err := ctrl.assignmentUsecase.GetByID(ctx, assignmentID, userID)
+	if err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Detail tugas berhasil diambil")
+}
+
+// Update handles PUT /api/v1/assignment/:id
+//
+// @Summary Update an assignment
+// @Description Change an assignment's details and dates. Submissions already made keep their on time or late status.
+// @Tags Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Assignment ID"
+// @Param request body dto.UpdateAssignmentRequest true "Assignment details"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AssignmentResponse} "Assignment updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or dates"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of the class"
+// @Failure 404 {object} dto.ErrorResponse "Assignment not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment/{id} [put]
+func (ctrl *AssignmentController) Update(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	assignmentID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	var req dto.UpdateAssignmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.assignmentUsecase.Update(ctx, assignmentID, userID, req)
+	if err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Tugas berhasil diperbarui")
+}
+
+// Delete handles DELETE /api/v1/assignment/:id
+//
+// @Summary Delete an assignment
+// @Description Delete an assignment and its submissions. The submitted projects stay with their mahasiswa.
+// @Tags Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} dto.SuccessResponse "Assignment deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid assignment ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of the class"
+// @Failure 404 {object} dto.ErrorResponse "Assignment not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment/{id} [delete]
+func (ctrl *AssignmentController) Delete(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	assignmentID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	if err := ctrl.assignmentUsecase.Delete(ctx, assignmentID, userID); err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, nil, "Tugas berhasil dihapus")
+}
+
+// GetSubmissions handles GET /api/v1/assignment/:id/submissions
+//
+// @Summary Get assignment submissions
+// @Description List the submissions of an assignment with how many mahasiswa handed it in on time, late or not at all
+// @Tags Assignment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AssignmentSubmissionListData} "Submissions retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid assignment ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of the class"
+// @Failure 404 {object} dto.ErrorResponse "Assignment not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment/{id}/submissions [get]
+func (ctrl *AssignmentController) GetSubmissions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	assignmentID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	result, err := ctrl.assignmentUsecase.GetSubmissions(ctx, assignmentID, userID)
+	if err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Daftar pengumpulan tugas berhasil diambil")
+}
+
+// DownloadSubmissions handles GET /api/v1/assignment/:id/submissions/download
+//
+// @Summary Download all submissions as ZIP
+// @Description Stream the submitted projects as one ZIP with a folder per mahasiswa and a manifest.json listing submissions whose project was deleted
+// @Tags Assignment
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path int true "Assignment ID"
+// @Success 200 {file} binary "ZIP file containing the submitted projects"
+// @Failure 400 {object} dto.ErrorResponse "Invalid assignment ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of the class"
+// @Failure 404 {object} dto.ErrorResponse "Assignment not found or nothing submitted"
+// @Failure 413 {object} dto.ErrorResponse "Submissions exceed the archive size limit"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /assignment/{id}/submissions/download [get]
+func (ctrl *AssignmentController) DownloadSubmissions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	assignmentID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	download, err := ctrl.assignmentUsecase.DownloadSubmissions(ctx, assignmentID, userID)
+	if err != nil {
+		return ctrl.handleAssignmentError(c, err)
+	}
+
+	return ctrl.SendDownload(c, download)
+}
+
+func (ctrl *AssignmentController) handleAssignmentError(c *fiber.Ctx, err error) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return httputil.SendAppError(c, appErr)
+	}
+	return ctrl.SendInternalError(c)
+}
@@ -0,0 +1,151 @@
+package http_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAssignmentUsecase is a mock for AssignmentUsecase
+type MockAssignmentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentUsecase) Create(ctx context.Context, userID string, req dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentResponse), args.Error(1)
+}
+
+func (m *MockAssignmentUsecase) GetList(ctx context.Context, userID string, params dto.AssignmentListQueryParams) (*dto.AssignmentListData, error) {
+	args := m.Called(userID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentListData), args.Error(1)
+}
+
+func (m *MockAssignmentUsecase) GetByID(ctx context.Context, id uint, userID string) (*dto.AssignmentResponse, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentResponse), args.Error(1)
+}
+
+func (m *MockAssignmentUsecase) Update(ctx context.Context, id uint, userID string, req dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	args := m.Called(id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentResponse), args.Error(1)
+}
+
+func (m *MockAssignmentUsecase) Delete(ctx context.Context, id uint, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentUsecase) GetSubmissions(ctx context.Context, id uint, userID string) (*dto.AssignmentSubmissionListData, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AssignmentSubmissionListData), args.Error(1)
+}
+
+func (m *MockAssignmentUsecase) DownloadSubmissions(ctx context.Context, id uint, userID string) (*storage.Download, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Download), args.Error(1)
+}
+
+func (m *MockAssignmentUsecase) CheckSubmission(ctx context.Context, assignmentID uint, userID string, fileSize int64) error {
+	args := m.Called(assignmentID, userID, fileSize)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentUsecase) RecordSubmission(ctx context.Context, assignmentID uint, userID string, version *domain.ProjectVersion) error {
+	args := m.Called(assignmentID, userID, version)
+	return args.Error(0)
+}
+
+func TestAssignmentController_Create(t *testing.T) {
+	t.Parallel()
+	mockAssignmentUC := new(MockAssignmentUsecase)
+	app := newAssignmentApp(mockAssignmentUC)
+
+	dueAt := time.Date(2025, 10, 1, 23, 59, 0, 0, time.UTC)
+	req := dto.CreateAssignmentRequest{ClassID: 1, Judul: "Tugas ERD", DueAt: dueAt, LateWindowMinutes: 60}
+	mockAssignmentUC.On("Create", "user-1", req).Return(&dto.AssignmentResponse{ID: 1, Judul: "Tugas ERD"}, nil)
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/assignment",
+		`{"class_id":1,"judul":"Tugas ERD","due_at":"2025-10-01T23:59:00Z","late_window_minutes":60}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	mockAssignmentUC.AssertExpectations(t)
+
+	resp, err = app.Test(jsonRequest("POST", "/api/v1/assignment", `{"class_id":1,"judul":"Tugas ERD"}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, "due_at is required")
+	mockAssignmentUC.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestAssignmentController_GetSubmissions_Forbidden(t *testing.T) {
+	t.Parallel()
+	mockAssignmentUC := new(MockAssignmentUsecase)
+	app := newAssignmentApp(mockAssignmentUC)
+
+	mockAssignmentUC.On("GetSubmissions", uint(1), "user-1").
+		Return(nil, apperrors.NewForbiddenError("Hanya dosen kelas yang dapat mengelola tugas"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/assignment/1/submissions", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestAssignmentController_DownloadSubmissions(t *testing.T) {
+	t.Parallel()
+	mockAssignmentUC := new(MockAssignmentUsecase)
+	app := newAssignmentApp(mockAssignmentUC)
+
+	backend := storage.NewMemoryBackend()
+	key := "/data/projects/mhs-1/abc/project.zip"
+	require.NoError(t, backend.Put(context.Background(), key, strings.NewReader("erd"), 3))
+	download, err := storage.NewArchiveDownload(context.Background(), backend, "Tugas ERD.zip", []storage.ArchiveEntry{
+		{Kind: storage.ArchiveKindProject, ID: "3", OwnerID: "mhs-1", OwnerName: "Ani", Title: "Ani - ERD", FileName: "project.zip", Key: key},
+	}, nil)
+	require.NoError(t, err)
+	mockAssignmentUC.On("DownloadSubmissions", uint(1), "user-1").Return(download, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/assignment/1/submissions/download", http.NoBody))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "Tugas ERD.zip")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	assert.Equal(t, "projects/Ani - ERD/project.zip", reader.File[0].Name)
+}
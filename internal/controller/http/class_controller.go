@@ -0,0 +1,532 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"invento-service/internal/controller/base"
+	"invento-service/internal/dto"
+	"invento-service/internal/helper"
+	"invento-service/internal/httputil"
+	"invento-service/internal/usecase"
+	"path/filepath"
+	"strings"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+type ClassController struct {
+	*base.BaseController
+	classUsecase usecase.ClassUsecase
+	excelHelper  *helper.ExcelHelper
+}
+
+func NewClassController(classUsecase usecase.ClassUsecase, excelHelper *helper.ExcelHelper, baseCtrl *base.BaseController) *ClassController {
+	return &ClassController{
+		BaseController: baseCtrl,
+		classUsecase:   classUsecase,
+		excelHelper:    excelHelper,
+	}
+}
+
+// GetList handles GET /api/v1/class
+//
+// @Summary Get list of classes
+// @Description Retrieve the classes the user teaches or is enrolled in
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search by kode or nama"
+// @Param periode query string false "Filter by academic period"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClassListData} "Classes retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class [get]
+func (ctrl *ClassController) GetList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var params dto.ClassListQueryParams
+	if err := c.QueryParser(&params); err != nil {
+		return ctrl.SendBadRequest(c, "Parameter query tidak valid")
+	}
+
+	result, err := ctrl.classUsecase.GetList(ctx, userID, params)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Daftar kelas berhasil diambil")
+}
+
+// Create handles POST /api/v1/class
+//
+// @Summary Create a class
+// @Description Create a class taught by the user and, optionally, other lecturers. A join code for mahasiswa is generated.
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateClassRequest true "Class details"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ClassResponse} "Class created successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or lecturer"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Lecturer not found"
+// @Failure 409 {object} dto.ErrorResponse "Kode already used in this period"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class [post]
+func (ctrl *ClassController) Create(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var req dto.CreateClassRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.classUsecase.Create(ctx, userID, req)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendCreated(c, result, "Kelas berhasil dibuat")
+}
+
+// GetByID handles GET /api/v1/class/:id
+//
+// @Summary Get class detail
+// @Description Retrieve a class the user is a member of. The join code is only shown to its lecturers.
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClassResponse} "Class retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a member of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id} [get]
+func (ctrl *ClassController) GetByID(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	result, err := ctrl.classUsecase.GetByID(ctx, classID, userID)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Detail kelas berhasil diambil")
+}
+
+// Update handles PUT /api/v1/class/:id
+//
+// @Summary Update a class
+// @Description Update the class details. When dosen_ids is given it replaces the lecturers of the class.
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Param request body dto.UpdateClassRequest true "Class details"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClassResponse} "Class updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID, request or lecturer"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class or lecturer not found"
+// @Failure 409 {object} dto.ErrorResponse "Kode already used in this period"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id} [put]
+func (ctrl *ClassController) Update(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	var req dto.UpdateClassRequest
+	if err = c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.classUsecase.Update(ctx, classID, userID, req)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kelas berhasil diperbarui")
+}
+
+// Delete handles DELETE /api/v1/class/:id
+//
+// @Summary Delete a class
+// @Description Delete a class together with its enrollments, assignments and their submissions
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Success 200 {object} dto.SuccessResponse "Class deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id} [delete]
+func (ctrl *ClassController) Delete(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	if err := ctrl.classUsecase.Delete(ctx, classID, userID); err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, nil, "Kelas berhasil dihapus")
+}
+
+// RegenerateJoinCode handles POST /api/v1/class/:id/join-code
+//
+// @Summary Regenerate join code
+// @Description Replace the join code of a class; the previous code stops working
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClassJoinCodeData} "Join code regenerated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id}/join-code [post]
+func (ctrl *ClassController) RegenerateJoinCode(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	result, err := ctrl.classUsecase.RegenerateJoinCode(ctx, classID, userID)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kode bergabung berhasil diperbarui")
+}
+
+// Join handles POST /api/v1/class/join
+//
+// @Summary Join a class
+// @Description Enroll the authenticated mahasiswa in the class with the given join code
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.JoinClassRequest true "Join code"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClassResponse} "Joined class successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - only mahasiswa can join"
+// @Failure 404 {object} dto.ErrorResponse "No class with this join code"
+// @Failure 409 {object} dto.ErrorResponse "Already a member of the class"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/join [post]
+func (ctrl *ClassController) Join(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var req dto.JoinClassRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.classUsecase.Join(ctx, userID, req)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Berhasil bergabung ke kelas")
+}
+
+// GetMembers handles GET /api/v1/class/:id/members
+//
+// @Summary Get class members
+// @Description Retrieve the lecturers and enrolled mahasiswa of a class
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ClassMemberItem} "Members retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id}/members [get]
+func (ctrl *ClassController) GetMembers(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	result, err := ctrl.classUsecase.GetMembers(ctx, classID, userID)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Daftar anggota kelas berhasil diambil")
+}
+
+// AddMembers handles POST /api/v1/class/:id/members
+//
+// @Summary Enroll mahasiswa by email
+// @Description Enroll registered mahasiswa into the class. Emails that cannot be enrolled are skipped and reported.
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Param request body dto.AddClassMembersRequest true "Emails of the mahasiswa"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ImportReport} "Enrollment report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID or request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id}/members [post]
+func (ctrl *ClassController) AddMembers(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	var req dto.AddClassMembersRequest
+	if err = c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	report, err := ctrl.classUsecase.AddMembers(ctx, classID, userID, req)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, report, enrollmentMessage(report))
+}
+
+// GetImportTemplate handles GET /api/v1/class/import/template
+//
+// @Summary Download enrollment template
+// @Description Download the Excel template for enrolling mahasiswa into a class
+// @Tags Class
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Success 200 {file} file "Excel template"
+// @Failure 500 {object} dto.ErrorResponse "Failed to generate template"
+// @Router /class/import/template [get]
+func (ctrl *ClassController) GetImportTemplate(c *fiber.Ctx) error {
+	f, err := ctrl.excelHelper.GenerateClassEnrollmentTemplate()
+	if err != nil {
+		return ctrl.SendInternalError(c)
+	}
+	defer f.Close()
+
+	c.Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Set("Content-Disposition", `attachment; filename="template_anggota_kelas.xlsx"`)
+
+	if err := f.Write(c.Response().BodyWriter()); err != nil {
+		return ctrl.SendInternalError(c)
+	}
+
+	return nil
+}
+
+// ImportMembers handles POST /api/v1/class/:id/members/import
+//
+// @Summary Enroll mahasiswa from Excel
+// @Description Enroll the mahasiswa listed in a filled-in enrollment template (.xlsx). Rows that cannot be enrolled are skipped and reported.
+// @Tags Class
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Param file formData file true "Enrollment template (.xlsx)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ImportReport} "Enrollment report"
+// @Failure 400 {object} dto.ErrorResponse "Invalid class ID or file"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id}/members/import [post]
+func (ctrl *ClassController) ImportMembers(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return ctrl.SendBadRequest(c, "File harus disertakan")
+	}
+
+	if strings.ToLower(filepath.Ext(fileHeader.Filename)) != ".xlsx" {
+		return ctrl.SendBadRequest(c, "Format file harus .xlsx")
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return ctrl.SendInternalError(c)
+	}
+	defer src.Close()
+
+	f, err := excelize.OpenReader(src)
+	if err != nil {
+		return ctrl.SendBadRequest(c, "File Excel tidak dapat dibaca")
+	}
+	defer f.Close()
+
+	report, err := ctrl.classUsecase.ImportMembers(ctx, classID, userID, f)
+	if err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, report, enrollmentMessage(report))
+}
+
+// RemoveMember handles DELETE /api/v1/class/:id/members/:user_id
+//
+// @Summary Remove a mahasiswa from a class
+// @Description Unenroll a mahasiswa. Lecturers are changed by updating the class.
+// @Tags Class
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Class ID"
+// @Param user_id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse "Member removed successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid ID or member is a lecturer"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not a lecturer of this class"
+// @Failure 404 {object} dto.ErrorResponse "Class or member not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /class/{id}/members/{user_id} [delete]
+func (ctrl *ClassController) RemoveMember(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	classID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	memberID := c.Params("user_id")
+	if _, err = uuid.Parse(memberID); err != nil {
+		return ctrl.SendBadRequest(c, "ID user tidak valid")
+	}
+
+	if err := ctrl.classUsecase.RemoveMember(ctx, classID, userID, memberID); err != nil {
+		return ctrl.handleClassError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, nil, "Anggota berhasil dikeluarkan dari kelas")
+}
+
+func (ctrl *ClassController) handleClassError(c *fiber.Ctx, err error) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return httputil.SendAppError(c, appErr)
+	}
+	return ctrl.SendInternalError(c)
+}
+
+func enrollmentMessage(report *dto.ImportReport) string {
+	switch {
+	case report.Berhasil > 0 && report.Dilewati > 0:
+		return fmt.Sprintf("Pendaftaran selesai: %d berhasil, %d dilewati", report.Berhasil, report.Dilewati)
+	case report.Berhasil > 0:
+		return fmt.Sprintf("Pendaftaran berhasil: %d mahasiswa ditambahkan", report.Berhasil)
+	default:
+		return "Tidak ada mahasiswa yang berhasil ditambahkan"
+	}
+}
@@ -0,0 +1,235 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"invento-service/internal/dto"
+	"invento-service/internal/helper"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// MockClassUsecase is a mock for ClassUsecase
+type MockClassUsecase struct {
+	mock.Mock
+}
+
+func (m *MockClassUsecase) Create(ctx context.Context, userID string, req dto.CreateClassRequest) (*dto.ClassResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ClassResponse), args.Error(1)
+}
+
+func (m *MockClassUsecase) GetList(ctx context.Context, userID string, params dto.ClassListQueryParams) (*dto.ClassListData, error) {
+	args := m.Called(userID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ClassListData), args.Error(1)
+}
+
+func (m *MockClassUsecase) GetByID(ctx context.Context, id uint, userID string) (*dto.ClassResponse, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ClassResponse), args.Error(1)
+}
+
+func (m *MockClassUsecase) Update(ctx context.Context, id uint, userID string, req dto.UpdateClassRequest) (*dto.ClassResponse, error) {
+	args := m.Called(id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ClassResponse), args.Error(1)
+}
+
+func (m *MockClassUsecase) Delete(ctx context.Context, id uint, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockClassUsecase) RegenerateJoinCode(ctx context.Context, id uint, userID string) (*dto.ClassJoinCodeData, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ClassJoinCodeData), args.Error(1)
+}
+
+func (m *MockClassUsecase) GetMembers(ctx context.Context, id uint, userID string) ([]dto.ClassMemberItem, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ClassMemberItem), args.Error(1)
+}
+
+func (m *MockClassUsecase) AddMembers(ctx context.Context, id uint, userID string, req dto.AddClassMembersRequest) (*dto.ImportReport, error) {
+	args := m.Called(id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ImportReport), args.Error(1)
+}
+
+func (m *MockClassUsecase) ImportMembers(ctx context.Context, id uint, userID string, file *excelize.File) (*dto.ImportReport, error) {
+	args := m.Called(id, userID, file)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ImportReport), args.Error(1)
+}
+
+func (m *MockClassUsecase) RemoveMember(ctx context.Context, id uint, userID, memberID string) error {
+	args := m.Called(id, userID, memberID)
+	return args.Error(0)
+}
+
+func (m *MockClassUsecase) Join(ctx context.Context, userID string, req dto.JoinClassRequest) (*dto.ClassResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ClassResponse), args.Error(1)
+}
+
+func TestClassController_Create(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	req := dto.CreateClassRequest{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1"}
+	mockClassUC.On("Create", "user-1", req).Return(&dto.ClassResponse{ID: 1, Kode: "TIF101", JoinCode: "ABCD2345"}, nil)
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/class", `{"kode":"TIF101","nama":"Basis Data","periode":"2025/2026-1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	mockClassUC.AssertExpectations(t)
+}
+
+func TestClassController_Create_InvalidLecturer(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/class",
+		`{"kode":"TIF101","nama":"Basis Data","periode":"2025/2026-1","dosen_ids":["bukan-uuid"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockClassUC.AssertNotCalled(t, "Create")
+}
+
+func TestClassController_GetByID_InvalidID(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/class/abc", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockClassUC.AssertNotCalled(t, "GetByID")
+}
+
+func TestClassController_Join(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	mockClassUC.On("Join", "user-1", dto.JoinClassRequest{Code: "ABCD2345"}).Return(&dto.ClassResponse{ID: 1, Peran: "mahasiswa"}, nil)
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/class/join", `{"code":"ABCD2345"}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockClassUC.AssertExpectations(t)
+}
+
+func TestClassController_AddMembers(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	req := dto.AddClassMembersRequest{Emails: []string{"ani@student.polije.ac.id"}}
+	mockClassUC.On("AddMembers", uint(1), "user-1", req).Return(&dto.ImportReport{TotalBaris: 1, Berhasil: 1}, nil)
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/class/1/members", `{"emails":["ani@student.polije.ac.id"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockClassUC.AssertExpectations(t)
+
+	resp, err = app.Test(jsonRequest("POST", "/api/v1/class/1/members", `{"emails":["bukan-email"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestClassController_ImportMembers(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	template, err := helper.NewExcelHelper().GenerateClassEnrollmentTemplate()
+	require.NoError(t, err)
+	var xlsx bytes.Buffer
+	require.NoError(t, template.Write(&xlsx))
+
+	upload := func(filename string, content []byte) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", filename)
+		_, _ = part.Write(content)
+		_ = writer.Close()
+		req := httptest.NewRequest("POST", "/api/v1/class/1/members/import", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	mockClassUC.On("ImportMembers", uint(1), "user-1", mock.AnythingOfType("*excelize.File")).
+		Return(&dto.ImportReport{TotalBaris: 1, Berhasil: 1}, nil)
+
+	resp, err := app.Test(upload("anggota.xlsx", xlsx.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(upload("anggota.csv", []byte("email\n")))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockClassUC.AssertNumberOfCalls(t, "ImportMembers", 1)
+}
+
+func TestClassController_GetImportTemplate(t *testing.T) {
+	t.Parallel()
+	app := newClassApp(new(MockClassUsecase))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/class/import/template", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "template_anggota_kelas.xlsx")
+
+	f, err := excelize.OpenReader(resp.Body)
+	require.NoError(t, err)
+	defer f.Close()
+	header, err := f.GetCellValue("Data Mahasiswa", "A1")
+	require.NoError(t, err)
+	assert.Equal(t, "Email", header)
+}
+
+func TestClassController_RemoveMember_InvalidUserID(t *testing.T) {
+	t.Parallel()
+	mockClassUC := new(MockClassUsecase)
+	app := newClassApp(mockClassUC)
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/api/v1/class/1/members/bukan-uuid", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockClassUC.AssertNotCalled(t, "RemoveMember")
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"errors"
+	"invento-service/internal/controller/base"
+	"invento-service/internal/httputil"
+	"invento-service/internal/middleware"
+	"invento-service/internal/storage"
+	"net/url"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+// FileController serves stored files through the signed links issued by
+// PathResolver.ConvertToAPIPath. There is no session on these requests, since the
+// links end up in <img> tags and plain anchors, so the signature is the only check.
+// The user a link was issued to is signed into it and logged with every download.
+type FileController struct {
+	*base.BaseController
+	signer   *storage.URLSigner
+	backend  storage.Backend
+	basePath string
+	logger   zerolog.Logger
+}
+
+// NewFileController creates a new FileController instance. basePath is the storage
+// root that signed paths are relative to.
+func NewFileController(signer *storage.URLSigner, backend storage.Backend, basePath string, logger zerolog.Logger) *FileController {
+	return &FileController{
+		BaseController: base.NewBaseController("", nil),
+		signer:         signer,
+		backend:        backend,
+		basePath:       basePath,
+		logger:         logger.With().Str("component", "FileController").Logger(),
+	}
+}
+
+// ServeSignedFile sends the file a signed link points to.
+//
+// @Summary Unduh file melalui tautan bertanda tangan
+// @Description Mengirim file yang tersimpan (foto profil, project, modul) melalui tautan yang ditandatangani HMAC dan memiliki batas waktu. Tautan diperoleh dari field URL pada response API lain, misalnya download_url dan foto_profil. Mendukung Range dan conditional request.
+// @Description Note: This endpoint is registered at /uploads (not under /api/v1).
+// @Tags Files
+// @Produce application/octet-stream
+// @Param path path string true "Path file relatif terhadap direktori penyimpanan"
+// @Param uid query string true "ID user penerima tautan"
+// @Param expires query int true "Waktu kedaluwarsa tautan (unix timestamp)"
+// @Param signature query string true "Tanda tangan HMAC-SHA256 tautan"
+// @Param Range header string false "Byte range, misalnya bytes=0-1023"
+// @Success 200 {file} binary "File"
+// @Success 206 {file} binary "Sebagian file sesuai Range"
+// @Failure 403 {object} dto.ErrorResponse "Tautan tidak valid atau sudah kedaluwarsa"
+// @Failure 404 {object} dto.ErrorResponse "File tidak ditemukan"
+// @Failure 416 {object} dto.ErrorResponse "Range tidak dapat dipenuhi"
+// @Router /uploads/{path} [get]
+func (ctrl *FileController) ServeSignedFile(c *fiber.Ctx) error {
+	relPath, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return httputil.SendErrorResponse(c, fiber.StatusForbidden, "Tautan unduhan tidak valid", nil)
+	}
+	relPath = path.Clean("/" + relPath)
+	if relPath == "/" {
+		return ctrl.SendNotFound(c, "File tidak ditemukan")
+	}
+
+	userID := c.Query("uid")
+	if err := ctrl.signer.Verify(relPath, userID, c.Query("expires"), c.Query("signature"), time.Now()); err != nil {
+		ctrl.logger.Warn().
+			Err(err).
+			Str("path", relPath).
+			Str("user_id", userID).
+			Str("ip", c.IP()).
+			Str("request_id", middleware.GetRequestID(c)).
+			Msg("rejected signed download link")
+		message := "Tautan unduhan tidak valid"
+		if errors.Is(err, storage.ErrSignedURLExpired) {
+			message = "Tautan unduhan sudah kedaluwarsa"
+		}
+		return httputil.SendErrorResponse(c, fiber.StatusForbidden, message, nil)
+	}
+
+	download := storage.NewFileDownload(ctrl.backend, filepath.Join(ctrl.basePath, filepath.FromSlash(relPath)), "")
+	if err := httputil.SendStoredFile(c, download); err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return ctrl.SendNotFound(c, "File tidak ditemukan")
+		}
+		ctrl.logger.Error().Err(err).Str("path", relPath).Str("user_id", userID).Msg("failed to send stored file")
+		return ctrl.SendInternalError(c)
+	}
+
+	ctrl.logger.Info().
+		Str("path", relPath).
+		Str("user_id", userID).
+		Str("ip", c.IP()).
+		Str("user_agent", c.Get(fiber.HeaderUserAgent)).
+		Str("request_id", middleware.GetRequestID(c)).
+		Int("status", c.Response().StatusCode()).
+		Str("range", c.Get(fiber.HeaderRange)).
+		Msg("file downloaded")
+	return nil
+}
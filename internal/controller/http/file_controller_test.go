@@ -0,0 +1,110 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"invento-service/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpcontroller "invento-service/internal/controller/http"
+)
+
+func newFileControllerApp(t *testing.T) (*fiber.App, *storage.URLSigner) {
+	t.Helper()
+	backend := storage.NewMemoryBackend()
+	require.NoError(t, backend.Put(context.Background(), "/data/profil/u1/foto profil.jpg", strings.NewReader("jpeg-bytes"), 10))
+
+	signer := storage.NewURLSigner("test-secret", 15*time.Minute)
+	controller := httpcontroller.NewFileController(signer, backend, "/data", zerolog.Nop())
+
+	app := fiber.New()
+	app.Get("/uploads/*", controller.ServeSignedFile)
+	return app, signer
+}
+
+func TestFileController_ServeSignedFile(t *testing.T) {
+	t.Parallel()
+	app, signer := newFileControllerApp(t)
+
+	t.Run("signed link", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(fiber.MethodGet, signer.Sign("profil/u1/foto profil.jpg", "u1", time.Now()), http.NoBody)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "jpeg-bytes", string(body))
+		assert.Equal(t, "image/jpeg", resp.Header.Get(fiber.HeaderContentType))
+	})
+
+	t.Run("unsigned path", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(fiber.MethodGet, "/uploads/profil/u1/foto%20profil.jpg", http.NoBody)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "Tautan unduhan tidak valid", response["message"])
+	})
+
+	t.Run("signature reused for another file", func(t *testing.T) {
+		t.Parallel()
+		link, err := url.Parse(signer.Sign("profil/u1/foto profil.jpg", "u1", time.Now()))
+		require.NoError(t, err)
+		req := httptest.NewRequest(fiber.MethodGet, "/uploads/profil/u2/foto.jpg?"+link.RawQuery, http.NoBody)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("link handed to another user", func(t *testing.T) {
+		t.Parallel()
+		link, err := url.Parse(signer.Sign("profil/u1/foto profil.jpg", "u1", time.Now()))
+		require.NoError(t, err)
+		query := link.Query()
+		query.Set("uid", "u2")
+		link.RawQuery = query.Encode()
+		req := httptest.NewRequest(fiber.MethodGet, link.String(), http.NoBody)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(fiber.MethodGet, signer.Sign("profil/u1/foto profil.jpg", "u1", time.Now().Add(-time.Hour)), http.NoBody)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "Tautan unduhan sudah kedaluwarsa", response["message"])
+	})
+
+	t.Run("signed link to a missing file", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(fiber.MethodGet, signer.Sign("profil/u1/hapus.jpg", "u1", time.Now()), http.NoBody)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,109 @@
+package http_test
+
+import (
+	"invento-service/internal/controller/base"
+	"invento-service/internal/helper"
+	"invento-service/internal/rbac"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	httpcontroller "invento-service/internal/controller/http"
+)
+
+// Helper function to create test base controller
+func getTestBaseController() *base.BaseController {
+	casbin := &rbac.CasbinEnforcer{}
+	return base.NewBaseController("https://test.supabase.co", casbin)
+}
+
+// Helper function to set authenticated user in context
+func setAuthenticatedUser(c *fiber.Ctx) {
+	c.Locals("user_id", "user-1")
+	c.Locals("user_email", "test@example.com")
+	c.Locals("user_role", "user")
+}
+
+// newAuthenticatedApp returns an app whose requests all come from user-1.
+func newAuthenticatedApp() *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setAuthenticatedUser(c)
+		return c.Next()
+	})
+	return app
+}
+
+func jsonRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// newModulApp serves the modul lifecycle, revision and library routes.
+func newModulApp(mockModulUC *MockModulUsecase) *fiber.App {
+	controller := httpcontroller.NewModulController(mockModulUC, getTestConfig(), getTestBaseController())
+
+	app := newAuthenticatedApp()
+	app.Get("/api/v1/modul/library", controller.GetLibrary)
+	app.Get("/api/v1/modul/reviews", controller.GetReviewQueue)
+	app.Get("/api/v1/modul/:id", controller.GetByID)
+	app.Put("/api/v1/modul/:id/visibility", controller.UpdateVisibility)
+	app.Post("/api/v1/modul/:id/submit", controller.Submit)
+	app.Post("/api/v1/modul/:id/publish", controller.Publish)
+	app.Post("/api/v1/modul/:id/withdraw", controller.Withdraw)
+	app.Post("/api/v1/modul/:id/archive", controller.Archive)
+	app.Post("/api/v1/modul/:id/review", controller.Review)
+	app.Get("/api/v1/modul/:id/revisions", controller.GetRevisions)
+	app.Get("/api/v1/modul/:id/revisions/:revision/download", controller.DownloadRevision)
+	app.Post("/api/v1/modul/:id/revisions/:revision/rollback", controller.Rollback)
+	return app
+}
+
+// newProjectApp serves the project browsing and version routes.
+func newProjectApp(mockProjectUC *MockProjectUsecase) *fiber.App {
+	controller := httpcontroller.NewProjectController(mockProjectUC, "https://test.supabase.co", nil)
+
+	app := newAuthenticatedApp()
+	app.Get("/api/v1/project/:id/tree", controller.GetTree)
+	app.Get("/api/v1/project/:id/file", controller.GetFile)
+	app.Get("/api/v1/project/:id/versions", controller.GetVersions)
+	app.Get("/api/v1/project/:id/versions/:version/download", controller.DownloadVersion)
+	app.Post("/api/v1/project/:id/versions/:version/restore", controller.RestoreVersion)
+	return app
+}
+
+func newProjectReviewApp(mockReviewUC *MockProjectReviewUsecase) *fiber.App {
+	controller := httpcontroller.NewProjectReviewController(mockReviewUC, getTestBaseController())
+
+	app := newAuthenticatedApp()
+	app.Get("/api/v1/project/reviews", controller.GetQueue)
+	app.Post("/api/v1/project/:id/review", controller.Review)
+	return app
+}
+
+func newClassApp(mockClassUC *MockClassUsecase) *fiber.App {
+	controller := httpcontroller.NewClassController(mockClassUC, helper.NewExcelHelper(), getTestBaseController())
+
+	app := newAuthenticatedApp()
+	app.Post("/api/v1/class", controller.Create)
+	app.Post("/api/v1/class/join", controller.Join)
+	app.Get("/api/v1/class/import/template", controller.GetImportTemplate)
+	app.Get("/api/v1/class/:id", controller.GetByID)
+	app.Post("/api/v1/class/:id/members", controller.AddMembers)
+	app.Post("/api/v1/class/:id/members/import", controller.ImportMembers)
+	app.Delete("/api/v1/class/:id/members/:user_id", controller.RemoveMember)
+	return app
+}
+
+func newAssignmentApp(mockAssignmentUC *MockAssignmentUsecase) *fiber.App {
+	controller := httpcontroller.NewAssignmentController(mockAssignmentUC, getTestBaseController())
+
+	app := newAuthenticatedApp()
+	app.Post("/api/v1/assignment", controller.Create)
+	app.Get("/api/v1/assignment/:id/submissions", controller.GetSubmissions)
+	app.Get("/api/v1/assignment/:id/submissions/download", controller.DownloadSubmissions)
+	return app
+}
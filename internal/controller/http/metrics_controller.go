@@ -0,0 +1,39 @@
+package http
+
+import (
+	"bytes"
+	"invento-service/internal/httputil"
+	"invento-service/internal/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MetricsController serves scrape endpoints for external monitoring systems.
+type MetricsController struct {
+	exporter *metrics.Exporter
+}
+
+// NewMetricsController creates a new metrics controller instance.
+// Like the health endpoints, the scrape endpoint requires no authentication.
+func NewMetricsController(exporter *metrics.Exporter) *MetricsController {
+	return &MetricsController{exporter: exporter}
+}
+
+// PrometheusMetrics exposes metrics in the Prometheus text format
+//
+//	@Summary		Prometheus Metrics
+//	@Description	Mengambil metrik dalam format teks Prometheus: latensi HTTP per route, counter upload TUS, antrian upload, pool koneksi database, dan keputusan Casbin.
+//	@Description	Path dapat diubah melalui MONITORING_PROMETHEUS_PATH.
+//	@Tags			Monitoring
+//	@Produce		plain
+//	@Success		200	{string}	string	"Metrik dalam format Prometheus"
+//	@Router			/monitoring/prometheus [get]
+func (ctrl *MetricsController) PrometheusMetrics(c *fiber.Ctx) error {
+	var buf bytes.Buffer
+	if _, err := ctrl.exporter.WriteTo(&buf); err != nil {
+		return httputil.SendInternalServerErrorResponse(c)
+	}
+
+	c.Set(fiber.HeaderContentType, metrics.PrometheusContentType)
+	return c.Send(buf.Bytes())
+}
@@ -0,0 +1,41 @@
+package http_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpcontroller "invento-service/internal/controller/http"
+	"invento-service/internal/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsController_PrometheusMetrics_Success(t *testing.T) {
+	t.Parallel()
+	collector := metrics.NewHTTPCollector()
+	collector.Observe("GET", "/api/v1/modul", 200, 5*time.Millisecond)
+	controller := httpcontroller.NewMetricsController(metrics.NewExporter(metrics.ExporterSources{
+		HTTP: collector,
+		Tus:  metrics.NewTusMetrics(),
+	}))
+
+	app := fiber.New()
+	app.Get("/monitoring/prometheus", controller.PrometheusMetrics)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/monitoring/prometheus", http.NoBody))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, metrics.PrometheusContentType, resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `invento_http_requests_total{method="GET",route="/api/v1/modul",status="2xx"} 1`)
+	assert.Contains(t, string(body), `invento_tus_uploads_initiated_total{type="modul"} 0`)
+}
@@ -1,15 +1,13 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"invento-service/config"
 	"invento-service/internal/controller/base"
 	"invento-service/internal/dto"
 	"invento-service/internal/httputil"
 	"invento-service/internal/usecase"
-	"os"
-	"path/filepath"
-	"strings"
 
 	apperrors "invento-service/internal/errors"
 
@@ -169,18 +167,24 @@ func (ctrl *ModulController) UpdateMetadata(c *fiber.Ctx) error {
 // Download handles POST /api/v1/modul/download
 //
 // @Summary Download modules as ZIP
-// @Description Download one or more modules as a ZIP file
+// @Description Download one or more modules: the user's own and published modules shared with them through the library. Several modules are streamed as a ZIP laid out as moduls/<judul>.<ext> with a manifest.json
 // @Tags Modul
 // @Accept json
 // @Produce application/zip
 // @Security BearerAuth
 // @Param request body dto.ModulDownloadRequest true "Download request with module IDs"
+// @Param Range header string false "Byte range to resume a single-file download, e.g. bytes=1048576-"
 // @Success 200 {file} binary "ZIP file containing module files"
+// @Success 206 {file} binary "Requested byte range of a single file"
+// @Header 200,206 {string} ETag "Entity tag of a single file"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request format or empty IDs"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "One or more modules not found"
+// @Failure 413 {object} dto.ErrorResponse "Selected modules exceed the archive size limit"
+// @Failure 416 {string} string "Range not satisfiable"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/download [post]
+// @Router /modul/library/download [post]
 func (ctrl *ModulController) Download(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	userID := ctrl.GetAuthenticatedUserID(c)
@@ -201,7 +205,7 @@ func (ctrl *ModulController) Download(c *fiber.Ctx) error {
 		return nil
 	}
 
-	filePath, err := ctrl.modulUsecase.Download(ctx, userID, req.IDs)
+	download, err := ctrl.modulUsecase.Download(ctx, userID, req.IDs)
 	if err != nil {
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
@@ -210,15 +214,482 @@ func (ctrl *ModulController) Download(c *fiber.Ctx) error {
 		return ctrl.SendInternalError(c)
 	}
 
-	err = c.Download(filePath)
+	return ctrl.SendDownload(c, download)
+}
+
+// GetRevisions handles GET /api/v1/modul/:id/revisions
+//
+// @Summary List module revisions
+// @Description List every revision of a module, newest first. Each revision is a snapshot of the file and metadata with the fields that change touched, who made it and when.
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulRevisionListData} "Revisions retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/revisions [get]
+func (ctrl *ModulController) GetRevisions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	result, err := ctrl.modulUsecase.GetRevisions(ctx, modulID, userID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Riwayat revisi modul berhasil diambil")
+}
+
+// DownloadRevision handles GET /api/v1/modul/:id/revisions/:revision/download
+//
+// @Summary Download a module revision
+// @Description Download the module file as it was at the given revision
+// @Tags Modul
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Param revision path int true "Revision number"
+// @Param Range header string false "Byte range to resume the download, e.g. bytes=1048576-"
+// @Success 200 {file} binary "Module file of the revision"
+// @Success 206 {file} binary "Requested byte range of the file"
+// @Header 200,206 {string} ETag "Entity tag of the file"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID or revision"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module, revision or revision file not found"
+// @Failure 416 {string} string "Range not satisfiable"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/revisions/{revision}/download [get]
+func (ctrl *ModulController) DownloadRevision(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	revision, err := c.ParamsInt("revision")
+	if err != nil || revision <= 0 {
+		return ctrl.SendBadRequest(c, "Revisi tidak valid")
+	}
+
+	download, err := ctrl.modulUsecase.DownloadRevision(ctx, modulID, userID, revision)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendDownload(c, download)
+}
+
+// Rollback handles POST /api/v1/modul/:id/revisions/:revision/rollback
+//
+// @Summary Roll back a module to a revision
+// @Description Restore the file and metadata a module had at the given revision. The rollback is recorded as a new revision.
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Param revision path int true "Revision number"
+// @Param request body dto.RollbackModulRequest false "Optional note for the new revision"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulRevisionItem} "Module rolled back successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID, revision or request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module, revision or revision file not found"
+// @Failure 409 {object} dto.ErrorResponse "Module already matches the revision"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/revisions/{revision}/rollback [post]
+func (ctrl *ModulController) Rollback(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	revision, err := c.ParamsInt("revision")
+	if err != nil || revision <= 0 {
+		return ctrl.SendBadRequest(c, "Revisi tidak valid")
+	}
+
+	var req dto.RollbackModulRequest
+	if len(c.Body()) > 0 {
+		if err = c.BodyParser(&req); err != nil {
+			return ctrl.SendBadRequest(c, "Format request tidak valid")
+		}
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.modulUsecase.Rollback(ctx, modulID, userID, revision, req)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Modul berhasil dikembalikan ke revisi sebelumnya")
+}
+
+// GetByID handles GET /api/v1/modul/:id
+//
+// @Summary Get module detail
+// @Description Get a module with its lifecycle status and timestamps. Owners see their modules in any status; other users only see published modules.
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulResponse} "Module retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - module is not published"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id} [get]
+func (ctrl *ModulController) GetByID(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	result, err := ctrl.modulUsecase.GetByID(ctx, modulID, userID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Detail modul berhasil diambil")
+}
+
+// Submit handles POST /api/v1/modul/:id/submit
+//
+// @Summary Submit a module for review
+// @Description Move a draft module to in_review. A reviewer then publishes it or returns it to draft.
+// @Tags Modul
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulResponse} "Module submitted for review"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 409 {object} dto.ErrorResponse "Module is not a draft"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/submit [post]
+func (ctrl *ModulController) Submit(c *fiber.Ctx) error {
+	return ctrl.changeStatus(c, ctrl.modulUsecase.Submit, "Modul berhasil diajukan untuk review")
+}
+
+// Publish handles POST /api/v1/modul/:id/publish
+//
+// @Summary Publish a module
+// @Description Publish a draft module directly, without review, making it visible to other users
+// @Tags Modul
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulResponse} "Module published"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 409 {object} dto.ErrorResponse "Module is not a draft"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/publish [post]
+func (ctrl *ModulController) Publish(c *fiber.Ctx) error {
+	return ctrl.changeStatus(c, ctrl.modulUsecase.Publish, "Modul berhasil dipublikasikan")
+}
+
+// Withdraw handles POST /api/v1/modul/:id/withdraw
+//
+// @Summary Return a module to draft
+// @Description Take a module that is in review, published or archived back to draft, hiding it from other users
+// @Tags Modul
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulResponse} "Module returned to draft"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 409 {object} dto.ErrorResponse "Module is already a draft"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/withdraw [post]
+func (ctrl *ModulController) Withdraw(c *fiber.Ctx) error {
+	return ctrl.changeStatus(c, ctrl.modulUsecase.Withdraw, "Modul berhasil dikembalikan ke draft")
+}
+
+// Archive handles POST /api/v1/modul/:id/archive
+//
+// @Summary Archive a module
+// @Description Archive a published module, hiding it from other users
+// @Tags Modul
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulResponse} "Module archived"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 409 {object} dto.ErrorResponse "Module is not published"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/archive [post]
+func (ctrl *ModulController) Archive(c *fiber.Ctx) error {
+	return ctrl.changeStatus(c, ctrl.modulUsecase.Archive, "Modul berhasil diarsipkan")
+}
+
+// Review handles POST /api/v1/modul/:id/review
+//
+// @Summary Review a submitted module
+// @Description Approve a module in review, publishing it, or reject it back to draft. Owners cannot review their own modules.
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Param request body dto.ReviewModulRequest true "Review decision"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulResponse} "Review recorded"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID or request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - own module"
+// @Failure 404 {object} dto.ErrorResponse "Module not found"
+// @Failure 409 {object} dto.ErrorResponse "Module is not in review"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/review [post]
+func (ctrl *ModulController) Review(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	var req dto.ReviewModulRequest
+	if err = c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.modulUsecase.Review(ctx, modulID, userID, req)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Review modul berhasil disimpan")
+}
+
+// GetReviewQueue handles GET /api/v1/modul/reviews
+//
+// @Summary List modules waiting for review
+// @Description List modules in review from all owners, longest waiting first
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulReviewListData} "Review queue retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/reviews [get]
+func (ctrl *ModulController) GetReviewQueue(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	if ctrl.GetAuthenticatedUserID(c) == "" {
+		return nil
+	}
+
+	page, limit := httputil.ParsePaginationQuery(c)
+	result, err := ctrl.modulUsecase.GetReviewQueue(ctx, page, limit)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Antrean review modul berhasil diambil")
+}
+
+// changeStatus runs one of the owner's lifecycle transitions on the modul in the path.
+func (ctrl *ModulController) changeStatus(c *fiber.Ctx, transition func(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error), message string) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	result, err := transition(ctx, modulID, userID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, message)
+}
+
+// GetLibrary handles GET /api/v1/modul/library
+//
+// @Summary Browse the module library
+// @Description List published modules shared with the current user, either with every authenticated user or with their role, including their own. Newest publication first.
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search in judul and deskripsi"
+// @Param filter_type query string false "Filter by MIME type"
+// @Param owner_id query string false "Filter by owner (UUID)"
+// @Param published_from query string false "Published on or after this date (YYYY-MM-DD)"
+// @Param published_to query string false "Published on or before this date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulLibraryData} "Library retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/library [get]
+func (ctrl *ModulController) GetLibrary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var params dto.ModulLibraryQueryParams
+	if err := c.QueryParser(&params); err != nil {
+		return ctrl.SendBadRequest(c, "Parameter query tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, params) {
+		return nil
+	}
+
+	result, err := ctrl.modulUsecase.GetLibrary(ctx, userID, params)
 	if err != nil {
-		return err
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
 	}
 
-	cleanPath := filepath.ToSlash(filepath.Clean(filePath))
-	if strings.Contains(cleanPath, "/uploads/temp/") || strings.HasPrefix(cleanPath, "uploads/temp/") || strings.HasPrefix(cleanPath, "./uploads/temp/") {
-		_ = os.Remove(filePath)
+	return ctrl.SendSuccess(c, result, "Perpustakaan modul berhasil diambil")
+}
+
+// UpdateVisibility handles PUT /api/v1/modul/:id/visibility
+//
+// @Summary Set module visibility
+// @Description Choose who besides the owner sees the module once it is published: nobody (private), users with one of role_ids (roles) or every authenticated user (authenticated)
+// @Tags Modul
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Module ID (UUID)"
+// @Param request body dto.UpdateModulVisibilityRequest true "Visibility and, for roles, the role IDs"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ModulVisibilityData} "Visibility updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid module ID or request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this module"
+// @Failure 404 {object} dto.ErrorResponse "Module or role not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /modul/{id}/visibility [put]
+func (ctrl *ModulController) UpdateVisibility(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	modulID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathUUID already sent HTTP error response
+	}
+
+	var req dto.UpdateModulVisibilityRequest
+	if err = c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.modulUsecase.UpdateVisibility(ctx, modulID, userID, req)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
 	}
 
-	return nil
+	return ctrl.SendSuccess(c, result, "Visibilitas modul berhasil diperbarui")
 }
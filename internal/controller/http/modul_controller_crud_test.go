@@ -4,9 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"invento-service/internal/controller/base"
 	"invento-service/internal/dto"
-	"invento-service/internal/rbac"
+	"invento-service/internal/storage"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -54,25 +53,100 @@ func (m *MockModulUsecase) Delete(ctx context.Context, modulID, userID string) e
 	return args.Error(0)
 }
 
-func (m *MockModulUsecase) Download(ctx context.Context, userID string, modulIDs []string) (string, error) {
+func (m *MockModulUsecase) Download(ctx context.Context, userID string, modulIDs []string) (*storage.Download, error) {
 	args := m.Called(userID, modulIDs)
 	if args.Get(0) == nil {
-		return "", args.Error(1)
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Download), args.Error(1)
+}
+
+func (m *MockModulUsecase) GetRevisions(ctx context.Context, modulID, userID string) (*dto.ModulRevisionListData, error) {
+	args := m.Called(modulID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulRevisionListData), args.Error(1)
+}
+
+func (m *MockModulUsecase) DownloadRevision(ctx context.Context, modulID, userID string, revision int) (*storage.Download, error) {
+	args := m.Called(modulID, userID, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Download), args.Error(1)
+}
+
+func (m *MockModulUsecase) Rollback(ctx context.Context, modulID, userID string, revision int, req dto.RollbackModulRequest) (*dto.ModulRevisionItem, error) {
+	args := m.Called(modulID, userID, revision, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulRevisionItem), args.Error(1)
+}
+
+func (m *MockModulUsecase) Submit(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	args := m.Called(modulID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return args.String(0), args.Error(1)
+	return args.Get(0).(*dto.ModulResponse), args.Error(1)
 }
 
-// Helper function to create test base controller
-func getTestBaseController() *base.BaseController {
-	casbin := &rbac.CasbinEnforcer{}
-	return base.NewBaseController("https://test.supabase.co", casbin)
+func (m *MockModulUsecase) Publish(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	args := m.Called(modulID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulResponse), args.Error(1)
 }
 
-// Helper function to set authenticated user in context
-func setAuthenticatedUser(c *fiber.Ctx) {
-	c.Locals("user_id", "user-1")
-	c.Locals("user_email", "test@example.com")
-	c.Locals("user_role", "user")
+func (m *MockModulUsecase) Withdraw(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	args := m.Called(modulID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulResponse), args.Error(1)
+}
+
+func (m *MockModulUsecase) Archive(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	args := m.Called(modulID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulResponse), args.Error(1)
+}
+
+func (m *MockModulUsecase) Review(ctx context.Context, modulID, reviewerID string, req dto.ReviewModulRequest) (*dto.ModulResponse, error) {
+	args := m.Called(modulID, reviewerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulResponse), args.Error(1)
+}
+
+func (m *MockModulUsecase) GetReviewQueue(ctx context.Context, page, limit int) (*dto.ModulReviewListData, error) {
+	args := m.Called(page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulReviewListData), args.Error(1)
+}
+
+func (m *MockModulUsecase) GetLibrary(ctx context.Context, userID string, params dto.ModulLibraryQueryParams) (*dto.ModulLibraryData, error) {
+	args := m.Called(userID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulLibraryData), args.Error(1)
+}
+
+func (m *MockModulUsecase) UpdateVisibility(ctx context.Context, modulID, userID string, req dto.UpdateModulVisibilityRequest) (*dto.ModulVisibilityData, error) {
+	args := m.Called(modulID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ModulVisibilityData), args.Error(1)
 }
 
 // TestModulController_GetList_Success tests successful retrieval of module list
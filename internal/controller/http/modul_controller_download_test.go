@@ -1,15 +1,21 @@
 package http_test
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	httpcontroller "invento-service/internal/controller/http"
 
@@ -35,19 +41,60 @@ func TestModulController_Download_Success(t *testing.T) {
 		IDs: []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"},
 	}
 
-	mockModulUC.On("Download", "user-1", []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"}).Return("/tmp/nonexistent.zip", nil)
+	mockModulUC.On("Download", "user-1", []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"}).Return(storage.NewFileDownload(storage.NewLocalBackend(), "/tmp/nonexistent.zip", "nonexistent.zip"), nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/v1/modul/download", bytes.NewReader(bodyBytes))
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := app.Test(req)
 	assert.NoError(t, err)
-	// Note: Fiber's Download will return 404 for non-existent files, which is expected behavior
 	assert.Equal(t, 404, resp.StatusCode)
 
 	mockModulUC.AssertExpectations(t)
 }
 
+func TestModulController_Download_StreamsArchive(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	controller := httpcontroller.NewModulController(mockModulUC, getTestConfig(), getTestBaseController())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setAuthenticatedUser(c)
+		return c.Next()
+	})
+	app.Post("/api/v1/modul/download", controller.Download)
+
+	ctx := context.Background()
+	backend := storage.NewMemoryBackend()
+	require.NoError(t, backend.Put(ctx, "/data/moduls/a.pdf", strings.NewReader("modul-a"), 7))
+	require.NoError(t, backend.Put(ctx, "/data/moduls/b.pdf", strings.NewReader("modul-b"), 7))
+	download, err := storage.NewArchiveDownload(ctx, backend, "moduls.zip", []storage.ArchiveEntry{
+		{Kind: storage.ArchiveKindModul, ID: "a", Title: "Modul A", FileName: "a.pdf", Key: "/data/moduls/a.pdf"},
+		{Kind: storage.ArchiveKindModul, ID: "b", Title: "Modul B", FileName: "b.pdf", Key: "/data/moduls/b.pdf"},
+	}, nil)
+	require.NoError(t, err)
+
+	ids := []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"}
+	mockModulUC.On("Download", "user-1", ids).Return(download, nil)
+
+	resp := app_testing.MakeRequest(app, "POST", "/api/v1/modul/download", dto.ModulDownloadRequest{IDs: ids}, "")
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="moduls.zip"`, resp.Header.Get(fiber.HeaderContentDisposition))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	names := make([]string, 0, len(reader.File))
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"moduls/Modul A.pdf", "moduls/Modul B.pdf", storage.ManifestFileName}, names)
+
+	mockModulUC.AssertExpectations(t)
+}
+
 // TestModulController_Download_EmptyIDs tests download with empty ID list
 func TestModulController_Download_EmptyIDs(t *testing.T) {
 	t.Parallel()
@@ -92,7 +139,7 @@ func TestModulController_Download_NotFound(t *testing.T) {
 	}
 
 	appErr := apperrors.NewNotFoundError("Salah satu modul tidak ditemukan")
-	mockModulUC.On("Download", "user-1", []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440999"}).Return("", appErr)
+	mockModulUC.On("Download", "user-1", []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440999"}).Return(nil, appErr)
 
 	resp := app_testing.MakeRequest(app, "POST", "/api/v1/modul/download", reqBody, "")
 
@@ -140,7 +187,7 @@ func TestModulController_Download_InternalError(t *testing.T) {
 		IDs: []string{"550e8400-e29b-41d4-a716-446655440001"},
 	}
 
-	mockModulUC.On("Download", "user-1", []string{"550e8400-e29b-41d4-a716-446655440001"}).Return("", errors.New("zip creation failed"))
+	mockModulUC.On("Download", "user-1", []string{"550e8400-e29b-41d4-a716-446655440001"}).Return(nil, errors.New("zip creation failed"))
 
 	resp := app_testing.MakeRequest(app, "POST", "/api/v1/modul/download", reqBody, "")
 
@@ -0,0 +1,83 @@
+package http_test
+
+import (
+	"invento-service/internal/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulController_GetLibrary(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	params := dto.ModulLibraryQueryParams{
+		Search:        "basis",
+		FilterType:    "application/pdf",
+		OwnerID:       lifecycleModulID,
+		PublishedFrom: "2025-01-01",
+		Page:          2,
+	}
+	mockModulUC.On("GetLibrary", "user-1", params).Return(&dto.ModulLibraryData{
+		Items: []dto.ModulLibraryItem{{ID: lifecycleModulID, OwnerName: "Dosen Satu"}},
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET",
+		"/api/v1/modul/library?search=basis&filter_type=application/pdf&owner_id="+lifecycleModulID+"&published_from=2025-01-01&page=2", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockModulUC.AssertExpectations(t)
+}
+
+func TestModulController_GetLibrary_InvalidFilters(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"owner_id=bukan-uuid", "published_to=31-01-2025"} {
+		t.Run(query, func(t *testing.T) {
+			t.Parallel()
+			mockModulUC := new(MockModulUsecase)
+			app := newModulApp(mockModulUC)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/library?"+query, http.NoBody))
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			mockModulUC.AssertNotCalled(t, "GetLibrary")
+		})
+	}
+}
+
+func TestModulController_UpdateVisibility(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	req := dto.UpdateModulVisibilityRequest{Visibility: "roles", RoleIDs: []uint{2}}
+	mockModulUC.On("UpdateVisibility", lifecycleModulID, "user-1", req).
+		Return(&dto.ModulVisibilityData{ModulID: lifecycleModulID, Visibility: "roles", RoleIDs: []uint{2}}, nil)
+
+	httpReq := httptest.NewRequest("PUT", "/api/v1/modul/"+lifecycleModulID+"/visibility", strings.NewReader(`{"visibility":"roles","role_ids":[2]}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockModulUC.AssertExpectations(t)
+}
+
+func TestModulController_UpdateVisibility_InvalidLevel(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	httpReq := httptest.NewRequest("PUT", "/api/v1/modul/"+lifecycleModulID+"/visibility", strings.NewReader(`{"visibility":"public"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockModulUC.AssertNotCalled(t, "UpdateVisibility")
+}
@@ -0,0 +1,120 @@
+package http_test
+
+import (
+	"encoding/json"
+	"invento-service/internal/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "invento-service/internal/errors"
+)
+
+const lifecycleModulID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestModulController_Transitions(t *testing.T) {
+	t.Parallel()
+
+	for _, action := range []string{"Submit", "Publish", "Withdraw", "Archive"} {
+		t.Run(action, func(t *testing.T) {
+			t.Parallel()
+			mockModulUC := new(MockModulUsecase)
+			app := newModulApp(mockModulUC)
+
+			mockModulUC.On(action, lifecycleModulID, "user-1").Return(&dto.ModulResponse{ID: lifecycleModulID, Status: "published"}, nil)
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/modul/"+lifecycleModulID+"/"+strings.ToLower(action), http.NoBody))
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			assert.Equal(t, "published", response["data"].(map[string]interface{})["status"])
+			mockModulUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModulController_Publish_Conflict(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	mockModulUC.On("Publish", lifecycleModulID, "user-1").Return(nil, apperrors.NewConflictError("Modul berstatus archived tidak dapat dipublikasikan"))
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/modul/"+lifecycleModulID+"/publish", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestModulController_Transition_InvalidID(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/modul/bukan-uuid/submit", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockModulUC.AssertNotCalled(t, "Submit")
+}
+
+func TestModulController_Review(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	req := dto.ReviewModulRequest{Decision: "reject", Catatan: "Lengkapi daftar pustaka"}
+	mockModulUC.On("Review", lifecycleModulID, "user-1", req).Return(&dto.ModulResponse{ID: lifecycleModulID, Status: "draft"}, nil)
+
+	httpReq := httptest.NewRequest("POST", "/api/v1/modul/"+lifecycleModulID+"/review", strings.NewReader(`{"decision":"reject","catatan":"Lengkapi daftar pustaka"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockModulUC.AssertExpectations(t)
+}
+
+func TestModulController_Review_InvalidDecision(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	httpReq := httptest.NewRequest("POST", "/api/v1/modul/"+lifecycleModulID+"/review", strings.NewReader(`{"decision":"maybe"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(httpReq)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockModulUC.AssertNotCalled(t, "Review")
+}
+
+func TestModulController_GetByID(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	mockModulUC.On("GetByID", lifecycleModulID, "user-1").Return(nil, apperrors.NewForbiddenError("Tidak memiliki akses ke modul ini"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/"+lifecycleModulID, http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestModulController_GetReviewQueue(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	mockModulUC.On("GetReviewQueue", 2, 100).Return(&dto.ModulReviewListData{
+		Items: []dto.ModulReviewItem{{ID: lifecycleModulID, OwnerName: "Dosen Satu"}},
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/reviews?page=2&limit=500", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockModulUC.AssertExpectations(t)
+}
@@ -0,0 +1,114 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "invento-service/internal/errors"
+)
+
+const revisionModulID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestModulController_GetRevisions(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	mockModulUC.On("GetRevisions", revisionModulID, "user-1").Return(&dto.ModulRevisionListData{
+		ModulID: revisionModulID,
+		Items: []dto.ModulRevisionItem{
+			{Revision: 2, Action: "metadata", Changes: []dto.ModulRevisionChange{{Field: "judul", Before: "Lama", After: "Baru"}}, IsCurrent: true},
+			{Revision: 1, Action: "create"},
+		},
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/"+revisionModulID+"/revisions", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	items := response["data"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 2)
+	changes := items[0].(map[string]interface{})["changes"].([]interface{})
+	assert.Equal(t, "judul", changes[0].(map[string]interface{})["field"])
+	mockModulUC.AssertExpectations(t)
+}
+
+func TestModulController_GetRevisions_InvalidID(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/bukan-uuid/revisions", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockModulUC.AssertNotCalled(t, "GetRevisions")
+}
+
+func TestModulController_DownloadRevision(t *testing.T) {
+	t.Parallel()
+	mockModulUC := new(MockModulUsecase)
+	app := newModulApp(mockModulUC)
+
+	backend := storage.NewMemoryBackend()
+	key := "/data/moduls/user-1/abc/v1.dat"
+	require.NoError(t, backend.Put(context.Background(), key, strings.NewReader("modul lama"), 10))
+	mockModulUC.On("DownloadRevision", revisionModulID, "user-1", 1).Return(storage.NewFileDownload(backend, key, "materi.pdf"), nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/"+revisionModulID+"/revisions/1/download", http.NoBody))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "modul lama", string(body))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/modul/"+revisionModulID+"/revisions/0/download", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestModulController_Rollback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		mockModulUC := new(MockModulUsecase)
+		app := newModulApp(mockModulUC)
+
+		mockModulUC.On("Rollback", revisionModulID, "user-1", 1, dto.RollbackModulRequest{Catatan: "pakai materi tahun lalu"}).
+			Return(&dto.ModulRevisionItem{Revision: 3, Action: "rollback", IsCurrent: true}, nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/modul/"+revisionModulID+"/revisions/1/rollback", strings.NewReader(`{"catatan":"pakai materi tahun lalu"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockModulUC.AssertExpectations(t)
+	})
+
+	t.Run("already matches", func(t *testing.T) {
+		t.Parallel()
+		mockModulUC := new(MockModulUsecase)
+		app := newModulApp(mockModulUC)
+
+		mockModulUC.On("Rollback", revisionModulID, "user-1", 2, dto.RollbackModulRequest{}).
+			Return(nil, apperrors.NewConflictError("modul sudah sama dengan revisi ini"))
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/modul/"+revisionModulID+"/revisions/2/rollback", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
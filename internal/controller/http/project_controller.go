@@ -222,16 +222,21 @@ func (ctrl *ProjectController) Delete(c *fiber.Ctx) error {
 // Download handles POST /api/v1/project/download
 //
 // @Summary Download projects as ZIP
-// @Description Download one or more projects as a ZIP file
+// @Description Download one or more projects. Several projects are streamed as a ZIP laid out as projects/<nama_project>/ with a manifest.json
 // @Tags Project
 // @Accept json
 // @Produce application/zip
 // @Security BearerAuth
 // @Param request body dto.ProjectDownloadRequest true "Download request with project IDs"
+// @Param Range header string false "Byte range to resume a single-file download, e.g. bytes=1048576-"
 // @Success 200 {file} binary "ZIP file containing project files"
+// @Success 206 {file} binary "Requested byte range of a single file"
+// @Header 200,206 {string} ETag "Entity tag of a single file"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request format or empty IDs"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "One or more projects not found"
+// @Failure 413 {object} dto.ErrorResponse "Selected projects exceed the archive size limit"
+// @Failure 416 {string} string "Range not satisfiable"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/download [post]
 func (ctrl *ProjectController) Download(c *fiber.Ctx) error {
@@ -259,7 +264,7 @@ func (ctrl *ProjectController) Download(c *fiber.Ctx) error {
 	}
 
 	// Call usecase
-	filePath, err := ctrl.projectUsecase.Download(ctx, userID, req.IDs)
+	download, err := ctrl.projectUsecase.Download(ctx, userID, req.IDs)
 	if err != nil {
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
@@ -268,5 +273,260 @@ func (ctrl *ProjectController) Download(c *fiber.Ctx) error {
 		return ctrl.SendInternalError(c)
 	}
 
-	return c.Download(filePath)
+	return ctrl.SendDownload(c, download)
+}
+
+// GetTree handles GET /api/v1/project/:id/tree
+//
+// @Summary Get project file tree
+// @Description List the files inside the project ZIP as a directory tree, read from the archive's central directory without extracting it. Available to the owner and the assigned reviewers.
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectTreeData} "File tree retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - neither owner nor reviewer"
+// @Failure 404 {object} dto.ErrorResponse "Project or project file not found"
+// @Failure 422 {object} dto.ErrorResponse "Project file is not a readable ZIP"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/tree [get]
+func (ctrl *ProjectController) GetTree(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	// Get authenticated user ID using base controller
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	// Parse project ID from path
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+
+	// Call usecase
+	result, err := ctrl.projectUsecase.GetTree(ctx, projectID, userID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Struktur file project berhasil diambil")
+}
+
+// GetFile handles GET /api/v1/project/:id/file
+//
+// @Summary Get a file from a project
+// @Description Stream a single file from the project ZIP, decompressed on the fly. The content type is detected from the extension or the file's first bytes. Available to the owner and the assigned reviewers.
+// @Tags Project
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param path query string true "Path of the file inside the archive, as listed by /project/{id}/tree"
+// @Success 200 {file} binary "File content"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID or file path"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - neither owner nor reviewer"
+// @Failure 404 {object} dto.ErrorResponse "Project or file not found"
+// @Failure 422 {object} dto.ErrorResponse "Project file is not a readable ZIP"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/file [get]
+func (ctrl *ProjectController) GetFile(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	// Get authenticated user ID using base controller
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	// Parse project ID from path
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+
+	filePath := c.Query("path")
+	if filePath == "" {
+		return ctrl.SendBadRequest(c, "Parameter path wajib diisi")
+	}
+
+	// Call usecase
+	file, err := ctrl.projectUsecase.OpenFile(ctx, projectID, userID, filePath)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	// Uploaded files are untrusted: keep browsers from sniffing another type or
+	// running scripts if an HTML entry is opened directly.
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderContentSecurityPolicy, "sandbox")
+	return c.SendStream(file.Body, int(file.Size))
+}
+
+// GetVersions handles GET /api/v1/project/:id/versions
+//
+// @Summary List project versions
+// @Description List every stored version of the project file, newest first. Older versions beyond the configured retention are pruned automatically.
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectVersionListData} "Versions retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this project"
+// @Failure 404 {object} dto.ErrorResponse "Project not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/versions [get]
+func (ctrl *ProjectController) GetVersions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	// Get authenticated user ID using base controller
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	// Parse project ID from path
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+
+	// Call usecase
+	result, err := ctrl.projectUsecase.GetVersions(ctx, projectID, userID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Versi project berhasil diambil")
+}
+
+// DownloadVersion handles GET /api/v1/project/:id/versions/:version/download
+//
+// @Summary Download a project version
+// @Description Download the project file as it was stored in the given version
+// @Tags Project
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param version path int true "Version number"
+// @Param Range header string false "Byte range to resume the download, e.g. bytes=1048576-"
+// @Success 200 {file} binary "Project file of the version"
+// @Success 206 {file} binary "Requested byte range of the file"
+// @Header 200,206 {string} ETag "Entity tag of the file"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID or version"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this project"
+// @Failure 404 {object} dto.ErrorResponse "Project or version not found"
+// @Failure 416 {string} string "Range not satisfiable"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/versions/{version}/download [get]
+func (ctrl *ProjectController) DownloadVersion(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	// Get authenticated user ID using base controller
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	// Parse project ID and version from path
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+	version, err := c.ParamsInt("version")
+	if err != nil || version <= 0 {
+		return ctrl.SendBadRequest(c, "Versi tidak valid")
+	}
+
+	// Call usecase
+	download, err := ctrl.projectUsecase.DownloadVersion(ctx, projectID, userID, version)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendDownload(c, download)
+}
+
+// RestoreVersion handles POST /api/v1/project/:id/versions/:version/restore
+//
+// @Summary Restore a project version
+// @Description Make an older version the project's current file. The restore is recorded as a new version, so it can itself be undone. A reviewed project goes back to submitted.
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param version path int true "Version number"
+// @Param request body dto.RestoreProjectVersionRequest false "Optional note for the new version"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectVersionItem} "Version restored successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID, version or request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - no access to this project"
+// @Failure 404 {object} dto.ErrorResponse "Project, version or version file not found"
+// @Failure 409 {object} dto.ErrorResponse "Version is already the current file or the review status changed meanwhile"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/versions/{version}/restore [post]
+func (ctrl *ProjectController) RestoreVersion(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	// Get authenticated user ID using base controller
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	// Parse project ID and version from path
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+	version, err := c.ParamsInt("version")
+	if err != nil || version <= 0 {
+		return ctrl.SendBadRequest(c, "Versi tidak valid")
+	}
+
+	// The body is optional; it only carries a note for the new version
+	var req dto.RestoreProjectVersionRequest
+	if len(c.Body()) > 0 {
+		if err = c.BodyParser(&req); err != nil {
+			return ctrl.SendBadRequest(c, "Format request tidak valid")
+		}
+	}
+
+	// Validate request
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	// Call usecase
+	result, err := ctrl.projectUsecase.RestoreVersion(ctx, projectID, userID, version, req)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return httputil.SendAppError(c, appErr)
+		}
+		return ctrl.SendInternalError(c)
+	}
+
+	return ctrl.SendSuccess(c, result, "Versi project berhasil dipulihkan")
 }
@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	"encoding/json"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "invento-service/internal/errors"
+)
+
+func TestProjectController_GetTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		mockUC.On("GetTree", uint(1), "user-1").Return(&dto.ProjectTreeData{
+			ProjectID:  1,
+			TotalFiles: 1,
+			TotalSize:  13,
+			Items: []dto.ProjectTreeNode{{
+				Name: "src", Path: "src", Type: "dir", Size: 13,
+				Children: []dto.ProjectTreeNode{{Name: "main.go", Path: "src/main.go", Type: "file", Size: 13}},
+			}},
+		}, nil)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/tree", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "Struktur file project berhasil diambil", response["message"])
+		data := response["data"].(map[string]interface{})
+		items := data["items"].([]interface{})
+		require.Len(t, items, 1)
+		src := items[0].(map[string]interface{})
+		assert.Equal(t, "dir", src["type"])
+		assert.Len(t, src["children"], 1)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		mockUC.On("GetTree", uint(2), "user-1").Return(nil, apperrors.NewForbiddenError("anda tidak memiliki akses ke project ini"))
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/2/tree", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestProjectController_GetFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams file with detected type", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		content := "package main\n"
+		mockUC.On("OpenFile", uint(1), "user-1", "src/main.go").Return(&storage.ArchiveFile{
+			Path:        "src/main.go",
+			Size:        int64(len(content)),
+			ContentType: "text/plain; charset=utf-8",
+			Body:        io.NopCloser(strings.NewReader(content)),
+		}, nil)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/file?path=src/main.go", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(body))
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/file", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockUC.AssertNotCalled(t, "OpenFile")
+	})
+
+	t.Run("entry not found", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		mockUC.On("OpenFile", uint(1), "user-1", "nope.txt").Return(nil, apperrors.NewNotFoundError("file di dalam project"))
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/file?path=nope.txt", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
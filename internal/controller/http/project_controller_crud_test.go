@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"invento-service/internal/dto"
+	"invento-service/internal/storage"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -52,9 +53,52 @@ func (m *MockProjectUsecase) Delete(ctx context.Context, projectID uint, userID
 	return args.Error(0)
 }
 
-func (m *MockProjectUsecase) Download(ctx context.Context, userID string, projectIDs []uint) (string, error) {
+func (m *MockProjectUsecase) Download(ctx context.Context, userID string, projectIDs []uint) (*storage.Download, error) {
 	args := m.Called(userID, projectIDs)
-	return args.String(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Download), args.Error(1)
+}
+
+func (m *MockProjectUsecase) GetTree(ctx context.Context, projectID uint, userID string) (*dto.ProjectTreeData, error) {
+	args := m.Called(projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectTreeData), args.Error(1)
+}
+
+func (m *MockProjectUsecase) OpenFile(ctx context.Context, projectID uint, userID, filePath string) (*storage.ArchiveFile, error) {
+	args := m.Called(projectID, userID, filePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.ArchiveFile), args.Error(1)
+}
+
+func (m *MockProjectUsecase) GetVersions(ctx context.Context, projectID uint, userID string) (*dto.ProjectVersionListData, error) {
+	args := m.Called(projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectVersionListData), args.Error(1)
+}
+
+func (m *MockProjectUsecase) DownloadVersion(ctx context.Context, projectID uint, userID string, version int) (*storage.Download, error) {
+	args := m.Called(projectID, userID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Download), args.Error(1)
+}
+
+func (m *MockProjectUsecase) RestoreVersion(ctx context.Context, projectID uint, userID string, version int, req dto.RestoreProjectVersionRequest) (*dto.ProjectVersionItem, error) {
+	args := m.Called(projectID, userID, version, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectVersionItem), args.Error(1)
 }
 
 // Test 1: GetByID_Success
@@ -284,7 +328,7 @@ func TestProjectController_Download_SingleFile(t *testing.T) {
 		IDs: []uint{1},
 	}
 
-	mockUC.On("Download", "user-1", []uint{1}).Return("/test/path/file.zip", nil)
+	mockUC.On("Download", "user-1", []uint{1}).Return(storage.NewFileDownload(storage.NewLocalBackend(), "/test/path/file.zip", "file.zip"), nil)
 
 	app := fiber.New()
 	app.Use(func(c *fiber.Ctx) error {
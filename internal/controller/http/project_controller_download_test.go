@@ -2,16 +2,21 @@ package http_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	httpcontroller "invento-service/internal/controller/http"
 
@@ -121,7 +126,7 @@ func TestProjectController_Download_ProjectNotFound(t *testing.T) {
 	}
 
 	appErr := apperrors.NewNotFoundError("Project")
-	mockUC.On("Download", "user-1", []uint{1, 2}).Return("", appErr)
+	mockUC.On("Download", "user-1", []uint{1, 2}).Return(nil, appErr)
 
 	app := fiber.New()
 	app.Use(func(c *fiber.Ctx) error {
@@ -367,3 +372,37 @@ func TestProjectController_GetList_FilterKategoriBoundaryCases(t *testing.T) {
 }
 
 // Test 20: GetList_PaginationBoundaries
+
+func TestProjectController_Download_SingleFileRange(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockProjectUsecase)
+	controller := httpcontroller.NewProjectController(mockUC, "https://test.supabase.co", nil)
+
+	backend := storage.NewMemoryBackend()
+	key := "/data/projects/user-1/abc/project.zip"
+	require.NoError(t, backend.Put(context.Background(), key, strings.NewReader("0123456789"), 10))
+	mockUC.On("Download", "user-1", []uint{1}).Return(storage.NewFileDownload(backend, key, "Sistem Informasi.zip"), nil)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-1")
+		c.Locals("user_email", "test@example.com")
+		c.Locals("user_role", "user")
+		return c.Next()
+	})
+	app.Post("/api/v1/project/download", controller.Download)
+
+	resp := app_testing.MakeRequestWithHeaders(app, "POST", "/api/v1/project/download", dto.ProjectDownloadRequest{IDs: []uint{1}}, map[string]string{
+		"Range": "bytes=5-",
+	})
+	require.Equal(t, fiber.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "bytes 5-9/10", resp.Header.Get(fiber.HeaderContentRange))
+	assert.Equal(t, `attachment; filename="Sistem Informasi.zip"`, resp.Header.Get(fiber.HeaderContentDisposition))
+	assert.NotEmpty(t, resp.Header.Get(fiber.HeaderETag))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "56789", string(body))
+
+	mockUC.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "invento-service/internal/errors"
+)
+
+func TestProjectController_GetVersions(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockProjectUsecase)
+	app := newProjectApp(mockUC)
+
+	mockUC.On("GetVersions", uint(1), "user-1").Return(&dto.ProjectVersionListData{
+		ProjectID: 1,
+		Retention: 10,
+		Items: []dto.ProjectVersionItem{
+			{Version: 2, Ukuran: "2.00KB", IsCurrent: true},
+			{Version: 1, Ukuran: "1.00KB", Catatan: "versi awal"},
+		},
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/versions", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	data := response["data"].(map[string]interface{})
+	items := data["items"].([]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, true, items[0].(map[string]interface{})["is_current"])
+	assert.Equal(t, "versi awal", items[1].(map[string]interface{})["catatan"])
+	mockUC.AssertExpectations(t)
+}
+
+func TestProjectController_DownloadVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		backend := storage.NewMemoryBackend()
+		key := "/data/projects/user-1/abc/project.zip"
+		require.NoError(t, backend.Put(context.Background(), key, strings.NewReader("versi-1"), 7))
+		mockUC.On("DownloadVersion", uint(1), "user-1", 1).Return(storage.NewFileDownload(backend, key, "Alpha v1.zip"), nil)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/versions/1/download", http.NoBody))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, `attachment; filename="Alpha v1.zip"`, resp.Header.Get(fiber.HeaderContentDisposition))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "versi-1", string(body))
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/1/versions/abc/download", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockUC.AssertNotCalled(t, "DownloadVersion")
+	})
+}
+
+func TestProjectController_RestoreVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without body", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		mockUC.On("RestoreVersion", uint(1), "user-1", 1, dto.RestoreProjectVersionRequest{}).
+			Return(&dto.ProjectVersionItem{Version: 3, IsCurrent: true, Catatan: "Dipulihkan dari versi 1"}, nil)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/project/1/versions/1/restore", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "Versi project berhasil dipulihkan", response["message"])
+		assert.InDelta(t, 3, response["data"].(map[string]interface{})["version"], 0)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("with note", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		mockUC.On("RestoreVersion", uint(1), "user-1", 2, dto.RestoreProjectVersionRequest{Catatan: "kembali ke demo"}).
+			Return(&dto.ProjectVersionItem{Version: 4, IsCurrent: true}, nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/project/1/versions/2/restore", strings.NewReader(`{"catatan":"kembali ke demo"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("already current", func(t *testing.T) {
+		t.Parallel()
+		mockUC := new(MockProjectUsecase)
+		app := newProjectApp(mockUC)
+
+		mockUC.On("RestoreVersion", uint(1), "user-1", 2, dto.RestoreProjectVersionRequest{}).
+			Return(nil, apperrors.NewConflictError("versi ini sudah menjadi versi aktif project"))
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/project/1/versions/2/restore", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"errors"
+	"invento-service/internal/controller/base"
+	"invento-service/internal/dto"
+	"invento-service/internal/httputil"
+	"invento-service/internal/usecase"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProjectReviewController struct {
+	*base.BaseController
+	projectReviewUsecase usecase.ProjectReviewUsecase
+}
+
+func NewProjectReviewController(projectReviewUsecase usecase.ProjectReviewUsecase, baseCtrl *base.BaseController) *ProjectReviewController {
+	return &ProjectReviewController{
+		BaseController:       baseCtrl,
+		projectReviewUsecase: projectReviewUsecase,
+	}
+}
+
+// GetQueue handles GET /api/v1/project/reviews
+//
+// @Summary List projects to review
+// @Description List the projects the user is assigned to review, longest waiting first. Without a status it lists projects submitted or under review.
+// @Tags Project Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Review status" Enums(submitted, under_review, revision_requested, approved)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectReviewListData} "Review queue retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/reviews [get]
+func (ctrl *ProjectReviewController) GetQueue(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	var params dto.ProjectReviewQueueParams
+	if err := c.QueryParser(&params); err != nil {
+		return ctrl.SendBadRequest(c, "Parameter query tidak valid")
+	}
+
+	result, err := ctrl.projectReviewUsecase.GetQueue(ctx, userID, params)
+	if err != nil {
+		return ctrl.handleProjectReviewError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Daftar review project berhasil diambil")
+}
+
+// AssignReviewers handles PUT /api/v1/project/:id/reviewers
+//
+// @Summary Choose project reviewers
+// @Description Replace the dosen asked to review the project. Only the project owner can choose reviewers; changing them puts the review back to submitted.
+// @Tags Project Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param request body dto.AssignProjectReviewersRequest true "Dosen to review the project"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectReviewResponse} "Reviewers assigned successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or a user is not a dosen"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not the project owner"
+// @Failure 404 {object} dto.ErrorResponse "Project or dosen not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/reviewers [put]
+func (ctrl *ProjectReviewController) AssignReviewers(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	var req dto.AssignProjectReviewersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.projectReviewUsecase.AssignReviewers(ctx, projectID, userID, req)
+	if err != nil {
+		return ctrl.handleProjectReviewError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Reviewer project berhasil disimpan")
+}
+
+// GetReview handles GET /api/v1/project/:id/review
+//
+// @Summary Get project review
+// @Description Retrieve the review status, reviewers and review history of a project. Available to the owner and the assigned reviewers.
+// @Tags Project Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectReviewResponse} "Project review retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - neither owner nor reviewer"
+// @Failure 404 {object} dto.ErrorResponse "Project not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/review [get]
+func (ctrl *ProjectReviewController) GetReview(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	result, err := ctrl.projectReviewUsecase.GetReview(ctx, projectID, userID)
+	if err != nil {
+		return ctrl.handleProjectReviewError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Review project berhasil diambil")
+}
+
+// Review handles POST /api/v1/project/:id/review
+//
+// @Summary Act on a project review
+// @Description Take a review step as an assigned reviewer: start moves a submitted project under review, request_revision and approve conclude the review. A comment is required when requesting a revision.
+// @Tags Project Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param request body dto.ReviewProjectRequest true "Review step"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ProjectReviewResponse} "Review recorded"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or missing revision comment"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - not an assigned reviewer"
+// @Failure 404 {object} dto.ErrorResponse "Project not found"
+// @Failure 409 {object} dto.ErrorResponse "Step not allowed in the current review status"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/review [post]
+func (ctrl *ProjectReviewController) Review(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	var req dto.ReviewProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	result, err := ctrl.projectReviewUsecase.Review(ctx, projectID, userID, req)
+	if err != nil {
+		return ctrl.handleProjectReviewError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Review project berhasil disimpan")
+}
+
+// Download handles GET /api/v1/project/:id/review/download
+//
+// @Summary Download a project under review
+// @Description Download the current file of a project the user reviews
+// @Tags Project Review
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {file} binary "Project file"
+// @Failure 400 {object} dto.ErrorResponse "Invalid project ID"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - neither owner nor reviewer"
+// @Failure 404 {object} dto.ErrorResponse "Project not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /project/{id}/review/download [get]
+func (ctrl *ProjectReviewController) Download(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	projectID, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return nil //nolint:nilerr // ParsePathID already sent HTTP error response
+	}
+
+	download, err := ctrl.projectReviewUsecase.Download(ctx, projectID, userID)
+	if err != nil {
+		return ctrl.handleProjectReviewError(c, err)
+	}
+
+	return ctrl.SendDownload(c, download)
+}
+
+func (ctrl *ProjectReviewController) handleProjectReviewError(c *fiber.Ctx, err error) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return httputil.SendAppError(c, appErr)
+	}
+	return ctrl.SendInternalError(c)
+}
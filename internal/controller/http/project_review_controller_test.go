@@ -0,0 +1,108 @@
+package http_test
+
+import (
+	"context"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProjectReviewUsecase is a mock for ProjectReviewUsecase
+type MockProjectReviewUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProjectReviewUsecase) AssignReviewers(ctx context.Context, projectID uint, userID string, req dto.AssignProjectReviewersRequest) (*dto.ProjectReviewResponse, error) {
+	args := m.Called(projectID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectReviewResponse), args.Error(1)
+}
+
+func (m *MockProjectReviewUsecase) GetReview(ctx context.Context, projectID uint, userID string) (*dto.ProjectReviewResponse, error) {
+	args := m.Called(projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectReviewResponse), args.Error(1)
+}
+
+func (m *MockProjectReviewUsecase) Review(ctx context.Context, projectID uint, reviewerID string, req dto.ReviewProjectRequest) (*dto.ProjectReviewResponse, error) {
+	args := m.Called(projectID, reviewerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectReviewResponse), args.Error(1)
+}
+
+func (m *MockProjectReviewUsecase) GetQueue(ctx context.Context, reviewerID string, params dto.ProjectReviewQueueParams) (*dto.ProjectReviewListData, error) {
+	args := m.Called(reviewerID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectReviewListData), args.Error(1)
+}
+
+func (m *MockProjectReviewUsecase) Download(ctx context.Context, projectID uint, userID string) (*storage.Download, error) {
+	args := m.Called(projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Download), args.Error(1)
+}
+
+func TestProjectReviewController_Review(t *testing.T) {
+	t.Parallel()
+	mockReviewUC := new(MockProjectReviewUsecase)
+	app := newProjectReviewApp(mockReviewUC)
+
+	req := dto.ReviewProjectRequest{Action: "request_revision", Komentar: "Lengkapi dokumentasi"}
+	mockReviewUC.On("Review", uint(5), "user-1", req).
+		Return(&dto.ProjectReviewResponse{ProjectID: 5, ReviewStatus: "revision_requested"}, nil)
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/project/5/review", `{"action":"request_revision","komentar":"Lengkapi dokumentasi"}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(jsonRequest("POST", "/api/v1/project/5/review", `{"action":"reject"}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, "unknown action")
+	mockReviewUC.AssertNumberOfCalls(t, "Review", 1)
+}
+
+func TestProjectReviewController_Review_NotReviewer(t *testing.T) {
+	t.Parallel()
+	mockReviewUC := new(MockProjectReviewUsecase)
+	app := newProjectReviewApp(mockReviewUC)
+
+	mockReviewUC.On("Review", uint(5), "user-1", dto.ReviewProjectRequest{Action: "approve"}).
+		Return(nil, apperrors.NewForbiddenError("Hanya reviewer project yang dapat mereview project ini"))
+
+	resp, err := app.Test(jsonRequest("POST", "/api/v1/project/5/review", `{"action":"approve"}`))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestProjectReviewController_GetQueue(t *testing.T) {
+	t.Parallel()
+	mockReviewUC := new(MockProjectReviewUsecase)
+	app := newProjectReviewApp(mockReviewUC)
+
+	mockReviewUC.On("GetQueue", "user-1", dto.ProjectReviewQueueParams{Status: "under_review", Page: 2}).
+		Return(&dto.ProjectReviewListData{Items: []dto.ProjectReviewItem{}}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/reviews?status=under_review&page=2", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockReviewUC.AssertExpectations(t)
+}
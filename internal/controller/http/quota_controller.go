@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"invento-service/internal/controller/base"
+	"invento-service/internal/dto"
+	"invento-service/internal/httputil"
+	"invento-service/internal/usecase"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// QuotaController handles storage quota HTTP requests: the current user's usage
+// and the admin endpoints that adjust per-user and per-role limits.
+type QuotaController struct {
+	*base.BaseController
+	quotaUsecase usecase.QuotaUsecase
+}
+
+// NewQuotaController creates a new QuotaController instance.
+func NewQuotaController(quotaUsecase usecase.QuotaUsecase, baseController *base.BaseController) *QuotaController {
+	if baseController == nil {
+		baseController = base.NewBaseController("", nil)
+	}
+
+	return &QuotaController{
+		BaseController: baseController,
+		quotaUsecase:   quotaUsecase,
+	}
+}
+
+// GetMyQuota handles GET /api/v1/profile/quota - Get current user storage quota
+// @Summary Get my storage quota
+// @Description Get the storage limits and current usage (bytes and file count) of the authenticated user, including uploads still in progress
+// @Tags User Profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotaData}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /profile/quota [get]
+func (ctrl *QuotaController) GetMyQuota(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID := ctrl.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil // unauthorized response already sent
+	}
+
+	result, err := ctrl.quotaUsecase.GetQuota(ctx, userID)
+	if err != nil {
+		return ctrl.handleQuotaError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kuota penyimpanan berhasil diambil")
+}
+
+// GetUserQuota handles GET /api/v1/user/{id}/quota - Get user storage quota
+// @Summary Get user storage quota
+// @Description Get the storage limits, override and current usage of a specific user
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotaData}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/quota [get]
+func (ctrl *QuotaController) GetUserQuota(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return err // error response already sent
+	}
+
+	result, err := ctrl.quotaUsecase.GetQuota(ctx, userID)
+	if err != nil {
+		return ctrl.handleQuotaError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kuota penyimpanan user berhasil diambil")
+}
+
+// UpdateUserQuota handles PUT /api/v1/user/{id}/quota - Override user storage quota
+// @Summary Update user storage quota
+// @Description Override the storage limits of a specific user. Omitted fields fall back to the role quota; sending neither field removes the override. A limit of 0 means unlimited.
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param request body dto.UpdateUserQuotaRequest true "Quota override"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotaData}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /user/{id}/quota [put]
+func (ctrl *QuotaController) UpdateUserQuota(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userID, err := ctrl.ParsePathUUID(c)
+	if err != nil {
+		return err // error response already sent
+	}
+
+	var req dto.UpdateUserQuotaRequest
+	if err = c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil // validation error response already sent
+	}
+
+	result, err := ctrl.quotaUsecase.UpdateUserQuota(ctx, userID, req)
+	if err != nil {
+		return ctrl.handleQuotaError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kuota penyimpanan user berhasil diperbarui")
+}
+
+// GetRoleQuota retrieves the default storage quota of a role.
+//
+// @Summary  Get role storage quota
+// @Description Retrieves the storage limits applied to users of a role that have no override
+// @Tags   Roles
+// @Accept   json
+// @Produce  json
+// @Param   id path  int true "Role ID"
+// @Success  200 {object} dto.SuccessResponse{data=dto.RoleQuotaData} "Role quota retrieved successfully"
+// @Failure  400 {object} dto.ErrorResponse "Invalid role ID"
+// @Failure  404 {object} dto.ErrorResponse "Role not found"
+// @Failure  500 {object} dto.ErrorResponse "Internal server error"
+// @Router   /role/{id}/quota [get]
+// @Security  BearerAuth
+func (ctrl *QuotaController) GetRoleQuota(c *fiber.Ctx) error {
+	id, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.UserContext()
+	result, err := ctrl.quotaUsecase.GetRoleQuota(ctx, id)
+	if err != nil {
+		return ctrl.handleQuotaError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kuota penyimpanan role berhasil diambil")
+}
+
+// UpdateRoleQuota sets the default storage quota of a role.
+//
+// @Summary  Update role storage quota
+// @Description Sets the storage limits applied to users of a role that have no override. A limit of 0 means unlimited.
+// @Tags   Roles
+// @Accept   json
+// @Produce  json
+// @Param   id  path  int       true "Role ID"
+// @Param   request body  dto.UpdateRoleQuotaRequest true "Role quota"
+// @Success  200  {object} dto.SuccessResponse{data=dto.RoleQuotaData} "Role quota updated successfully"
+// @Failure  400  {object} dto.ErrorResponse "Invalid request format"
+// @Failure  404  {object} dto.ErrorResponse "Role not found"
+// @Failure  500  {object} dto.ErrorResponse "Internal server error"
+// @Router   /role/{id}/quota [put]
+// @Security  BearerAuth
+func (ctrl *QuotaController) UpdateRoleQuota(c *fiber.Ctx) error {
+	id, err := ctrl.ParsePathID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateRoleQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return ctrl.SendBadRequest(c, "Format request tidak valid")
+	}
+
+	if !ctrl.ValidateStruct(c, req) {
+		return nil
+	}
+
+	ctx := c.UserContext()
+	result, err := ctrl.quotaUsecase.UpdateRoleQuota(ctx, id, req)
+	if err != nil {
+		return ctrl.handleQuotaError(c, err)
+	}
+
+	return ctrl.SendSuccess(c, result, "Kuota penyimpanan role berhasil diperbarui")
+}
+
+// handleQuotaError maps quota usecase errors to HTTP responses.
+func (ctrl *QuotaController) handleQuotaError(c *fiber.Ctx, err error) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return httputil.SendAppError(c, appErr)
+	}
+	return ctrl.SendInternalError(c)
+}
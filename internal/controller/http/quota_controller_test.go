@@ -0,0 +1,213 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"invento-service/internal/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpcontroller "invento-service/internal/controller/http"
+
+	apperrors "invento-service/internal/errors"
+	app_testing "invento-service/internal/testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockQuotaUsecase is a mock for usecase.QuotaUsecase
+type MockQuotaUsecase struct {
+	mock.Mock
+}
+
+func (m *MockQuotaUsecase) GetQuota(ctx context.Context, userID string) (*dto.QuotaData, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.QuotaData), args.Error(1)
+}
+
+func (m *MockQuotaUsecase) CheckUpload(ctx context.Context, userID string, check dto.QuotaCheck) error {
+	args := m.Called(ctx, userID, check)
+	return args.Error(0)
+}
+
+func (m *MockQuotaUsecase) UpdateUserQuota(ctx context.Context, userID string, req dto.UpdateUserQuotaRequest) (*dto.QuotaData, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.QuotaData), args.Error(1)
+}
+
+func (m *MockQuotaUsecase) GetRoleQuota(ctx context.Context, roleID uint) (*dto.RoleQuotaData, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleQuotaData), args.Error(1)
+}
+
+func (m *MockQuotaUsecase) UpdateRoleQuota(ctx context.Context, roleID uint, req dto.UpdateRoleQuotaRequest) (*dto.RoleQuotaData, error) {
+	args := m.Called(ctx, roleID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleQuotaData), args.Error(1)
+}
+
+func TestQuotaController_GetMyQuota_Success(t *testing.T) {
+	t.Parallel()
+	mockQuotaUC := new(MockQuotaUsecase)
+	controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+
+	app := setupTestAppWithAuthForUser()
+	app.Get("/api/v1/profile/quota", controller.GetMyQuota)
+
+	userID := "00000000-0000-0000-0000-000000000001"
+	mockQuotaUC.On("GetQuota", mock.Anything, userID).Return(&dto.QuotaData{
+		UserID:    userID,
+		MaxBytes:  1024,
+		MaxFiles:  5,
+		UsedBytes: 512,
+		UsedFiles: 2,
+	}, nil)
+
+	token := app_testing.GenerateTestToken(userID, "test@example.com", "admin")
+	req := httptest.NewRequest("GET", "/api/v1/profile/quota", http.NoBody)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("X-Test-User-ID", "1")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, "Kuota penyimpanan berhasil diambil", response["message"])
+	data := response["data"].(map[string]interface{})
+	assert.InDelta(t, 512, data["used_bytes"], 0)
+	assert.InDelta(t, 5, data["max_files"], 0)
+
+	mockQuotaUC.AssertExpectations(t)
+}
+
+func TestQuotaController_GetMyQuota_Unauthorized(t *testing.T) {
+	t.Parallel()
+	mockQuotaUC := new(MockQuotaUsecase)
+	controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+
+	app := setupTestAppWithAuthForUser()
+	app.Get("/api/v1/profile/quota", controller.GetMyQuota)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/profile/quota", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	mockQuotaUC.AssertNotCalled(t, "GetQuota", mock.Anything, mock.Anything)
+}
+
+func TestQuotaController_UpdateUserQuota(t *testing.T) {
+	t.Parallel()
+	userID := "00000000-0000-0000-0000-000000000002"
+
+	t.Run("sets override", func(t *testing.T) {
+		t.Parallel()
+		mockQuotaUC := new(MockQuotaUsecase)
+		controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+		app := fiber.New()
+		app.Put("/api/v1/user/:id/quota", controller.UpdateUserQuota)
+
+		maxBytes := int64(2048)
+		mockQuotaUC.On("UpdateUserQuota", mock.Anything, userID, dto.UpdateUserQuotaRequest{MaxBytes: &maxBytes}).
+			Return(&dto.QuotaData{UserID: userID, MaxBytes: maxBytes, Override: &dto.QuotaOverride{MaxBytes: &maxBytes}}, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/user/"+userID+"/quota", strings.NewReader(`{"max_bytes":2048}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockQuotaUC.AssertExpectations(t)
+	})
+
+	t.Run("rejects negative limit", func(t *testing.T) {
+		t.Parallel()
+		mockQuotaUC := new(MockQuotaUsecase)
+		controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+		app := fiber.New()
+		app.Put("/api/v1/user/:id/quota", controller.UpdateUserQuota)
+
+		req := httptest.NewRequest("PUT", "/api/v1/user/"+userID+"/quota", strings.NewReader(`{"max_files":-1}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockQuotaUC.AssertNotCalled(t, "UpdateUserQuota", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		t.Parallel()
+		mockQuotaUC := new(MockQuotaUsecase)
+		controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+		app := fiber.New()
+		app.Put("/api/v1/user/:id/quota", controller.UpdateUserQuota)
+
+		mockQuotaUC.On("UpdateUserQuota", mock.Anything, userID, dto.UpdateUserQuotaRequest{}).
+			Return(nil, apperrors.NewNotFoundError("User"))
+
+		req := httptest.NewRequest("PUT", "/api/v1/user/"+userID+"/quota", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestQuotaController_RoleQuota(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+		mockQuotaUC := new(MockQuotaUsecase)
+		controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+		app := fiber.New()
+		app.Get("/api/v1/role/:id/quota", controller.GetRoleQuota)
+
+		mockQuotaUC.On("GetRoleQuota", mock.Anything, uint(3)).
+			Return(&dto.RoleQuotaData{RoleID: 3, NamaRole: "mahasiswa", MaxBytes: 1024, MaxFiles: 10}, nil)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/role/3/quota", http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockQuotaUC.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+		mockQuotaUC := new(MockQuotaUsecase)
+		controller := httpcontroller.NewQuotaController(mockQuotaUC, nil)
+		app := fiber.New()
+		app.Put("/api/v1/role/:id/quota", controller.UpdateRoleQuota)
+
+		reqBody := dto.UpdateRoleQuotaRequest{MaxBytes: 4096, MaxFiles: 0}
+		mockQuotaUC.On("UpdateRoleQuota", mock.Anything, uint(3), reqBody).
+			Return(&dto.RoleQuotaData{RoleID: 3, NamaRole: "mahasiswa", MaxBytes: 4096}, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/role/3/quota", strings.NewReader(`{"max_bytes":4096,"max_files":0}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "Kuota penyimpanan role berhasil diperbarui", response["message"])
+		mockQuotaUC.AssertExpectations(t)
+	})
+}
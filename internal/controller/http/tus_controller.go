@@ -2,9 +2,11 @@ package http
 
 import (
 	"invento-service/config"
+	"invento-service/internal/domain"
 	"invento-service/internal/dto"
 	"invento-service/internal/upload"
 	"invento-service/internal/usecase"
+	"io"
 	"strconv"
 
 	base "invento-service/internal/controller/base"
@@ -94,20 +96,27 @@ func (ctrl *TusController) ResetUploadQueue(c *fiber.Ctx) error {
 
 // InitiateUpload handles POST /api/v1/project/upload/ - Initiate new project upload
 // @Summary Initiate project upload
-// @Description Start a new TUS resumable upload for a project file
+// @Description Start a new TUS resumable upload for a project file. An application/offset+octet-stream body is stored as the first chunk and reflected in the returned offset
 // @Tags TUS Upload
-// @Accept json
+// @Accept json,application/offset+octet-stream
 // @Produce json
 // @Security BearerAuth
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
-// @Param Upload-Length header int true "Total file size in bytes"
-// @Param Upload-Metadata header string true "Upload metadata (nama_project, kategori, semester)"
-// @Success 201 {object} dto.SuccessResponse{data=dto.TusUploadResponse} "Upload initiated"
+// @Param Upload-Length header int false "Total file size in bytes, required unless Upload-Concat is final or Upload-Defer-Length is sent"
+// @Param Upload-Defer-Length header int false "Set to 1 when the size is not known yet; declare it later with Upload-Length on PATCH"
+// @Param Upload-Metadata header string false "Upload metadata (nama_project, kategori, semester, catatan, assignment_id), not sent for a partial upload. Catatan is stored as the note of the new project version. Without kategori the category is detected from the archive contents. assignment_id submits the project for that assignment"
+// @Param Upload-Concat header string false "'partial' for a slice of the file, or 'final;<upload url> ...' to join completed partial uploads"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TusUploadResponse} "Upload initiated, queued until a slot is free, or concatenated"
 // @Header 201 {string} Location "Upload URL"
 // @Header 201 {string} Tus-Resumable "TUS protocol version"
+// @Header 201 {string} Upload-Expires "RFC 7231 time after which the upload is discarded"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
-// @Failure 409 {object} dto.ErrorResponse "No upload slot available"
+// @Failure 409 {object} dto.ErrorResponse "User already has an upload in progress"
+// @Failure 413 {object} dto.ErrorResponse "File larger than the storage quota"
+// @Failure 422 {object} dto.ErrorResponse "Data sent with the request completed a file that is not a safe ZIP archive"
+// @Failure 460 {object} dto.ErrorResponse "Checksum of the data sent with the request does not match; the upload exists at offset 0"
+// @Failure 507 {object} dto.ErrorResponse "Storage quota exceeded"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/upload/ [post]
 func (ctrl *TusController) InitiateUpload(c *fiber.Ctx) error {
@@ -124,14 +133,32 @@ func (ctrl *TusController) initiateUpload(c *fiber.Ctx, projectID *uint) error {
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
-	fileSize, err := ctrl.parseUploadLength(c)
+	concat, err := upload.ParseUploadConcat(c.Get(upload.HeaderUploadConcat))
 	if err != nil {
-		return ctrl.base.SendBadRequest(c, "Header Upload-Length tidak valid")
+		return ctrl.base.SendBadRequest(c, err.Error())
+	}
+	isFinal := concat != nil && !concat.Partial
+
+	// A final upload takes its length from the partial uploads it joins.
+	var (
+		fileSize int64
+		body     io.Reader
+	)
+	if !isFinal {
+		fileSize, err = upload.ParseCreationLength(c)
+		if err != nil {
+			return ctrl.base.SendBadRequest(c, err.Error())
+		}
+		if body, err = parseCreationBody(c); err != nil {
+			return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+		}
 	}
 
 	uploadMetadata := c.Get(upload.HeaderUploadMetadata)
 	metadata := dto.TusUploadInitRequest{}
-	if projectID == nil || uploadMetadata != "" {
+	if concat != nil && concat.Partial {
+		metadata.ConcatPartial = true
+	} else if projectID == nil || uploadMetadata != "" {
 		metadata, err = ctrl.parseUploadMetadata(uploadMetadata)
 		if err != nil {
 			return ctrl.base.SendBadRequest(c, "Format Upload-Metadata tidak valid")
@@ -140,6 +167,9 @@ func (ctrl *TusController) initiateUpload(c *fiber.Ctx, projectID *uint) error {
 			return nil
 		}
 	}
+	if isFinal {
+		metadata.ConcatParts = concat.Parts
+	}
 
 	var result *dto.TusUploadResponse
 	ctx := c.UserContext()
@@ -152,9 +182,47 @@ func (ctrl *TusController) initiateUpload(c *fiber.Ctx, projectID *uint) error {
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
-	upload.SetTusResponseHeaders(c, 0, fileSize)
-	upload.SetTusLocationHeader(c, result.UploadURL)
-	return upload.SendTusInitiateResponse(c, result.UploadID, result.UploadURL, fileSize)
+	if isFinal {
+		return upload.SendTusConcatenatedResponse(c, result.UploadID, result.UploadURL, result.Length)
+	}
+
+	length := max(fileSize, 0)
+	upload.SetTusExpiresHeader(c, result.ExpiresAt)
+	if result.Status == domain.UploadStatusQueued {
+		return upload.SendTusQueuedResponse(c, result.UploadID, result.UploadURL, length, result.QueuePosition)
+	}
+
+	var offset int64
+	if body != nil {
+		if offset, err = ctrl.writeCreationBody(c, projectID, result.UploadID, userID, body); err != nil {
+			upload.SetTusLocationHeader(c, result.UploadURL)
+			return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+		}
+	}
+
+	return upload.SendTusInitiateResponse(c, result.UploadID, result.UploadURL, offset, length)
+}
+
+// writeCreationBody stores the data sent with the creation request. A rejected chunk,
+// such as a checksum mismatch or an unsafe archive, is returned as the request's error.
+// The upload already exists at this point, so any other failed write only leaves the
+// offset at zero for the client to resume with PATCH.
+func (ctrl *TusController) writeCreationBody(c *fiber.Ctx, projectID *uint, uploadID, userID string, body io.Reader) (int64, error) {
+	var (
+		newOffset int64
+		err       error
+	)
+	ctx := c.UserContext()
+	if projectID == nil {
+		newOffset, err = ctrl.tusUsecase.HandleChunk(ctx, uploadID, userID, 0, body)
+	} else {
+		newOffset, err = ctrl.tusUsecase.HandleProjectUpdateChunk(ctx, *projectID, uploadID, userID, 0, body)
+	}
+	if err != nil {
+		return 0, creationBodyError(err)
+	}
+
+	return newOffset, nil
 }
 
 // UploadChunk handles PATCH /api/v1/project/upload/{id} - Upload file chunk
@@ -166,14 +234,20 @@ func (ctrl *TusController) initiateUpload(c *fiber.Ctx, projectID *uint) error {
 // @Param id path string true "Upload ID"
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
 // @Param Upload-Offset header int true "Byte offset for this chunk"
+// @Param Upload-Checksum header string false "Chunk checksum as '<sha1|sha256|md5> <base64 digest>'"
+// @Param Upload-Length header int false "Total file size, declared once for an upload created with Upload-Defer-Length"
 // @Param Content-Type header string true "Content type" default(application/offset+octet-stream)
 // @Success 204 "Chunk uploaded"
 // @Header 204 {string} Upload-Offset "New byte offset after chunk"
 // @Header 204 {string} Tus-Resumable "TUS protocol version"
+// @Header 204 {string} Upload-Expires "Expiry moved forward by this chunk"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
 // @Failure 409 {object} dto.ErrorResponse "Offset mismatch"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
+// @Failure 422 "Completed file is not a safe ZIP archive; the upload is marked failed and failure_reason says why"
+// @Failure 460 "Checksum mismatch, offset not advanced"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/upload/{id} [patch]
 func (ctrl *TusController) UploadChunk(c *fiber.Ctx) error {
@@ -203,8 +277,18 @@ func (ctrl *TusController) uploadChunk(c *fiber.Ctx, projectID *uint) error {
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
-	var newOffset int64
 	ctx := c.UserContext()
+	declaredLength, declared, err := parseDeclaredLength(c)
+	if err != nil {
+		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+	}
+	if declared {
+		if err = ctrl.tusUsecase.DeclareUploadLength(ctx, uploadID, userID, declaredLength); err != nil {
+			return handleTusChunkError(c, err, ctrl.config.Upload.TusVersion)
+		}
+	}
+
+	var newOffset int64
 	if projectID == nil {
 		newOffset, err = ctrl.tusUsecase.HandleChunk(ctx, uploadID, userID, offset, bodyReader)
 	} else {
@@ -214,6 +298,7 @@ func (ctrl *TusController) uploadChunk(c *fiber.Ctx, projectID *uint) error {
 		return handleTusChunkError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
+	upload.SetTusExpiresHeader(c, upload.UploadExpiresAt(ctrl.config.Upload.IdleTimeout))
 	return upload.SendTusChunkResponse(c, newOffset)
 }
 
@@ -227,9 +312,13 @@ func (ctrl *TusController) uploadChunk(c *fiber.Ctx, projectID *uint) error {
 // @Success 200 "Upload status"
 // @Header 200 {string} Upload-Offset "Current byte offset"
 // @Header 200 {string} Upload-Length "Total file size"
+// @Header 200 {string} Upload-Defer-Length "Sent as 1 while the size is not declared yet"
+// @Header 200 {string} Upload-Expires "Omitted once the upload is completed"
+// @Header 200 {string} Upload-Concat "'partial' or 'final;<part urls>' for uploads created with Upload-Concat"
 // @Header 200 {string} Tus-Resumable "TUS protocol version"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/upload/{id} [head]
 func (ctrl *TusController) GetUploadStatus(c *fiber.Ctx) error {
@@ -255,26 +344,27 @@ func (ctrl *TusController) getUploadStatus(c *fiber.Ctx, projectID *uint) error
 	}
 
 	var (
-		offset int64
-		length int64
+		status *dto.TusUploadStatus
 		err    error
 	)
 	ctx := c.UserContext()
 	if projectID == nil {
-		offset, length, err = ctrl.tusUsecase.GetUploadStatus(ctx, uploadID, userID)
+		status, err = ctrl.tusUsecase.GetUploadStatus(ctx, uploadID, userID)
 	} else {
-		offset, length, err = ctrl.tusUsecase.GetProjectUpdateUploadStatus(ctx, *projectID, uploadID, userID)
+		status, err = ctrl.tusUsecase.GetProjectUpdateUploadStatus(ctx, *projectID, uploadID, userID)
 	}
 	if err != nil {
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
-	return upload.SendTusHeadResponse(c, offset, length)
+	upload.SetTusExpiresHeader(c, status.ExpiresAt)
+	upload.SetTusConcatHeader(c, status.ConcatPartial, status.ConcatParts)
+	return upload.SendTusHeadResponse(c, status.Offset, status.Length)
 }
 
 // GetUploadInfo handles GET /api/v1/project/upload/{id} - Get upload info
 // @Summary Get project upload info
-// @Description Get detailed information about a TUS project upload
+// @Description Get detailed information about a TUS project upload. Uploads rejected by archive inspection stay readable, with status failed and the reason in failure_reason
 // @Tags TUS Upload
 // @Produce json
 // @Security BearerAuth
@@ -374,20 +464,6 @@ func (ctrl *TusController) cancelUpload(c *fiber.Ctx, projectID *uint) error {
 	return upload.SendTusDeleteResponse(c)
 }
 
-func (ctrl *TusController) parseUploadLength(c *fiber.Ctx) (int64, error) {
-	uploadLengthStr := c.Get(upload.HeaderUploadLength)
-	if uploadLengthStr == "" {
-		return 0, fiber.NewError(fiber.StatusBadRequest, "Upload-Length header required")
-	}
-
-	fileSize, err := strconv.ParseInt(uploadLengthStr, 10, 64)
-	if err != nil || fileSize <= 0 {
-		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid Upload-Length")
-	}
-
-	return fileSize, nil
-}
-
 func (ctrl *TusController) parseUploadMetadata(metadataHeader string) (dto.TusUploadInitRequest, error) {
 	var metadata dto.TusUploadInitRequest
 
@@ -401,8 +477,9 @@ func (ctrl *TusController) parseUploadMetadata(metadataHeader string) (dto.TusUp
 	}
 	if kategori, ok := metadataMap["kategori"]; ok {
 		metadata.Kategori = kategori
-	} else {
-		metadata.Kategori = "website"
+	}
+	if catatan, ok := metadataMap["catatan"]; ok {
+		metadata.Catatan = catatan
 	}
 	if semesterStr, ok := metadataMap["semester"]; ok {
 		semester, err := strconv.Atoi(semesterStr)
@@ -410,6 +487,16 @@ func (ctrl *TusController) parseUploadMetadata(metadataHeader string) (dto.TusUp
 			metadata.Semester = semester
 		}
 	}
+	// Unlike semester, a malformed assignment_id is rejected: ignoring it would store
+	// the project without handing it in.
+	if assignmentStr, ok := metadataMap["assignment_id"]; ok && assignmentStr != "" {
+		assignmentID, err := strconv.ParseUint(assignmentStr, 10, 0)
+		if err != nil {
+			return metadata, fiber.NewError(fiber.StatusBadRequest, "invalid assignment_id")
+		}
+		id := uint(assignmentID)
+		metadata.AssignmentID = &id
+	}
 
 	return metadata, nil
 }
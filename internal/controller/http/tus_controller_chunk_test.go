@@ -252,7 +252,7 @@ func TestGetProjectUpdateUploadStatus_Success(t *testing.T) {
 	})
 	app.Head("/api/v1/tus/project/:id/upload/:upload_id", controller.GetProjectUpdateUploadStatus)
 
-	mockUC.On("GetProjectUpdateUploadStatus", mock.Anything, uint(1), "test-update-upload-id", "user-123").Return(int64(524288), int64(1048576), nil)
+	mockUC.On("GetProjectUpdateUploadStatus", mock.Anything, uint(1), "test-update-upload-id", "user-123").Return(&dto.TusUploadStatus{Offset: 524288, Length: 1048576}, nil)
 
 	req := httptest.NewRequest("HEAD", "/api/v1/tus/project/1/upload/test-update-upload-id", http.NoBody)
 	req.Header.Set("Tus-Resumable", "1.0.0")
@@ -329,3 +329,63 @@ func TestResetUploadQueue_Success(t *testing.T) {
 }
 
 // TestGetProjectUpdateUploadInfo_Success tests GET for project update upload metadata
+
+// TestUploadChunk_ChecksumMismatch tests that a rejected checksum is reported as TUS 460
+func TestUploadChunk_ChecksumMismatch(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	cfg := getTusTestConfig()
+	controller := httpcontroller.NewTusController(mockUC, cfg)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Patch("/api/v1/tus/upload/:id", controller.UploadChunk)
+
+	chunkData := []byte("test chunk data")
+	mockUC.On("HandleChunk", mock.Anything, "test-upload-id", "user-123", int64(0), mock.Anything).Return(int64(0), apperrors.NewTusChecksumMismatchError())
+
+	req := httptest.NewRequest("PATCH", "/api/v1/tus/upload/test-upload-id", bytes.NewReader(chunkData))
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Offset", "0")
+	req.Header.Set("Upload-Checksum", "sha1 Kq5sNclPz7QV2+lfQIuc6R7oRu0=")
+	req.Header.Set("Content-Type", upload.TusContentType)
+	req.Header.Set("Content-Length", strconv.Itoa(len(chunkData)))
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, upload.StatusChecksumMismatch, resp.StatusCode)
+
+	mockUC.AssertExpectations(t)
+}
+
+// TestUploadChunk_UnsupportedChecksumAlgorithm tests rejection before the chunk reaches the usecase
+func TestUploadChunk_UnsupportedChecksumAlgorithm(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	cfg := getTusTestConfig()
+	controller := httpcontroller.NewTusController(mockUC, cfg)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Patch("/api/v1/tus/upload/:id", controller.UploadChunk)
+
+	chunkData := []byte("test chunk data")
+	req := httptest.NewRequest("PATCH", "/api/v1/tus/upload/test-upload-id", bytes.NewReader(chunkData))
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Offset", "0")
+	req.Header.Set("Upload-Checksum", "crc32 AAAAAA==")
+	req.Header.Set("Content-Type", upload.TusContentType)
+	req.Header.Set("Content-Length", strconv.Itoa(len(chunkData)))
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	mockUC.AssertNotCalled(t, "HandleChunk", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -42,15 +42,21 @@ package http
 //    Headers:
 //      - Tus-Resumable: 1.0.0
 //      - Upload-Length: [total file size in bytes]
+//        or Upload-Defer-Length: 1 when the size is not known yet
 //      - Upload-Metadata: [base64 encoded metadata]
+//    Body (optional): first chunk with Content-Type application/offset+octet-stream
 //
 //    Server -> 201 Created
 //    Headers:
 //      - Tus-Resumable: 1.0.0
 //      - Location: [upload URL]
-//      - Upload-Offset: 0
+//      - Upload-Offset: [0, or the bytes stored from the request body]
+//      - Upload-Expires: [RFC 7231 date after which the upload is discarded]
 //    Body: JSON with upload_id, upload_url, offset, length
 //
+//    A queued upload (status "queued") expires as well unless the client keeps
+//    polling it with HEAD or GET; every poll moves Upload-Expires forward.
+//
 // 2. UPLOAD CHUNKS (PATCH)
 //    Client -> PATCH [upload URL from Location header]
 //    Headers:
@@ -58,12 +64,14 @@ package http
 //      - Upload-Offset: [current offset]
 //      - Content-Type: application/offset+octet-stream
 //      - Content-Length: [chunk size]
+//      - Upload-Length: [total file size, once, for a deferred-length upload]
 //    Body: [binary chunk data]
 //
 //    Server -> 204 No Content (on success)
 //    Headers:
 //      - Tus-Resumable: 1.0.0
 //      - Upload-Offset: [new offset after this chunk]
+//      - Upload-Expires: [expiry moved forward by the idle timeout]
 //
 //    Server -> 409 Conflict (on offset mismatch)
 //    Headers:
@@ -80,6 +88,8 @@ package http
 //      - Tus-Resumable: 1.0.0
 //      - Upload-Offset: [current offset]
 //      - Upload-Length: [total file size]
+//        or Upload-Defer-Length: 1 while the size is still unknown
+//      - Upload-Expires: [omitted once the upload is completed]
 //
 // 4. CANCEL UPLOAD (DELETE)
 //    Client -> DELETE [upload URL]
@@ -113,6 +123,9 @@ package http
 //   - Upload already completed
 //   - Response includes Upload-Offset header with expected value
 //
+// 410 Gone
+//   - Upload expired and was removed by the cleanup job
+//
 // 412 Precondition Failed
 //   - Unsupported TUS protocol version
 //
@@ -121,7 +134,7 @@ package http
 //   - Chunk size exceeds maximum
 //
 // 423 Locked
-//   - Upload session is inactive/expired
+//   - Upload session is inactive (queued, cancelled or failed)
 //
 // 500 Internal Server Error
 //   - Server-side processing error
@@ -105,7 +105,7 @@ func TestTusModulController_ModulUpdateEndpoints_Success(t *testing.T) {
 		Length:    4096,
 	}, nil).Once()
 	mockUC.On("HandleModulUpdateChunk", mock.Anything, modulID, "update-upload-id", "user-123", int64(0), mock.Anything).Return(int64(4), nil).Once()
-	mockUC.On("GetModulUpdateUploadStatus", mock.Anything, modulID, "update-upload-id", "user-123").Return(int64(4), int64(4096), nil).Once()
+	mockUC.On("GetModulUpdateUploadStatus", mock.Anything, modulID, "update-upload-id", "user-123").Return(int64(4), int64(4096), time.Time{}, nil).Once()
 	mockUC.On("GetModulUpdateUploadInfo", mock.Anything, modulID, "update-upload-id", "user-123").Return(&dto.TusModulUploadInfoResponse{UploadID: "update-upload-id"}, nil).Once()
 	mockUC.On("CancelModulUpdateUpload", mock.Anything, modulID, "update-upload-id", "user-123").Return(nil).Once()
 
@@ -3,6 +3,7 @@ package http_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"invento-service/internal/domain"
 	"invento-service/internal/upload"
 	"io"
@@ -185,6 +186,11 @@ func (m *MockTusModulControllerUsecase) HandleModulChunk(ctx context.Context, up
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockTusModulControllerUsecase) DeclareModulUploadLength(ctx context.Context, uploadID, userID string, length int64) error {
+	args := m.Called(ctx, uploadID, userID, length)
+	return args.Error(0)
+}
+
 func (m *MockTusModulControllerUsecase) GetModulUploadInfo(ctx context.Context, uploadID, userID string) (*dto.TusModulUploadInfoResponse, error) {
 	args := m.Called(ctx, uploadID, userID)
 	if args.Get(0) == nil {
@@ -193,9 +199,9 @@ func (m *MockTusModulControllerUsecase) GetModulUploadInfo(ctx context.Context,
 	return args.Get(0).(*dto.TusModulUploadInfoResponse), args.Error(1)
 }
 
-func (m *MockTusModulControllerUsecase) GetModulUploadStatus(ctx context.Context, uploadID, userID string) (offset, length int64, err error) {
+func (m *MockTusModulControllerUsecase) GetModulUploadStatus(ctx context.Context, uploadID, userID string) (offset, length int64, expiresAt time.Time, err error) {
 	args := m.Called(ctx, uploadID, userID)
-	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Get(2).(time.Time), args.Error(3)
 }
 
 func (m *MockTusModulControllerUsecase) CancelModulUpload(ctx context.Context, uploadID, userID string) error {
@@ -224,9 +230,9 @@ func (m *MockTusModulControllerUsecase) HandleModulUpdateChunk(ctx context.Conte
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockTusModulControllerUsecase) GetModulUpdateUploadStatus(ctx context.Context, modulID, uploadID, userID string) (offset, length int64, err error) {
+func (m *MockTusModulControllerUsecase) GetModulUpdateUploadStatus(ctx context.Context, modulID, uploadID, userID string) (offset, length int64, expiresAt time.Time, err error) {
 	args := m.Called(ctx, modulID, uploadID, userID)
-	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Get(2).(time.Time), args.Error(3)
 }
 
 func (m *MockTusModulControllerUsecase) GetModulUpdateUploadInfo(ctx context.Context, modulID, uploadID, userID string) (*dto.TusModulUploadInfoResponse, error) {
@@ -281,6 +287,76 @@ func TestTusModulController_InitiateUpload_Success(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestTusModulController_InitiateUpload_WithUploadCompletes(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusModulControllerUsecase)
+	controller := httpcontroller.NewTusModulController(mockUC, getTusTestConfig(), getTusBaseController())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/modul/upload", controller.InitiateUpload)
+
+	fileData := []byte("small modul")
+	metadataHeader := encodeTusMetadata(map[string]string{"judul": "Modul Kecil"})
+	mockUC.On("InitiateModulUpload", mock.Anything, "user-123", int64(len(fileData)), metadataHeader).Return(&dto.TusModulUploadResponse{
+		UploadID:  "modul-upload-id",
+		UploadURL: "/modul/upload/modul-upload-id",
+		Length:    int64(len(fileData)),
+	}, nil)
+	mockUC.On("HandleModulChunk", mock.Anything, "modul-upload-id", "user-123", int64(0), mock.Anything).Return(int64(len(fileData)), nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/modul/upload", bytes.NewReader(fileData))
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Length", strconv.Itoa(len(fileData)))
+	req.Header.Set("Upload-Metadata", metadataHeader)
+	req.Header.Set("Content-Type", upload.TusContentType)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, strconv.Itoa(len(fileData)), resp.Header.Get("Upload-Offset"))
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), fmt.Sprintf(`"offset":%d`, len(fileData)))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestTusModulController_InitiateUpload_DeferLength(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusModulControllerUsecase)
+	controller := httpcontroller.NewTusModulController(mockUC, getTusTestConfig(), getTusBaseController())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/modul/upload", controller.InitiateUpload)
+
+	metadataHeader := encodeTusMetadata(map[string]string{"judul": "Ekspor Modul"})
+	mockUC.On("InitiateModulUpload", mock.Anything, "user-123", upload.DeferredLength, metadataHeader).Return(&dto.TusModulUploadResponse{
+		UploadID:  "modul-upload-id",
+		UploadURL: "/modul/upload/modul-upload-id",
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/modul/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Defer-Length", "1")
+	req.Header.Set("Upload-Metadata", metadataHeader)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Upload-Length"))
+
+	mockUC.AssertExpectations(t)
+}
+
 func TestTusModulController_UploadChunk_Success(t *testing.T) {
 	t.Parallel()
 	mockUC := new(MockTusModulControllerUsecase)
@@ -326,7 +402,7 @@ func TestTusModulController_GetUploadStatus_Success(t *testing.T) {
 	})
 	app.Head("/api/v1/tus/modul/:upload_id", controller.GetUploadStatus)
 
-	mockUC.On("GetModulUploadStatus", mock.Anything, "modul-upload-id", "user-123").Return(int64(512), int64(1024), nil)
+	mockUC.On("GetModulUploadStatus", mock.Anything, "modul-upload-id", "user-123").Return(int64(512), int64(1024), time.Time{}, nil)
 
 	req := httptest.NewRequest("HEAD", "/api/v1/tus/modul/modul-upload-id", http.NoBody)
 	req.Header.Set("Tus-Resumable", "1.0.0")
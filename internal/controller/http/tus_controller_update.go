@@ -8,21 +8,28 @@ import (
 
 // InitiateProjectUpdateUpload handles POST /api/v1/project/{id}/upload - Initiate project update upload
 // @Summary Initiate project update upload
-// @Description Start a new TUS resumable upload to update an existing project file
+// @Description Start a new TUS resumable upload to update an existing project file. An application/offset+octet-stream body is stored as the first chunk and reflected in the returned offset. A project that reviewers have taken up goes back to submitted for review once the upload completes
 // @Tags TUS Upload
-// @Accept json
+// @Accept json,application/offset+octet-stream
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Project ID"
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
-// @Param Upload-Length header int true "Total file size in bytes"
-// @Param Upload-Metadata header string false "Upload metadata (nama_project, kategori, semester)"
-// @Success 201 {object} dto.SuccessResponse{data=dto.TusUploadResponse} "Update upload initiated"
+// @Param Upload-Length header int false "Total file size in bytes, required unless Upload-Concat is final or Upload-Defer-Length is sent"
+// @Param Upload-Defer-Length header int false "Set to 1 when the size is not known yet; declare it later with Upload-Length on PATCH"
+// @Param Upload-Metadata header string false "Upload metadata (nama_project, kategori, semester, catatan, assignment_id). Omitted fields keep the project's values; a detected kategori follows the new archive unless the user chose one. assignment_id submits the updated project for that assignment"
+// @Param Upload-Concat header string false "'partial' for a slice of the file, or 'final;<upload url> ...' to join completed partial uploads"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TusUploadResponse} "Update upload initiated or queued until a slot is free"
 // @Header 201 {string} Location "Upload URL"
 // @Header 201 {string} Tus-Resumable "TUS protocol version"
+// @Header 201 {string} Upload-Expires "RFC 7231 time after which the upload is discarded"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Project not found"
+// @Failure 413 {object} dto.ErrorResponse "File larger than the storage quota"
+// @Failure 422 {object} dto.ErrorResponse "Data sent with the request completed a file that is not a safe ZIP archive"
+// @Failure 460 {object} dto.ErrorResponse "Checksum of the data sent with the request does not match; the upload exists at offset 0"
+// @Failure 507 {object} dto.ErrorResponse "Storage quota exceeded"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/{id}/upload [post]
 func (ctrl *TusController) InitiateProjectUpdateUpload(c *fiber.Ctx) error {
@@ -44,14 +51,20 @@ func (ctrl *TusController) InitiateProjectUpdateUpload(c *fiber.Ctx) error {
 // @Param upload_id path string true "Upload ID"
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
 // @Param Upload-Offset header int true "Byte offset for this chunk"
+// @Param Upload-Checksum header string false "Chunk checksum as '<sha1|sha256|md5> <base64 digest>'"
+// @Param Upload-Length header int false "Total file size, declared once for an upload created with Upload-Defer-Length"
 // @Param Content-Type header string true "Content type" default(application/offset+octet-stream)
 // @Success 204 "Chunk uploaded"
 // @Header 204 {string} Upload-Offset "New byte offset after chunk"
 // @Header 204 {string} Tus-Resumable "TUS protocol version"
+// @Header 204 {string} Upload-Expires "Expiry moved forward by this chunk"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
 // @Failure 409 {object} dto.ErrorResponse "Offset mismatch"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
+// @Failure 422 "Completed file is not a safe ZIP archive; the upload is marked failed and failure_reason says why"
+// @Failure 460 "Checksum mismatch, offset not advanced"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/{id}/update/{upload_id} [patch]
 func (ctrl *TusController) UploadProjectUpdateChunk(c *fiber.Ctx) error {
@@ -73,10 +86,14 @@ func (ctrl *TusController) UploadProjectUpdateChunk(c *fiber.Ctx) error {
 // @Success 200 "Upload status"
 // @Header 200 {string} Upload-Offset "Current byte offset"
 // @Header 200 {string} Upload-Length "Total file size"
+// @Header 200 {string} Upload-Defer-Length "Sent as 1 while the size is not declared yet"
+// @Header 200 {string} Upload-Expires "Omitted once the upload is completed"
+// @Header 200 {string} Upload-Concat "'final;<part urls>' for an update assembled from partial uploads"
 // @Header 200 {string} Tus-Resumable "TUS protocol version"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /project/{id}/update/{upload_id} [head]
 func (ctrl *TusController) GetProjectUpdateUploadStatus(c *fiber.Ctx) error {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"invento-service/config"
 	"invento-service/internal/domain"
 	"invento-service/internal/rbac"
@@ -62,6 +63,11 @@ func (m *MockTusUploadUsecase) HandleChunk(ctx context.Context, uploadID, userID
 	return args.Get(0).(int64), nil
 }
 
+func (m *MockTusUploadUsecase) DeclareUploadLength(ctx context.Context, uploadID, userID string, length int64) error {
+	args := m.Called(ctx, uploadID, userID, length)
+	return args.Error(0)
+}
+
 func (m *MockTusUploadUsecase) GetUploadInfo(ctx context.Context, uploadID, userID string) (*dto.TusUploadInfoResponse, error) {
 	args := m.Called(ctx, uploadID, userID)
 	if args.Get(0) == nil {
@@ -75,9 +81,12 @@ func (m *MockTusUploadUsecase) CancelUpload(ctx context.Context, uploadID, userI
 	return args.Error(0)
 }
 
-func (m *MockTusUploadUsecase) GetUploadStatus(ctx context.Context, uploadID, userID string) (offset, length int64, err error) {
+func (m *MockTusUploadUsecase) GetUploadStatus(ctx context.Context, uploadID, userID string) (*dto.TusUploadStatus, error) {
 	args := m.Called(ctx, uploadID, userID)
-	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TusUploadStatus), args.Error(1)
 }
 
 func (m *MockTusUploadUsecase) InitiateProjectUpdateUpload(ctx context.Context, projectID uint, userID string, fileSize int64, metadata dto.TusUploadInitRequest) (*dto.TusUploadResponse, error) {
@@ -96,9 +105,12 @@ func (m *MockTusUploadUsecase) HandleProjectUpdateChunk(ctx context.Context, pro
 	return args.Get(0).(int64), nil
 }
 
-func (m *MockTusUploadUsecase) GetProjectUpdateUploadStatus(ctx context.Context, projectID uint, uploadID, userID string) (offset, length int64, err error) {
+func (m *MockTusUploadUsecase) GetProjectUpdateUploadStatus(ctx context.Context, projectID uint, uploadID, userID string) (*dto.TusUploadStatus, error) {
 	args := m.Called(ctx, projectID, uploadID, userID)
-	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TusUploadStatus), args.Error(1)
 }
 
 func (m *MockTusUploadUsecase) GetProjectUpdateUploadInfo(ctx context.Context, projectID uint, uploadID, userID string) (*dto.TusUploadInfoResponse, error) {
@@ -215,7 +227,357 @@ func TestInitiateUpload_Success(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+// TestInitiateUpload_Queued tests initiation while all upload slots are taken
+func TestInitiateUpload_Queued(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	cfg := getTusTestConfig()
+	controller := httpcontroller.NewTusController(mockUC, cfg)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	metadata := dto.TusUploadInitRequest{
+		NamaProject: "Test Project",
+		Kategori:    "website",
+		Semester:    1,
+	}
+
+	mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", int64(1048576), metadata).Return(&dto.TusUploadResponse{
+		UploadID:      "queued-upload-id",
+		UploadURL:     "/project/upload/queued-upload-id",
+		Length:        1048576,
+		Status:        domain.UploadStatusQueued,
+		QueuePosition: 2,
+		ExpiresAt:     time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC),
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Length", "1048576")
+	req.Header.Set("Upload-Metadata", encodeTusMetadata(map[string]string{
+		"nama_project": "Test Project",
+		"kategori":     "website",
+		"semester":     "1",
+	}))
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Location"), "queued-upload-id")
+	// Queued uploads expire too unless the client keeps polling them.
+	assert.Equal(t, "Wed, 04 Mar 2026 10:30:00 GMT", resp.Header.Get("Upload-Expires"))
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"status":"queued"`)
+	assert.Contains(t, string(body), `"queue_position":2`)
+
+	mockUC.AssertExpectations(t)
+}
+
+// TestInitiateUpload_AssignmentMetadata tests assignment_id parsing from Upload-Metadata
+func TestInitiateUpload_AssignmentMetadata(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	assignmentID := uint(7)
+	metadata := dto.TusUploadInitRequest{
+		NamaProject:  "Tugas ERD",
+		Kategori:     "website",
+		Semester:     3,
+		AssignmentID: &assignmentID,
+	}
+	mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", int64(1024), metadata).Return(&dto.TusUploadResponse{
+		UploadID:  "assignment-upload-id",
+		UploadURL: "/project/upload/assignment-upload-id",
+		Length:    1024,
+	}, nil)
+
+	newRequest := func(assignment string) *http.Request {
+		req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+		req.Header.Set("Tus-Resumable", "1.0.0")
+		req.Header.Set("Upload-Length", "1024")
+		req.Header.Set("Upload-Metadata", encodeTusMetadata(map[string]string{
+			"nama_project":  "Tugas ERD",
+			"kategori":      "website",
+			"semester":      "3",
+			"assignment_id": assignment,
+		}))
+		return req
+	}
+
+	resp, err := app.Test(newRequest("7"))
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	resp, err = app.Test(newRequest("tugas"))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	mockUC.AssertNumberOfCalls(t, "InitiateUpload", 1)
+}
+
 // TestInitiateUpload_InvalidHeaders tests missing TUS-Resumable header
+func TestInitiateUpload_PartialSkipsMetadata(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", int64(2048), dto.TusUploadInitRequest{ConcatPartial: true}).Return(&dto.TusUploadResponse{
+		UploadID:  "part-1",
+		UploadURL: "/project/upload/part-1",
+		Length:    2048,
+		Status:    domain.UploadStatusPending,
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Length", "2048")
+	req.Header.Set("Upload-Concat", "partial")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "/project/upload/part-1", resp.Header.Get("Location"))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestInitiateUpload_FinalConcatenation(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	metadata := dto.TusUploadInitRequest{
+		NamaProject: "Test Project",
+		Kategori:    "website",
+		Semester:    1,
+		ConcatParts: []string{"part-1", "part-2"},
+	}
+	mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", int64(0), metadata).Return(&dto.TusUploadResponse{
+		UploadID:  "final-id",
+		UploadURL: "/project/upload/final-id",
+		Offset:    4096,
+		Length:    4096,
+		Status:    domain.UploadStatusCompleted,
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Concat", "final;/api/v1/project/upload/part-1 /api/v1/project/upload/part-2")
+	req.Header.Set("Upload-Metadata", encodeTusMetadata(map[string]string{
+		"nama_project": "Test Project",
+		"kategori":     "website",
+		"semester":     "1",
+	}))
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "4096", resp.Header.Get("Upload-Offset"))
+	assert.Equal(t, "4096", resp.Header.Get("Upload-Length"))
+	assert.Equal(t, "/project/upload/final-id", resp.Header.Get("Location"))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestInitiateUpload_InvalidUploadConcat(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Concat", "final;")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	mockUC.AssertNotCalled(t, "InitiateUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInitiateUpload_DeferLength(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	metadata := dto.TusUploadInitRequest{NamaProject: "Export", Kategori: "website", Semester: 1}
+	mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", upload.DeferredLength, metadata).Return(&dto.TusUploadResponse{
+		UploadID:  "deferred-id",
+		UploadURL: "/project/upload/deferred-id",
+		Status:    domain.UploadStatusPending,
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Defer-Length", "1")
+	req.Header.Set("Upload-Metadata", encodeTusMetadata(map[string]string{
+		"nama_project": "Export",
+		"kategori":     "website",
+		"semester":     "1",
+	}))
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Upload-Length"))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestInitiateUpload_DeferLengthWithUploadLength(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Length", "2048")
+	req.Header.Set("Upload-Defer-Length", "1")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	mockUC.AssertNotCalled(t, "InitiateUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInitiateUpload_WithUpload(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+	chunkData := []byte("first chunk")
+	metadata := dto.TusUploadInitRequest{NamaProject: "Test Project", Kategori: "website", Semester: 1}
+	mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", int64(2048), metadata).Return(&dto.TusUploadResponse{
+		UploadID:  "test-upload-id",
+		UploadURL: "/project/upload/test-upload-id",
+		Length:    2048,
+		Status:    domain.UploadStatusPending,
+	}, nil)
+	mockUC.On("HandleChunk", mock.Anything, "test-upload-id", "user-123", int64(0), mock.Anything).Return(int64(len(chunkData)), nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/tus/upload", bytes.NewReader(chunkData))
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Length", "2048")
+	req.Header.Set("Content-Type", upload.TusContentType)
+	req.Header.Set("Upload-Metadata", encodeTusMetadata(map[string]string{
+		"nama_project": "Test Project",
+		"kategori":     "website",
+		"semester":     "1",
+	}))
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, strconv.Itoa(len(chunkData)), resp.Header.Get("Upload-Offset"))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestInitiateUpload_WithUploadRejectedChunk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		chunkErr   error
+		wantStatus int
+		wantOffset string
+	}{
+		{"checksum mismatch", apperrors.NewTusChecksumMismatchError(), upload.StatusChecksumMismatch, ""},
+		{"unsafe archive", apperrors.NewArchiveRejectedError("arsip berisi path tidak aman"), fiber.StatusUnprocessableEntity, ""},
+		{"transient write failure", errors.New("disk busy"), fiber.StatusCreated, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockUC := new(MockTusUploadUsecase)
+			controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				setTusAuthenticatedUser(c, "user-123", "test@example.com")
+				return c.Next()
+			})
+			app.Post("/api/v1/tus/upload", controller.InitiateUpload)
+
+			metadata := dto.TusUploadInitRequest{NamaProject: "Test Project", Kategori: "website", Semester: 1}
+			mockUC.On("InitiateUpload", mock.Anything, "user-123", "test@example.com", "user", int64(2048), metadata).Return(&dto.TusUploadResponse{
+				UploadID:  "test-upload-id",
+				UploadURL: "/project/upload/test-upload-id",
+				Length:    2048,
+				Status:    domain.UploadStatusPending,
+			}, nil)
+			mockUC.On("HandleChunk", mock.Anything, "test-upload-id", "user-123", int64(0), mock.Anything).Return(int64(0), tt.chunkErr)
+
+			req := httptest.NewRequest("POST", "/api/v1/tus/upload", bytes.NewReader([]byte("first chunk")))
+			req.Header.Set("Tus-Resumable", "1.0.0")
+			req.Header.Set("Upload-Length", "2048")
+			req.Header.Set("Content-Type", upload.TusContentType)
+			req.Header.Set("Upload-Metadata", encodeTusMetadata(map[string]string{
+				"nama_project": "Test Project",
+				"kategori":     "website",
+				"semester":     "1",
+			}))
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantOffset, resp.Header.Get("Upload-Offset"))
+			assert.Equal(t, "/project/upload/test-upload-id", resp.Header.Get("Location"))
+		})
+	}
+}
+
 func TestInitiateUpload_InvalidHeaders(t *testing.T) {
 	t.Parallel()
 	mockUC := new(MockTusUploadUsecase)
@@ -309,6 +671,41 @@ func TestUploadChunk_Success(t *testing.T) {
 	assert.Equal(t, "1.0.0", resp.Header.Get("Tus-Resumable"))
 	assert.Equal(t, strconv.Itoa(len(chunkData)), resp.Header.Get("Upload-Offset"))
 
+	expiresAt, err := http.ParseTime(resp.Header.Get("Upload-Expires"))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Duration(cfg.Upload.IdleTimeout)*time.Second), expiresAt, 2*time.Second)
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestUploadChunk_DeclaresDeferredLength(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Patch("/api/v1/tus/upload/:id", controller.UploadChunk)
+
+	chunkData := []byte("last chunk")
+	mockUC.On("DeclareUploadLength", mock.Anything, "deferred-id", "user-123", int64(4096)).Return(nil).Once()
+	mockUC.On("HandleChunk", mock.Anything, "deferred-id", "user-123", int64(4086), mock.Anything).Return(int64(4096), nil).Once()
+
+	req := httptest.NewRequest("PATCH", "/api/v1/tus/upload/deferred-id", bytes.NewReader(chunkData))
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Offset", "4086")
+	req.Header.Set("Upload-Length", "4096")
+	req.Header.Set("Content-Type", upload.TusContentType)
+	req.Header.Set("Content-Length", strconv.Itoa(len(chunkData)))
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Equal(t, "4096", resp.Header.Get("Upload-Offset"))
+
 	mockUC.AssertExpectations(t)
 }
 
@@ -403,7 +800,7 @@ func TestGetUploadStatus_Success(t *testing.T) {
 	})
 	app.Head("/api/v1/tus/upload/:id", controller.GetUploadStatus)
 
-	mockUC.On("GetUploadStatus", mock.Anything, "test-upload-id", "user-123").Return(int64(524288), int64(1048576), nil)
+	mockUC.On("GetUploadStatus", mock.Anything, "test-upload-id", "user-123").Return(&dto.TusUploadStatus{Offset: 524288, Length: 1048576}, nil)
 
 	req := httptest.NewRequest("HEAD", "/api/v1/tus/upload/test-upload-id", http.NoBody)
 	req.Header.Set("Tus-Resumable", "1.0.0")
@@ -420,6 +817,100 @@ func TestGetUploadStatus_Success(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestGetUploadStatus_DeferredLength(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Head("/api/v1/tus/upload/:id", controller.GetUploadStatus)
+
+	mockUC.On("GetUploadStatus", mock.Anything, "deferred-id", "user-123").Return(&dto.TusUploadStatus{Offset: 1024}, nil)
+
+	req := httptest.NewRequest("HEAD", "/api/v1/tus/upload/deferred-id", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "1024", resp.Header.Get("Upload-Offset"))
+	assert.Empty(t, resp.Header.Get("Upload-Length"))
+	assert.Equal(t, "1", resp.Header.Get("Upload-Defer-Length"))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetUploadStatus_UploadExpires(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Head("/api/v1/tus/upload/:id", controller.GetUploadStatus)
+
+	expiresAt := time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)
+	mockUC.On("GetUploadStatus", mock.Anything, "active-id", "user-123").Return(&dto.TusUploadStatus{Offset: 512, Length: 1024, ExpiresAt: expiresAt}, nil).Once()
+	mockUC.On("GetUploadStatus", mock.Anything, "expired-id", "user-123").Return(nil, apperrors.NewTusExpiredError()).Once()
+
+	req := httptest.NewRequest("HEAD", "/api/v1/tus/upload/active-id", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "Wed, 04 Mar 2026 10:30:00 GMT", resp.Header.Get("Upload-Expires"))
+
+	req = httptest.NewRequest("HEAD", "/api/v1/tus/upload/expired-id", http.NoBody)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusGone, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Upload-Expires"))
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetUploadStatus_UploadConcat(t *testing.T) {
+	t.Parallel()
+	mockUC := new(MockTusUploadUsecase)
+	controller := httpcontroller.NewTusController(mockUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Head("/api/v1/tus/upload/:id", controller.GetUploadStatus)
+
+	mockUC.On("GetUploadStatus", mock.Anything, "part-id", "user-123").Return(&dto.TusUploadStatus{Offset: 4, Length: 8, ConcatPartial: true}, nil).Once()
+	mockUC.On("GetUploadStatus", mock.Anything, "final-id", "user-123").Return(&dto.TusUploadStatus{
+		Offset: 16, Length: 16, ConcatParts: []string{"/project/upload/part-1", "/project/upload/part-2"},
+	}, nil).Once()
+	mockUC.On("GetUploadStatus", mock.Anything, "regular-id", "user-123").Return(&dto.TusUploadStatus{Offset: 1, Length: 8}, nil).Once()
+
+	for id, want := range map[string]string{
+		"part-id":    "partial",
+		"final-id":   "final;/project/upload/part-1 /project/upload/part-2",
+		"regular-id": "",
+	} {
+		req := httptest.NewRequest("HEAD", "/api/v1/tus/upload/"+id, http.NoBody)
+		req.Header.Set("Tus-Resumable", "1.0.0")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, want, resp.Header.Get("Upload-Concat"), id)
+	}
+
+	mockUC.AssertExpectations(t)
+}
+
 // TestGetUploadStatus_NotFound tests non-existent upload
 func TestGetUploadStatus_NotFound(t *testing.T) {
 	t.Parallel()
@@ -434,7 +925,7 @@ func TestGetUploadStatus_NotFound(t *testing.T) {
 	})
 	app.Head("/api/v1/tus/upload/:id", controller.GetUploadStatus)
 
-	mockUC.On("GetUploadStatus", mock.Anything, "nonexistent-id", "user-123").Return(int64(0), int64(0), apperrors.NewNotFoundError("upload"))
+	mockUC.On("GetUploadStatus", mock.Anything, "nonexistent-id", "user-123").Return(nil, apperrors.NewNotFoundError("upload"))
 
 	req := httptest.NewRequest("HEAD", "/api/v1/tus/upload/nonexistent-id", http.NoBody)
 	req.Header.Set("Tus-Resumable", "1.0.0")
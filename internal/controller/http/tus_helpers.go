@@ -7,6 +7,7 @@ import (
 	"invento-service/internal/middleware"
 	"invento-service/internal/upload"
 	"io"
+	"strconv"
 
 	apperrors "invento-service/internal/errors"
 
@@ -68,7 +69,62 @@ func parseChunkRequest(c *fiber.Ctx) (offset int64, body io.Reader, err error) {
 		return 0, nil, fiber.NewError(fiber.StatusBadRequest, "Ukuran chunk tidak sesuai dengan Content-Length")
 	}
 
-	return tusHeaders.UploadOffset, bytes.NewReader(bodyBytes), nil
+	bodyReader, err := newChunkReader(c, bodyBytes)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return tusHeaders.UploadOffset, bodyReader, nil
+}
+
+// parseCreationBody returns the first chunk sent along with a creation request
+// (creation-with-upload), or nil when the POST carries no upload data.
+func parseCreationBody(c *fiber.Ctx) (io.Reader, error) {
+	bodyBytes := c.Body()
+	if len(bodyBytes) == 0 || c.Get(upload.HeaderContentType) != upload.TusContentType {
+		return nil, nil
+	}
+	if int64(len(bodyBytes)) > upload.MaxChunkSize {
+		return nil, apperrors.NewPayloadTooLargeError("Ukuran data awal melebihi batas ukuran chunk")
+	}
+
+	return newChunkReader(c, bodyBytes)
+}
+
+// creationBodyError decides how a failed write of the data sent with a creation request
+// is answered. Errors the usecase raised on purpose are reported to the client; plain
+// I/O failures are dropped, since the upload exists and can be resumed from offset 0.
+func creationBodyError(err error) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return nil
+}
+
+// parseDeclaredLength reads the Upload-Length a client sends on PATCH to fix the size of
+// an upload created with Upload-Defer-Length. ok is false when the header is absent.
+func parseDeclaredLength(c *fiber.Ctx) (length int64, ok bool, err error) {
+	lengthStr := c.Get(upload.HeaderUploadLength)
+	if lengthStr == "" {
+		return 0, false, nil
+	}
+
+	length, err = strconv.ParseInt(lengthStr, 10, 64)
+	if err != nil || length <= 0 {
+		return 0, false, apperrors.NewValidationError("Header Upload-Length tidak valid", nil)
+	}
+
+	return length, true, nil
+}
+
+func newChunkReader(c *fiber.Ctx, body []byte) (io.Reader, error) {
+	checksum, err := upload.ParseUploadChecksum(c.Get(upload.HeaderUploadChecksum))
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error(), nil)
+	}
+
+	return upload.NewChecksumReader(bytes.NewReader(body), checksum), nil
 }
 
 func handleTusChunkError(c *fiber.Ctx, err error, tusVersion string) error {
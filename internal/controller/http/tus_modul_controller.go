@@ -6,6 +6,8 @@ import (
 	"invento-service/internal/dto"
 	"invento-service/internal/upload"
 	"invento-service/internal/usecase"
+	"io"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -51,20 +53,25 @@ func (ctrl *TusModulController) CheckUploadSlot(c *fiber.Ctx) error {
 
 // InitiateUpload handles POST /api/v1/modul/upload/ - Initiate new modul upload
 // @Summary Initiate modul upload
-// @Description Start a new TUS resumable upload for a module file
+// @Description Start a new TUS resumable upload for a module file. An application/offset+octet-stream body is stored as the first chunk and reflected in the returned offset
 // @Tags TUS Modul Upload
-// @Accept json
+// @Accept json,application/offset+octet-stream
 // @Produce json
 // @Security BearerAuth
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
-// @Param Upload-Length header int true "Total file size in bytes"
-// @Param Upload-Metadata header string true "Upload metadata (judul, deskripsi)"
+// @Param Upload-Length header int false "Total file size in bytes, required unless Upload-Defer-Length is sent"
+// @Param Upload-Defer-Length header int false "Set to 1 when the size is not known yet; declare it later with Upload-Length on PATCH"
+// @Param Upload-Metadata header string true "Upload metadata (judul, deskripsi, optional catatan for the first revision)"
 // @Success 201 {object} dto.SuccessResponse{data=dto.TusModulUploadResponse} "Upload initiated"
 // @Header 201 {string} Location "Upload URL"
 // @Header 201 {string} Tus-Resumable "TUS protocol version"
+// @Header 201 {string} Upload-Expires "RFC 7231 time after which the upload is discarded"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 409 {object} dto.ErrorResponse "No upload slot available"
+// @Failure 413 {object} dto.ErrorResponse "File larger than the storage quota"
+// @Failure 460 {object} dto.ErrorResponse "Checksum of the data sent with the request does not match; the upload exists at offset 0"
+// @Failure 507 {object} dto.ErrorResponse "Storage quota exceeded"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/upload/ [post]
 func (ctrl *TusModulController) InitiateUpload(c *fiber.Ctx) error {
@@ -73,21 +80,25 @@ func (ctrl *TusModulController) InitiateUpload(c *fiber.Ctx) error {
 
 // InitiateModulUpdateUpload handles POST /api/v1/modul/{id}/upload - Initiate modul update upload
 // @Summary Initiate modul update upload
-// @Description Start a new TUS resumable upload to update an existing module file
+// @Description Start a new TUS resumable upload to update an existing module file. An application/offset+octet-stream body is stored as the first chunk and reflected in the returned offset
 // @Tags TUS Modul Upload
-// @Accept json
+// @Accept json,application/offset+octet-stream
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Modul ID (UUID)"
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
-// @Param Upload-Length header int true "Total file size in bytes"
-// @Param Upload-Metadata header string true "Upload metadata (judul, deskripsi)"
+// @Param Upload-Length header int false "Total file size in bytes, required unless Upload-Defer-Length is sent"
+// @Param Upload-Defer-Length header int false "Set to 1 when the size is not known yet; declare it later with Upload-Length on PATCH"
+// @Param Upload-Metadata header string true "Upload metadata (judul, deskripsi, optional catatan describing the change)"
 // @Success 201 {object} dto.SuccessResponse{data=dto.TusModulUploadResponse} "Update upload initiated"
 // @Header 201 {string} Location "Upload URL"
 // @Header 201 {string} Tus-Resumable "TUS protocol version"
+// @Header 201 {string} Upload-Expires "RFC 7231 time after which the upload is discarded"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Modul not found"
+// @Failure 413 {object} dto.ErrorResponse "File larger than the storage quota"
+// @Failure 507 {object} dto.ErrorResponse "Storage quota exceeded"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/{id}/upload [post]
 func (ctrl *TusModulController) InitiateModulUpdateUpload(c *fiber.Ctx) error {
@@ -109,29 +120,39 @@ func (ctrl *TusModulController) initiateUpload(c *fiber.Ctx, modulID *string) er
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
-	tusHeaders, err := upload.GetTusHeaders(c)
+	fileSize, err := upload.ParseCreationLength(c)
 	if err != nil {
 		return upload.SendTusValidationErrorResponse(c, err.Error())
 	}
-	if tusHeaders.UploadLength <= 0 {
-		return upload.SendTusValidationErrorResponse(c, "Header Upload-Length wajib diisi")
-	}
-	if tusHeaders.UploadMetadata == "" {
+	uploadMetadata := c.Get(upload.HeaderUploadMetadata)
+	if uploadMetadata == "" {
 		return upload.SendTusValidationErrorResponse(c, "Header Upload-Metadata wajib diisi")
 	}
+	body, err := parseCreationBody(c)
+	if err != nil {
+		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+	}
 
 	var result *dto.TusModulUploadResponse
 	if modulID == nil {
-		result, err = ctrl.tusModulUsecase.InitiateModulUpload(ctx, userID, tusHeaders.UploadLength, tusHeaders.UploadMetadata)
+		result, err = ctrl.tusModulUsecase.InitiateModulUpload(ctx, userID, fileSize, uploadMetadata)
 	} else {
-		result, err = ctrl.tusModulUsecase.InitiateModulUpdateUpload(ctx, *modulID, userID, tusHeaders.UploadLength, tusHeaders.UploadMetadata)
+		result, err = ctrl.tusModulUsecase.InitiateModulUpdateUpload(ctx, *modulID, userID, fileSize, uploadMetadata)
 	}
 	if err != nil {
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
-	upload.SetTusResponseHeaders(c, 0, tusHeaders.UploadLength)
+	if body != nil {
+		if result.Offset, err = ctrl.writeCreationBody(c, modulID, result.UploadID, userID, body); err != nil {
+			upload.SetTusLocationHeader(c, result.UploadURL)
+			return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+		}
+	}
+
+	upload.SetTusResponseHeaders(c, result.Offset, result.Length)
 	upload.SetTusLocationHeader(c, result.UploadURL)
+	upload.SetTusExpiresHeader(c, result.ExpiresAt)
 
 	message := "Upload modul berhasil diinisiasi"
 	if modulID != nil {
@@ -141,6 +162,27 @@ func (ctrl *TusModulController) initiateUpload(c *fiber.Ctx, modulID *string) er
 	return ctrl.base.SendCreated(c, result, message)
 }
 
+// writeCreationBody stores the data sent with the creation request. A small modul sent
+// whole completes here. A rejected chunk is returned as the request's error; on any
+// other failure the offset stays at zero and the client resumes with PATCH.
+func (ctrl *TusModulController) writeCreationBody(c *fiber.Ctx, modulID *string, uploadID, userID string, body io.Reader) (int64, error) {
+	var (
+		newOffset int64
+		err       error
+	)
+	ctx := c.UserContext()
+	if modulID == nil {
+		newOffset, err = ctrl.tusModulUsecase.HandleModulChunk(ctx, uploadID, userID, 0, body)
+	} else {
+		newOffset, err = ctrl.tusModulUsecase.HandleModulUpdateChunk(ctx, *modulID, uploadID, userID, 0, body)
+	}
+	if err != nil {
+		return 0, creationBodyError(err)
+	}
+
+	return newOffset, nil
+}
+
 // UploadChunk handles PATCH /api/v1/modul/upload/{upload_id} - Upload modul file chunk
 // @Summary Upload modul file chunk
 // @Description Upload a chunk of data for a TUS resumable modul upload
@@ -150,14 +192,19 @@ func (ctrl *TusModulController) initiateUpload(c *fiber.Ctx, modulID *string) er
 // @Param upload_id path string true "Upload ID"
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
 // @Param Upload-Offset header int true "Byte offset for this chunk"
+// @Param Upload-Checksum header string false "Chunk checksum as '<sha1|sha256|md5> <base64 digest>'"
+// @Param Upload-Length header int false "Total file size, declared once for an upload created with Upload-Defer-Length"
 // @Param Content-Type header string true "Content type" default(application/offset+octet-stream)
 // @Success 204 "Chunk uploaded"
 // @Header 204 {string} Upload-Offset "New byte offset after chunk"
 // @Header 204 {string} Tus-Resumable "TUS protocol version"
+// @Header 204 {string} Upload-Expires "Expiry moved forward by this chunk"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
 // @Failure 409 {object} dto.ErrorResponse "Offset mismatch"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
+// @Failure 460 "Checksum mismatch, offset not advanced"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/upload/{upload_id} [patch]
 func (ctrl *TusModulController) UploadChunk(c *fiber.Ctx) error {
@@ -174,14 +221,19 @@ func (ctrl *TusModulController) UploadChunk(c *fiber.Ctx) error {
 // @Param upload_id path string true "Upload ID"
 // @Param Tus-Resumable header string true "TUS protocol version" default(1.0.0)
 // @Param Upload-Offset header int true "Byte offset for this chunk"
+// @Param Upload-Checksum header string false "Chunk checksum as '<sha1|sha256|md5> <base64 digest>'"
+// @Param Upload-Length header int false "Total file size, declared once for an upload created with Upload-Defer-Length"
 // @Param Content-Type header string true "Content type" default(application/offset+octet-stream)
 // @Success 204 "Chunk uploaded"
 // @Header 204 {string} Upload-Offset "New byte offset after chunk"
 // @Header 204 {string} Tus-Resumable "TUS protocol version"
+// @Header 204 {string} Upload-Expires "Expiry moved forward by this chunk"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
 // @Failure 409 {object} dto.ErrorResponse "Offset mismatch"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
+// @Failure 460 "Checksum mismatch, offset not advanced"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/{id}/update/{upload_id} [patch]
 func (ctrl *TusModulController) UploadModulUpdateChunk(c *fiber.Ctx) error {
@@ -213,6 +265,16 @@ func (ctrl *TusModulController) uploadChunk(c *fiber.Ctx, modulID *string) error
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
+	declaredLength, declared, err := parseDeclaredLength(c)
+	if err != nil {
+		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+	}
+	if declared {
+		if err = ctrl.tusModulUsecase.DeclareModulUploadLength(ctx, uploadID, userID, declaredLength); err != nil {
+			return handleTusChunkError(c, err, ctrl.config.Upload.TusVersion)
+		}
+	}
+
 	var newOffset int64
 	if modulID == nil {
 		newOffset, err = ctrl.tusModulUsecase.HandleModulChunk(ctx, uploadID, userID, offset, bodyReader)
@@ -223,6 +285,7 @@ func (ctrl *TusModulController) uploadChunk(c *fiber.Ctx, modulID *string) error
 		return handleTusChunkError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
+	upload.SetTusExpiresHeader(c, upload.UploadExpiresAt(ctrl.config.Upload.IdleTimeout))
 	return upload.SendTusChunkResponse(c, newOffset)
 }
 
@@ -236,9 +299,12 @@ func (ctrl *TusModulController) uploadChunk(c *fiber.Ctx, modulID *string) error
 // @Success 200 "Upload status"
 // @Header 200 {string} Upload-Offset "Current byte offset"
 // @Header 200 {string} Upload-Length "Total file size"
+// @Header 200 {string} Upload-Defer-Length "Sent as 1 while the size is not declared yet"
+// @Header 200 {string} Upload-Expires "Omitted once the upload is completed"
 // @Header 200 {string} Tus-Resumable "TUS protocol version"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/upload/{upload_id} [head]
 func (ctrl *TusModulController) GetUploadStatus(c *fiber.Ctx) error {
@@ -256,10 +322,13 @@ func (ctrl *TusModulController) GetUploadStatus(c *fiber.Ctx) error {
 // @Success 200 "Upload status"
 // @Header 200 {string} Upload-Offset "Current byte offset"
 // @Header 200 {string} Upload-Length "Total file size"
+// @Header 200 {string} Upload-Defer-Length "Sent as 1 while the size is not declared yet"
+// @Header 200 {string} Upload-Expires "Omitted once the upload is completed"
 // @Header 200 {string} Tus-Resumable "TUS protocol version"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Upload not found"
+// @Failure 410 {object} dto.ErrorResponse "Upload expired"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /modul/{id}/update/{upload_id} [head]
 func (ctrl *TusModulController) GetModulUpdateUploadStatus(c *fiber.Ctx) error {
@@ -287,19 +356,21 @@ func (ctrl *TusModulController) getUploadStatus(c *fiber.Ctx, modulID *string) e
 	}
 
 	var (
-		offset int64
-		length int64
-		err    error
+		offset    int64
+		length    int64
+		expiresAt time.Time
+		err       error
 	)
 	if modulID == nil {
-		offset, length, err = ctrl.tusModulUsecase.GetModulUploadStatus(ctx, uploadID, userID)
+		offset, length, expiresAt, err = ctrl.tusModulUsecase.GetModulUploadStatus(ctx, uploadID, userID)
 	} else {
-		offset, length, err = ctrl.tusModulUsecase.GetModulUpdateUploadStatus(ctx, *modulID, uploadID, userID)
+		offset, length, expiresAt, err = ctrl.tusModulUsecase.GetModulUpdateUploadStatus(ctx, *modulID, uploadID, userID)
 	}
 	if err != nil {
 		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
 	}
 
+	upload.SetTusExpiresHeader(c, expiresAt)
 	return upload.SendTusHeadResponse(c, offset, length)
 }
 
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"invento-service/config"
+	"invento-service/internal/dto"
+	"invento-service/internal/upload"
+	"invento-service/internal/usecase"
+	"time"
+
+	base "invento-service/internal/controller/base"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// uploadEventsHeartbeat keeps idle streams alive through proxies and lets the
+// server notice disconnected clients.
+const uploadEventsHeartbeat = 15 * time.Second
+
+// UploadEventsController streams upload progress as server-sent events so clients
+// no longer need to poll the TUS HEAD or info endpoints.
+type UploadEventsController struct {
+	base            *base.BaseController
+	bus             *upload.ProgressBus
+	tusUsecase      usecase.TusUploadUsecase
+	tusModulUsecase usecase.TusModulUsecase
+	config          *config.Config
+	heartbeat       time.Duration
+}
+
+func NewUploadEventsController(
+	bus *upload.ProgressBus,
+	tusUsecase usecase.TusUploadUsecase,
+	tusModulUsecase usecase.TusModulUsecase,
+	cfg *config.Config,
+	baseCtrl ...*base.BaseController,
+) *UploadEventsController {
+	resolvedBase := base.NewBaseController("", nil)
+	if len(baseCtrl) > 0 && baseCtrl[0] != nil {
+		resolvedBase = baseCtrl[0]
+	}
+
+	return &UploadEventsController{
+		base:            resolvedBase,
+		bus:             bus,
+		tusUsecase:      tusUsecase,
+		tusModulUsecase: tusModulUsecase,
+		config:          cfg,
+		heartbeat:       uploadEventsHeartbeat,
+	}
+}
+
+// StreamUserUploads handles GET /api/v1/upload/events - Stream progress of all uploads
+// @Summary Stream all upload progress
+// @Description Server-sent events stream that pushes offset, progress, queue position and status changes of every project and modul upload owned by the authenticated user
+// @Tags Upload Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} dto.TusProgressEvent "Stream of progress events"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Router /upload/events [get]
+func (ctrl *UploadEventsController) StreamUserUploads(c *fiber.Ctx) error {
+	userID := ctrl.base.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	events, cancel := ctrl.bus.Subscribe(userID, "")
+	return ctrl.stream(c, "", events, cancel, nil)
+}
+
+// StreamProjectUpload handles GET /api/v1/project/upload/:id/events - Stream progress of one project upload
+// @Summary Stream project upload progress
+// @Description Server-sent events stream for a single project upload. The first event is the current state; the stream ends once the upload completes, is cancelled, fails or expires
+// @Tags Upload Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Upload ID"
+// @Success 200 {object} dto.TusProgressEvent "Stream of progress events"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Upload not found"
+// @Router /project/upload/{id}/events [get]
+func (ctrl *UploadEventsController) StreamProjectUpload(c *fiber.Ctx) error {
+	userID := ctrl.base.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	uploadID := c.Params("id")
+	if uploadID == "" {
+		return ctrl.base.SendBadRequest(c, "ID upload tidak valid")
+	}
+
+	// Subscribing before reading the snapshot means an event published in between,
+	// possibly the terminal one, still reaches the stream.
+	events, cancel := ctrl.bus.Subscribe(userID, uploadID)
+	info, err := ctrl.tusUsecase.GetUploadInfo(c.UserContext(), uploadID, userID)
+	if err != nil {
+		cancel()
+		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+	}
+
+	return ctrl.stream(c, uploadID, events, cancel, &dto.TusProgressEvent{
+		UploadID:      info.UploadID,
+		UserID:        userID,
+		Kind:          upload.ProgressKindProject,
+		Status:        info.Status,
+		Offset:        info.Offset,
+		Length:        info.Length,
+		Progress:      info.Progress,
+		QueuePosition: info.QueuePosition,
+	})
+}
+
+// StreamModulUpload handles GET /api/v1/modul/upload/:upload_id/events - Stream progress of one modul upload
+// @Summary Stream modul upload progress
+// @Description Server-sent events stream for a single modul upload. The first event is the current state; the stream ends once the upload completes, is cancelled, fails or expires
+// @Tags Upload Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param upload_id path string true "Upload ID"
+// @Success 200 {object} dto.TusProgressEvent "Stream of progress events"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Upload not found"
+// @Router /modul/upload/{upload_id}/events [get]
+func (ctrl *UploadEventsController) StreamModulUpload(c *fiber.Ctx) error {
+	userID := ctrl.base.GetAuthenticatedUserID(c)
+	if userID == "" {
+		return nil
+	}
+
+	uploadID := c.Params("upload_id")
+	if uploadID == "" {
+		return ctrl.base.SendBadRequest(c, "ID upload tidak valid")
+	}
+
+	// Subscribing before reading the snapshot means an event published in between,
+	// possibly the terminal one, still reaches the stream.
+	events, cancel := ctrl.bus.Subscribe(userID, uploadID)
+	info, err := ctrl.tusModulUsecase.GetModulUploadInfo(c.UserContext(), uploadID, userID)
+	if err != nil {
+		cancel()
+		return handleTusUsecaseError(c, err, ctrl.config.Upload.TusVersion)
+	}
+
+	return ctrl.stream(c, uploadID, events, cancel, &dto.TusProgressEvent{
+		UploadID: info.UploadID,
+		UserID:   userID,
+		Kind:     upload.ProgressKindModul,
+		Status:   info.Status,
+		Offset:   info.Offset,
+		Length:   info.Length,
+		Progress: info.Progress,
+	})
+}
+
+// stream writes snapshot, then the events of a subscription the caller opened before
+// reading it, and cancels the subscription when the stream ends. Streams for a single
+// upload end after a terminal status.
+func (ctrl *UploadEventsController) stream(c *fiber.Ctx, uploadID string, events <-chan dto.TusProgressEvent, cancel func(), snapshot *dto.TusProgressEvent) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	singleUpload := uploadID != ""
+	heartbeat := ctrl.heartbeat
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		if snapshot != nil {
+			if err := writeProgressEvent(w, snapshot); err != nil {
+				return
+			}
+			if singleUpload && upload.IsTerminalUploadStatus(snapshot.Status) {
+				return
+			}
+		} else {
+			fmt.Fprint(w, ": connected\n\n")
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err := writeProgressEvent(w, &event); err != nil {
+					return
+				}
+				if singleUpload && upload.IsTerminalUploadStatus(event.Status) {
+					return
+				}
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+func writeProgressEvent(w *bufio.Writer, event *dto.TusProgressEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data)
+	return w.Flush()
+}
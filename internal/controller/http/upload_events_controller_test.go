@@ -0,0 +1,136 @@
+package http_test
+
+import (
+	"invento-service/internal/domain"
+	"invento-service/internal/upload"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpcontroller "invento-service/internal/controller/http"
+
+	dto "invento-service/internal/dto"
+	apperrors "invento-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newUploadEventsTestApp(bus *upload.ProgressBus, projectUC *MockTusUploadUsecase, modulUC *MockTusModulControllerUsecase) *fiber.App {
+	controller := httpcontroller.NewUploadEventsController(bus, projectUC, modulUC, getTusTestConfig())
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		setTusAuthenticatedUser(c, "user-123", "test@example.com")
+		return c.Next()
+	})
+	app.Get("/api/v1/project/upload/:id/events", controller.StreamProjectUpload)
+	app.Get("/api/v1/modul/upload/:upload_id/events", controller.StreamModulUpload)
+	return app
+}
+
+func TestStreamProjectUpload_CompletedUploadSendsSnapshotAndCloses(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+	mockUC := new(MockTusUploadUsecase)
+	app := newUploadEventsTestApp(bus, mockUC, new(MockTusModulControllerUsecase))
+
+	mockUC.On("GetUploadInfo", mock.Anything, "upload-1", "user-123").Return(&dto.TusUploadInfoResponse{
+		UploadID: "upload-1",
+		Status:   domain.UploadStatusCompleted,
+		Progress: 100,
+		Offset:   1024,
+		Length:   1024,
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/upload/upload-1/events", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), "event: progress\n")
+	assert.Contains(t, string(body), `"status":"completed"`)
+	assert.Contains(t, string(body), `"kind":"project"`)
+	assert.Equal(t, 0, bus.SubscriberCount())
+}
+
+func TestStreamModulUpload_StreamsEventsUntilTerminalStatus(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+	mockUC := new(MockTusModulControllerUsecase)
+	app := newUploadEventsTestApp(bus, new(MockTusUploadUsecase), mockUC)
+
+	mockUC.On("GetModulUploadInfo", mock.Anything, "modul-upload-id", "user-123").Return(&dto.TusModulUploadInfoResponse{
+		UploadID: "modul-upload-id",
+		Status:   domain.UploadStatusUploading,
+		Offset:   256,
+		Length:   1024,
+	}, nil)
+
+	go func() {
+		for bus.SubscriberCount() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		bus.Publish(dto.TusProgressEvent{UploadID: "other-upload", UserID: "user-123", Status: domain.UploadStatusUploading})
+		bus.Publish(dto.TusProgressEvent{UploadID: "modul-upload-id", UserID: "user-123", Status: domain.UploadStatusUploading, Offset: 512, Length: 1024})
+		bus.Publish(dto.TusProgressEvent{UploadID: "modul-upload-id", UserID: "user-123", Status: domain.UploadStatusCancelled, Offset: 512, Length: 1024})
+	}()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/modul/upload/modul-upload-id/events", nil), 5000)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `"offset":256`)
+	assert.Contains(t, string(body), `"offset":512`)
+	assert.Contains(t, string(body), `"status":"cancelled"`)
+	assert.NotContains(t, string(body), "other-upload")
+}
+
+func TestStreamProjectUpload_KeepsEventPublishedWhileReadingSnapshot(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+	mockUC := new(MockTusUploadUsecase)
+	app := newUploadEventsTestApp(bus, mockUC, new(MockTusModulControllerUsecase))
+
+	// The upload completes right after its info was read as still uploading.
+	mockUC.On("GetUploadInfo", mock.Anything, "upload-1", "user-123").Run(func(mock.Arguments) {
+		bus.Publish(dto.TusProgressEvent{UploadID: "upload-1", UserID: "user-123", Status: domain.UploadStatusCompleted, Offset: 1024, Length: 1024})
+	}).Return(&dto.TusUploadInfoResponse{
+		UploadID: "upload-1",
+		Status:   domain.UploadStatusUploading,
+		Offset:   512,
+		Length:   1024,
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/upload/upload-1/events", nil), 5000)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `"offset":512`)
+	assert.Contains(t, string(body), `"status":"completed"`)
+	assert.Equal(t, 0, bus.SubscriberCount())
+}
+
+func TestStreamProjectUpload_NotOwned(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+	mockUC := new(MockTusUploadUsecase)
+	app := newUploadEventsTestApp(bus, mockUC, new(MockTusModulControllerUsecase))
+
+	mockUC.On("GetUploadInfo", mock.Anything, "upload-1", "user-123").
+		Return(nil, apperrors.NewForbiddenError("Anda tidak memiliki akses ke upload ini"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/project/upload/upload-1/events", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, 0, bus.SubscriberCount())
+}
@@ -293,17 +293,22 @@ func (ctrl *UserController) GetUserPermissions(c *fiber.Ctx) error {
 
 // DownloadUserFiles handles POST /api/v1/user/{id}/download - Download user files
 // @Summary Download user files
-// @Description Download files owned by a specific user
+// @Description Download files owned by a specific user. Several files are streamed as a structured ZIP whose manifest.json also lists missing files
 // @Tags User Management
 // @Accept json
 // @Produce application/octet-stream
 // @Security BearerAuth
 // @Param id path string true "User ID (UUID)"
 // @Param request body dto.DownloadUserFilesRequest true "Download request"
+// @Param Range header string false "Byte range to resume a single-file download, e.g. bytes=1048576-"
 // @Success 200 {file} binary
+// @Success 206 {file} binary "Requested byte range of a single file"
+// @Header 200,206 {string} ETag "Entity tag of a single file"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 416 {string} string "Range not satisfiable"
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /user/{id}/download [post]
 func (ctrl *UserController) DownloadUserFiles(c *fiber.Ctx) error {
@@ -333,7 +338,7 @@ func (ctrl *UserController) DownloadUserFiles(c *fiber.Ctx) error {
 		modulIDsStr[i] = strconv.FormatUint(uint64(id), 10)
 	}
 
-	filePath, err := ctrl.userUsecase.DownloadUserFiles(ctx, ownerUserID, projectIDsStr, modulIDsStr)
+	download, err := ctrl.userUsecase.DownloadUserFiles(ctx, ownerUserID, projectIDsStr, modulIDsStr)
 	if err != nil {
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
@@ -342,7 +347,7 @@ func (ctrl *UserController) DownloadUserFiles(c *fiber.Ctx) error {
 		return ctrl.SendInternalError(c)
 	}
 
-	return c.Download(filePath)
+	return ctrl.SendDownload(c, download)
 }
 
 // GetUsersForRole handles GET /api/v1/role/:id/users - Get users for a specific role
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"invento-service/internal/dto"
+	"invento-service/internal/storage"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -260,7 +262,7 @@ func TestUserController_DownloadUserFiles_Success(t *testing.T) {
 	assert.NoError(t, err)
 	tmpFile.Close()
 
-	mockUserUC.On("DownloadUserFiles", mock.Anything, "00000000-0000-0000-0000-000000000001", []string{"1", "2"}, []string{"3", "4"}).Return(tmpFile.Name(), nil)
+	mockUserUC.On("DownloadUserFiles", mock.Anything, "00000000-0000-0000-0000-000000000001", []string{"1", "2"}, []string{"3", "4"}).Return(storage.NewFileDownload(storage.NewLocalBackend(), tmpFile.Name(), filepath.Base(tmpFile.Name())), nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/v1/user/00000000-0000-0000-0000-000000000001/download", bytes.NewReader(bodyBytes))
@@ -320,7 +322,7 @@ func TestUserController_DownloadUserFiles_UserNotFound(t *testing.T) {
 	}
 
 	appErr := apperrors.NewNotFoundError("User tidak ditemukan")
-	mockUserUC.On("DownloadUserFiles", mock.Anything, "00000000-0000-0000-0000-000000000999", []string{"1", "2"}, []string{}).Return("", appErr)
+	mockUserUC.On("DownloadUserFiles", mock.Anything, "00000000-0000-0000-0000-000000000999", []string{"1", "2"}, []string{}).Return(nil, appErr)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/v1/user/00000000-0000-0000-0000-000000000999/download", bytes.NewReader(bodyBytes))
@@ -433,7 +435,7 @@ func TestUserController_DownloadUserFiles_InternalError(t *testing.T) {
 		ModulIDs:   []uint{},
 	}
 
-	mockUserUC.On("DownloadUserFiles", mock.Anything, "00000000-0000-0000-0000-000000000001", []string{"1"}, []string{}).Return("", errors.New("zip creation failed"))
+	mockUserUC.On("DownloadUserFiles", mock.Anything, "00000000-0000-0000-0000-000000000001", []string{"1"}, []string{}).Return(nil, errors.New("zip creation failed"))
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/v1/user/00000000-0000-0000-0000-000000000001/download", bytes.NewReader(bodyBytes))
@@ -3,6 +3,7 @@ package http_test
 import (
 	"context"
 	"invento-service/internal/dto"
+	"invento-service/internal/storage"
 	"mime/multipart"
 
 	"github.com/gofiber/fiber/v2"
@@ -65,12 +66,12 @@ func (m *MockUserUsecase) GetUserPermissions(ctx context.Context, userID string)
 	return args.Get(0).([]dto.UserPermissionItem), args.Error(1)
 }
 
-func (m *MockUserUsecase) DownloadUserFiles(ctx context.Context, ownerUserID string, projectIDs, modulIDs []string) (string, error) {
+func (m *MockUserUsecase) DownloadUserFiles(ctx context.Context, ownerUserID string, projectIDs, modulIDs []string) (*storage.Download, error) {
 	args := m.Called(ctx, ownerUserID, projectIDs, modulIDs)
-	if args.Get(0) == nil || args.Get(0).(string) == "" {
-		return "", args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	return args.String(0), args.Error(1)
+	return args.Get(0).(*storage.Download), args.Error(1)
 }
 
 func (m *MockUserUsecase) GetUsersForRole(ctx context.Context, roleID uint) ([]dto.UserListItem, error) {
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// Assignment is a task set by the lecturers of a class. Its mahasiswa hand it in by
+// uploading a project with the assignment's ID in the upload metadata.
+type Assignment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ClassID   uint      `json:"class_id" gorm:"not null;index"`
+	Judul     string    `json:"judul" gorm:"not null;size:255"`
+	Deskripsi string    `json:"deskripsi" gorm:"type:text"`
+	OpenAt    time.Time `json:"open_at" gorm:"not null"`
+	DueAt     time.Time `json:"due_at" gorm:"not null"`
+	// LateWindowMinutes is how long after DueAt submissions are still accepted,
+	// marked as late.
+	LateWindowMinutes int `json:"late_window_minutes" gorm:"not null;default:0"`
+	// MaxSize limits the submitted file in bytes; zero leaves only the project
+	// upload limit.
+	MaxSize   int64     `json:"max_size" gorm:"not null;default:0"`
+	CreatedBy string    `json:"created_by" gorm:"not null;type:uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Class     Class     `json:"class,omitempty" gorm:"foreignKey:ClassID"`
+}
+
+// ClosesAt is the last moment a submission is accepted.
+func (a *Assignment) ClosesAt() time.Time {
+	return a.DueAt.Add(time.Duration(a.LateWindowMinutes) * time.Minute)
+}
+
+// SubmissionStatus classifies a submission made at t.
+func (a *Assignment) SubmissionStatus(t time.Time) string {
+	if t.After(a.DueAt) {
+		return SubmissionStatusLate
+	}
+	return SubmissionStatusOnTime
+}
+
+const (
+	SubmissionStatusOnTime = "on_time"
+	SubmissionStatusLate   = "late"
+)
+
+// AssignmentSubmission is the project a mahasiswa handed in for an assignment. A
+// mahasiswa has at most one; submitting again replaces it. PathFile, Checksum and
+// FileSize record the file as submitted, so later updates to the project do not
+// change what the lecturer receives.
+type AssignmentSubmission struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	AssignmentID uint      `json:"assignment_id" gorm:"not null;uniqueIndex:idx_assignment_submissions_user"`
+	UserID       string    `json:"user_id" gorm:"not null;type:uuid;uniqueIndex:idx_assignment_submissions_user"`
+	ProjectID    uint      `json:"project_id" gorm:"not null;index"`
+	Status       string    `json:"status" gorm:"not null;size:20"`
+	PathFile     string    `json:"path_file" gorm:"size:500"`
+	Checksum     string    `json:"checksum" gorm:"size:64"`
+	FileSize     int64     `json:"file_size" gorm:"not null;default:0"`
+	SubmittedAt  time.Time `json:"submitted_at" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	User         User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Project      Project   `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// Class is a course (kelas / mata kuliah) taught in one academic period. Its dosen
+// and mahasiswa are recorded as ClassMember rows.
+type Class struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	Kode      string `json:"kode" gorm:"not null;size:50;uniqueIndex:idx_classes_kode_periode"`
+	Nama      string `json:"nama" gorm:"not null;size:255"`
+	Periode   string `json:"periode" gorm:"not null;size:20;uniqueIndex:idx_classes_kode_periode"`
+	Deskripsi string `json:"deskripsi" gorm:"type:text"`
+	// JoinCode lets mahasiswa enroll themselves; lecturers can regenerate it to stop
+	// a leaked code from being used.
+	JoinCode  string    `json:"join_code" gorm:"not null;size:16;uniqueIndex"`
+	CreatedBy string    `json:"created_by" gorm:"not null;type:uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Class) TableName() string {
+	return "classes"
+}
+
+// Roles a user can have within a class.
+const (
+	ClassRoleDosen     = "dosen"
+	ClassRoleMahasiswa = "mahasiswa"
+)
+
+// Ways a mahasiswa ends up enrolled in a class.
+const (
+	ClassJoinedManual   = "manual"
+	ClassJoinedImport   = "import"
+	ClassJoinedJoinCode = "join_code"
+)
+
+type ClassMember struct {
+	ClassID   uint      `json:"class_id" gorm:"primaryKey"`
+	UserID    string    `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Role      string    `json:"role" gorm:"not null;size:20"`
+	JoinedVia string    `json:"joined_via" gorm:"not null;size:20"`
+	CreatedAt time.Time `json:"created_at"`
+	User      User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+func (ClassMember) TableName() string {
+	return "class_members"
+}
@@ -8,18 +8,29 @@ import (
 )
 
 type Modul struct {
-	ID        string    `json:"id" gorm:"type:uuid;primaryKey"`
-	UserID    string    `json:"user_id" gorm:"not null;type:uuid"`
-	Judul     string    `json:"judul" gorm:"not null;size:255"`
-	Deskripsi string    `json:"deskripsi" gorm:"type:text"`
-	FilePath  string    `json:"file_path" gorm:"column:file_path;size:500"`
-	FileName  string    `json:"file_name" gorm:"column:file_name;size:255"`
-	FileSize  int64     `json:"file_size" gorm:"column:file_size"`
-	MimeType  string    `json:"mime_type" gorm:"column:mime_type;size:100"`
-	Status    string    `json:"status" gorm:"size:50;default:'pending'"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	User      User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	ID        string `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    string `json:"user_id" gorm:"not null;type:uuid"`
+	Judul     string `json:"judul" gorm:"not null;size:255"`
+	Deskripsi string `json:"deskripsi" gorm:"type:text"`
+	FilePath  string `json:"file_path" gorm:"column:file_path;size:500"`
+	FileName  string `json:"file_name" gorm:"column:file_name;size:255"`
+	FileSize  int64  `json:"file_size" gorm:"column:file_size"`
+	MimeType  string `json:"mime_type" gorm:"column:mime_type;size:100"`
+	Status    string `json:"status" gorm:"size:50;default:'draft'"`
+	// Visibility decides who besides the owner sees the modul once it is published.
+	Visibility string `json:"visibility" gorm:"size:20;default:'authenticated'"`
+	// Lifecycle timestamps. StatusChangedAt is set on every transition, the others
+	// when the modul last entered that state.
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	ArchivedAt      *time.Time `json:"archived_at,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedBy      *string    `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	CatatanReview   string     `json:"catatan_review" gorm:"size:500"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	User            User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
 func (m *Modul) BeforeCreate(tx *gorm.DB) error {
@@ -32,3 +43,80 @@ func (m *Modul) BeforeCreate(tx *gorm.DB) error {
 func (Modul) TableName() string {
 	return "moduls"
 }
+
+// Lifecycle states of a Modul. A draft is published either directly by its owner or
+// through review; only published moduls are visible to anyone but the owner.
+const (
+	ModulStatusDraft     = "draft"
+	ModulStatusInReview  = "in_review"
+	ModulStatusPublished = "published"
+	ModulStatusArchived  = "archived"
+)
+
+// NormalizeModulStatus maps the statuses moduls were stored with before the
+// lifecycle existed ("pending" and "completed") to draft.
+func NormalizeModulStatus(status string) string {
+	switch status {
+	case ModulStatusInReview, ModulStatusPublished, ModulStatusArchived:
+		return status
+	}
+	return ModulStatusDraft
+}
+
+// Visibility levels of a published Modul. With ModulVisibilityRoles only users whose
+// role is listed in ModulAllowedRole see it.
+const (
+	ModulVisibilityPrivate       = "private"
+	ModulVisibilityRoles         = "roles"
+	ModulVisibilityAuthenticated = "authenticated"
+)
+
+// ModulAllowedRole grants a role access to a modul shared with ModulVisibilityRoles.
+type ModulAllowedRole struct {
+	ModulID   string    `json:"modul_id" gorm:"type:uuid;primaryKey"`
+	RoleID    uint      `json:"role_id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ModulAllowedRole) TableName() string {
+	return "modul_allowed_roles"
+}
+
+// Actions recorded on a ModulRevision.
+const (
+	ModulRevisionCreate   = "create"
+	ModulRevisionFile     = "file"
+	ModulRevisionMetadata = "metadata"
+	ModulRevisionRollback = "rollback"
+)
+
+// ModulRevision is a snapshot of a modul after one change, together with the fields
+// that change touched. Revision files are kept for the life of the modul so older
+// material stays retrievable.
+type ModulRevision struct {
+	ID        uint                  `json:"id" gorm:"primaryKey"`
+	ModulID   string                `json:"modul_id" gorm:"not null;type:uuid;index;uniqueIndex:idx_modul_revisions_number"`
+	Revision  int                   `json:"revision" gorm:"not null;uniqueIndex:idx_modul_revisions_number"`
+	Action    string                `json:"action" gorm:"not null;size:20"`
+	Judul     string                `json:"judul" gorm:"not null;size:255"`
+	Deskripsi string                `json:"deskripsi" gorm:"type:text"`
+	FilePath  string                `json:"file_path" gorm:"size:500"`
+	FileName  string                `json:"file_name" gorm:"size:255"`
+	FileSize  int64                 `json:"file_size"`
+	MimeType  string                `json:"mime_type" gorm:"size:100"`
+	Changes   []ModulRevisionChange `json:"changes" gorm:"serializer:json"`
+	Catatan   string                `json:"catatan" gorm:"size:500"`
+	ChangedBy string                `json:"changed_by" gorm:"not null;type:uuid"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+func (ModulRevision) TableName() string {
+	return "modul_revisions"
+}
+
+// ModulRevisionChange is one field a revision changed, with its value before and after.
+type ModulRevisionChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
@@ -3,14 +3,109 @@ package domain
 import "time"
 
 type Project struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	UserID      string    `json:"user_id" gorm:"not null;type:uuid"`
-	NamaProject string    `json:"nama_project" gorm:"not null;size:255"`
-	Kategori    string    `json:"kategori" gorm:"not null;size:50"`
-	Semester    int       `json:"semester" gorm:"not null"`
-	Ukuran      string    `json:"ukuran" gorm:"not null;size:50"`
-	PathFile    string    `json:"path_file" gorm:"not null;size:500"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	User        User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	UserID      string `json:"user_id" gorm:"not null;type:uuid"`
+	NamaProject string `json:"nama_project" gorm:"not null;size:255"`
+	Kategori    string `json:"kategori" gorm:"not null;size:50"`
+	// KategoriTerdeteksi, KategoriConfidence and KategoriSignals record what the
+	// content classifier concluded from the uploaded archive, even when the user
+	// chose Kategori explicitly.
+	KategoriTerdeteksi string   `json:"kategori_terdeteksi" gorm:"size:50"`
+	KategoriConfidence float64  `json:"kategori_confidence" gorm:"default:0"`
+	KategoriSignals    []string `json:"kategori_signals" gorm:"serializer:json"`
+	Semester           int      `json:"semester" gorm:"not null"`
+	Ukuran             string   `json:"ukuran" gorm:"not null;size:50"`
+	FileSize           int64    `json:"file_size" gorm:"column:file_size;default:0"`
+	PathFile           string   `json:"path_file" gorm:"not null;size:500"`
+	// ReviewStatus is where the project stands with its dosen reviewers;
+	// ReviewStatusChangedAt is when it last moved.
+	ReviewStatus          string     `json:"review_status" gorm:"size:50;default:'submitted';index"`
+	ReviewStatusChangedAt *time.Time `json:"review_status_changed_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+	User                  User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// Project categories accepted in the kategori field.
+const (
+	KategoriWebsite         = "website"
+	KategoriMobile          = "mobile"
+	KategoriIoT             = "iot"
+	KategoriMachineLearning = "machine_learning"
+	KategoriDeepLearning    = "deep_learning"
+)
+
+// Review states of a Project. A project is submitted when uploaded, an assigned
+// reviewer takes it under review and either approves it or requests a revision,
+// and uploading a new file sends it back to submitted.
+const (
+	ProjectReviewSubmitted         = "submitted"
+	ProjectReviewUnderReview       = "under_review"
+	ProjectReviewRevisionRequested = "revision_requested"
+	ProjectReviewApproved          = "approved"
+)
+
+// NormalizeProjectReviewStatus treats projects stored before reviews existed as
+// submitted.
+func NormalizeProjectReviewStatus(status string) string {
+	switch status {
+	case ProjectReviewUnderReview, ProjectReviewRevisionRequested, ProjectReviewApproved:
+		return status
+	}
+	return ProjectReviewSubmitted
+}
+
+// ProjectReviewer is a dosen the project owner asked to review the project.
+type ProjectReviewer struct {
+	ProjectID  uint      `json:"project_id" gorm:"primaryKey"`
+	UserID     string    `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	AssignedBy string    `json:"assigned_by" gorm:"not null;type:uuid"`
+	CreatedAt  time.Time `json:"created_at"`
+	User       User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// ProjectReviewEvent records one review status change of a project together with
+// the comment left by whoever made it.
+type ProjectReviewEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ProjectID  uint      `json:"project_id" gorm:"not null;index"`
+	ActorID    string    `json:"actor_id" gorm:"not null;type:uuid"`
+	FromStatus string    `json:"from_status" gorm:"not null;size:50"`
+	ToStatus   string    `json:"to_status" gorm:"not null;size:50"`
+	Komentar   string    `json:"komentar" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+	Actor      User      `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+}
+
+// ProjectVersion is one finalized file of a project. Every completed project upload
+// adds a version, and a restore adds one that points at the file it restored, so
+// several versions may share a PathFile.
+type ProjectVersion struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ProjectID  uint      `json:"project_id" gorm:"not null;index;uniqueIndex:idx_project_versions_number"`
+	Version    int       `json:"version" gorm:"not null;uniqueIndex:idx_project_versions_number"`
+	PathFile   string    `json:"path_file" gorm:"not null;size:500"`
+	FileSize   int64     `json:"file_size" gorm:"not null;default:0"`
+	Checksum   string    `json:"checksum" gorm:"size:64"`
+	UploadedBy string    `json:"uploaded_by" gorm:"not null;type:uuid"`
+	Catatan    string    `json:"catatan" gorm:"size:500"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ProjectManifest records the entries of a project's ZIP, taken from the archive
+// inspection run when the upload completed.
+type ProjectManifest struct {
+	ProjectID        uint                   `json:"project_id" gorm:"primaryKey"`
+	EntryCount       int                    `json:"entry_count" gorm:"not null"`
+	UncompressedSize int64                  `json:"uncompressed_size" gorm:"not null"`
+	Entries          []ProjectManifestEntry `json:"entries" gorm:"serializer:json"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+type ProjectManifestEntry struct {
+	Path           string `json:"path"`
+	Size           int64  `json:"size"`
+	CompressedSize int64  `json:"compressed_size"`
+	CRC32          uint32 `json:"crc32"`
 }
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// RoleQuota is the storage quota for every user with the role. A zero limit means
+// unlimited.
+type RoleQuota struct {
+	RoleID    uint      `json:"role_id" gorm:"primaryKey"`
+	MaxBytes  int64     `json:"max_bytes" gorm:"not null;default:0"`
+	MaxFiles  int       `json:"max_files" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (RoleQuota) TableName() string {
+	return "role_quotas"
+}
+
+// UserQuota overrides the role quota for one user. A nil limit falls back to the role.
+type UserQuota struct {
+	UserID    string    `json:"user_id" gorm:"primaryKey;type:uuid"`
+	MaxBytes  *int64    `json:"max_bytes,omitempty"`
+	MaxFiles  *int      `json:"max_files,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (UserQuota) TableName() string {
+	return "user_quotas"
+}
+
+// StorageUsage is what a user currently holds: stored projects and moduls plus
+// uploads that are still in progress, which are also counted in PendingBytes.
+type StorageUsage struct {
+	Bytes        int64
+	Files        int
+	PendingBytes int64
+}
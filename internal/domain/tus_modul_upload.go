@@ -10,6 +10,7 @@ type TusModulUpload struct {
 	UploadURL      string                 `json:"upload_url" gorm:"size:500"`
 	UploadMetadata TusModulUploadMetadata `json:"upload_metadata" gorm:"serializer:json"`
 	FileSize       int64                  `json:"file_size" gorm:"not null"`
+	LengthDeferred bool                   `json:"length_deferred" gorm:"default:false"`
 	CurrentOffset  int64                  `json:"current_offset" gorm:"default:0"`
 	FilePath       string                 `json:"file_path" gorm:"size:500"`
 	Status         string                 `json:"status" gorm:"not null;size:20;index"`
@@ -24,6 +25,7 @@ type TusModulUpload struct {
 type TusModulUploadMetadata struct {
 	Judul     string `json:"judul"`
 	Deskripsi string `json:"deskripsi"`
+	Catatan   string `json:"catatan,omitempty"`
 }
 
 type TusModulUploadInitRequest struct {
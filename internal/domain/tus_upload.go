@@ -10,6 +10,7 @@ type TusUpload struct {
 	UploadURL      string            `json:"upload_url" gorm:"size:500"`
 	UploadMetadata TusUploadMetadata `json:"upload_metadata" gorm:"serializer:json"`
 	FileSize       int64             `json:"file_size" gorm:"not null"`
+	LengthDeferred bool              `json:"length_deferred" gorm:"default:false"`
 	CurrentOffset  int64             `json:"current_offset" gorm:"default:0"`
 	FilePath       string            `json:"file_path" gorm:"size:500"`
 	Status         string            `json:"status" gorm:"not null;size:20;index"`
@@ -18,6 +19,8 @@ type TusUpload struct {
 	CreatedAt      time.Time         `json:"created_at"`
 	UpdatedAt      time.Time         `json:"updated_at"`
 	ExpiresAt      time.Time         `json:"expires_at" gorm:"index"`
+	FinalUploadID  *string           `json:"final_upload_id,omitempty" gorm:"size:36;index"`
+	FailureReason  string            `json:"failure_reason,omitempty" gorm:"size:500"`
 	User           User              `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
@@ -32,12 +35,21 @@ const (
 
 	UploadTypeProjectCreate = "project_create"
 	UploadTypeProjectUpdate = "project_update"
-	UploadTypeModulCreate   = "modul_create"
-	UploadTypeModulUpdate   = "modul_update"
+	// UploadTypeProjectPartial is one slice of a project file sent with the TUS
+	// concatenation extension; FinalUploadID is set once a final upload consumes it.
+	UploadTypeProjectPartial = "project_partial"
+	UploadTypeModulCreate    = "modul_create"
+	UploadTypeModulUpdate    = "modul_update"
 )
 
 type TusUploadMetadata struct {
 	NamaProject string `json:"nama_project"`
 	Kategori    string `json:"kategori"`
 	Semester    int    `json:"semester"`
+	// Catatan is the note stored with the project version this upload creates.
+	Catatan string `json:"catatan,omitempty"`
+	// AssignmentID marks the upload as a submission for that assignment.
+	AssignmentID *uint `json:"assignment_id,omitempty"`
+	// ConcatParts lists, in order, the partial uploads a final upload was assembled from.
+	ConcatParts []string `json:"concat_parts,omitempty"`
 }
@@ -0,0 +1,89 @@
+package dto
+
+import "time"
+
+// CreateAssignmentRequest sets an assignment for a class. OpenAt defaults to now.
+type CreateAssignmentRequest struct {
+	ClassID           uint       `json:"class_id" validate:"required,min=1"`
+	Judul             string     `json:"judul" validate:"required,min=3,max=255"`
+	Deskripsi         string     `json:"deskripsi" validate:"max=5000"`
+	OpenAt            *time.Time `json:"open_at"`
+	DueAt             time.Time  `json:"due_at" validate:"required"`
+	LateWindowMinutes int        `json:"late_window_minutes" validate:"min=0,max=43200"`
+	MaxSize           int64      `json:"max_size" validate:"min=0"`
+}
+
+type UpdateAssignmentRequest struct {
+	Judul             string    `json:"judul" validate:"required,min=3,max=255"`
+	Deskripsi         string    `json:"deskripsi" validate:"max=5000"`
+	OpenAt            time.Time `json:"open_at" validate:"required"`
+	DueAt             time.Time `json:"due_at" validate:"required"`
+	LateWindowMinutes int       `json:"late_window_minutes" validate:"min=0,max=43200"`
+	MaxSize           int64     `json:"max_size" validate:"min=0"`
+}
+
+type AssignmentListQueryParams struct {
+	ClassID uint `query:"class_id"`
+	Page    int  `query:"page"`
+	Limit   int  `query:"limit"`
+}
+
+// AssignmentListItem is an assignment in one of the user's classes. StatusPengumpulan
+// is the user's own submission status, empty when they have not submitted.
+type AssignmentListItem struct {
+	ID                uint      `json:"id"`
+	ClassID           uint      `json:"class_id"`
+	NamaKelas         string    `json:"nama_kelas"`
+	Judul             string    `json:"judul"`
+	Peran             string    `json:"peran"`
+	OpenAt            time.Time `json:"open_at"`
+	DueAt             time.Time `json:"due_at"`
+	JumlahPengumpulan int       `json:"jumlah_pengumpulan"`
+	StatusPengumpulan string    `json:"status_pengumpulan"`
+}
+
+type AssignmentListData struct {
+	Items      []AssignmentListItem `json:"items"`
+	Pagination PaginationData       `json:"pagination"`
+}
+
+// AssignmentResponse describes an assignment to a member of its class. Pengumpulan
+// is the requesting mahasiswa's own submission.
+type AssignmentResponse struct {
+	ID                uint                  `json:"id"`
+	ClassID           uint                  `json:"class_id"`
+	NamaKelas         string                `json:"nama_kelas"`
+	Judul             string                `json:"judul"`
+	Deskripsi         string                `json:"deskripsi"`
+	OpenAt            time.Time             `json:"open_at"`
+	DueAt             time.Time             `json:"due_at"`
+	LateWindowMinutes int                   `json:"late_window_minutes"`
+	ClosesAt          time.Time             `json:"closes_at"`
+	MaxSize           int64                 `json:"max_size"`
+	Peran             string                `json:"peran"`
+	Pengumpulan       *AssignmentSubmission `json:"pengumpulan,omitempty"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+}
+
+type AssignmentSubmission struct {
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	ProjectID   uint      `json:"project_id"`
+	NamaProject string    `json:"nama_project"`
+	Status      string    `json:"status"`
+	FileSize    int64     `json:"file_size"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// AssignmentSubmissionListData lists the submissions of an assignment together with
+// how many of the class's mahasiswa handed it in on time, late or not at all.
+type AssignmentSubmissionListData struct {
+	AssignmentID    uint                   `json:"assignment_id"`
+	JumlahMahasiswa int                    `json:"jumlah_mahasiswa"`
+	TepatWaktu      int                    `json:"tepat_waktu"`
+	Terlambat       int                    `json:"terlambat"`
+	BelumMengumpul  int                    `json:"belum_mengumpul"`
+	Items           []AssignmentSubmission `json:"items"`
+}
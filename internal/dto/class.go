@@ -0,0 +1,96 @@
+package dto
+
+import "time"
+
+type CreateClassRequest struct {
+	Kode      string `json:"kode" validate:"required,min=2,max=50"`
+	Nama      string `json:"nama" validate:"required,min=3,max=255"`
+	Periode   string `json:"periode" validate:"required,max=20"`
+	Deskripsi string `json:"deskripsi" validate:"max=2000"`
+	// DosenIDs are the other lecturers of the class; the creator always teaches it.
+	DosenIDs []string `json:"dosen_ids" validate:"omitempty,dive,uuid"`
+}
+
+// UpdateClassRequest changes the class details. DosenIDs, when given, replaces the
+// lecturers of the class.
+type UpdateClassRequest struct {
+	Kode      string   `json:"kode" validate:"required,min=2,max=50"`
+	Nama      string   `json:"nama" validate:"required,min=3,max=255"`
+	Periode   string   `json:"periode" validate:"required,max=20"`
+	Deskripsi string   `json:"deskripsi" validate:"max=2000"`
+	DosenIDs  []string `json:"dosen_ids" validate:"omitempty,min=1,dive,uuid"`
+}
+
+type ClassListQueryParams struct {
+	Search  string `query:"search"`
+	Periode string `query:"periode"`
+	Page    int    `query:"page"`
+	Limit   int    `query:"limit"`
+}
+
+type ClassListItem struct {
+	ID            uint      `json:"id"`
+	Kode          string    `json:"kode"`
+	Nama          string    `json:"nama"`
+	Periode       string    `json:"periode"`
+	Peran         string    `json:"peran"`
+	JumlahDosen   int       `json:"jumlah_dosen"`
+	JumlahAnggota int       `json:"jumlah_anggota"`
+	TanggalDibuat time.Time `json:"tanggal_dibuat"`
+}
+
+type ClassListData struct {
+	Items      []ClassListItem `json:"items"`
+	Pagination PaginationData  `json:"pagination"`
+}
+
+type ClassLecturer struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ClassResponse describes a class to one of its members. JoinCode is only filled
+// in for its lecturers.
+type ClassResponse struct {
+	ID            uint            `json:"id"`
+	Kode          string          `json:"kode"`
+	Nama          string          `json:"nama"`
+	Periode       string          `json:"periode"`
+	Deskripsi     string          `json:"deskripsi"`
+	JoinCode      string          `json:"join_code,omitempty"`
+	Peran         string          `json:"peran"`
+	Dosen         []ClassLecturer `json:"dosen"`
+	JumlahAnggota int             `json:"jumlah_anggota"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type ClassMemberItem struct {
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Peran     string    `json:"peran"`
+	JoinedVia string    `json:"joined_via"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type AddClassMembersRequest struct {
+	Emails []string `json:"emails" validate:"required,min=1,max=200,dive,required,email"`
+}
+
+type JoinClassRequest struct {
+	Code string `json:"code" validate:"required,max=16"`
+}
+
+type ClassJoinCodeData struct {
+	ClassID  uint   `json:"class_id"`
+	JoinCode string `json:"join_code"`
+}
+
+// ImportClassMemberRow is a single parsed row of the enrollment template.
+type ImportClassMemberRow struct {
+	RowNumber int
+	Email     string
+	Nama      string
+}
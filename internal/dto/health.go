@@ -83,15 +83,29 @@ type RuntimeInfo struct {
 }
 
 type HttpMetrics struct {
-	TotalRequests  int64         `json:"total_requests"`
-	ActiveRequests int           `json:"active_requests"`
-	ResponseTimes  ResponseTimes `json:"response_times"`
+	TotalRequests  int64            `json:"total_requests"`
+	ActiveRequests int              `json:"active_requests"`
+	StatusCodes    map[string]int64 `json:"status_codes,omitempty"`
+	ResponseTimes  ResponseTimes    `json:"response_times"`
+	Routes         []RouteMetrics   `json:"routes,omitempty"`
 }
 
 type ResponseTimes struct {
 	Min string `json:"min"`
 	Max string `json:"max"`
 	Avg string `json:"avg"`
+	P50 string `json:"p50,omitempty"`
+	P95 string `json:"p95,omitempty"`
+	P99 string `json:"p99,omitempty"`
+}
+
+// RouteMetrics holds request metrics for a single registered route pattern (e.g. /api/v1/project/:id).
+type RouteMetrics struct {
+	Method        string           `json:"method"`
+	Route         string           `json:"route"`
+	TotalRequests int64            `json:"total_requests"`
+	StatusCodes   map[string]int64 `json:"status_codes"`
+	ResponseTimes ResponseTimes    `json:"response_times"`
 }
 
 type ServicesStatus struct {
@@ -34,17 +34,127 @@ type ModulListData struct {
 }
 
 type ModulResponse struct {
-	ID        string    `json:"id"`
-	Judul     string    `json:"judul"`
-	Deskripsi string    `json:"deskripsi"`
-	FileName  string    `json:"file_name"`
-	MimeType  string    `json:"mime_type"`
-	FileSize  int64     `json:"file_size"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              string     `json:"id"`
+	Judul           string     `json:"judul"`
+	Deskripsi       string     `json:"deskripsi"`
+	FileName        string     `json:"file_name"`
+	MimeType        string     `json:"mime_type"`
+	FileSize        int64      `json:"file_size"`
+	Status          string     `json:"status"`
+	Visibility      string     `json:"visibility"`
+	RoleIDs         []uint     `json:"role_ids,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	ArchivedAt      *time.Time `json:"archived_at,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedBy      *string    `json:"reviewed_by,omitempty"`
+	CatatanReview   string     `json:"catatan_review,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ReviewModulRequest is the reviewer's decision on a modul submitted for review.
+type ReviewModulRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=approve reject"`
+	Catatan  string `json:"catatan" validate:"omitempty,max=500"`
+}
+
+type ModulReviewItem struct {
+	ID          string     `json:"id"`
+	Judul       string     `json:"judul"`
+	Deskripsi   string     `json:"deskripsi"`
+	FileName    string     `json:"file_name"`
+	MimeType    string     `json:"mime_type"`
+	FileSize    int64      `json:"file_size"`
+	OwnerID     string     `json:"owner_id"`
+	OwnerName   string     `json:"owner_name"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+}
+
+type ModulReviewListData struct {
+	Items      []ModulReviewItem `json:"items"`
+	Pagination PaginationData    `json:"pagination"`
 }
 
 type ModulDownloadRequest struct {
 	IDs []string `json:"ids" validate:"required,min=1"`
 }
+
+type ModulRevisionChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type ModulRevisionItem struct {
+	Revision  int                   `json:"revision"`
+	Action    string                `json:"action"`
+	Judul     string                `json:"judul"`
+	Deskripsi string                `json:"deskripsi"`
+	FileName  string                `json:"file_name"`
+	FileSize  int64                 `json:"file_size"`
+	MimeType  string                `json:"mime_type"`
+	Changes   []ModulRevisionChange `json:"changes"`
+	Catatan   string                `json:"catatan"`
+	ChangedBy string                `json:"changed_by"`
+	IsCurrent bool                  `json:"is_current"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+type ModulRevisionListData struct {
+	ModulID string              `json:"modul_id"`
+	Items   []ModulRevisionItem `json:"items"`
+}
+
+type RollbackModulRequest struct {
+	Catatan string `json:"catatan" validate:"omitempty,max=500"`
+}
+
+type UpdateModulVisibilityRequest struct {
+	Visibility string `json:"visibility" validate:"required,oneof=private roles authenticated"`
+	RoleIDs    []uint `json:"role_ids" validate:"omitempty,dive,min=1"`
+}
+
+type ModulVisibilityData struct {
+	ModulID    string `json:"modul_id"`
+	Visibility string `json:"visibility"`
+	RoleIDs    []uint `json:"role_ids"`
+}
+
+type ModulLibraryQueryParams struct {
+	Search        string `query:"search"`
+	FilterType    string `query:"filter_type"`
+	OwnerID       string `query:"owner_id" validate:"omitempty,uuid"`
+	PublishedFrom string `query:"published_from" validate:"omitempty,datetime=2006-01-02"`
+	PublishedTo   string `query:"published_to" validate:"omitempty,datetime=2006-01-02"`
+	Page          int    `query:"page"`
+	Limit         int    `query:"limit"`
+}
+
+// ModulLibraryFilter narrows the library catalog. PublishedFrom is inclusive and
+// PublishedTo exclusive.
+type ModulLibraryFilter struct {
+	Search        string
+	MimeType      string
+	OwnerID       string
+	PublishedFrom *time.Time
+	PublishedTo   *time.Time
+}
+
+type ModulLibraryItem struct {
+	ID          string     `json:"id"`
+	Judul       string     `json:"judul"`
+	Deskripsi   string     `json:"deskripsi"`
+	FileName    string     `json:"file_name"`
+	MimeType    string     `json:"mime_type"`
+	FileSize    int64      `json:"file_size"`
+	OwnerID     string     `json:"owner_id"`
+	OwnerName   string     `json:"owner_name"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+type ModulLibraryData struct {
+	Items      []ModulLibraryItem `json:"items"`
+	Pagination PaginationData     `json:"pagination"`
+}
@@ -32,6 +32,7 @@ type ProjectListItem struct {
 	Semester           int       `json:"semester"`
 	Ukuran             string    `json:"ukuran"`
 	PathFile           string    `json:"path_file"`
+	ReviewStatus       string    `json:"review_status"`
 	TerakhirDiperbarui time.Time `json:"terakhir_diperbarui"`
 }
 
@@ -41,16 +42,119 @@ type ProjectListData struct {
 }
 
 type ProjectResponse struct {
-	ID          uint      `json:"id"`
-	NamaProject string    `json:"nama_project"`
-	Kategori    string    `json:"kategori"`
-	Semester    int       `json:"semester"`
-	Ukuran      string    `json:"ukuran"`
-	PathFile    string    `json:"path_file"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                 uint      `json:"id"`
+	NamaProject        string    `json:"nama_project"`
+	Kategori           string    `json:"kategori"`
+	KategoriTerdeteksi string    `json:"kategori_terdeteksi"`
+	KategoriConfidence float64   `json:"kategori_confidence"`
+	KategoriSignals    []string  `json:"kategori_signals"`
+	Semester           int       `json:"semester"`
+	Ukuran             string    `json:"ukuran"`
+	PathFile           string    `json:"path_file"`
+	ReviewStatus       string    `json:"review_status"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 type ProjectDownloadRequest struct {
 	IDs []uint `json:"ids" validate:"required,min=1"`
 }
+
+// ProjectTreeNode is a file or directory inside a project archive. Directories carry
+// their contents in Children and the combined size of the files below them.
+type ProjectTreeNode struct {
+	Name     string            `json:"name"`
+	Path     string            `json:"path"`
+	Type     string            `json:"type" enums:"dir,file"`
+	Size     int64             `json:"size"`
+	Children []ProjectTreeNode `json:"children,omitempty"`
+}
+
+type ProjectTreeData struct {
+	ProjectID  uint              `json:"project_id"`
+	TotalFiles int               `json:"total_files"`
+	TotalSize  int64             `json:"total_size"`
+	Items      []ProjectTreeNode `json:"items"`
+}
+
+type ProjectVersionItem struct {
+	Version    int       `json:"version"`
+	FileSize   int64     `json:"file_size"`
+	Ukuran     string    `json:"ukuran"`
+	Checksum   string    `json:"checksum"`
+	UploadedBy string    `json:"uploaded_by"`
+	Catatan    string    `json:"catatan"`
+	IsCurrent  bool      `json:"is_current"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ProjectVersionListData struct {
+	ProjectID uint `json:"project_id"`
+	// Retention is how many versions are kept before the oldest are pruned; 0 keeps all.
+	Retention int                  `json:"retention"`
+	Items     []ProjectVersionItem `json:"items"`
+}
+
+type RestoreProjectVersionRequest struct {
+	Catatan string `json:"catatan" validate:"omitempty,max=500"`
+}
+
+// AssignProjectReviewersRequest replaces the dosen asked to review a project.
+type AssignProjectReviewersRequest struct {
+	DosenIDs []string `json:"dosen_ids" validate:"required,min=1,max=5,dive,uuid"`
+}
+
+// ReviewProjectRequest is a reviewer's step in a project review. Komentar is
+// required when requesting a revision.
+type ReviewProjectRequest struct {
+	Action   string `json:"action" validate:"required,oneof=start request_revision approve"`
+	Komentar string `json:"komentar" validate:"omitempty,max=2000"`
+}
+
+type ProjectReviewer struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+type ProjectReviewEvent struct {
+	ActorID    string    `json:"actor_id"`
+	ActorName  string    `json:"actor_name"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Komentar   string    `json:"komentar"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ProjectReviewResponse struct {
+	ProjectID       uint                 `json:"project_id"`
+	NamaProject     string               `json:"nama_project"`
+	OwnerID         string               `json:"owner_id"`
+	ReviewStatus    string               `json:"review_status"`
+	StatusChangedAt *time.Time           `json:"status_changed_at,omitempty"`
+	Reviewers       []ProjectReviewer    `json:"reviewers"`
+	History         []ProjectReviewEvent `json:"history"`
+}
+
+type ProjectReviewQueueParams struct {
+	Status string `query:"status"`
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+}
+
+type ProjectReviewItem struct {
+	ID              uint       `json:"id"`
+	NamaProject     string     `json:"nama_project"`
+	Kategori        string     `json:"kategori"`
+	Semester        int        `json:"semester"`
+	Ukuran          string     `json:"ukuran"`
+	OwnerID         string     `json:"owner_id"`
+	OwnerName       string     `json:"owner_name"`
+	ReviewStatus    string     `json:"review_status"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+}
+
+type ProjectReviewListData struct {
+	Items      []ProjectReviewItem `json:"items"`
+	Pagination PaginationData      `json:"pagination"`
+}
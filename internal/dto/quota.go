@@ -0,0 +1,48 @@
+package dto
+
+// QuotaData is a user's storage quota and what counts against it. A limit of 0 means
+// unlimited. UsedBytes includes the files kept for older project versions and modul
+// revisions, and PendingBytes, the size of uploads still in progress.
+type QuotaData struct {
+	UserID       string         `json:"user_id"`
+	MaxBytes     int64          `json:"max_bytes"`
+	MaxFiles     int            `json:"max_files"`
+	UsedBytes    int64          `json:"used_bytes"`
+	UsedFiles    int            `json:"used_files"`
+	PendingBytes int64          `json:"pending_bytes"`
+	Override     *QuotaOverride `json:"override,omitempty"`
+}
+
+// QuotaOverride holds the limits set for one user; nil fields follow the role.
+type QuotaOverride struct {
+	MaxBytes *int64 `json:"max_bytes"`
+	MaxFiles *int   `json:"max_files"`
+}
+
+// UpdateUserQuotaRequest sets a user's override. Sending null for a limit, or
+// omitting it, returns that limit to the role default.
+type UpdateUserQuotaRequest struct {
+	MaxBytes *int64 `json:"max_bytes" validate:"omitempty,min=0"`
+	MaxFiles *int   `json:"max_files" validate:"omitempty,min=0"`
+}
+
+type UpdateRoleQuotaRequest struct {
+	MaxBytes int64 `json:"max_bytes" validate:"min=0"`
+	MaxFiles int   `json:"max_files" validate:"min=0"`
+}
+
+type RoleQuotaData struct {
+	RoleID   uint   `json:"role_id"`
+	NamaRole string `json:"nama_role"`
+	MaxBytes int64  `json:"max_bytes"`
+	MaxFiles int    `json:"max_files"`
+}
+
+// QuotaCheck describes an upload about to be admitted. FreedBytes is what storing it
+// deletes again, such as the files an update prunes from history; NewFile is false
+// for updates and upload slices.
+type QuotaCheck struct {
+	Bytes      int64
+	FreedBytes int64
+	NewFile    bool
+}
@@ -6,29 +6,55 @@ import "time"
 // The domain version (TusUploadMetadata) has no validation tags and is used for GORM serialization.
 type TusUploadInitRequest struct {
 	NamaProject string `json:"nama_project" validate:"required,min=3,max=255"`
-	Kategori    string `json:"kategori" validate:"required,oneof=website mobile iot machine_learning deep_learning"`
-	Semester    int    `json:"semester" validate:"required,min=1,max=8"`
+	// Kategori may be left out to have it detected from the archive contents.
+	Kategori string `json:"kategori" validate:"omitempty,oneof=website mobile iot machine_learning deep_learning"`
+	Semester int    `json:"semester" validate:"required,min=1,max=8"`
+	// Catatan is kept with the project version the upload creates.
+	Catatan string `json:"catatan" validate:"omitempty,max=500"`
+	// AssignmentID hands the uploaded project in for that assignment.
+	AssignmentID *uint `json:"assignment_id" validate:"omitempty,min=1"`
+
+	// Set from the Upload-Concat header. A partial upload carries no metadata; a final
+	// upload lists the partial upload IDs it is assembled from.
+	ConcatPartial bool     `json:"-"`
+	ConcatParts   []string `json:"-"`
 }
 
 type TusUploadResponse struct {
-	UploadID  string `json:"upload_id"`
-	UploadURL string `json:"upload_url"`
-	Offset    int64  `json:"offset"`
-	Length    int64  `json:"length"`
+	UploadID      string    `json:"upload_id"`
+	UploadURL     string    `json:"upload_url"`
+	Offset        int64     `json:"offset"`
+	Length        int64     `json:"length"`
+	Status        string    `json:"status"`
+	QueuePosition int       `json:"queue_position"`
+	ExpiresAt     time.Time `json:"expires_at,omitzero"`
+}
+
+// TusUploadStatus is what a HEAD request reports about a project upload.
+type TusUploadStatus struct {
+	Offset    int64
+	Length    int64
+	ExpiresAt time.Time
+	// ConcatPartial marks a partial upload; ConcatParts holds the upload URLs, in order,
+	// of the partial uploads a final upload was assembled from.
+	ConcatPartial bool
+	ConcatParts   []string
 }
 
 type TusUploadInfoResponse struct {
-	UploadID    string    `json:"upload_id"`
-	ProjectID   uint      `json:"project_id,omitempty"`
-	NamaProject string    `json:"nama_project"`
-	Kategori    string    `json:"kategori"`
-	Semester    int       `json:"semester"`
-	Status      string    `json:"status"`
-	Progress    float64   `json:"progress"`
-	Offset      int64     `json:"offset"`
-	Length      int64     `json:"length"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	UploadID      string    `json:"upload_id"`
+	ProjectID     uint      `json:"project_id,omitempty"`
+	NamaProject   string    `json:"nama_project"`
+	Kategori      string    `json:"kategori"`
+	Semester      int       `json:"semester"`
+	Status        string    `json:"status"`
+	QueuePosition int       `json:"queue_position"`
+	Progress      float64   `json:"progress"`
+	Offset        int64     `json:"offset"`
+	Length        int64     `json:"length"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type TusUploadSlotResponse struct {
@@ -44,13 +70,15 @@ type TusUploadSlotResponse struct {
 type TusModulUploadInitRequest struct {
 	Judul     string `json:"judul" validate:"required,min=3,max=255"`
 	Deskripsi string `json:"deskripsi"`
+	Catatan   string `json:"catatan" validate:"omitempty,max=500"`
 }
 
 type TusModulUploadResponse struct {
-	UploadID  string `json:"upload_id"`
-	UploadURL string `json:"upload_url"`
-	Offset    int64  `json:"offset"`
-	Length    int64  `json:"length"`
+	UploadID  string    `json:"upload_id"`
+	UploadURL string    `json:"upload_url"`
+	Offset    int64     `json:"offset"`
+	Length    int64     `json:"length"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
 }
 
 type TusModulUploadInfoResponse struct {
@@ -72,3 +100,17 @@ type TusModulUploadSlotResponse struct {
 	QueueLength int    `json:"queue_length"`
 	MaxQueue    int    `json:"max_queue"`
 }
+
+// TusProgressEvent is pushed to upload progress streams whenever an upload moves.
+type TusProgressEvent struct {
+	UploadID      string    `json:"upload_id"`
+	UserID        string    `json:"-"`
+	Kind          string    `json:"kind"`
+	Status        string    `json:"status"`
+	Offset        int64     `json:"offset"`
+	Length        int64     `json:"length"`
+	Progress      float64   `json:"progress"`
+	QueuePosition int       `json:"queue_position"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
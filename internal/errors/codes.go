@@ -45,7 +45,23 @@ const (
 	// Message: "Upload sudah selesai"
 	ErrTusAlreadyCompleted = "TUS_ALREADY_COMPLETED"
 
+	// ErrTusChecksumMismatch indicates a TUS chunk failed its Upload-Checksum (HTTP 460)
+	// Message: "Checksum chunk tidak cocok"
+	ErrTusChecksumMismatch = "TUS_CHECKSUM_MISMATCH"
+
+	// ErrTusExpired indicates the TUS upload expired and its data was removed (HTTP 410)
+	// Message: "Upload sudah kedaluwarsa"
+	ErrTusExpired = "TUS_EXPIRED"
+
 	// ErrPayloadTooLarge indicates request payload exceeds limits (HTTP 413)
 	// Message: "Ukuran data melebihi batas maksimal"
 	ErrPayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+	// ErrQuotaExceeded indicates the user's storage quota has no room left (HTTP 507)
+	// Message: "Kuota penyimpanan tidak mencukupi"
+	ErrQuotaExceeded = "QUOTA_EXCEEDED"
+
+	// ErrArchiveRejected indicates an uploaded archive failed inspection (HTTP 422)
+	// Message: "Arsip ditolak"
+	ErrArchiveRejected = "ARCHIVE_REJECTED"
 )
@@ -196,6 +196,37 @@ func NewTusCompletedError() *AppError {
 	}
 }
 
+// NewTusChecksumMismatchError creates an error for a chunk whose content does not match
+// its Upload-Checksum header (HTTP 460, defined by the TUS checksum extension).
+// The upload offset is left unchanged so the client can resend the chunk.
+//
+// Example:
+//
+//	return errors.NewTusChecksumMismatchError()
+func NewTusChecksumMismatchError() *AppError {
+	return &AppError{
+		Code:       ErrTusChecksumMismatch,
+		Message:    "Checksum chunk tidak cocok",
+		HTTPStatus: 460,
+		Timestamp:  time.Now(),
+	}
+}
+
+// NewTusExpiredError creates an error for an upload whose Upload-Expires time passed
+// and whose data was removed by the cleanup job (HTTP 410).
+//
+// Example:
+//
+//	return errors.NewTusExpiredError()
+func NewTusExpiredError() *AppError {
+	return &AppError{
+		Code:       ErrTusExpired,
+		Message:    "Upload sudah kedaluwarsa",
+		HTTPStatus: fiber.StatusGone,
+		Timestamp:  time.Now(),
+	}
+}
+
 // NewPayloadTooLargeError creates an error for request payload exceeding limits (HTTP 413).
 // Used when file size or chunk size exceeds configured maximum.
 //
@@ -213,3 +244,39 @@ func NewPayloadTooLargeError(message string) *AppError {
 		Timestamp:  time.Now(),
 	}
 }
+
+// NewQuotaExceededError creates an error for an upload that does not fit in what is
+// left of the user's storage quota (HTTP 507).
+//
+// Example:
+//
+//	return errors.NewQuotaExceededError("Kuota penyimpanan tidak mencukupi, sisa 120.00MB")
+func NewQuotaExceededError(message string) *AppError {
+	if message == "" {
+		message = "Kuota penyimpanan tidak mencukupi"
+	}
+	return &AppError{
+		Code:       ErrQuotaExceeded,
+		Message:    message,
+		HTTPStatus: fiber.StatusInsufficientStorage,
+		Timestamp:  time.Now(),
+	}
+}
+
+// NewArchiveRejectedError creates an error for an uploaded archive that failed
+// inspection: corrupt, a zip bomb, or holding unsafe entries (HTTP 422).
+//
+// Example:
+//
+//	return errors.NewArchiveRejectedError("arsip ZIP berisi symlink: src/link")
+func NewArchiveRejectedError(message string) *AppError {
+	if message == "" {
+		message = "Arsip ditolak"
+	}
+	return &AppError{
+		Code:       ErrArchiveRejected,
+		Message:    message,
+		HTTPStatus: fiber.StatusUnprocessableEntity,
+		Timestamp:  time.Now(),
+	}
+}
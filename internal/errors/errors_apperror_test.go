@@ -217,6 +217,16 @@ func TestHTTPStatusCodes(t *testing.T) {
 			constructor:    NewTusCompletedError,
 			expectedStatus: fiber.StatusConflict,
 		},
+		{
+			name:           "TusChecksumMismatchError returns 460",
+			constructor:    NewTusChecksumMismatchError,
+			expectedStatus: 460,
+		},
+		{
+			name:           "TusExpiredError returns 410",
+			constructor:    NewTusExpiredError,
+			expectedStatus: fiber.StatusGone,
+		},
 		{
 			name: "PayloadTooLargeError returns 413",
 			constructor: func() *AppError {
@@ -224,6 +234,20 @@ func TestHTTPStatusCodes(t *testing.T) {
 			},
 			expectedStatus: fiber.StatusRequestEntityTooLarge,
 		},
+		{
+			name: "QuotaExceededError returns 507",
+			constructor: func() *AppError {
+				return NewQuotaExceededError("test")
+			},
+			expectedStatus: fiber.StatusInsufficientStorage,
+		},
+		{
+			name: "ArchiveRejectedError returns 422",
+			constructor: func() *AppError {
+				return NewArchiveRejectedError("test")
+			},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tt := range tests {
@@ -316,6 +340,20 @@ func TestErrorCodes(t *testing.T) {
 			},
 			expectedCode: ErrPayloadTooLarge,
 		},
+		{
+			name: "QuotaExceededError code",
+			constructor: func() *AppError {
+				return NewQuotaExceededError("test")
+			},
+			expectedCode: ErrQuotaExceeded,
+		},
+		{
+			name: "ArchiveRejectedError code",
+			constructor: func() *AppError {
+				return NewArchiveRejectedError("test")
+			},
+			expectedCode: ErrArchiveRejected,
+		},
 	}
 
 	for _, tt := range tests {
@@ -345,6 +383,8 @@ func TestTimestampSet(t *testing.T) {
 		NewTusInactiveError,
 		NewTusCompletedError,
 		func() *AppError { return NewPayloadTooLargeError("test") },
+		func() *AppError { return NewQuotaExceededError("test") },
+		func() *AppError { return NewArchiveRejectedError("test") },
 	}
 
 	for _, fn := range constructors {
@@ -195,6 +195,94 @@ func (h *ExcelHelper) ParseImportFile(f *excelize.File) ([]dto.ImportUserRow, er
 	return result, nil
 }
 
+// classEnrollmentSheet is the sheet class enrollment rows are read from.
+const classEnrollmentSheet = "Data Mahasiswa"
+
+// GenerateClassEnrollmentTemplate builds the workbook lecturers fill in to enroll
+// mahasiswa into a class.
+func (h *ExcelHelper) GenerateClassEnrollmentTemplate() (*excelize.File, error) {
+	f := excelize.NewFile()
+
+	idx, err := f.NewSheet(classEnrollmentSheet)
+	if err != nil {
+		return nil, err
+	}
+	f.SetActiveSheet(idx)
+
+	if err := f.DeleteSheet("Sheet1"); err != nil {
+		return nil, err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"D6EAF8"}, Pattern: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, header := range []string{"Email", "Nama"} {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(classEnrollmentSheet, cell, header)
+		f.SetCellStyle(classEnrollmentSheet, cell, cell, headerStyle)
+	}
+	f.SetCellValue(classEnrollmentSheet, "A2", "mahasiswa1@student.polije.ac.id")
+	f.SetCellValue(classEnrollmentSheet, "B2", "Siti Nurhaliza")
+	f.SetColWidth(classEnrollmentSheet, "A", "A", 35)
+	f.SetColWidth(classEnrollmentSheet, "B", "B", 30)
+
+	guide := "Panduan"
+	if _, err := f.NewSheet(guide); err != nil {
+		return nil, err
+	}
+
+	titleStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 14},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.SetCellValue(guide, "A1", "Panduan Pengisian Template Anggota Kelas")
+	f.SetCellStyle(guide, "A1", "A1", titleStyle)
+	f.SetCellValue(guide, "A3", "1. Isi kolom Email dengan email mahasiswa yang sudah terdaftar")
+	f.SetCellValue(guide, "A4", "2. Kolom Nama hanya sebagai penanda dan tidak mengubah data user")
+	f.SetCellValue(guide, "A5", "3. Email yang belum terdaftar, bukan mahasiswa, atau sudah menjadi anggota kelas akan dilewati")
+	f.SetCellValue(guide, "A6", "4. Hapus baris contoh sebelum mengimpor")
+	f.SetColWidth(guide, "A", "A", 90)
+
+	return f, nil
+}
+
+// ParseClassEnrollmentFile reads the enrollment rows from the "Data Mahasiswa" sheet.
+func (h *ExcelHelper) ParseClassEnrollmentFile(f *excelize.File) ([]dto.ImportClassMemberRow, error) {
+	rows, err := f.GetRows(classEnrollmentSheet)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca sheet '%s': %w", classEnrollmentSheet, err)
+	}
+
+	var result []dto.ImportClassMemberRow
+	for rowIdx, row := range rows {
+		if rowIdx == 0 {
+			continue
+		}
+
+		email := getCellValue(row, 0)
+		nama := getCellValue(row, 1)
+		if email == "" && nama == "" {
+			continue
+		}
+
+		result = append(result, dto.ImportClassMemberRow{
+			RowNumber: rowIdx + 1,
+			Email:     email,
+			Nama:      nama,
+		})
+	}
+
+	return result, nil
+}
+
 func getCellValue(row []string, index int) string {
 	if index >= len(row) {
 		return ""
@@ -0,0 +1,187 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"invento-service/internal/storage"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// SendStoredFile sends the single stored file of a download so that interrupted
+// transfers can be resumed. The response carries ETag, Last-Modified and Accept-Ranges;
+// conditional GET and HEAD requests are answered with 304, and a single byte range is
+// served with 206 unless If-Range shows the file has changed. Range is honoured on the
+// POST download endpoints as well, since that is how their clients fetch files.
+// Files with a FileName are sent as attachments, the rest inline.
+//
+// Errors from the backend, including storage.ErrObjectNotFound, are returned before
+// anything is written so the caller can choose the response.
+func SendStoredFile(c *fiber.Ctx, download *storage.Download) error {
+	ctx := c.UserContext()
+	info, err := download.Stat(ctx)
+	if err != nil {
+		return err
+	}
+
+	etag := info.EntityTag()
+	lastModified := info.ModTime.UTC().Truncate(time.Second)
+
+	c.Set(fiber.HeaderETag, etag)
+	if !info.ModTime.IsZero() {
+		c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+	}
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	if download.FileName != "" {
+		c.Type(filepath.Ext(download.FileName))
+		c.Set(fiber.HeaderContentDisposition, ContentDisposition(download.FileName))
+	} else {
+		c.Type(filepath.Ext(download.Key))
+	}
+
+	if (c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead) && notModified(c, etag, lastModified) {
+		c.Status(fiber.StatusNotModified)
+		return nil
+	}
+
+	offset, length, status := int64(0), info.Size, fiber.StatusOK
+	if rangeHeader := c.Get(fiber.HeaderRange); rangeHeader != "" && ifRangeMatches(c.Get(fiber.HeaderIfRange), etag, lastModified) {
+		start, n, rangeErr := parseByteRange(rangeHeader, info.Size)
+		switch {
+		case errors.Is(rangeErr, errRangeNotSatisfiable):
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", info.Size))
+			return c.SendStatus(fiber.StatusRequestedRangeNotSatisfiable)
+		case rangeErr == nil:
+			offset, length, status = start, n, fiber.StatusPartialContent
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, start+n-1, info.Size))
+		}
+		// A malformed or multi-part Range is ignored and the whole file is sent.
+	}
+
+	object, err := download.OpenRange(ctx, offset, length)
+	if err != nil {
+		return err
+	}
+
+	c.Status(status)
+	return c.SendStream(object, int(length))
+}
+
+// ContentDisposition builds an attachment header that keeps a non-ASCII file name
+// intact in filename* (RFC 6266) with an ASCII approximation in filename for older clients.
+func ContentDisposition(fileName string) string {
+	var fallback, encoded strings.Builder
+	for _, r := range fileName {
+		switch {
+		case r < 0x20 || r > 0x7e || r == '"' || r == '\\':
+			fallback.WriteByte('_')
+		default:
+			fallback.WriteRune(r)
+		}
+	}
+	for _, b := range []byte(fileName) {
+		if isAttrChar(b) {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	if fallback.String() == fileName {
+		return `attachment; filename="` + fileName + `"`
+	}
+	return `attachment; filename="` + fallback.String() + `"; filename*=UTF-8''` + encoded.String()
+}
+
+// isAttrChar reports whether b may appear unencoded in an RFC 5987 ext-value.
+func isAttrChar(b byte) bool {
+	switch {
+	case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", b) >= 0
+}
+
+// notModified evaluates If-None-Match, falling back to If-Modified-Since only when the
+// client sent no entity tags.
+func notModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := c.Get(fiber.HeaderIfModifiedSince); ims != "" {
+		since, err := http.ParseTime(ims)
+		return err == nil && !lastModified.After(since)
+	}
+	return false
+}
+
+// ifRangeMatches reports whether a Range request may be served partially. If-Range
+// holds either an entity tag, compared strongly, or the Last-Modified date.
+func ifRangeMatches(ifRange, etag string, lastModified time.Time) bool {
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return ifRange == etag
+	}
+	date, err := http.ParseTime(ifRange)
+	return err == nil && date.Equal(lastModified)
+}
+
+// parseByteRange parses a single "bytes=" range against an object of the given size
+// and returns the offset and length to send.
+func parseByteRange(header string, size int64) (offset, length int64, err error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, errors.New("unsupported range")
+	}
+
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return 0, 0, errors.New("malformed range")
+	}
+
+	if startStr == "" {
+		suffix, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || suffix < 0 {
+			return 0, 0, errors.New("malformed range")
+		}
+		if suffix == 0 || size == 0 {
+			return 0, 0, errRangeNotSatisfiable
+		}
+		suffix = min(suffix, size)
+		return size - suffix, suffix, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, errors.New("malformed range")
+	}
+	if start >= size {
+		return 0, 0, errRangeNotSatisfiable
+	}
+
+	end := size - 1
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, errors.New("malformed range")
+		}
+		end = min(end, size-1)
+	}
+	return start, end - start + 1, nil
+}
@@ -0,0 +1,169 @@
+package httputil_test
+
+import (
+	"context"
+	"invento-service/internal/httputil"
+	"invento-service/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStoredFileApp(t *testing.T, fileName string) (*fiber.App, storage.ObjectInfo) {
+	t.Helper()
+	ctx := context.Background()
+	backend := storage.NewMemoryBackend()
+	require.NoError(t, backend.Put(ctx, "/data/projects/u1/abc/project.zip", strings.NewReader("0123456789"), 10))
+	info, err := backend.Stat(ctx, "/data/projects/u1/abc/project.zip")
+	require.NoError(t, err)
+
+	app := fiber.New()
+	handler := func(c *fiber.Ctx) error {
+		return httputil.SendStoredFile(c, storage.NewFileDownload(backend, "/data/projects/u1/abc/project.zip", fileName))
+	}
+	app.Get("/file", handler)
+	app.Post("/file", handler)
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		err := httputil.SendStoredFile(c, storage.NewFileDownload(backend, "/data/missing.zip", ""))
+		assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+		return c.SendStatus(fiber.StatusNotFound)
+	})
+	return app, info
+}
+
+func doFileRequest(t *testing.T, app *fiber.App, method, path string, headers map[string]string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestSendStoredFile(t *testing.T) {
+	t.Parallel()
+	app, info := newStoredFileApp(t, "Sistem Informasi.zip")
+	etag := info.EntityTag()
+	lastModified := info.ModTime.UTC().Format(http.TimeFormat)
+
+	t.Run("full file with validators", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodGet, "/file", nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0123456789", body)
+		assert.Equal(t, etag, resp.Header.Get(fiber.HeaderETag))
+		assert.Equal(t, lastModified, resp.Header.Get(fiber.HeaderLastModified))
+		assert.Equal(t, "bytes", resp.Header.Get(fiber.HeaderAcceptRanges))
+		assert.Equal(t, `attachment; filename="Sistem Informasi.zip"`, resp.Header.Get(fiber.HeaderContentDisposition))
+		assert.Equal(t, "application/zip", resp.Header.Get(fiber.HeaderContentType))
+	})
+
+	t.Run("byte range", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"Range": "bytes=4-"})
+		assert.Equal(t, fiber.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "456789", body)
+		assert.Equal(t, "bytes 4-9/10", resp.Header.Get(fiber.HeaderContentRange))
+	})
+
+	t.Run("suffix range on the POST download endpoints", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodPost, "/file", map[string]string{"Range": "bytes=-3"})
+		assert.Equal(t, fiber.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "789", body)
+		assert.Equal(t, "bytes 7-9/10", resp.Header.Get(fiber.HeaderContentRange))
+	})
+
+	t.Run("unsatisfiable range", func(t *testing.T) {
+		t.Parallel()
+		resp, _ := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"Range": "bytes=10-"})
+		assert.Equal(t, fiber.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+		assert.Equal(t, "bytes */10", resp.Header.Get(fiber.HeaderContentRange))
+	})
+
+	t.Run("multi-part range falls back to the whole file", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"Range": "bytes=0-1,4-5"})
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0123456789", body)
+	})
+
+	t.Run("if-range with the current etag resumes", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"Range": "bytes=8-", "If-Range": etag})
+		assert.Equal(t, fiber.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "89", body)
+	})
+
+	t.Run("if-range with a stale validator restarts", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"Range": "bytes=8-", "If-Range": `"stale"`})
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0123456789", body)
+
+		resp, _ = doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"Range": "bytes=8-", "If-Range": "Mon, 02 Jan 2006 15:04:05 GMT"})
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("if-none-match answers 304", func(t *testing.T) {
+		t.Parallel()
+		resp, body := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"If-None-Match": `"other", ` + etag})
+		assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+		assert.Empty(t, body)
+	})
+
+	t.Run("if-modified-since answers 304", func(t *testing.T) {
+		t.Parallel()
+		resp, _ := doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+
+		resp, _ = doFileRequest(t, app, fiber.MethodGet, "/file", map[string]string{"If-Modified-Since": "Mon, 02 Jan 2006 15:04:05 GMT"})
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("missing object is left to the caller", func(t *testing.T) {
+		t.Parallel()
+		resp, _ := doFileRequest(t, app, fiber.MethodGet, "/missing", nil)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestSendStoredFile_Inline(t *testing.T) {
+	t.Parallel()
+	app, _ := newStoredFileApp(t, "")
+
+	resp, _ := doFileRequest(t, app, fiber.MethodGet, "/file", nil)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderContentDisposition))
+}
+
+func TestContentDisposition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+	}{
+		{"ascii", "Laporan Akhir.pdf", `attachment; filename="Laporan Akhir.pdf"`},
+		{"quote", `Modul "1".pdf`, `attachment; filename="Modul _1_.pdf"; filename*=UTF-8''Modul%20%221%22.pdf`},
+		{"non-ascii", "Tugas Akhir – Ringkasan.pdf", `attachment; filename="Tugas Akhir _ Ringkasan.pdf"; filename*=UTF-8''Tugas%20Akhir%20%E2%80%93%20Ringkasan.pdf`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, httputil.ContentDisposition(tt.fileName))
+		})
+	}
+}
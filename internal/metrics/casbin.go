@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// CasbinMetrics counts Casbin enforcement decisions per resource and action.
+type CasbinMetrics struct {
+	mu     sync.Mutex
+	counts map[casbinKey]*casbinCount
+}
+
+type casbinKey struct {
+	resource string
+	action   string
+}
+
+type casbinCount struct {
+	allowed int64
+	denied  int64
+}
+
+// CasbinDecisionSnapshot holds allow/deny totals for one resource + action pair.
+type CasbinDecisionSnapshot struct {
+	Resource string
+	Action   string
+	Allowed  int64
+	Denied   int64
+}
+
+// NewCasbinMetrics creates an empty decision counter.
+func NewCasbinMetrics() *CasbinMetrics {
+	return &CasbinMetrics{counts: make(map[casbinKey]*casbinCount)}
+}
+
+// Observe records a single enforcement result. Safe to call on a nil receiver.
+func (m *CasbinMetrics) Observe(resource, action string, allowed bool) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := casbinKey{resource: resource, action: action}
+	count, ok := m.counts[key]
+	if !ok {
+		count = &casbinCount{}
+		m.counts[key] = count
+	}
+	if allowed {
+		count.allowed++
+	} else {
+		count.denied++
+	}
+}
+
+// Snapshot returns all decision counts sorted by resource then action.
+func (m *CasbinMetrics) Snapshot() []CasbinDecisionSnapshot {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	result := make([]CasbinDecisionSnapshot, 0, len(m.counts))
+	for key, count := range m.counts {
+		result = append(result, CasbinDecisionSnapshot{
+			Resource: key.resource,
+			Action:   key.action,
+			Allowed:  count.allowed,
+			Denied:   count.denied,
+		})
+	}
+	m.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Resource != result[j].Resource {
+			return result[i].Resource < result[j].Resource
+		}
+		return result[i].Action < result[j].Action
+	})
+	return result
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCasbinMetrics_ObserveAndSnapshot(t *testing.T) {
+	t.Parallel()
+	m := NewCasbinMetrics()
+	m.Observe("Project", "read", true)
+	m.Observe("Project", "read", true)
+	m.Observe("Modul", "delete", false)
+
+	snapshot := m.Snapshot()
+	assert.Equal(t, []CasbinDecisionSnapshot{
+		{Resource: "Modul", Action: "delete", Denied: 1},
+		{Resource: "Project", Action: "read", Allowed: 2},
+	}, snapshot)
+}
+
+func TestCasbinMetrics_NilReceiver(t *testing.T) {
+	t.Parallel()
+	var m *CasbinMetrics
+
+	assert.NotPanics(t, func() { m.Observe("Project", "read", true) })
+	assert.Nil(t, m.Snapshot())
+}
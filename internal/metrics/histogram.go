@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the upper bounds (in seconds) used for request latency histograms.
+// They cover fast JSON endpoints as well as slow TUS chunk uploads and ZIP downloads.
+var DefaultLatencyBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram is a fixed-bucket histogram safe for concurrent use.
+// Observations above the last bound are counted in an implicit +Inf bucket.
+type Histogram struct {
+	mu     sync.Mutex
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+	min    float64
+	max    float64
+}
+
+// HistogramSnapshot is a point-in-time copy of a Histogram.
+// Counts are per bucket (not cumulative); the last element is the +Inf bucket.
+type HistogramSnapshot struct {
+	Bounds []float64
+	Counts []uint64
+	Count  uint64
+	Sum    float64
+	Min    float64
+	Max    float64
+}
+
+// NewHistogram creates a histogram with the given ascending bucket upper bounds.
+func NewHistogram(bounds []float64) *Histogram {
+	b := make([]float64, len(bounds))
+	copy(b, bounds)
+	return &Histogram{
+		bounds: b,
+		counts: make([]uint64, len(b)+1),
+	}
+}
+
+// Observe records a single value.
+func (h *Histogram) Observe(v float64) {
+	idx := len(h.bounds)
+	for i, bound := range h.bounds {
+		if v <= bound {
+			idx = i
+			break
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.counts[idx]++
+	if h.count == 0 || v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+	h.count++
+	h.sum += v
+}
+
+// Snapshot returns a copy of the current histogram state.
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counts := make([]uint64, len(h.counts))
+	copy(counts, h.counts)
+	return HistogramSnapshot{
+		Bounds: h.bounds,
+		Counts: counts,
+		Count:  h.count,
+		Sum:    h.sum,
+		Min:    h.min,
+		Max:    h.max,
+	}
+}
+
+// Mean returns the average observed value, or 0 if nothing has been observed.
+func (s HistogramSnapshot) Mean() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// Quantile estimates the q-th quantile (0 < q < 1) by linear interpolation inside
+// the bucket that contains the target rank, the same way Prometheus' histogram_quantile
+// does. The result is clamped to the observed min/max so sparse data stays realistic.
+func (s HistogramSnapshot) Quantile(q float64) float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	q = math.Max(0, math.Min(1, q))
+	rank := q * float64(s.Count)
+
+	var cumulative uint64
+	for i, c := range s.Counts {
+		if c == 0 || float64(cumulative+c) < rank {
+			cumulative += c
+			continue
+		}
+
+		lower := s.Min
+		if i > 0 && s.Bounds[i-1] > lower {
+			lower = s.Bounds[i-1]
+		}
+		upper := s.Max
+		if i < len(s.Bounds) && s.Bounds[i] < upper {
+			upper = s.Bounds[i]
+		}
+		if upper <= lower {
+			return upper
+		}
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(c)
+	}
+	return s.Max
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram_ObserveAndSnapshot(t *testing.T) {
+	t.Parallel()
+	h := NewHistogram([]float64{0.1, 1})
+
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(2)
+
+	snapshot := h.Snapshot()
+	assert.Equal(t, uint64(3), snapshot.Count)
+	assert.Equal(t, []uint64{1, 1, 1}, snapshot.Counts)
+	assert.InDelta(t, 2.55, snapshot.Sum, 1e-9)
+	assert.InDelta(t, 0.05, snapshot.Min, 1e-9)
+	assert.InDelta(t, 2.0, snapshot.Max, 1e-9)
+	assert.InDelta(t, 0.85, snapshot.Mean(), 1e-9)
+}
+
+func TestHistogram_SnapshotIsIndependentCopy(t *testing.T) {
+	t.Parallel()
+	h := NewHistogram([]float64{1})
+	h.Observe(0.5)
+
+	snapshot := h.Snapshot()
+	h.Observe(0.5)
+
+	assert.Equal(t, uint64(1), snapshot.Counts[0])
+	assert.Equal(t, uint64(2), h.Snapshot().Counts[0])
+}
+
+func TestHistogramSnapshot_QuantileEmpty(t *testing.T) {
+	t.Parallel()
+	snapshot := NewHistogram(DefaultLatencyBuckets).Snapshot()
+
+	assert.Zero(t, snapshot.Quantile(0.5))
+	assert.Zero(t, snapshot.Mean())
+}
+
+func TestHistogramSnapshot_QuantileInterpolates(t *testing.T) {
+	t.Parallel()
+	h := NewHistogram([]float64{0.01, 0.1, 1})
+	for i := 0; i < 90; i++ {
+		h.Observe(0.005)
+	}
+	for i := 0; i < 10; i++ {
+		h.Observe(0.5)
+	}
+
+	snapshot := h.Snapshot()
+	assert.LessOrEqual(t, snapshot.Quantile(0.5), 0.01)
+	assert.Greater(t, snapshot.Quantile(0.95), 0.1)
+	assert.LessOrEqual(t, snapshot.Quantile(0.99), 0.5)
+}
+
+func TestHistogramSnapshot_QuantileClampedToObservedRange(t *testing.T) {
+	t.Parallel()
+	h := NewHistogram([]float64{1, 10})
+	h.Observe(3)
+
+	snapshot := h.Snapshot()
+	assert.InDelta(t, 3.0, snapshot.Quantile(0.5), 1e-9)
+	assert.InDelta(t, 3.0, snapshot.Quantile(0.99), 1e-9)
+}
+
+func TestHistogram_ConcurrentObserve(t *testing.T) {
+	t.Parallel()
+	h := NewHistogram(DefaultLatencyBuckets)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				h.Observe(0.02)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(1000), h.Snapshot().Count)
+}
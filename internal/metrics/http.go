@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UnmatchedRoute is the route label used for requests that did not match any registered route.
+// Grouping them under one label keeps 404 scans from creating one series per raw path.
+const UnmatchedRoute = "unmatched"
+
+// HTTPCollector records request counts, in-flight requests, status classes and
+// latency histograms, both globally and per registered route pattern.
+type HTTPCollector struct {
+	inFlight atomic.Int64
+
+	mu      sync.RWMutex
+	total   int64
+	status  map[string]int64
+	latency *Histogram
+	routes  map[routeKey]*routeStats
+}
+
+type routeKey struct {
+	method string
+	path   string
+}
+
+type routeStats struct {
+	count   int64
+	status  map[string]int64
+	latency *Histogram
+}
+
+// HTTPSnapshot is a point-in-time copy of the collected HTTP metrics.
+type HTTPSnapshot struct {
+	TotalRequests int64
+	InFlight      int64
+	StatusClasses map[string]int64
+	Latency       HistogramSnapshot
+	Routes        []RouteSnapshot
+}
+
+// RouteSnapshot holds metrics for a single method + route pattern pair.
+type RouteSnapshot struct {
+	Method        string
+	Route         string
+	Requests      int64
+	StatusClasses map[string]int64
+	Latency       HistogramSnapshot
+}
+
+// NewHTTPCollector creates an empty collector.
+func NewHTTPCollector() *HTTPCollector {
+	return &HTTPCollector{
+		status:  make(map[string]int64),
+		latency: NewHistogram(DefaultLatencyBuckets),
+		routes:  make(map[routeKey]*routeStats),
+	}
+}
+
+// Middleware returns a Fiber handler that records metrics for every request.
+// It should be registered early so the latency covers the whole handler chain.
+// Errors from the chain are passed to the app's ErrorHandler first so the
+// recorded status matches what the client actually receives.
+func (m *HTTPCollector) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		entry := c.Route()
+		start := time.Now()
+		m.inFlight.Add(1)
+		defer m.inFlight.Add(-1)
+
+		if chainErr := c.Next(); chainErr != nil {
+			if err := c.App().ErrorHandler(c, chainErr); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		// c.Route() points at the last matched route. Fiber merges consecutive app.Use
+		// registrations into one route, so still seeing this middleware's own route
+		// means no endpoint handler matched the request.
+		route := c.Route()
+		path := route.Path
+		if route == entry {
+			path = UnmatchedRoute
+		}
+
+		m.Observe(c.Method(), path, c.Response().StatusCode(), time.Since(start))
+		return nil
+	}
+}
+
+// Observe records a finished request.
+func (m *HTTPCollector) Observe(method, route string, status int, duration time.Duration) {
+	seconds := duration.Seconds()
+	class := StatusClass(status)
+	key := routeKey{method: method, path: route}
+
+	m.mu.Lock()
+	m.total++
+	m.status[class]++
+	stats, ok := m.routes[key]
+	if !ok {
+		stats = &routeStats{
+			status:  make(map[string]int64),
+			latency: NewHistogram(DefaultLatencyBuckets),
+		}
+		m.routes[key] = stats
+	}
+	stats.count++
+	stats.status[class]++
+	m.mu.Unlock()
+
+	m.latency.Observe(seconds)
+	stats.latency.Observe(seconds)
+}
+
+// InFlight returns the number of requests currently being processed.
+func (m *HTTPCollector) InFlight() int64 {
+	return m.inFlight.Load()
+}
+
+// Snapshot returns a copy of all collected metrics with routes sorted by route then method.
+func (m *HTTPCollector) Snapshot() HTTPSnapshot {
+	m.mu.RLock()
+	snapshot := HTTPSnapshot{
+		TotalRequests: m.total,
+		InFlight:      m.inFlight.Load(),
+		StatusClasses: copyCounts(m.status),
+		Routes:        make([]RouteSnapshot, 0, len(m.routes)),
+	}
+	for key, stats := range m.routes {
+		snapshot.Routes = append(snapshot.Routes, RouteSnapshot{
+			Method:        key.method,
+			Route:         key.path,
+			Requests:      stats.count,
+			StatusClasses: copyCounts(stats.status),
+			Latency:       stats.latency.Snapshot(),
+		})
+	}
+	m.mu.RUnlock()
+
+	snapshot.Latency = m.latency.Snapshot()
+	sort.Slice(snapshot.Routes, func(i, j int) bool {
+		if snapshot.Routes[i].Route != snapshot.Routes[j].Route {
+			return snapshot.Routes[i].Route < snapshot.Routes[j].Route
+		}
+		return snapshot.Routes[i].Method < snapshot.Routes[j].Method
+	})
+	return snapshot
+}
+
+// StatusClass maps an HTTP status code to its class label, e.g. 404 -> "4xx".
+func StatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+func copyCounts(src map[string]int64) map[string]int64 {
+	dst := make(map[string]int64, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
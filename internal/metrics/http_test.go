@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findRoute(t *testing.T, snapshot HTTPSnapshot, method, route string) RouteSnapshot {
+	t.Helper()
+	for _, r := range snapshot.Routes {
+		if r.Method == method && r.Route == route {
+			return r
+		}
+	}
+	t.Fatalf("route %s %s not found in snapshot", method, route)
+	return RouteSnapshot{}
+}
+
+func TestHTTPCollector_Middleware_RecordsRoutePattern(t *testing.T) {
+	t.Parallel()
+	collector := NewHTTPCollector()
+	app := fiber.New()
+	app.Use(collector.Middleware())
+	app.Get("/project/:id", func(c *fiber.Ctx) error {
+		return c.SendString(c.Params("id"))
+	})
+
+	for _, id := range []string{"1", "2", "3"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/project/"+id, http.NoBody))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	}
+
+	snapshot := collector.Snapshot()
+	assert.Equal(t, int64(3), snapshot.TotalRequests)
+	assert.Equal(t, int64(3), snapshot.StatusClasses["2xx"])
+	require.Len(t, snapshot.Routes, 1)
+
+	route := findRoute(t, snapshot, "GET", "/project/:id")
+	assert.Equal(t, int64(3), route.Requests)
+	assert.Equal(t, uint64(3), route.Latency.Count)
+}
+
+func TestHTTPCollector_Middleware_RecordsErrorStatus(t *testing.T) {
+	t.Parallel()
+	collector := NewHTTPCollector()
+	app := fiber.New()
+	app.Use(collector.Middleware())
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusBadRequest, "bad")
+	})
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		return assert.AnError
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/fail", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/boom", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+	snapshot := collector.Snapshot()
+	assert.Equal(t, int64(1), snapshot.StatusClasses["4xx"])
+	assert.Equal(t, int64(1), snapshot.StatusClasses["5xx"])
+	assert.Equal(t, int64(1), findRoute(t, snapshot, "GET", "/boom").StatusClasses["5xx"])
+}
+
+func TestHTTPCollector_Middleware_GroupsUnmatchedRequests(t *testing.T) {
+	t.Parallel()
+	collector := NewHTTPCollector()
+	app := fiber.New()
+	app.Use(collector.Middleware())
+	app.Use(func(c *fiber.Ctx) error {
+		return c.Next()
+	})
+	app.Get("/known", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	for _, path := range []string{"/scan/a", "/scan/b", "/.env"} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, http.NoBody))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	}
+
+	snapshot := collector.Snapshot()
+	require.Len(t, snapshot.Routes, 1)
+	assert.Equal(t, UnmatchedRoute, snapshot.Routes[0].Route)
+	assert.Equal(t, int64(3), snapshot.Routes[0].Requests)
+}
+
+func TestHTTPCollector_Middleware_TracksInFlight(t *testing.T) {
+	t.Parallel()
+	collector := NewHTTPCollector()
+	app := fiber.New()
+	app.Use(collector.Middleware())
+
+	var observed int64
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		observed = collector.InFlight()
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/slow", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, int64(1), observed)
+	assert.Equal(t, int64(0), collector.InFlight())
+}
+
+func TestHTTPCollector_Snapshot_SortsRoutes(t *testing.T) {
+	t.Parallel()
+	collector := NewHTTPCollector()
+	collector.Observe("POST", "/b", 201, time.Millisecond)
+	collector.Observe("GET", "/b", 200, time.Millisecond)
+	collector.Observe("GET", "/a", 200, time.Millisecond)
+
+	snapshot := collector.Snapshot()
+	require.Len(t, snapshot.Routes, 3)
+	assert.Equal(t, "/a", snapshot.Routes[0].Route)
+	assert.Equal(t, "GET", snapshot.Routes[1].Method)
+	assert.Equal(t, "POST", snapshot.Routes[2].Method)
+}
+
+func TestStatusClass(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "2xx", StatusClass(204))
+	assert.Equal(t, "3xx", StatusClass(304))
+	assert.Equal(t, "4xx", StatusClass(460))
+	assert.Equal(t, "5xx", StatusClass(503))
+	assert.Equal(t, "unknown", StatusClass(0))
+}
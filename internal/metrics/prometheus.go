@@ -0,0 +1,245 @@
+package metrics
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const namespace = "invento"
+
+// QueueStats is implemented by upload queues that expose active and waiting counts.
+type QueueStats interface {
+	GetActiveCount() int
+	GetQueueLength() int
+}
+
+// DBStatsProvider is implemented by *sql.DB.
+type DBStatsProvider interface {
+	Stats() sql.DBStats
+}
+
+// NamedQueue pairs a queue with the value of its "queue" label.
+type NamedQueue struct {
+	Name  string
+	Queue QueueStats
+}
+
+// ExporterSources lists everything the Exporter reads from. Nil sources are skipped.
+type ExporterSources struct {
+	HTTP   *HTTPCollector
+	Tus    *TusMetrics
+	Casbin *CasbinMetrics
+	Queues []NamedQueue
+	DB     DBStatsProvider
+}
+
+// Exporter renders collected metrics in the Prometheus text exposition format.
+type Exporter struct {
+	sources ExporterSources
+}
+
+// NewExporter creates an exporter over the given sources.
+func NewExporter(sources ExporterSources) *Exporter {
+	return &Exporter{sources: sources}
+}
+
+// WriteTo writes all metric families to w.
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	var p promWriter
+
+	e.writeHTTP(&p)
+	e.writeTus(&p)
+	e.writeQueues(&p)
+	e.writeDB(&p)
+	e.writeCasbin(&p)
+
+	return p.buf.WriteTo(w)
+}
+
+func (e *Exporter) writeHTTP(p *promWriter) {
+	if e.sources.HTTP == nil {
+		return
+	}
+	snapshot := e.sources.HTTP.Snapshot()
+
+	p.family("http_requests_in_flight", "Number of HTTP requests currently being served.", "gauge")
+	p.sample("http_requests_in_flight", nil, float64(snapshot.InFlight))
+
+	p.family("http_requests_total", "Total HTTP requests by route pattern and status class.", "counter")
+	for _, route := range snapshot.Routes {
+		for _, class := range sortedKeys(route.StatusClasses) {
+			p.sample("http_requests_total", []string{"method", route.Method, "route", route.Route, "status", class}, float64(route.StatusClasses[class]))
+		}
+	}
+
+	p.family("http_request_duration_seconds", "HTTP request latency by route pattern.", "histogram")
+	for _, route := range snapshot.Routes {
+		p.histogram("http_request_duration_seconds", []string{"method", route.Method, "route", route.Route}, route.Latency)
+	}
+}
+
+func (e *Exporter) writeTus(p *promWriter) {
+	if e.sources.Tus == nil {
+		return
+	}
+	kinds := []struct {
+		name     string
+		snapshot TusCountersSnapshot
+	}{
+		{TusKindProject, e.sources.Tus.Project.Snapshot()},
+		{TusKindModul, e.sources.Tus.Modul.Snapshot()},
+	}
+
+	counters := []struct {
+		name  string
+		help  string
+		value func(TusCountersSnapshot) int64
+	}{
+		{"tus_uploads_initiated_total", "TUS uploads created.", func(s TusCountersSnapshot) int64 { return s.Initiated }},
+		{"tus_uploads_completed_total", "TUS uploads that received all bytes and were finalized.", func(s TusCountersSnapshot) int64 { return s.Completed }},
+		{"tus_uploads_cancelled_total", "TUS uploads cancelled by the client.", func(s TusCountersSnapshot) int64 { return s.Cancelled }},
+		{"tus_uploads_expired_total", "TUS uploads removed by the cleanup job after expiring.", func(s TusCountersSnapshot) int64 { return s.Expired }},
+		{"tus_upload_bytes_received_total", "Bytes written to TUS uploads.", func(s TusCountersSnapshot) int64 { return s.BytesReceived }},
+	}
+
+	for _, counter := range counters {
+		p.family(counter.name, counter.help, "counter")
+		for _, kind := range kinds {
+			p.sample(counter.name, []string{"type", kind.name}, float64(counter.value(kind.snapshot)))
+		}
+	}
+}
+
+func (e *Exporter) writeQueues(p *promWriter) {
+	if len(e.sources.Queues) == 0 {
+		return
+	}
+
+	p.family("tus_queue_active_uploads", "Uploads currently holding an upload slot.", "gauge")
+	for _, q := range e.sources.Queues {
+		p.sample("tus_queue_active_uploads", []string{"queue", q.Name}, float64(q.Queue.GetActiveCount()))
+	}
+
+	p.family("tus_queue_queued_uploads", "Uploads waiting for an upload slot.", "gauge")
+	for _, q := range e.sources.Queues {
+		p.sample("tus_queue_queued_uploads", []string{"queue", q.Name}, float64(q.Queue.GetQueueLength()))
+	}
+}
+
+func (e *Exporter) writeDB(p *promWriter) {
+	if e.sources.DB == nil {
+		return
+	}
+	stats := e.sources.DB.Stats()
+
+	gauges := []struct {
+		name  string
+		help  string
+		value float64
+	}{
+		{"db_max_open_connections", "Maximum number of open connections to the database.", float64(stats.MaxOpenConnections)},
+		{"db_open_connections", "Number of established connections, both in use and idle.", float64(stats.OpenConnections)},
+		{"db_in_use_connections", "Number of connections currently in use.", float64(stats.InUse)},
+		{"db_idle_connections", "Number of idle connections.", float64(stats.Idle)},
+	}
+	for _, g := range gauges {
+		p.family(g.name, g.help, "gauge")
+		p.sample(g.name, nil, g.value)
+	}
+
+	counters := []struct {
+		name  string
+		help  string
+		value float64
+	}{
+		{"db_wait_count_total", "Total number of connections waited for.", float64(stats.WaitCount)},
+		{"db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", stats.WaitDuration.Seconds()},
+		{"db_max_idle_closed_total", "Connections closed due to SetMaxIdleConns.", float64(stats.MaxIdleClosed)},
+		{"db_max_idle_time_closed_total", "Connections closed due to SetConnMaxIdleTime.", float64(stats.MaxIdleTimeClosed)},
+		{"db_max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime.", float64(stats.MaxLifetimeClosed)},
+	}
+	for _, c := range counters {
+		p.family(c.name, c.help, "counter")
+		p.sample(c.name, nil, c.value)
+	}
+}
+
+func (e *Exporter) writeCasbin(p *promWriter) {
+	if e.sources.Casbin == nil {
+		return
+	}
+
+	p.family("casbin_enforce_total", "Casbin permission checks by resource, action and result.", "counter")
+	for _, d := range e.sources.Casbin.Snapshot() {
+		p.sample("casbin_enforce_total", []string{"resource", d.Resource, "action", d.Action, "result", "allow"}, float64(d.Allowed))
+		p.sample("casbin_enforce_total", []string{"resource", d.Resource, "action", d.Action, "result", "deny"}, float64(d.Denied))
+	}
+}
+
+// promWriter builds the text exposition format. Labels are passed as alternating name/value pairs.
+type promWriter struct {
+	buf bytes.Buffer
+}
+
+func (p *promWriter) family(name, help, typ string) {
+	p.buf.WriteString("# HELP " + namespace + "_" + name + " " + help + "\n")
+	p.buf.WriteString("# TYPE " + namespace + "_" + name + " " + typ + "\n")
+}
+
+func (p *promWriter) sample(name string, labels []string, value float64) {
+	p.buf.WriteString(namespace + "_" + name)
+	if len(labels) > 0 {
+		p.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				p.buf.WriteByte(',')
+			}
+			p.buf.WriteString(labels[i] + `="` + escapeLabelValue(labels[i+1]) + `"`)
+		}
+		p.buf.WriteByte('}')
+	}
+	p.buf.WriteByte(' ')
+	p.buf.WriteString(formatFloat(value))
+	p.buf.WriteByte('\n')
+}
+
+func (p *promWriter) histogram(name string, labels []string, h HistogramSnapshot) {
+	var cumulative uint64
+	for i, bound := range h.Bounds {
+		cumulative += h.Counts[i]
+		p.sample(name+"_bucket", append(append([]string{}, labels...), "le", formatFloat(bound)), float64(cumulative))
+	}
+	p.sample(name+"_bucket", append(append([]string{}, labels...), "le", "+Inf"), float64(h.Count))
+	p.sample(name+"_sum", labels, h.Sum)
+	p.sample(name+"_count", labels, float64(h.Count))
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQueue struct {
+	active int
+	queued int
+}
+
+func (q fakeQueue) GetActiveCount() int { return q.active }
+func (q fakeQueue) GetQueueLength() int { return q.queued }
+
+type fakeDB struct {
+	stats sql.DBStats
+}
+
+func (d fakeDB) Stats() sql.DBStats { return d.stats }
+
+func renderExporter(t *testing.T, sources ExporterSources) string {
+	t.Helper()
+	var buf bytes.Buffer
+	_, err := NewExporter(sources).WriteTo(&buf)
+	require.NoError(t, err)
+	return buf.String()
+}
+
+func TestExporter_WriteTo_HTTPHistogram(t *testing.T) {
+	t.Parallel()
+	collector := NewHTTPCollector()
+	collector.Observe("GET", "/api/v1/project/:id", 200, 3*time.Millisecond)
+	collector.Observe("GET", "/api/v1/project/:id", 404, 20*time.Millisecond)
+
+	out := renderExporter(t, ExporterSources{HTTP: collector})
+
+	assert.Contains(t, out, "# TYPE invento_http_request_duration_seconds histogram\n")
+	assert.Contains(t, out, `invento_http_requests_total{method="GET",route="/api/v1/project/:id",status="2xx"} 1`)
+	assert.Contains(t, out, `invento_http_requests_total{method="GET",route="/api/v1/project/:id",status="4xx"} 1`)
+	assert.Contains(t, out, `invento_http_request_duration_seconds_bucket{method="GET",route="/api/v1/project/:id",le="0.005"} 1`)
+	assert.Contains(t, out, `invento_http_request_duration_seconds_bucket{method="GET",route="/api/v1/project/:id",le="0.025"} 2`)
+	assert.Contains(t, out, `invento_http_request_duration_seconds_bucket{method="GET",route="/api/v1/project/:id",le="+Inf"} 2`)
+	assert.Contains(t, out, `invento_http_request_duration_seconds_count{method="GET",route="/api/v1/project/:id"} 2`)
+	assert.Contains(t, out, "invento_http_requests_in_flight 0\n")
+}
+
+func TestExporter_WriteTo_TusCountersAndQueues(t *testing.T) {
+	t.Parallel()
+	tusMetrics := NewTusMetrics()
+	tusMetrics.Project.IncInitiated()
+	tusMetrics.Project.AddBytesReceived(2048)
+	tusMetrics.Modul.IncCancelled()
+	tusMetrics.Modul.AddExpired(3)
+
+	out := renderExporter(t, ExporterSources{
+		Tus: tusMetrics,
+		Queues: []NamedQueue{
+			{Name: TusKindProject, Queue: fakeQueue{active: 1, queued: 4}},
+			{Name: TusKindModul, Queue: fakeQueue{active: 2}},
+		},
+	})
+
+	assert.Contains(t, out, `invento_tus_uploads_initiated_total{type="project"} 1`)
+	assert.Contains(t, out, `invento_tus_upload_bytes_received_total{type="project"} 2048`)
+	assert.Contains(t, out, `invento_tus_uploads_cancelled_total{type="modul"} 1`)
+	assert.Contains(t, out, `invento_tus_uploads_expired_total{type="modul"} 3`)
+	assert.Contains(t, out, `invento_tus_uploads_completed_total{type="project"} 0`)
+	assert.Contains(t, out, `invento_tus_queue_active_uploads{queue="project"} 1`)
+	assert.Contains(t, out, `invento_tus_queue_queued_uploads{queue="project"} 4`)
+	assert.Contains(t, out, `invento_tus_queue_active_uploads{queue="modul"} 2`)
+}
+
+func TestExporter_WriteTo_DBStatsAndCasbin(t *testing.T) {
+	t.Parallel()
+	decisions := NewCasbinMetrics()
+	decisions.Observe("Project", "read", true)
+	decisions.Observe("Project", "read", false)
+
+	out := renderExporter(t, ExporterSources{
+		Casbin: decisions,
+		DB: fakeDB{stats: sql.DBStats{
+			MaxOpenConnections: 10,
+			OpenConnections:    3,
+			InUse:              1,
+			Idle:               2,
+			WaitCount:          5,
+			WaitDuration:       1500 * time.Millisecond,
+		}},
+	})
+
+	assert.Contains(t, out, "invento_db_max_open_connections 10\n")
+	assert.Contains(t, out, "invento_db_in_use_connections 1\n")
+	assert.Contains(t, out, "invento_db_wait_count_total 5\n")
+	assert.Contains(t, out, "invento_db_wait_duration_seconds_total 1.5\n")
+	assert.Contains(t, out, `invento_casbin_enforce_total{resource="Project",action="read",result="allow"} 1`)
+	assert.Contains(t, out, `invento_casbin_enforce_total{resource="Project",action="read",result="deny"} 1`)
+}
+
+func TestExporter_WriteTo_SkipsNilSources(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, renderExporter(t, ExporterSources{}))
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `a\"b\\c\nd`, escapeLabelValue("a\"b\\c\nd"))
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import "sync/atomic"
+
+// Upload kinds used as the "type" label on TUS metrics.
+const (
+	TusKindProject = "project"
+	TusKindModul   = "modul"
+)
+
+// TusMetrics groups the upload counters for project and modul uploads.
+type TusMetrics struct {
+	Project *TusCounters
+	Modul   *TusCounters
+}
+
+// TusCounters holds lifecycle counters for one kind of TUS upload.
+// All methods are safe to call on a nil receiver so components can run without metrics.
+type TusCounters struct {
+	initiated     atomic.Int64
+	completed     atomic.Int64
+	cancelled     atomic.Int64
+	expired       atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+// TusCountersSnapshot is a point-in-time copy of TusCounters.
+type TusCountersSnapshot struct {
+	Initiated     int64
+	Completed     int64
+	Cancelled     int64
+	Expired       int64
+	BytesReceived int64
+}
+
+// NewTusMetrics creates counters for both project and modul uploads.
+func NewTusMetrics() *TusMetrics {
+	return &TusMetrics{
+		Project: &TusCounters{},
+		Modul:   &TusCounters{},
+	}
+}
+
+func (c *TusCounters) IncInitiated() {
+	if c != nil {
+		c.initiated.Add(1)
+	}
+}
+
+func (c *TusCounters) IncCompleted() {
+	if c != nil {
+		c.completed.Add(1)
+	}
+}
+
+func (c *TusCounters) IncCancelled() {
+	if c != nil {
+		c.cancelled.Add(1)
+	}
+}
+
+func (c *TusCounters) AddExpired(n int) {
+	if c != nil && n > 0 {
+		c.expired.Add(int64(n))
+	}
+}
+
+func (c *TusCounters) AddBytesReceived(n int64) {
+	if c != nil && n > 0 {
+		c.bytesReceived.Add(n)
+	}
+}
+
+// Snapshot returns the current counter values.
+func (c *TusCounters) Snapshot() TusCountersSnapshot {
+	if c == nil {
+		return TusCountersSnapshot{}
+	}
+	return TusCountersSnapshot{
+		Initiated:     c.initiated.Load(),
+		Completed:     c.completed.Load(),
+		Cancelled:     c.cancelled.Load(),
+		Expired:       c.expired.Load(),
+		BytesReceived: c.bytesReceived.Load(),
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTusMetrics_CountersAreIndependent(t *testing.T) {
+	t.Parallel()
+	m := NewTusMetrics()
+	m.Project.IncInitiated()
+	m.Project.IncCompleted()
+	m.Modul.AddBytesReceived(512)
+	m.Modul.AddBytesReceived(-1)
+	m.Modul.AddExpired(0)
+
+	assert.Equal(t, TusCountersSnapshot{Initiated: 1, Completed: 1}, m.Project.Snapshot())
+	assert.Equal(t, TusCountersSnapshot{BytesReceived: 512}, m.Modul.Snapshot())
+}
+
+func TestTusCounters_NilReceiver(t *testing.T) {
+	t.Parallel()
+	var c *TusCounters
+
+	assert.NotPanics(t, func() {
+		c.IncInitiated()
+		c.IncCompleted()
+		c.IncCancelled()
+		c.AddExpired(1)
+		c.AddBytesReceived(10)
+	})
+	assert.Equal(t, TusCountersSnapshot{}, c.Snapshot())
+}
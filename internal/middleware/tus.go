@@ -2,18 +2,25 @@ package middleware
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func TusProtocolMiddleware(tusVersion string, maxSize int64) fiber.Handler {
+// TusProtocolMiddleware answers OPTIONS discovery and enforces Tus-Resumable. Extensions
+// beyond the creation family, termination and checksum that a route group supports are
+// listed in extensions.
+func TusProtocolMiddleware(tusVersion string, maxSize int64, extensions ...string) fiber.Handler {
+	tusExtension := strings.Join(append([]string{"creation", "creation-with-upload", "creation-defer-length", "termination", "checksum", "expiration"}, extensions...), ",")
+
 	return func(c *fiber.Ctx) error {
 		method := c.Method()
 
 		if method == "OPTIONS" {
 			c.Set("Tus-Resumable", tusVersion)
 			c.Set("Tus-Version", tusVersion)
-			c.Set("Tus-Extension", "creation,termination")
+			c.Set("Tus-Extension", tusExtension)
+			c.Set("Tus-Checksum-Algorithm", "sha1,sha256,md5")
 			c.Set("Tus-Max-Size", strconv.FormatInt(maxSize, 10))
 			return c.SendStatus(fiber.StatusNoContent)
 		}
@@ -48,10 +48,25 @@ func TestMiddleware_OptionsRequest(t *testing.T) {
 	assert.Equal(t, 204, resp.StatusCode)
 	assert.Equal(t, "1.0.0", resp.Header.Get("Tus-Resumable"))
 	assert.Equal(t, "1.0.0", resp.Header.Get("Tus-Version"))
-	assert.Equal(t, "creation,termination", resp.Header.Get("Tus-Extension"))
+	assert.Equal(t, "creation,creation-with-upload,creation-defer-length,termination,checksum,expiration", resp.Header.Get("Tus-Extension"))
+	assert.Equal(t, "sha1,sha256,md5", resp.Header.Get("Tus-Checksum-Algorithm"))
 	assert.Equal(t, "524288000", resp.Header.Get("Tus-Max-Size"))
 }
 
+func TestMiddleware_OptionsRequest_AdvertisesExtraExtensions(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+
+	app.Use(middleware.TusProtocolMiddleware("1.0.0", 524288000, "concatenation"))
+
+	req := httptest.NewRequest("OPTIONS", "/api/tus", bytes.NewBuffer(nil))
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Equal(t, "creation,creation-with-upload,creation-defer-length,termination,checksum,expiration,concatenation", resp.Header.Get("Tus-Extension"))
+}
+
 func TestTusProtocolMiddleware_MissingTusResumableOnPatch_Returns412(t *testing.T) {
 	t.Parallel()
 	app := fiber.New()
@@ -2,6 +2,7 @@ package rbac
 
 import (
 	"fmt"
+	"invento-service/internal/metrics"
 
 	"github.com/casbin/casbin/v2"
 	casbinmodel "github.com/casbin/casbin/v2/model"
@@ -32,6 +33,7 @@ type CasbinEnforcerInterface interface {
 
 type CasbinEnforcer struct {
 	enforcer *casbin.Enforcer
+	metrics  *metrics.CasbinMetrics
 }
 
 // Ensure CasbinEnforcer implements CasbinEnforcerInterface
@@ -110,11 +112,17 @@ func (ce *CasbinEnforcer) GetPermissionsForRole(roleName string) ([][]string, er
 	return permissions, nil
 }
 
+// SetMetrics attaches a counter that records every CheckPermission decision.
+func (ce *CasbinEnforcer) SetMetrics(m *metrics.CasbinMetrics) {
+	ce.metrics = m
+}
+
 func (ce *CasbinEnforcer) CheckPermission(roleName, resource, action string) (bool, error) {
 	allowed, err := ce.enforcer.Enforce(roleName, resource, action)
 	if err != nil {
 		return false, fmt.Errorf("gagal memeriksa permission: %w", err)
 	}
+	ce.metrics.Observe(resource, action, allowed)
 	return allowed, nil
 }
 
@@ -1,6 +1,7 @@
 package rbac_test
 
 import (
+	"invento-service/internal/metrics"
 	"invento-service/internal/rbac"
 	"testing"
 
@@ -247,6 +248,26 @@ func TestCheckPermission_Allowed(t *testing.T) {
 	assert.True(t, allowed)
 }
 
+func TestCheckPermission_RecordsMetrics(t *testing.T) {
+	enforcer := setupTestCasbinEnforcer(t)
+	decisions := metrics.NewCasbinMetrics()
+	enforcer.SetMetrics(decisions)
+
+	require.NoError(t, enforcer.AddPermissionForRole("admin", "users", "read"))
+
+	_, err := enforcer.CheckPermission("admin", "users", "read")
+	require.NoError(t, err)
+	_, err = enforcer.CheckPermission("guest", "users", "read")
+	require.NoError(t, err)
+	_, err = enforcer.CheckPermission("guest", "users", "delete")
+	require.NoError(t, err)
+
+	snapshot := decisions.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, metrics.CasbinDecisionSnapshot{Resource: "users", Action: "delete", Denied: 1}, snapshot[0])
+	assert.Equal(t, metrics.CasbinDecisionSnapshot{Resource: "users", Action: "read", Allowed: 1, Denied: 1}, snapshot[1])
+}
+
 func TestCheckPermission_Denied_NoPolicy(t *testing.T) {
 	enforcer := setupTestCasbinEnforcer(t)
 
@@ -6,7 +6,21 @@ const (
 	ResourceRole       = "Role"
 	ResourceUser       = "User"
 	ResourceProject    = "Project"
-	ResourceModul      = "Modul"
+	// ResourceProjectReview covers project reviews: read for following a review as
+	// owner or reviewer, review for the dosen acting on it.
+	ResourceProjectReview = "ProjectReview"
+	ResourceModul         = "Modul"
+	// ResourceModulLibrary is the catalog of moduls shared by other users, granted
+	// separately so roles can browse it without managing moduls of their own.
+	ResourceModulLibrary = "ModulLibrary"
+	ResourceClass        = "Class"
+	// ResourceClassEnrollment covers managing the mahasiswa of a class, apart from
+	// managing the class itself.
+	ResourceClassEnrollment = "ClassEnrollment"
+	ResourceAssignment      = "Assignment"
+	// ResourceAssignmentSubmission covers reviewing and downloading what mahasiswa
+	// handed in for an assignment.
+	ResourceAssignmentSubmission = "AssignmentSubmission"
 )
 
 // RBAC Actions - correspond to Casbin policy actions
@@ -16,4 +30,11 @@ const (
 	ActionUpdate   = "update"
 	ActionDelete   = "delete"
 	ActionDownload = "download"
+	// Modul lifecycle: publish covers submitting, publishing and withdrawing one's
+	// own moduls, review deciding on moduls submitted by others.
+	ActionPublish = "publish"
+	ActionReview  = "review"
+	ActionArchive = "archive"
+	// ActionJoin lets mahasiswa enroll themselves in a class with its join code.
+	ActionJoin = "join"
 )
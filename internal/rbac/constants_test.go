@@ -13,7 +13,13 @@ func TestRBACResourceConstants(t *testing.T) {
 	assert.Equal(t, "Role", rbac.ResourceRole)
 	assert.Equal(t, "User", rbac.ResourceUser)
 	assert.Equal(t, "Project", rbac.ResourceProject)
+	assert.Equal(t, "ProjectReview", rbac.ResourceProjectReview)
 	assert.Equal(t, "Modul", rbac.ResourceModul)
+	assert.Equal(t, "ModulLibrary", rbac.ResourceModulLibrary)
+	assert.Equal(t, "Class", rbac.ResourceClass)
+	assert.Equal(t, "ClassEnrollment", rbac.ResourceClassEnrollment)
+	assert.Equal(t, "Assignment", rbac.ResourceAssignment)
+	assert.Equal(t, "AssignmentSubmission", rbac.ResourceAssignmentSubmission)
 }
 
 func TestRBACActionConstants(t *testing.T) {
@@ -23,4 +29,8 @@ func TestRBACActionConstants(t *testing.T) {
 	assert.Equal(t, "update", rbac.ActionUpdate)
 	assert.Equal(t, "delete", rbac.ActionDelete)
 	assert.Equal(t, "download", rbac.ActionDownload)
+	assert.Equal(t, "publish", rbac.ActionPublish)
+	assert.Equal(t, "review", rbac.ActionReview)
+	assert.Equal(t, "archive", rbac.ActionArchive)
+	assert.Equal(t, "join", rbac.ActionJoin)
 }
@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"invento-service/internal/domain"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Archive entry kinds, also written to the manifest.
+const (
+	ArchiveKindProject = "project"
+	ArchiveKindModul   = "modul"
+)
+
+const ManifestFileName = "manifest.json"
+
+// ArchiveEntry is one stored file to be placed in a batch download archive.
+type ArchiveEntry struct {
+	Kind      string
+	ID        string
+	OwnerID   string
+	OwnerName string
+	Title     string
+	FileName  string
+	Key       string
+}
+
+func ProjectArchiveEntry(project *domain.Project, key string) ArchiveEntry {
+	return ArchiveEntry{
+		Kind:      ArchiveKindProject,
+		ID:        fmt.Sprintf("%d", project.ID),
+		OwnerID:   project.UserID,
+		OwnerName: project.User.Name,
+		Title:     project.NamaProject,
+		FileName:  filepath.Base(key),
+		Key:       key,
+	}
+}
+
+func ModulArchiveEntry(modul *domain.Modul, key string) ArchiveEntry {
+	fileName := modul.FileName
+	if fileName == "" {
+		fileName = filepath.Base(key)
+	}
+	return ArchiveEntry{
+		Kind:      ArchiveKindModul,
+		ID:        modul.ID,
+		OwnerID:   modul.UserID,
+		OwnerName: modul.User.Name,
+		Title:     modul.Judul,
+		FileName:  fileName,
+		Key:       key,
+	}
+}
+
+// DownloadName is the file name offered to the client when the entry is downloaded on
+// its own: the project title with the stored file's extension, or the modul's original
+// file name.
+func (e *ArchiveEntry) DownloadName() string {
+	if e.Kind == ArchiveKindProject {
+		if title := SanitizeArchiveName(e.Title); title != "" {
+			return title + strings.ToLower(filepath.Ext(e.FileName))
+		}
+	}
+	if name := SanitizeArchiveName(e.FileName); name != "" {
+		return name
+	}
+	return filepath.Base(e.Key)
+}
+
+// ArchiveManifest is written as manifest.json at the root of every batch archive.
+type ArchiveManifest struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Files       []ArchiveManifestFile `json:"files"`
+	Missing     []string              `json:"missing,omitempty"`
+}
+
+type ArchiveManifestFile struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	OwnerID   string `json:"owner_id"`
+	OwnerName string `json:"owner_name,omitempty"`
+	Title     string `json:"title"`
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+}
+
+// WriteArchive zips the entries into w with projects under projects/<nama_project>/
+// and moduls as moduls/<judul>.<ext>, followed by a manifest.json describing every
+// file and listing the missing ones. Nothing is buffered on disk, so w can be the
+// response body itself.
+func WriteArchive(ctx context.Context, backend Backend, entries []ArchiveEntry, missing []string, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+	manifest := ArchiveManifest{
+		GeneratedAt: time.Now().UTC(),
+		Files:       make([]ArchiveManifestFile, 0, len(entries)),
+		Missing:     missing,
+	}
+
+	names := newArchiveNamer()
+	for i := range entries {
+		entry := &entries[i]
+		if err := ctx.Err(); err != nil {
+			_ = zipWriter.Close()
+			return err
+		}
+		file, err := writeArchiveEntry(ctx, zipWriter, backend, entry, names.pathFor(entry))
+		if err != nil {
+			_ = zipWriter.Close()
+			return err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	writer, err := zipWriter.Create(ManifestFileName)
+	if err != nil {
+		_ = zipWriter.Close()
+		return err
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		_ = zipWriter.Close()
+		return err
+	}
+
+	return zipWriter.Close()
+}
+
+func writeArchiveEntry(ctx context.Context, zipWriter *zip.Writer, backend Backend, entry *ArchiveEntry, name string) (ArchiveManifestFile, error) {
+	info, err := backend.Stat(ctx, entry.Key)
+	if err != nil {
+		return ArchiveManifestFile{}, err
+	}
+
+	object, err := backend.Open(ctx, entry.Key)
+	if err != nil {
+		return ArchiveManifestFile{}, err
+	}
+	defer object.Close()
+
+	writer, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: info.ModTime,
+	})
+	if err != nil {
+		return ArchiveManifestFile{}, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(writer, hash), object)
+	if err != nil {
+		return ArchiveManifestFile{}, err
+	}
+
+	return ArchiveManifestFile{
+		Type:      entry.Kind,
+		ID:        entry.ID,
+		OwnerID:   entry.OwnerID,
+		OwnerName: entry.OwnerName,
+		Title:     entry.Title,
+		Path:      name,
+		Size:      size,
+		SHA256:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// archiveNamer hands out archive paths, numbering duplicates as "name (2)". Names are
+// compared case-insensitively so archives extract cleanly on Windows and macOS.
+type archiveNamer struct {
+	used map[string]bool
+}
+
+func newArchiveNamer() *archiveNamer {
+	return &archiveNamer{used: make(map[string]bool)}
+}
+
+func (an *archiveNamer) pathFor(entry *ArchiveEntry) string {
+	base := SanitizeArchiveName(entry.Title)
+	if base == "" {
+		base = fmt.Sprintf("%s-%s", entry.Kind, entry.ID)
+	}
+
+	for n := 1; ; n++ {
+		name := base
+		if n > 1 {
+			name = fmt.Sprintf("%s (%d)", base, n)
+		}
+
+		var candidate string
+		if entry.Kind == ArchiveKindProject {
+			candidate = path.Join("projects", name, SanitizeArchiveName(entry.FileName))
+		} else {
+			candidate = path.Join("moduls", name+strings.ToLower(filepath.Ext(entry.FileName)))
+		}
+
+		if !an.used[strings.ToLower(candidate)] {
+			an.used[strings.ToLower(candidate)] = true
+			return candidate
+		}
+	}
+}
+
+// SanitizeArchiveName turns a user-supplied title into a single safe path segment.
+func SanitizeArchiveName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsControl(r), strings.ContainsRune(`/\:*?"<>|`, r):
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	cleaned := strings.Join(strings.Fields(b.String()), " ")
+	cleaned = strings.Trim(cleaned, ". ")
+	if len(cleaned) > 100 {
+		cleaned = strings.TrimSpace(strings.ToValidUTF8(cleaned[:100], ""))
+	}
+	return cleaned
+}
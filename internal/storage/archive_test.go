@@ -0,0 +1,127 @@
+package storage_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"invento-service/internal/domain"
+	"invento-service/internal/storage"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeArchiveName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain title", "Sistem Informasi Akademik", "Sistem Informasi Akademik"},
+		{"path separators", "../../etc/passwd", "_.._etc_passwd"},
+		{"reserved characters", `Modul: "Dasar" <1>?`, "Modul_ _Dasar_ _1__"},
+		{"whitespace and dots", "  Laporan\tAkhir . ", "Laporan_Akhir"},
+		{"empty", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, storage.SanitizeArchiveName(tt.in))
+		})
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := storage.NewMemoryBackend()
+
+	put := func(key, data string) {
+		require.NoError(t, backend.Put(ctx, key, bytes.NewReader([]byte(data)), int64(len(data))))
+	}
+	put("/data/projects/u1/a/project.zip", "project-a")
+	put("/data/projects/u2/b/project.zip", "project-b")
+	put("/data/moduls/u1/c/file.pdf", "modul-c")
+	put("/data/moduls/u1/d/file.PDF", "modul-d")
+
+	entries := []storage.ArchiveEntry{
+		storage.ProjectArchiveEntry(&domain.Project{ID: 1, UserID: "u1", NamaProject: "Web/App"}, "/data/projects/u1/a/project.zip"),
+		storage.ProjectArchiveEntry(&domain.Project{ID: 2, UserID: "u2", NamaProject: "web_app", User: domain.User{Name: "Budi"}}, "/data/projects/u2/b/project.zip"),
+		storage.ModulArchiveEntry(&domain.Modul{ID: "m1", UserID: "u1", Judul: "Pertemuan 1", FileName: "materi.pdf"}, "/data/moduls/u1/c/file.pdf"),
+		storage.ModulArchiveEntry(&domain.Modul{ID: "m2", UserID: "u1", Judul: "pertemuan 1"}, "/data/moduls/u1/d/file.PDF"),
+		storage.ModulArchiveEntry(&domain.Modul{ID: "m3", UserID: "u1"}, "/data/moduls/u1/c/file.pdf"),
+	}
+	missing := []string{"Project ID 9: /data/projects/u1/x/project.zip"}
+
+	var buf bytes.Buffer
+	require.NoError(t, storage.WriteArchive(ctx, backend, entries, missing, &buf))
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	contents := make(map[string]string)
+	names := make([]string, 0, len(reader.File))
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, rc.Close())
+		require.NoError(t, err)
+		names = append(names, f.Name)
+		contents[f.Name] = string(data)
+	}
+
+	assert.Equal(t, []string{
+		"projects/Web_App/project.zip",
+		"projects/web_app (2)/project.zip",
+		"moduls/Pertemuan 1.pdf",
+		"moduls/pertemuan 1 (2).pdf",
+		"moduls/modul-m3.pdf",
+		storage.ManifestFileName,
+	}, names)
+	assert.Equal(t, "project-b", contents["projects/web_app (2)/project.zip"])
+
+	var manifest storage.ArchiveManifest
+	require.NoError(t, json.Unmarshal([]byte(contents[storage.ManifestFileName]), &manifest))
+	assert.Equal(t, missing, manifest.Missing)
+	require.Len(t, manifest.Files, 5)
+
+	second := manifest.Files[1]
+	sum := sha256.Sum256([]byte("project-b"))
+	assert.Equal(t, storage.ArchiveKindProject, second.Type)
+	assert.Equal(t, "2", second.ID)
+	assert.Equal(t, "u2", second.OwnerID)
+	assert.Equal(t, "Budi", second.OwnerName)
+	assert.Equal(t, int64(len("project-b")), second.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), second.SHA256)
+	assert.Equal(t, "projects/web_app (2)/project.zip", second.Path)
+}
+
+func TestWriteArchive_MissingObject(t *testing.T) {
+	t.Parallel()
+	entries := []storage.ArchiveEntry{{Kind: storage.ArchiveKindModul, ID: "m1", Title: "x", Key: "/missing.pdf"}}
+
+	err := storage.WriteArchive(context.Background(), storage.NewMemoryBackend(), entries, nil, io.Discard)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestArchiveEntry_DownloadName(t *testing.T) {
+	t.Parallel()
+
+	project := storage.ProjectArchiveEntry(&domain.Project{ID: 1, NamaProject: "Sistem: Informasi"}, "/data/projects/u1/a/project.ZIP")
+	assert.Equal(t, "Sistem_ Informasi.zip", project.DownloadName())
+
+	untitled := storage.ProjectArchiveEntry(&domain.Project{ID: 2}, "/data/projects/u1/b/project.zip")
+	assert.Equal(t, "project.zip", untitled.DownloadName())
+
+	modul := storage.ModulArchiveEntry(&domain.Modul{ID: "m1", Judul: "Pertemuan 1", FileName: "Materi Pertemuan 1.pdf"}, "/data/moduls/u1/c/a1b2.pdf")
+	assert.Equal(t, "Materi Pertemuan 1.pdf", modul.DownloadName())
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/config"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Storage backend names accepted by STORAGE_BACKEND.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrObjectNotFound is returned by a Backend when the requested key does not exist.
+var ErrObjectNotFound = errors.New("objek tidak ditemukan")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Size    int64
+	ModTime time.Time
+	// Checksum is the object's entity tag as reported by the backend, without quotes.
+	// Backends that do not keep one leave it empty.
+	Checksum string
+}
+
+// EntityTag returns a strong HTTP entity tag for the object: the stored checksum when
+// there is one, otherwise one derived from the modification time and size.
+func (info ObjectInfo) EntityTag() string {
+	if info.Checksum != "" {
+		return `"` + info.Checksum + `"`
+	}
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime.Unix(), info.Size)
+}
+
+// Backend stores finalized files. Keys are the file paths the application already
+// records in the database, so switching backends does not require a data migration;
+// each implementation maps a path onto its own namespace.
+type Backend interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// RangeOpener is implemented by backends that can read part of an object without
+// fetching everything before it.
+type RangeOpener interface {
+	OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+}
+
+// FileMover is implemented by backends that can take ownership of a local file
+// without copying it, such as LocalBackend moving a finished TUS upload in place.
+type FileMover interface {
+	MoveFile(ctx context.Context, srcPath, key string) error
+}
+
+var (
+	defaultBackendMu sync.RWMutex
+	defaultBackend   Backend = NewLocalBackend()
+)
+
+// DefaultBackend returns the backend used by package-level helpers such as DeleteFile.
+func DefaultBackend() Backend {
+	defaultBackendMu.RLock()
+	defer defaultBackendMu.RUnlock()
+	return defaultBackend
+}
+
+// SetDefaultBackend replaces the process-wide backend. It is meant to be called once
+// during startup, before stores and helpers are constructed.
+func SetDefaultBackend(backend Backend) {
+	defaultBackendMu.Lock()
+	defer defaultBackendMu.Unlock()
+	defaultBackend = backend
+}
+
+// NewBackend builds the backend selected by STORAGE_BACKEND.
+func NewBackend(cfg *config.Config, pathResolver *PathResolver) (Backend, error) {
+	switch cfg.Storage.Backend {
+	case "", BackendLocal:
+		return NewLocalBackend(), nil
+	case BackendS3:
+		return NewS3Backend(S3Options{
+			Endpoint:     cfg.Storage.S3Endpoint,
+			Region:       cfg.Storage.S3Region,
+			Bucket:       cfg.Storage.S3Bucket,
+			AccessKey:    cfg.Storage.S3AccessKey,
+			SecretKey:    cfg.Storage.S3SecretKey,
+			UsePathStyle: cfg.Storage.S3UsePathStyle,
+			BasePath:     pathResolver.GetBasePath(),
+		})
+	default:
+		return nil, fmt.Errorf("storage backend tidak dikenal: %s", cfg.Storage.Backend)
+	}
+}
+
+// OpenRange reads length bytes of the object under key starting at offset. Backends
+// without RangeOpener are read from the start and the leading bytes are discarded.
+func OpenRange(ctx context.Context, backend Backend, key string, offset, length int64) (io.ReadCloser, error) {
+	if opener, ok := backend.(RangeOpener); ok {
+		return opener.OpenRange(ctx, key, offset, length)
+	}
+
+	object, err := backend.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, object, offset); err != nil {
+		object.Close()
+		return nil, err
+	}
+	return limitedReadCloser(object, length), nil
+}
+
+func limitedReadCloser(rc io.ReadCloser, length int64) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(rc, length), rc}
+}
+
+// putFile uploads the file at srcPath to backend under key.
+func putFile(ctx context.Context, backend Backend, srcPath, key string) error {
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return backend.Put(ctx, key, file, info.Size())
+}
+
+// StoreFile hands the local file at srcPath to backend under key. Backends that
+// implement FileMover take the file over directly; others receive a copy and the
+// source file is removed once the upload succeeds.
+func StoreFile(ctx context.Context, backend Backend, srcPath, key string) error {
+	if mover, ok := backend.(FileMover); ok {
+		return mover.MoveFile(ctx, srcPath, key)
+	}
+
+	if err := putFile(ctx, backend, srcPath, key); err != nil {
+		return err
+	}
+	return os.Remove(srcPath)
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalBackend keeps objects on the local filesystem; a key is the file path itself.
+type LocalBackend struct{}
+
+func NewLocalBackend() *LocalBackend {
+	return &LocalBackend{}
+}
+
+func (lb *LocalBackend) Put(_ context.Context, key string, r io.Reader, _ int64) error {
+	if err := os.MkdirAll(filepath.Dir(key), 0o755); err != nil {
+		return fmt.Errorf("gagal membuat direktori: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(key), ".put-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), key)
+}
+
+func (lb *LocalBackend) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(key)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return file, err
+}
+
+func (lb *LocalBackend) OpenRange(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(key)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return limitedReadCloser(file, length), nil
+}
+
+func (lb *LocalBackend) Stat(_ context.Context, key string) (ObjectInfo, error) {
+	info, err := os.Stat(key)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.IsDir()) {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete removes the file at key. A missing file is not an error.
+func (lb *LocalBackend) Delete(_ context.Context, key string) error {
+	if err := os.Remove(key); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (lb *LocalBackend) MoveFile(_ context.Context, srcPath, key string) error {
+	if err := os.MkdirAll(filepath.Dir(key), 0o755); err != nil {
+		return fmt.Errorf("gagal membuat direktori: %w", err)
+	}
+	return MoveFile(srcPath, key)
+}
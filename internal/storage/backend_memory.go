@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data    []byte
+	modTime time.Time
+}
+
+// MemoryBackend keeps objects in memory. It stands in for object storage in tests.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{objects: make(map[string]memoryObject)}
+}
+
+func (mb *MemoryBackend) Put(_ context.Context, key string, r io.Reader, _ int64) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.objects[key] = memoryObject{data: data, modTime: time.Now()}
+	return nil
+}
+
+func (mb *MemoryBackend) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	obj, ok := mb.objects[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(obj.data)), nil
+}
+
+func (mb *MemoryBackend) OpenRange(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	obj, ok := mb.objects[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	end := min(offset+length, int64(len(obj.data)))
+	start := min(offset, end)
+	return io.NopCloser(bytes.NewReader(obj.data[start:end])), nil
+}
+
+func (mb *MemoryBackend) Stat(_ context.Context, key string) (ObjectInfo, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	obj, ok := mb.objects[key]
+	if !ok {
+		return ObjectInfo{}, ErrObjectNotFound
+	}
+	return ObjectInfo{Size: int64(len(obj.data)), ModTime: obj.modTime}, nil
+}
+
+func (mb *MemoryBackend) Delete(_ context.Context, key string) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	delete(mb.objects, key)
+	return nil
+}
+
+// Keys returns the stored keys in no particular order.
+func (mb *MemoryBackend) Keys() []string {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+	keys := make([]string, 0, len(mb.objects))
+	for key := range mb.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,334 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3EmptyPayload    = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type S3Options struct {
+	Endpoint     string
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool
+	// BasePath is trimmed from keys so objects are laid out relative to the upload root.
+	BasePath   string
+	HTTPClient *http.Client
+}
+
+// S3Backend talks to an S3-compatible object store (AWS S3, MinIO) over its REST API,
+// signing each request with AWS Signature Version 4.
+type S3Backend struct {
+	endpoint     *url.URL
+	region       string
+	bucket       string
+	accessKey    string
+	secretKey    string
+	usePathStyle bool
+	basePath     string
+	client       *http.Client
+	now          func() time.Time
+}
+
+func NewS3Backend(opts S3Options) (*S3Backend, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" || opts.AccessKey == "" || opts.SecretKey == "" {
+		return nil, errors.New("konfigurasi S3 tidak lengkap: endpoint, bucket, access key dan secret key wajib diisi")
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(opts.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint S3 tidak valid: %s", opts.Endpoint)
+	}
+
+	region := opts.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	basePath := ""
+	if opts.BasePath != "" {
+		basePath = filepath.ToSlash(filepath.Clean(opts.BasePath))
+	}
+
+	return &S3Backend{
+		endpoint:     endpoint,
+		region:       region,
+		bucket:       opts.Bucket,
+		accessKey:    opts.AccessKey,
+		secretKey:    opts.SecretKey,
+		usePathStyle: opts.UsePathStyle,
+		basePath:     basePath,
+		client:       client,
+		now:          time.Now,
+	}, nil
+}
+
+func (sb *S3Backend) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if size < 0 {
+		return errors.New("ukuran objek wajib diketahui untuk upload S3")
+	}
+
+	req, err := sb.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+
+	resp, err := sb.do(req, s3UnsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3ResponseError(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+func (sb *S3Backend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := sb.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sb.do(req, s3EmptyPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, s3ResponseError(http.MethodGet, key, resp)
+	}
+}
+
+// OpenRange asks S3 for a byte range. A server that ignores Range and answers with the
+// whole object is handled by skipping to offset.
+func (sb *S3Backend) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if length <= 0 {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+
+	req, err := sb.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	resp, err := sb.do(req, s3EmptyPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return limitedReadCloser(resp.Body, length), nil
+	case http.StatusOK:
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return limitedReadCloser(resp.Body, length), nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, s3ResponseError(http.MethodGet, key, resp)
+	}
+}
+
+func (sb *S3Backend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	req, err := sb.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	resp, err := sb.do(req, s3EmptyPayload)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ObjectInfo{}, ErrObjectNotFound
+	default:
+		return ObjectInfo{}, s3ResponseError(http.MethodHead, key, resp)
+	}
+
+	info := ObjectInfo{}
+	if size, parseErr := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); parseErr == nil {
+		info.Size = size
+	}
+	if modTime, parseErr := http.ParseTime(resp.Header.Get("Last-Modified")); parseErr == nil {
+		info.ModTime = modTime
+	}
+	info.Checksum = strings.Trim(resp.Header.Get("ETag"), `"`)
+	return info, nil
+}
+
+// Delete removes the object. S3 answers 204 for missing keys as well, so deleting
+// twice is not an error.
+func (sb *S3Backend) Delete(ctx context.Context, key string) error {
+	req, err := sb.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sb.do(req, s3EmptyPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3ResponseError(http.MethodDelete, key, resp)
+	}
+	return nil
+}
+
+// ObjectKey maps a file path onto the bucket namespace: the upload root is trimmed
+// and separators become forward slashes.
+func (sb *S3Backend) ObjectKey(path string) string {
+	key := filepath.ToSlash(filepath.Clean(path))
+	if sb.basePath != "" && sb.basePath != "." {
+		if rel, ok := strings.CutPrefix(key, sb.basePath+"/"); ok {
+			key = rel
+		}
+	}
+	return strings.TrimLeft(strings.TrimPrefix(key, "./"), "/")
+}
+
+func (sb *S3Backend) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	objectKey := sb.ObjectKey(key)
+	if objectKey == "" || objectKey == "." {
+		return nil, fmt.Errorf("key objek tidak valid: %q", key)
+	}
+
+	u := *sb.endpoint
+	prefix := strings.TrimRight(sb.endpoint.Path, "/")
+	escapedPrefix := strings.TrimRight(sb.endpoint.EscapedPath(), "/")
+	if sb.usePathStyle {
+		u.Path = prefix + "/" + sb.bucket + "/" + objectKey
+		u.RawPath = escapedPrefix + "/" + s3EscapePath(sb.bucket) + "/" + s3EscapePath(objectKey)
+	} else {
+		u.Host = sb.bucket + "." + u.Host
+		u.Path = prefix + "/" + objectKey
+		u.RawPath = escapedPrefix + "/" + s3EscapePath(objectKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat request S3: %w", err)
+	}
+	return req, nil
+}
+
+func (sb *S3Backend) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	sb.sign(req, payloadHash, sb.now().UTC())
+
+	resp, err := sb.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request S3 gagal: %w", err)
+	}
+	return resp, nil
+}
+
+// sign adds the SigV4 Authorization header. Only host, x-amz-content-sha256 and
+// x-amz-date are signed, which is all S3 requires.
+func (sb *S3Backend) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := dateStamp + "/" + sb.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+sb.secretKey), dateStamp)
+	signingKey = hmacSHA256(signingKey, sb.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, sb.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath percent-encodes every byte outside the SigV4 unreserved set while
+// keeping the slashes between path segments.
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func s3ResponseError(method, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("S3 %s %s gagal dengan status %d: %s", method, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
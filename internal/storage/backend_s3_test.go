@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal path-style S3 endpoint that records objects in memory and
+// rejects requests without a SigV4 Authorization header.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), s3Algorithm+" Credential=") || r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := r.URL.EscapedPath()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"9a0364b9e99bb480dd25e1f0284c8555"`)
+		w.Header().Set("Last-Modified", "Wed, 04 Mar 2026 10:30:00 GMT")
+		status := http.StatusOK
+		if spec, ok := strings.CutPrefix(r.Header.Get("Range"), "bytes="); ok && r.Method == http.MethodGet {
+			startStr, endStr, _ := strings.Cut(spec, "-")
+			start, _ := strconv.Atoi(startStr)
+			end, _ := strconv.Atoi(endStr)
+			data = data[start : end+1]
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3Backend(t *testing.T, endpoint string) *S3Backend {
+	t.Helper()
+	backend, err := NewS3Backend(S3Options{
+		Endpoint:     endpoint,
+		Region:       "ap-southeast-1",
+		Bucket:       "invento",
+		AccessKey:    "AKIDEXAMPLE",
+		SecretKey:    "secret",
+		UsePathStyle: true,
+		BasePath:     "/volume1/data-invento/",
+	})
+	require.NoError(t, err)
+	return backend
+}
+
+func TestS3Backend_RoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fake, server := newFakeS3(t)
+	backend := newTestS3Backend(t, server.URL)
+	key := "/volume1/data-invento/projects/u1/abc/laporan akhir.zip"
+
+	require.NoError(t, backend.Put(ctx, key, bytes.NewReader([]byte("payload")), 7))
+	fake.mu.Lock()
+	assert.Contains(t, fake.objects, "/invento/projects/u1/abc/laporan%20akhir.zip")
+	fake.mu.Unlock()
+
+	info, err := backend.Stat(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), info.Size)
+	assert.Equal(t, time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC), info.ModTime.UTC())
+	assert.Equal(t, `"9a0364b9e99bb480dd25e1f0284c8555"`, info.EntityTag())
+
+	object, err := backend.Open(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(object)
+	require.NoError(t, object.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+
+	object, err = backend.OpenRange(ctx, key, 3, 3)
+	require.NoError(t, err)
+	data, err = io.ReadAll(object)
+	require.NoError(t, object.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "loa", string(data))
+
+	require.NoError(t, backend.Delete(ctx, key))
+	_, err = backend.Stat(ctx, key)
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+	_, err = backend.Open(ctx, key)
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestS3Backend_ErrorStatus(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	t.Cleanup(server.Close)
+	backend := newTestS3Backend(t, server.URL)
+
+	err := backend.Put(context.Background(), "projects/file.zip", bytes.NewReader([]byte("x")), 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestS3Backend_ObjectKey(t *testing.T) {
+	t.Parallel()
+	backend := newTestS3Backend(t, "http://localhost:9000")
+
+	assert.Equal(t, "projects/u1/abc/file.zip", backend.ObjectKey("/volume1/data-invento/projects/u1/abc/file.zip"))
+	assert.Equal(t, "profil/u1/profil.png", backend.ObjectKey("profil/u1/profil.png"))
+	assert.Equal(t, "uploads/modul/a.pdf", backend.ObjectKey("./uploads/modul/a.pdf"))
+}
+
+func TestS3Backend_Sign(t *testing.T) {
+	t.Parallel()
+
+	t.Run("path style", func(t *testing.T) {
+		t.Parallel()
+		backend := newTestS3Backend(t, "https://s3.example.com")
+		req, err := backend.newRequest(context.Background(), http.MethodGet, "projects/u1/file.zip", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://s3.example.com/invento/projects/u1/file.zip", req.URL.String())
+
+		backend.sign(req, s3EmptyPayload, time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC))
+		assert.Equal(t, "20260304T103000Z", req.Header.Get("X-Amz-Date"))
+		assert.Equal(t, s3EmptyPayload, req.Header.Get("X-Amz-Content-Sha256"))
+
+		auth := req.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260304/ap-southeast-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+		assert.Len(t, strings.TrimPrefix(auth[strings.Index(auth, "Signature="):], "Signature="), 64)
+
+		again := req.Clone(context.Background())
+		backend.sign(again, s3EmptyPayload, time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC))
+		assert.Equal(t, auth, again.Header.Get("Authorization"), "signing is deterministic")
+
+		backend.sign(again, s3EmptyPayload, time.Date(2026, time.March, 4, 10, 30, 1, 0, time.UTC))
+		assert.NotEqual(t, auth, again.Header.Get("Authorization"))
+	})
+
+	t.Run("virtual hosted style", func(t *testing.T) {
+		t.Parallel()
+		backend, err := NewS3Backend(S3Options{
+			Endpoint:  "https://s3.ap-southeast-1.amazonaws.com",
+			Bucket:    "invento",
+			AccessKey: "AKIDEXAMPLE",
+			SecretKey: "secret",
+		})
+		require.NoError(t, err)
+
+		req, err := backend.newRequest(context.Background(), http.MethodPut, "moduls/1/a b+c.pdf", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "invento.s3.ap-southeast-1.amazonaws.com", req.URL.Host)
+		assert.Equal(t, "/moduls/1/a%20b%2Bc.pdf", req.URL.EscapedPath())
+	})
+}
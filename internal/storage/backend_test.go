@@ -0,0 +1,190 @@
+package storage_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"invento-service/config"
+	"invento-service/internal/domain"
+	"invento-service/internal/storage"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend_RoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := storage.NewLocalBackend()
+	key := filepath.Join(t.TempDir(), "projects", "u1", "abc", "file.zip")
+
+	require.NoError(t, backend.Put(ctx, key, bytes.NewReader([]byte("payload")), 7))
+
+	info, err := backend.Stat(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), info.Size)
+
+	object, err := backend.Open(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(object)
+	require.NoError(t, object.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+
+	object, err = backend.OpenRange(ctx, key, 2, 3)
+	require.NoError(t, err)
+	data, err = io.ReadAll(object)
+	require.NoError(t, object.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "ylo", string(data))
+
+	require.NoError(t, backend.Delete(ctx, key))
+	require.NoError(t, backend.Delete(ctx, key), "deleting a missing file is not an error")
+
+	_, err = backend.Stat(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = backend.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestOpenRange_WithoutRangeOpener(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := struct{ storage.Backend }{storage.NewMemoryBackend()}
+	require.NoError(t, backend.Put(ctx, "/data/file.zip", bytes.NewReader([]byte("0123456789")), 10))
+
+	object, err := storage.OpenRange(ctx, backend, "/data/file.zip", 6, 3)
+	require.NoError(t, err)
+	data, err := io.ReadAll(object)
+	require.NoError(t, object.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "678", string(data))
+}
+
+func TestObjectInfo_EntityTag(t *testing.T) {
+	t.Parallel()
+	modTime := time.Unix(1772620200, 0)
+
+	assert.Equal(t, `"69a809a8-a"`, storage.ObjectInfo{Size: 10, ModTime: modTime}.EntityTag())
+	assert.Equal(t, `"abc123"`, storage.ObjectInfo{Size: 10, ModTime: modTime, Checksum: "abc123"}.EntityTag())
+}
+
+func TestStoreFile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("local backend moves the file", func(t *testing.T) {
+		t.Parallel()
+		src := filepath.Join(t.TempDir(), "file.zip")
+		require.NoError(t, os.WriteFile(src, []byte("zip"), 0o644))
+		dst := filepath.Join(t.TempDir(), "final", "file.zip")
+
+		require.NoError(t, storage.StoreFile(ctx, storage.NewLocalBackend(), src, dst))
+
+		data, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		assert.Equal(t, "zip", string(data))
+		assert.NoFileExists(t, src)
+	})
+
+	t.Run("other backends receive a copy and the source is removed", func(t *testing.T) {
+		t.Parallel()
+		src := filepath.Join(t.TempDir(), "file.zip")
+		require.NoError(t, os.WriteFile(src, []byte("zip"), 0o644))
+		backend := storage.NewMemoryBackend()
+
+		require.NoError(t, storage.StoreFile(ctx, backend, src, "/data/projects/file.zip"))
+
+		info, err := backend.Stat(ctx, "/data/projects/file.zip")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), info.Size)
+		assert.NoFileExists(t, src)
+	})
+}
+
+func TestNewBackend(t *testing.T) {
+	t.Parallel()
+	pathResolver := storage.NewPathResolver(&config.Config{})
+
+	backend, err := storage.NewBackend(&config.Config{}, pathResolver)
+	require.NoError(t, err)
+	assert.IsType(t, &storage.LocalBackend{}, backend)
+
+	backend, err = storage.NewBackend(&config.Config{Storage: config.StorageConfig{
+		Backend:     storage.BackendS3,
+		S3Endpoint:  "http://localhost:9000",
+		S3Bucket:    "invento",
+		S3AccessKey: "access",
+		S3SecretKey: "secret",
+	}}, pathResolver)
+	require.NoError(t, err)
+	assert.IsType(t, &storage.S3Backend{}, backend)
+
+	_, err = storage.NewBackend(&config.Config{Storage: config.StorageConfig{Backend: storage.BackendS3}}, pathResolver)
+	assert.Error(t, err, "s3 without credentials")
+
+	_, err = storage.NewBackend(&config.Config{Storage: config.StorageConfig{Backend: "ftp"}}, pathResolver)
+	assert.Error(t, err)
+}
+
+func TestDownloadHelper_WithBackend(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	basePath := t.TempDir()
+	pathResolver := storage.NewPathResolver(&config.Config{Upload: config.UploadConfig{PathDevelopment: basePath}})
+
+	backend := storage.NewMemoryBackend()
+	projectPath := filepath.Join(basePath, "projects", "u1", "abc", "project.zip")
+	modulPath := filepath.Join(basePath, "moduls", "u1", "def", "modul.pdf")
+	require.NoError(t, backend.Put(ctx, projectPath, bytes.NewReader([]byte("project")), 7))
+	require.NoError(t, backend.Put(ctx, modulPath, bytes.NewReader([]byte("modul")), 5))
+
+	dh := storage.NewDownloadHelper(pathResolver, zerolog.Nop())
+	dh.SetBackend(backend)
+
+	entries, notFound, err := dh.PrepareFilesForDownload(
+		[]domain.Project{{ID: 1, PathFile: projectPath}, {ID: 2, PathFile: filepath.Join(basePath, "missing.zip")}},
+		[]domain.Modul{{ID: "550e8400-e29b-41d4-a716-446655440001", FilePath: modulPath}},
+	)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, projectPath, entries[0].Key)
+	assert.Equal(t, modulPath, entries[1].Key)
+	assert.Len(t, notFound, 1)
+
+	t.Run("several files are zipped from the backend", func(t *testing.T) {
+		t.Parallel()
+		download, err := dh.CreateDownload(ctx, entries, notFound, "u1")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, download.WriteArchive(ctx, &buf))
+		reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		names := make([]string, 0, len(reader.File))
+		for _, f := range reader.File {
+			names = append(names, f.Name)
+		}
+		assert.ElementsMatch(t, []string{"projects/project-1/project.zip", "moduls/modul-550e8400-e29b-41d4-a716-446655440001.pdf", storage.ManifestFileName}, names)
+	})
+
+	t.Run("a single remote file is read from the backend", func(t *testing.T) {
+		t.Parallel()
+		download, err := dh.CreateDownload(ctx, entries[1:], nil, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, modulPath, download.Key)
+
+		object, err := download.Open(ctx)
+		require.NoError(t, err)
+		defer object.Close()
+		data, err := io.ReadAll(object)
+		require.NoError(t, err)
+		assert.Equal(t, "modul", string(data))
+	})
+}
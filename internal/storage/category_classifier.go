@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// CategoryDetection is the category ClassifyProjectZip settled on, how sure it is
+// (0 to 1) and the evidence behind it.
+type CategoryDetection struct {
+	Kategori   string
+	Confidence float64
+	Signals    []string
+}
+
+// categorySignal is the weight a signal adds to its category: 3 for files that only
+// appear in one kind of project, 2 for strong hints, 1 for file types that are merely
+// typical.
+type categorySignal struct {
+	kategori string
+	weight   int
+}
+
+const (
+	// confidentScore is the score at which a category no longer needs more evidence
+	// to reach full confidence.
+	confidentScore = 6
+	// maxClassifierScanFiles and maxClassifierScanBytes bound how much source is read
+	// when looking for imports; the archive has already passed InspectZip.
+	maxClassifierScanFiles = 200
+	maxClassifierScanBytes = 1 << 20
+)
+
+// categoryPriority breaks ties toward the more specific category, so a Flask app
+// serving a scikit-learn model counts as machine learning rather than a website.
+var categoryPriority = []string{
+	domain.KategoriDeepLearning,
+	domain.KategoriMachineLearning,
+	domain.KategoriIoT,
+	domain.KategoriMobile,
+	domain.KategoriWebsite,
+}
+
+var categoryFileSignals = map[string]categorySignal{
+	"package.json":        {domain.KategoriWebsite, 2},
+	"index.html":          {domain.KategoriWebsite, 2},
+	"composer.json":       {domain.KategoriWebsite, 2},
+	"androidmanifest.xml": {domain.KategoriMobile, 3},
+	"pubspec.yaml":        {domain.KategoriMobile, 3},
+	"platformio.ini":      {domain.KategoriIoT, 3},
+}
+
+var categoryExtSignals = map[string]categorySignal{
+	".html":      {domain.KategoriWebsite, 1},
+	".css":       {domain.KategoriWebsite, 1},
+	".php":       {domain.KategoriWebsite, 1},
+	".jsx":       {domain.KategoriWebsite, 1},
+	".tsx":       {domain.KategoriWebsite, 1},
+	".vue":       {domain.KategoriWebsite, 1},
+	".xcodeproj": {domain.KategoriMobile, 3},
+	".kt":        {domain.KategoriMobile, 1},
+	".swift":     {domain.KategoriMobile, 1},
+	".dart":      {domain.KategoriMobile, 1},
+	".ino":       {domain.KategoriIoT, 3},
+	".ipynb":     {domain.KategoriMachineLearning, 1},
+	".pkl":       {domain.KategoriMachineLearning, 1},
+	".joblib":    {domain.KategoriMachineLearning, 1},
+	".h5":        {domain.KategoriDeepLearning, 2},
+	".pt":        {domain.KategoriDeepLearning, 2},
+	".pth":       {domain.KategoriDeepLearning, 2},
+	".onnx":      {domain.KategoriDeepLearning, 2},
+	".tflite":    {domain.KategoriDeepLearning, 2},
+}
+
+var categoryLibrarySignals = map[string]categorySignal{
+	"sklearn":      {domain.KategoriMachineLearning, 3},
+	"scikit-learn": {domain.KategoriMachineLearning, 3},
+	"torch":        {domain.KategoriDeepLearning, 3},
+	"pytorch":      {domain.KategoriDeepLearning, 3},
+	"tensorflow":   {domain.KategoriDeepLearning, 3},
+	"keras":        {domain.KategoriDeepLearning, 3},
+}
+
+var (
+	// pythonImportPattern also matches notebook cells, where each source line is a
+	// JSON string and so starts with a quote.
+	pythonImportPattern = regexp.MustCompile(`(?m)(?:^|")\s*(?:import|from)\s+(sklearn|torch|tensorflow|keras)\b`)
+	// dependencyPattern matches requirements.txt, Pipfile and environment.yml entries.
+	dependencyPattern = regexp.MustCompile(`(?mi)^\s*(?:-\s*)?"?(scikit-learn|sklearn|pytorch|torch|tensorflow|keras)\b`)
+)
+
+// classifierIgnoredDirs hold dependencies and tooling rather than the student's own
+// work, so what they contain says nothing about the project.
+var classifierIgnoredDirs = map[string]bool{
+	"node_modules":  true,
+	"vendor":        true,
+	".git":          true,
+	"venv":          true,
+	".venv":         true,
+	"__pycache__":   true,
+	"site-packages": true,
+}
+
+// ClassifyProjectZip guesses the category of the project ZIP at filePath from its
+// contents: marker files such as package.json or AndroidManifest.xml, typical file
+// types, and the Python libraries imported by scripts and notebooks. An archive
+// without any recognisable signal is reported as a website with zero confidence.
+func ClassifyProjectZip(filePath string) (*CategoryDetection, error) {
+	reader, err := zip.OpenReader(filePath)
+	if errors.Is(err, zip.ErrInsecurePath) && reader != nil {
+		err = nil
+	}
+	if err != nil {
+		return nil, ErrZipCorrupt
+	}
+	defer reader.Close()
+
+	c := &categoryClassifier{scores: map[string]int{}, seen: map[string]bool{}, signals: map[string][]string{}}
+	scanned := 0
+	for _, file := range reader.File {
+		name := normalizeArchivePath(file.Name)
+		if checkZipEntryName(file.Name) != nil || inIgnoredDir(name) {
+			continue
+		}
+
+		lowerName := strings.ToLower(name)
+		for _, dir := range strings.Split(path.Dir(lowerName), "/") {
+			if strings.HasSuffix(dir, ".xcodeproj") {
+				c.add("*.xcodeproj", categoryExtSignals[".xcodeproj"], name)
+			}
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		base := path.Base(lowerName)
+		ext := path.Ext(base)
+		if signal, ok := categoryFileSignals[base]; ok {
+			c.add(path.Base(name), signal, name)
+		}
+		if signal, ok := categoryExtSignals[ext]; ok {
+			c.add("*"+ext, signal, name)
+		}
+
+		if pattern := libraryPattern(base, ext); pattern != nil && scanned < maxClassifierScanFiles {
+			scanned++
+			if err := c.scanLibraries(file, name, pattern); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return c.result(), nil
+}
+
+type categoryClassifier struct {
+	scores  map[string]int
+	seen    map[string]bool
+	signals map[string][]string
+}
+
+// add counts a signal once per archive, however many files carry it.
+func (c *categoryClassifier) add(label string, signal categorySignal, entryPath string) {
+	if c.seen[label] {
+		return
+	}
+	c.seen[label] = true
+	c.scores[signal.kategori] += signal.weight
+	c.signals[signal.kategori] = append(c.signals[signal.kategori], fmt.Sprintf("%s (%s)", label, entryPath))
+}
+
+func (c *categoryClassifier) scanLibraries(file *zip.File, name string, pattern *regexp.Regexp) error {
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrZipCorrupt, file.Name)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxClassifierScanBytes))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrZipCorrupt, file.Name)
+	}
+
+	for _, match := range pattern.FindAllSubmatch(content, -1) {
+		library := strings.ToLower(string(match[1]))
+		if pattern == pythonImportPattern {
+			c.add("import "+library, categoryLibrarySignals[library], name)
+		} else {
+			c.add("dependency "+library, categoryLibrarySignals[library], name)
+		}
+	}
+	return nil
+}
+
+func (c *categoryClassifier) result() *CategoryDetection {
+	best, total := "", 0
+	for _, kategori := range categoryPriority {
+		total += c.scores[kategori]
+		if c.scores[kategori] > c.scores[best] {
+			best = kategori
+		}
+	}
+
+	if best == "" {
+		return &CategoryDetection{Kategori: domain.KategoriWebsite, Signals: []string{}}
+	}
+
+	top := float64(c.scores[best])
+	confidence := top / float64(total) * math.Min(1, top/confidentScore)
+	return &CategoryDetection{
+		Kategori:   best,
+		Confidence: math.Round(confidence*100) / 100,
+		Signals:    c.signals[best],
+	}
+}
+
+// libraryPattern returns the pattern to search the entry with, or nil for files that
+// are not Python sources, notebooks or dependency lists.
+func libraryPattern(base, ext string) *regexp.Regexp {
+	switch {
+	case ext == ".py" || ext == ".ipynb":
+		return pythonImportPattern
+	case base == "requirements.txt" || base == "pipfile" || base == "environment.yml" || base == "environment.yaml":
+		return dependencyPattern
+	}
+	return nil
+}
+
+func inIgnoredDir(name string) bool {
+	for _, dir := range strings.Split(path.Dir(name), "/") {
+		if classifierIgnoredDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package storage_test
+
+import (
+	"archive/zip"
+	"invento-service/internal/domain"
+	"invento-service/internal/storage"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func zipFiles(names ...string) []zipTestEntry {
+	entries := make([]zipTestEntry, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, zipTestEntry{name: name, content: []byte("x"), method: zip.Store})
+	}
+	return entries
+}
+
+func TestClassifyProjectZip(t *testing.T) {
+	t.Parallel()
+
+	notebook := []byte(`{"cells": [{"cell_type": "code", "source": ["import pandas as pd\n", "from sklearn.model_selection import train_test_split\n"]}]}`)
+
+	tests := []struct {
+		name     string
+		entries  []zipTestEntry
+		want     string
+		signal   string
+		minScore float64
+	}{
+		{
+			name:     "website",
+			entries:  zipFiles("package.json", "public/index.html", "src/App.vue", "src/style.css"),
+			want:     domain.KategoriWebsite,
+			signal:   "package.json (package.json)",
+			minScore: 0.8,
+		},
+		{
+			name:     "android",
+			entries:  zipFiles("app/src/main/AndroidManifest.xml", "app/src/main/java/MainActivity.kt"),
+			want:     domain.KategoriMobile,
+			signal:   "AndroidManifest.xml (app/src/main/AndroidManifest.xml)",
+			minScore: 0.6,
+		},
+		{
+			name:    "flutter",
+			entries: zipFiles("pubspec.yaml", "lib/main.dart"),
+			want:    domain.KategoriMobile,
+			signal:  "pubspec.yaml (pubspec.yaml)",
+		},
+		{
+			name:    "xcode project",
+			entries: zipFiles("Todo.xcodeproj/project.pbxproj", "Todo/ContentView.swift"),
+			want:    domain.KategoriMobile,
+			signal:  "*.xcodeproj (Todo.xcodeproj/project.pbxproj)",
+		},
+		{
+			name:     "react native wins over its package.json",
+			entries:  zipFiles("package.json", "android/app/src/main/AndroidManifest.xml", "ios/App.xcodeproj/project.pbxproj"),
+			want:     domain.KategoriMobile,
+			minScore: 0.7,
+		},
+		{
+			name:    "arduino",
+			entries: zipFiles("sketch/sketch.ino"),
+			want:    domain.KategoriIoT,
+			signal:  "*.ino (sketch/sketch.ino)",
+		},
+		{
+			name:    "platformio",
+			entries: zipFiles("platformio.ini", "src/main.cpp"),
+			want:    domain.KategoriIoT,
+		},
+		{
+			name:    "notebook with sklearn",
+			entries: []zipTestEntry{{name: "notebooks/model.ipynb", content: notebook, method: zip.Deflate}},
+			want:    domain.KategoriMachineLearning,
+			signal:  "import sklearn (notebooks/model.ipynb)",
+		},
+		{
+			name: "pytorch",
+			entries: []zipTestEntry{
+				{name: "train.py", content: []byte("import os\nimport torch\nfrom torch import nn\n"), method: zip.Deflate},
+				{name: "requirements.txt", content: []byte("numpy==1.26\ntorch>=2.0\n"), method: zip.Store},
+			},
+			want:     domain.KategoriDeepLearning,
+			signal:   "dependency torch (requirements.txt)",
+			minScore: 1,
+		},
+		{
+			name: "flask app serving a model ties toward machine learning",
+			entries: append(zipFiles("templates/index.html", "static/style.css", "model.pkl"),
+				zipTestEntry{name: "app.py", content: []byte("from flask import Flask\nimport sklearn\n"), method: zip.Store}),
+			want: domain.KategoriMachineLearning,
+		},
+		{
+			name:    "dependencies are ignored",
+			entries: zipFiles("main.go", "node_modules/left-pad/package.json", "venv/lib/site-packages/torch/__init__.py"),
+			want:    domain.KategoriWebsite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			detection, err := storage.ClassifyProjectZip(writeTestZip(t, tt.entries...))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, detection.Kategori)
+			if tt.signal != "" {
+				assert.Contains(t, detection.Signals, tt.signal)
+			}
+			assert.GreaterOrEqual(t, detection.Confidence, tt.minScore)
+			assert.LessOrEqual(t, detection.Confidence, 1.0)
+		})
+	}
+}
+
+func TestClassifyProjectZip_NoSignals(t *testing.T) {
+	t.Parallel()
+	detection, err := storage.ClassifyProjectZip(writeTestZip(t, zipFiles("main.go", "README.md")...))
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.KategoriWebsite, detection.Kategori)
+	assert.Zero(t, detection.Confidence)
+	assert.Empty(t, detection.Signals)
+}
+
+func TestClassifyProjectZip_Corrupt(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "project.zip")
+	require.NoError(t, os.WriteFile(filePath, []byte("bukan zip"), 0o644))
+
+	_, err := storage.ClassifyProjectZip(filePath)
+	assert.ErrorIs(t, err, storage.ErrZipCorrupt)
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// ErrArchiveTooLarge is returned when the files selected for a batch download add up
+// to more than the configured archive limit.
+var ErrArchiveTooLarge = errors.New("ukuran total file melebihi batas arsip")
+
+var maxArchiveSize atomic.Int64
+
+// MaxArchiveSize returns the limit on the combined size of the files in one batch
+// download; zero means no limit.
+func MaxArchiveSize() int64 {
+	return maxArchiveSize.Load()
+}
+
+// SetMaxArchiveSize sets the batch download limit from DOWNLOAD_MAX_ARCHIVE_SIZE.
+func SetMaxArchiveSize(size int64) {
+	maxArchiveSize.Store(size)
+}
+
+// Download is what a download endpoint sends back: either one stored file, or several
+// files that are zipped on the fly while the response is being written.
+type Download struct {
+	FileName string
+	Key      string
+	Entries  []ArchiveEntry
+	Missing  []string
+	Size     int64
+	backend  Backend
+}
+
+// NewFileDownload sends the object stored under key as is.
+func NewFileDownload(backend Backend, key, fileName string) *Download {
+	return &Download{
+		FileName: fileName,
+		Key:      key,
+		backend:  backend,
+	}
+}
+
+// NewArchiveDownload checks that every entry still exists and that together they fit
+// within MaxArchiveSize before anything is sent, since a streamed archive cannot turn
+// into an error response halfway through.
+func NewArchiveDownload(ctx context.Context, backend Backend, fileName string, entries []ArchiveEntry, missing []string) (*Download, error) {
+	var total int64
+	for i := range entries {
+		info, err := backend.Stat(ctx, entries[i].Key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entries[i].Key, err)
+		}
+		total += info.Size
+	}
+
+	if limit := MaxArchiveSize(); limit > 0 && total > limit {
+		return nil, ErrArchiveTooLarge
+	}
+
+	return &Download{
+		FileName: fileName,
+		Entries:  entries,
+		Missing:  missing,
+		Size:     total,
+		backend:  backend,
+	}, nil
+}
+
+// IsArchive reports whether the download is a zip of several files.
+func (d *Download) IsArchive() bool {
+	return d.Key == ""
+}
+
+// Stat describes the single stored file.
+func (d *Download) Stat(ctx context.Context) (ObjectInfo, error) {
+	return d.backend.Stat(ctx, d.Key)
+}
+
+// Open reads the single stored file.
+func (d *Download) Open(ctx context.Context) (io.ReadCloser, error) {
+	return d.backend.Open(ctx, d.Key)
+}
+
+// OpenRange reads part of the single stored file.
+func (d *Download) OpenRange(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	return OpenRange(ctx, d.backend, d.Key, offset, length)
+}
+
+// WriteArchive streams the zip of all entries into w.
+func (d *Download) WriteArchive(ctx context.Context, w io.Writer) error {
+	return WriteArchive(ctx, d.backend, d.Entries, d.Missing, w)
+}
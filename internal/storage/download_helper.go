@@ -1,10 +1,10 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"invento-service/internal/domain"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -13,16 +13,23 @@ import (
 
 type DownloadHelper struct {
 	pathResolver *PathResolver
+	backend      Backend
 	logger       zerolog.Logger
 }
 
 func NewDownloadHelper(pathResolver *PathResolver, logger zerolog.Logger) *DownloadHelper {
 	return &DownloadHelper{
 		pathResolver: pathResolver,
+		backend:      DefaultBackend(),
 		logger:       logger,
 	}
 }
 
+// SetBackend overrides the backend files are read from.
+func (dh *DownloadHelper) SetBackend(backend Backend) {
+	dh.backend = backend
+}
+
 func (dh *DownloadHelper) ValidateDownloadRequest(projectIDs, modulIDs []string) error {
 	if len(projectIDs) == 0 && len(modulIDs) == 0 {
 		return errors.New("project IDs atau modul IDs harus diisi minimal salah satu")
@@ -62,63 +69,52 @@ func (dh *DownloadHelper) resolvePath(relativePath string) string {
 	return absPath
 }
 
-func (dh *DownloadHelper) PrepareFilesForDownload(projects []domain.Project, moduls []domain.Modul) (filePaths, notFoundFiles []string, err error) {
-	for _, project := range projects {
+// PrepareFilesForDownload resolves the stored file of every project and modul and
+// returns the ones present in storage as archive entries, plus a description of each
+// file that could not be found.
+func (dh *DownloadHelper) PrepareFilesForDownload(projects []domain.Project, moduls []domain.Modul) (entries []ArchiveEntry, notFoundFiles []string, err error) {
+	ctx := context.Background()
+	for i := range projects {
+		project := &projects[i]
 		resolvedPath := dh.resolvePath(project.PathFile)
 
-		if _, statErr := os.Stat(resolvedPath); statErr == nil {
-			filePaths = append(filePaths, resolvedPath)
+		if _, statErr := dh.backend.Stat(ctx, resolvedPath); statErr == nil {
+			entries = append(entries, ProjectArchiveEntry(project, resolvedPath))
 		} else {
 			notFoundFiles = append(notFoundFiles, fmt.Sprintf("Project ID %d: %s", project.ID, project.PathFile))
 		}
 	}
 
-	for _, modul := range moduls {
+	for i := range moduls {
+		modul := &moduls[i]
 		resolvedPath := dh.resolvePath(modul.FilePath)
 
-		if _, statErr := os.Stat(resolvedPath); statErr == nil {
-			filePaths = append(filePaths, resolvedPath)
+		if _, statErr := dh.backend.Stat(ctx, resolvedPath); statErr == nil {
+			entries = append(entries, ModulArchiveEntry(modul, resolvedPath))
 		} else {
 			notFoundFiles = append(notFoundFiles, fmt.Sprintf("Modul ID %s: %s", modul.ID, modul.FilePath))
 		}
 	}
 
-	if len(filePaths) == 0 {
+	if len(entries) == 0 {
 		return nil, notFoundFiles, errors.New("semua file tidak ditemukan di server")
 	}
 
-	return filePaths, notFoundFiles, nil
+	return entries, notFoundFiles, nil
 }
 
-func (dh *DownloadHelper) CreateDownloadZip(filePaths []string, userID string) (string, error) {
-	if len(filePaths) == 0 {
-		return "", errors.New("tidak ada file untuk didownload")
-	}
-
-	if len(filePaths) == 1 {
-		return filePaths[0], nil
-	}
-
-	basePath := dh.pathResolver.GetBasePath()
-	tempDir := filepath.Join(basePath, "temp")
-
-	if err := os.MkdirAll(tempDir, 0o755); err != nil {
-		return "", errors.New("gagal membuat direktori temp")
-	}
-
-	identifier, err := GenerateUniqueIdentifier(8)
-	if err != nil {
-		return "", errors.New("gagal generate identifier")
+// CreateDownload returns what to send for the given entries: the file itself when there
+// is only one, otherwise a structured archive whose manifest also lists the missing files.
+func (dh *DownloadHelper) CreateDownload(ctx context.Context, entries []ArchiveEntry, missing []string, userID string) (*Download, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("tidak ada file untuk didownload")
 	}
 
-	zipFileName := fmt.Sprintf("user_%s_files_%s.zip", userID, identifier)
-	zipFilePath := filepath.Join(tempDir, zipFileName)
-
-	if err := CreateZipArchive(filePaths, zipFilePath); err != nil {
-		return "", errors.New("gagal membuat file zip")
+	if len(entries) == 1 {
+		return NewFileDownload(dh.backend, entries[0].Key, entries[0].DownloadName()), nil
 	}
 
-	return zipFilePath, nil
+	return NewArchiveDownload(ctx, dh.backend, fmt.Sprintf("user_%s_files.zip", userID), entries, missing)
 }
 
 func (dh *DownloadHelper) GetFilesByIDs(projectIDs []uint, modulIDs []string, projects []domain.Project, moduls []domain.Modul) ([]domain.Project, []domain.Modul) {
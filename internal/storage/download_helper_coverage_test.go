@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"invento-service/config"
 	"invento-service/internal/domain"
 	"os"
@@ -150,8 +151,8 @@ func TestGetFilesByIDs_DoesNotModifyOriginals(t *testing.T) {
 	assert.Len(t, moduls, originalModulsLen)
 }
 
-// TestCreateDownloadZip_SingleFile returns single file path
-func TestCreateDownloadZip_SingleFile(t *testing.T) {
+// TestCreateDownload_SingleFile sends the file itself
+func TestCreateDownload_SingleFile(t *testing.T) {
 	t.Parallel()
 	// Create temp file
 	tmpFile, err := os.CreateTemp("", "test*.txt")
@@ -162,44 +163,49 @@ func TestCreateDownloadZip_SingleFile(t *testing.T) {
 	pathResolver := NewPathResolver(&config.Config{})
 	dh := NewDownloadHelper(pathResolver, zerolog.Nop())
 
-	zipPath, err := dh.CreateDownloadZip([]string{tmpFile.Name()}, "123")
+	download, err := dh.CreateDownload(context.Background(), []ArchiveEntry{{Kind: ArchiveKindProject, ID: "1", FileName: "test.txt", Key: tmpFile.Name()}}, nil, "123")
 	assert.NoError(t, err)
-	assert.Equal(t, tmpFile.Name(), zipPath)
+	assert.False(t, download.IsArchive())
+	assert.Equal(t, tmpFile.Name(), download.Key)
+	assert.Equal(t, "test.txt", download.FileName)
 }
 
-// TestCreateDownloadZip_EmptyArray tests error with empty file array
-func TestCreateDownloadZip_EmptyArray(t *testing.T) {
+// TestCreateDownload_EmptyArray tests error with empty file array
+func TestCreateDownload_EmptyArray(t *testing.T) {
 	t.Parallel()
 	pathResolver := NewPathResolver(&config.Config{})
 	dh := NewDownloadHelper(pathResolver, zerolog.Nop())
 
-	zipPath, err := dh.CreateDownloadZip([]string{}, "123")
+	download, err := dh.CreateDownload(context.Background(), []ArchiveEntry{}, nil, "123")
 	assert.Error(t, err)
-	assert.Empty(t, zipPath)
+	assert.Nil(t, download)
 	assert.Contains(t, err.Error(), "tidak ada file")
 }
 
-// TestCreateDownloadZip_MultipleFiles tests creating zip with multiple files
-func TestCreateDownloadZip_MultipleFiles(t *testing.T) {
+// TestCreateDownload_MultipleFiles builds an archive without touching disk
+func TestCreateDownload_MultipleFiles(t *testing.T) {
 	t.Parallel()
-	// Create temp files
-	tmpFile1, err := os.CreateTemp("", "test1*.txt")
-	require.NoError(t, err)
-	tmpFile1.Close()
-	defer os.Remove(tmpFile1.Name())
-
-	tmpFile2, err := os.CreateTemp("", "test2*.txt")
-	require.NoError(t, err)
-	tmpFile2.Close()
-	defer os.Remove(tmpFile2.Name())
+	tmpDir := t.TempDir()
+	file1 := filepath.Join(tmpDir, "test1.txt")
+	file2 := filepath.Join(tmpDir, "test2.txt")
+	require.NoError(t, os.WriteFile(file1, []byte("test1"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("test22"), 0o644))
 
-	pathResolver := NewPathResolver(&config.Config{})
+	pathResolver := NewPathResolver(&config.Config{Upload: config.UploadConfig{PathDevelopment: tmpDir}})
 	dh := NewDownloadHelper(pathResolver, zerolog.Nop())
 
-	// This would create a zip file, but we can't test the full functionality
-	// without a proper path resolver setup
-	// Just verify it doesn't panic
-	assert.NotNil(t, dh)
+	download, err := dh.CreateDownload(context.Background(), []ArchiveEntry{
+		{Kind: ArchiveKindProject, ID: "1", Key: file1},
+		{Kind: ArchiveKindProject, ID: "2", Key: file2},
+	}, nil, "123")
+	require.NoError(t, err)
+	assert.True(t, download.IsArchive())
+	assert.Equal(t, "user_123_files.zip", download.FileName)
+	assert.Equal(t, int64(11), download.Size)
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temp zip is written next to the files")
 }
 
 // TestPrepareFilesForDownload_AllFilesFound tests when all files exist
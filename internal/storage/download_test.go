@@ -0,0 +1,47 @@
+package storage_test
+
+import (
+	"context"
+	"invento-service/internal/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArchiveDownload(t *testing.T) {
+	ctx := context.Background()
+	backend := storage.NewMemoryBackend()
+	require.NoError(t, backend.Put(ctx, "/data/a.pdf", strings.NewReader("12345"), 5))
+	require.NoError(t, backend.Put(ctx, "/data/b.pdf", strings.NewReader("1234567"), 7))
+	entries := []storage.ArchiveEntry{
+		{Kind: storage.ArchiveKindModul, ID: "a", Key: "/data/a.pdf"},
+		{Kind: storage.ArchiveKindModul, ID: "b", Key: "/data/b.pdf"},
+	}
+
+	t.Run("sums the size of every entry", func(t *testing.T) {
+		download, err := storage.NewArchiveDownload(ctx, backend, "moduls.zip", entries, nil)
+		require.NoError(t, err)
+		assert.True(t, download.IsArchive())
+		assert.Equal(t, int64(12), download.Size)
+	})
+
+	t.Run("missing entry fails before streaming", func(t *testing.T) {
+		_, err := storage.NewArchiveDownload(ctx, backend, "moduls.zip", append(entries, storage.ArchiveEntry{Key: "/data/c.pdf"}), nil)
+		assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	})
+
+	t.Run("archive over the limit is refused", func(t *testing.T) {
+		previous := storage.MaxArchiveSize()
+		t.Cleanup(func() { storage.SetMaxArchiveSize(previous) })
+
+		storage.SetMaxArchiveSize(12)
+		_, err := storage.NewArchiveDownload(ctx, backend, "moduls.zip", entries, nil)
+		require.NoError(t, err)
+
+		storage.SetMaxArchiveSize(11)
+		_, err = storage.NewArchiveDownload(ctx, backend, "moduls.zip", entries, nil)
+		assert.ErrorIs(t, err, storage.ErrArchiveTooLarge)
+	})
+}
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"archive/zip"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -10,6 +12,7 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -86,25 +89,6 @@ func GetFileSize(fileHeader *multipart.FileHeader) string {
 	return FormatFileSize(fileHeader.Size)
 }
 
-func DetectProjectCategory(filename string) string {
-	lowerFilename := strings.ToLower(filename)
-
-	switch {
-	case strings.Contains(lowerFilename, "website") || strings.Contains(lowerFilename, "web"):
-		return "website"
-	case strings.Contains(lowerFilename, "mobile") || strings.Contains(lowerFilename, "android") || strings.Contains(lowerFilename, "ios"):
-		return "mobile"
-	case strings.Contains(lowerFilename, "iot") || strings.Contains(lowerFilename, "arduino") || strings.Contains(lowerFilename, "sensor"):
-		return "iot"
-	case strings.Contains(lowerFilename, "machine") || strings.Contains(lowerFilename, "ml"):
-		return "machine_learning"
-	case strings.Contains(lowerFilename, "deep") || strings.Contains(lowerFilename, "dl") || strings.Contains(lowerFilename, "neural"):
-		return "deep_learning"
-	default:
-		return "website"
-	}
-}
-
 func CreateUserDirectory(email, role string) (string, error) {
 	emailParts := strings.Split(email, "@")
 	if len(emailParts) != 2 {
@@ -218,11 +202,9 @@ func SaveUploadedFile(fileHeader *multipart.FileHeader, destPath string) error {
 	return err
 }
 
+// DeleteFile removes path from the default backend. A missing file is not an error.
 func DeleteFile(path string) error {
-	if _, err := os.Stat(path); err == nil {
-		return os.Remove(path)
-	}
-	return nil
+	return DefaultBackend().Delete(context.Background(), path)
 }
 
 func MoveFile(src, dst string) error {
@@ -267,7 +249,40 @@ func FormatFileSize(size int64) string {
 	}
 }
 
+// ParseFileSize reads back a size written by FormatFileSize, such as the Ukuran of
+// projects stored before their exact size was recorded. Unparseable input gives 0.
+func ParseFileSize(size string) int64 {
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"GB", 1024 * 1024 * 1024},
+		{"MB", 1024 * 1024},
+		{"KB", 1024},
+		{"B", 1},
+	}
+
+	size = strings.ToUpper(strings.TrimSpace(size))
+	for _, unit := range units {
+		number, ok := strings.CutSuffix(size, unit.suffix)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil || value < 0 {
+			return 0
+		}
+		return int64(value * unit.multiplier)
+	}
+	return 0
+}
+
 func CreateZipArchive(filePaths []string, outputPath string) error {
+	return CreateZipArchiveFrom(context.Background(), NewLocalBackend(), filePaths, outputPath)
+}
+
+// CreateZipArchiveFrom writes a zip at outputPath holding the objects stored under keys.
+func CreateZipArchiveFrom(ctx context.Context, backend Backend, keys []string, outputPath string) error {
 	zipFile, err := os.Create(outputPath)
 	if err != nil {
 		return err
@@ -277,8 +292,8 @@ func CreateZipArchive(filePaths []string, outputPath string) error {
 	zipWriter := zip.NewWriter(zipFile)
 	defer func() { _ = zipWriter.Close() }()
 
-	for _, filePath := range filePaths {
-		if err := addFileToZip(zipWriter, filePath); err != nil {
+	for _, key := range keys {
+		if err := addObjectToZip(ctx, zipWriter, backend, key); err != nil {
 			return err
 		}
 	}
@@ -286,32 +301,30 @@ func CreateZipArchive(filePaths []string, outputPath string) error {
 	return nil
 }
 
-func addFileToZip(zipWriter *zip.Writer, filePath string) error {
-	file, err := os.Open(filePath)
+func addObjectToZip(ctx context.Context, zipWriter *zip.Writer, backend Backend, key string) error {
+	info, err := backend.Stat(ctx, key)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	info, err := file.Stat()
+	object, err := backend.Open(ctx, key)
 	if err != nil {
 		return err
 	}
+	defer object.Close()
 
-	header, err := zip.FileInfoHeader(info)
-	if err != nil {
-		return err
+	header := &zip.FileHeader{
+		Name:     filepath.Base(key),
+		Method:   zip.Deflate,
+		Modified: info.ModTime,
 	}
 
-	header.Name = filepath.Base(filePath)
-	header.Method = zip.Deflate
-
 	writer, err := zipWriter.CreateHeader(header)
 	if err != nil {
 		return err
 	}
 
-	_, err = io.Copy(writer, file)
+	_, err = io.Copy(writer, object)
 	return err
 }
 
@@ -323,3 +336,18 @@ func GetFileSizeFromPath(filePath string) string {
 
 	return FormatFileSize(info.Size())
 }
+
+// FileSHA256 returns the hex-encoded SHA-256 of the local file at filePath.
+func FileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
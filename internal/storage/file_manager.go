@@ -17,6 +17,11 @@ func NewFileManager(cfg *config.Config) *FileManager {
 	}
 }
 
+// ProjectVersionRetention is how many versions of a project are kept; 0 keeps all.
+func (fm *FileManager) ProjectVersionRetention() int {
+	return fm.config.Upload.ProjectVersionRetention
+}
+
 func (fm *FileManager) GenerateRandomDirectory() (string, error) {
 	return GenerateRandomString(10)
 }
@@ -204,35 +204,6 @@ func TestGetFileSize(t *testing.T) {
 	}
 }
 
-func TestDetectProjectCategory(t *testing.T) {
-	t.Parallel()
-	tests := []struct {
-		filename         string
-		expectedCategory string
-	}{
-		{"website project", "website"},
-		{"web app", "website"},
-		{"mobile app", "mobile"},
-		{"android project", "mobile"},
-		{"ios development", "mobile"},
-		{"iot sensor", "iot"},
-		{"arduino controller", "iot"},
-		{"machine learning", "machine_learning"},
-		{"ml model", "machine_learning"},
-		{"deep learning", "deep_learning"},
-		{"neural network", "deep_learning"},
-		{"unknown project", "website"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.filename, func(t *testing.T) {
-			t.Parallel()
-			category := storage.DetectProjectCategory(tt.filename)
-			assert.Equal(t, tt.expectedCategory, category)
-		})
-	}
-}
-
 func TestValidateImageFile(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -183,6 +183,30 @@ func TestFormatFileSize(t *testing.T) {
 	}
 }
 
+func TestParseFileSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		size     string
+		expected int64
+	}{
+		{"bytes", "500B", 500},
+		{"kilobytes", "1.50KB", 1536},
+		{"megabytes", "2.00MB", 2097152},
+		{"gigabytes", "2.00GB", 2147483648},
+		{"lowercase with space", "12 mb", 12582912},
+		{"legacy label", "small", 0},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, storage.ParseFileSize(tt.size))
+		})
+	}
+}
+
 func TestGetFileSizeFromPath(t *testing.T) {
 	t.Parallel()
 	tempDir := t.TempDir()
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type PathResolver struct {
@@ -14,6 +15,7 @@ type PathResolver struct {
 	pathDevelopment     string
 	tempPathProduction  string
 	tempPathDevelopment string
+	urlSigner           *URLSigner
 }
 
 func NewPathResolver(cfg *config.Config) *PathResolver {
@@ -26,6 +28,12 @@ func NewPathResolver(cfg *config.Config) *PathResolver {
 	}
 }
 
+// SetURLSigner makes ConvertToAPIPath return signed, expiring links instead of bare
+// /uploads paths.
+func (pr *PathResolver) SetURLSigner(signer *URLSigner) {
+	pr.urlSigner = signer
+}
+
 func (pr *PathResolver) GetBasePath() string {
 	if pr.env == config.EnvProduction {
 		return pr.pathProduction
@@ -118,7 +126,9 @@ func (pr *PathResolver) GetModulFilePath(userID uint, identifier, filename strin
 	return filepath.Join(basePath, "moduls", fmt.Sprintf("%d", userID), identifier, filename)
 }
 
-func (pr *PathResolver) ConvertToAPIPath(absolutePath *string) *string {
+// ConvertToAPIPath turns a stored file path into its download link. With a URL signer
+// set, the link is signed for userID, the user the link is handed to.
+func (pr *PathResolver) ConvertToAPIPath(absolutePath *string, userID string) *string {
 	if absolutePath == nil {
 		return nil
 	}
@@ -142,7 +152,10 @@ func (pr *PathResolver) ConvertToAPIPath(absolutePath *string) *string {
 			relativePath = "/" + relativePath
 		}
 
-		apiPath := "/uploads" + relativePath
+		apiPath := SignedURLPrefix + relativePath
+		if pr.urlSigner != nil {
+			apiPath = pr.urlSigner.Sign(relativePath, userID, time.Now())
+		}
 		result := new(string)
 		*result = apiPath
 		return result
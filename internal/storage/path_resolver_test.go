@@ -3,9 +3,11 @@ package storage_test
 import (
 	"invento-service/config"
 	"invento-service/internal/storage"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -316,10 +318,10 @@ func TestPathResolver_ConvertToAPIPath(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			if tt.name == "nil pointer" {
-				result := pr.ConvertToAPIPath(nil)
+				result := pr.ConvertToAPIPath(nil, "user-1")
 				assert.Nil(t, result)
 			} else {
-				result := pr.ConvertToAPIPath(&tt.absolutePath)
+				result := pr.ConvertToAPIPath(&tt.absolutePath, "user-1")
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.expectedPath, *result)
 			}
@@ -333,7 +335,7 @@ func TestPathResolver_ConvertToAPIPath_OutsideBase(t *testing.T) {
 
 	// Path outside base path should be returned unchanged
 	outsidePath := "/etc/passwd"
-	result := pr.ConvertToAPIPath(&outsidePath)
+	result := pr.ConvertToAPIPath(&outsidePath, "user-1")
 
 	assert.NotNil(t, result)
 	assert.Equal(t, outsidePath, *result)
@@ -345,9 +347,27 @@ func TestPathResolver_ConvertToAPIPath_RelativePath(t *testing.T) {
 
 	// Relative path should be handled correctly
 	relativePath := filepath.Join(pr.GetBasePath(), "test.txt")
-	apiPath := pr.ConvertToAPIPath(&relativePath)
+	apiPath := pr.ConvertToAPIPath(&relativePath, "user-1")
 
 	assert.NotNil(t, apiPath)
 	assert.Contains(t, *apiPath, "/uploads/")
 	assert.Contains(t, *apiPath, "test.txt")
 }
+
+func TestPathResolver_ConvertToAPIPath_Signed(t *testing.T) {
+	t.Parallel()
+	pr, tempDir := setupPathResolverTest(t)
+	signer := storage.NewURLSigner("test-secret", 15*time.Minute)
+	pr.SetURLSigner(signer)
+
+	absolutePath := filepath.Join(tempDir, "profil", "456", "avatar.jpg")
+	result := pr.ConvertToAPIPath(&absolutePath, "456")
+	require.NotNil(t, result)
+
+	link, err := url.Parse(*result)
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/profil/456/avatar.jpg", link.Path)
+	query := link.Query()
+	assert.Equal(t, "456", query.Get("uid"))
+	assert.NoError(t, signer.Verify("/profil/456/avatar.jpg", "456", query.Get("expires"), query.Get("signature"), time.Now()))
+}
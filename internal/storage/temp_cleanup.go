@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// tempArtifactPatterns match the zips and file copies that downloads used to write
+// before archives were streamed. In-progress TUS uploads share the temp directory but
+// live in subdirectories, which the sweep never touches.
+var tempArtifactPatterns = []string{
+	"user_*_*",
+	"projects_*.zip",
+	"moduls_*.zip",
+}
+
+// TempCleanup periodically removes download artifacts left in the temp directories.
+type TempCleanup struct {
+	dirs            []string
+	cleanupInterval time.Duration
+	maxAge          time.Duration
+	stopChan        chan bool
+	isRunning       bool
+	logger          zerolog.Logger
+}
+
+func NewTempCleanup(dirs []string, cleanupInterval, maxAge int, logger zerolog.Logger) *TempCleanup {
+	return &TempCleanup{
+		dirs:            uniqueDirs(dirs),
+		cleanupInterval: time.Duration(cleanupInterval) * time.Second,
+		maxAge:          time.Duration(maxAge) * time.Second,
+		stopChan:        make(chan bool),
+		logger:          logger.With().Str("component", "TempCleanup").Logger(),
+	}
+}
+
+func (tc *TempCleanup) Start() {
+	if tc.isRunning || tc.cleanupInterval <= 0 {
+		return
+	}
+
+	tc.isRunning = true
+	go tc.run()
+	tc.logger.Info().Strs("dirs", tc.dirs).Msg("temp cleanup started")
+}
+
+func (tc *TempCleanup) Stop() {
+	if !tc.isRunning {
+		return
+	}
+
+	tc.stopChan <- true
+	tc.isRunning = false
+	tc.logger.Info().Msg("temp cleanup stopped")
+}
+
+func (tc *TempCleanup) run() {
+	tc.CleanupOnce(time.Now())
+
+	ticker := time.NewTicker(tc.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			tc.CleanupOnce(now)
+		case <-tc.stopChan:
+			return
+		}
+	}
+}
+
+// CleanupOnce removes artifacts last modified more than maxAge before now and
+// returns how many were deleted.
+func (tc *TempCleanup) CleanupOnce(now time.Time) int {
+	removed := 0
+	for _, dir := range tc.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				tc.logger.Warn().Err(err).Str("dir", dir).Msg("failed to read temp directory")
+			}
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() || !isTempArtifact(entry.Name()) {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil || now.Sub(info.ModTime()) < tc.maxAge {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				tc.logger.Warn().Err(err).Str("file", path).Msg("failed to delete temp artifact")
+				continue
+			}
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		tc.logger.Info().Int("count", removed).Msg("cleaned temp artifacts")
+	}
+	return removed
+}
+
+func isTempArtifact(name string) bool {
+	for _, pattern := range tempArtifactPatterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueDirs(dirs []string) []string {
+	seen := make(map[string]bool, len(dirs))
+	unique := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		key := filepath.Clean(dir)
+		if abs, err := filepath.Abs(dir); err == nil {
+			key = abs
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, dir)
+	}
+	return unique
+}
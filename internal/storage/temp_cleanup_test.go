@@ -0,0 +1,57 @@
+package storage_test
+
+import (
+	"invento-service/internal/storage"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTempCleanup_CleanupOnce(t *testing.T) {
+	t.Parallel()
+	baseTemp := t.TempDir()
+	legacyTemp := t.TempDir()
+	old := time.Now().Add(-2 * time.Hour)
+
+	write := func(path string, modTime time.Time) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	staleUserZip := filepath.Join(baseTemp, "user_u1_files_ab12cd34.zip")
+	staleUserCopy := filepath.Join(baseTemp, "user_u1_ab12cd34_modul.pdf")
+	staleProjects := filepath.Join(legacyTemp, "projects_ab12cd34.zip")
+	freshModuls := filepath.Join(legacyTemp, "moduls_ab12cd34.zip")
+	unrelated := filepath.Join(baseTemp, "notes.txt")
+	tusUpload := filepath.Join(baseTemp, "uploads", "upload-1", "file.zip")
+	write(staleUserZip, old)
+	write(staleUserCopy, old)
+	write(staleProjects, old)
+	write(freshModuls, time.Now())
+	write(unrelated, old)
+	write(tusUpload, old)
+
+	cleanup := storage.NewTempCleanup([]string{baseTemp, legacyTemp, baseTemp + "/"}, 60, 3600, zerolog.Nop())
+
+	assert.Equal(t, 3, cleanup.CleanupOnce(time.Now()))
+	assert.NoFileExists(t, staleUserZip)
+	assert.NoFileExists(t, staleUserCopy)
+	assert.NoFileExists(t, staleProjects)
+	assert.FileExists(t, freshModuls)
+	assert.FileExists(t, unrelated)
+	assert.FileExists(t, tusUpload, "in-progress uploads are never swept")
+
+	assert.Equal(t, 0, cleanup.CleanupOnce(time.Now()))
+}
+
+func TestTempCleanup_MissingDirectory(t *testing.T) {
+	t.Parallel()
+	cleanup := storage.NewTempCleanup([]string{filepath.Join(t.TempDir(), "absent")}, 60, 0, zerolog.Nop())
+	assert.Equal(t, 0, cleanup.CleanupOnce(time.Now()))
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// SignedURLPrefix is the route that serves stored files through signed links.
+const SignedURLPrefix = "/uploads"
+
+var (
+	// ErrSignedURLInvalid is returned when a download link is malformed or its
+	// signature does not match the path.
+	ErrSignedURLInvalid = errors.New("tautan unduhan tidak valid")
+	// ErrSignedURLExpired is returned when a correctly signed link is past its expiry.
+	ErrSignedURLExpired = errors.New("tautan unduhan sudah kedaluwarsa")
+)
+
+// URLSigner issues and checks HMAC-SHA256 signed, time-limited links to stored files.
+// A link covers one path relative to the storage base path and the user it was issued
+// to, and is valid until the unix time in its expires parameter.
+type URLSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewURLSigner(secret string, ttl time.Duration) *URLSigner {
+	return &URLSigner{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// TTL returns how long issued links stay valid.
+func (s *URLSigner) TTL() time.Duration {
+	return s.ttl
+}
+
+// Sign returns the signed link to relPath issued to userID, valid for the signer's TTL
+// from now. The user ID travels in the uid parameter so downloads can be attributed.
+func (s *URLSigner) Sign(relPath, userID string, now time.Time) string {
+	relPath = cleanSignedPath(relPath)
+	expires := now.Add(s.ttl).Unix()
+
+	query := url.Values{}
+	query.Set("uid", userID)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.signature(relPath, userID, expires))
+
+	link := url.URL{Path: SignedURLPrefix + relPath, RawQuery: query.Encode()}
+	return link.String()
+}
+
+// Verify checks the uid, expires and signature parameters of a link to relPath.
+func (s *URLSigner) Verify(relPath, userID, expires, signature string, now time.Time) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" {
+		return ErrSignedURLInvalid
+	}
+
+	expected := s.signature(cleanSignedPath(relPath), userID, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrSignedURLInvalid
+	}
+	if now.Unix() > expiresAt {
+		return ErrSignedURLExpired
+	}
+	return nil
+}
+
+func (s *URLSigner) signature(relPath, userID string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(relPath))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(userID))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cleanSignedPath normalizes a relative path so that "a/b", "/a/b" and "/a/./b"
+// all carry the same signature.
+func cleanSignedPath(relPath string) string {
+	return path.Clean("/" + relPath)
+}
@@ -0,0 +1,68 @@
+package storage_test
+
+import (
+	"invento-service/internal/storage"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSigner(t *testing.T) {
+	t.Parallel()
+	signer := storage.NewURLSigner("test-secret", 10*time.Minute)
+	issuedAt := time.Unix(1700000000, 0)
+
+	link, err := url.Parse(signer.Sign("projects/u1/abc/project.zip", "u1", issuedAt))
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/projects/u1/abc/project.zip", link.Path)
+	assert.Equal(t, "1700000600", link.Query().Get("expires"))
+	assert.Equal(t, "u1", link.Query().Get("uid"))
+
+	expires, signature := link.Query().Get("expires"), link.Query().Get("signature")
+
+	t.Run("valid until expiry", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, signer.Verify("/projects/u1/abc/project.zip", "u1", expires, signature, issuedAt.Add(10*time.Minute)))
+		assert.NoError(t, signer.Verify("/projects/u1/./abc/project.zip", "u1", expires, signature, issuedAt))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+		err := signer.Verify("/projects/u1/abc/project.zip", "u1", expires, signature, issuedAt.Add(11*time.Minute))
+		assert.ErrorIs(t, err, storage.ErrSignedURLExpired)
+	})
+
+	t.Run("other path", func(t *testing.T) {
+		t.Parallel()
+		err := signer.Verify("/projects/u2/abc/project.zip", "u1", expires, signature, issuedAt)
+		assert.ErrorIs(t, err, storage.ErrSignedURLInvalid)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		t.Parallel()
+		err := signer.Verify("/projects/u1/abc/project.zip", "u2", expires, signature, issuedAt)
+		assert.ErrorIs(t, err, storage.ErrSignedURLInvalid)
+	})
+
+	t.Run("extended expiry", func(t *testing.T) {
+		t.Parallel()
+		err := signer.Verify("/projects/u1/abc/project.zip", "u1", "1800000000", signature, issuedAt)
+		assert.ErrorIs(t, err, storage.ErrSignedURLInvalid)
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		t.Parallel()
+		other := storage.NewURLSigner("another-secret", 10*time.Minute)
+		err := other.Verify("/projects/u1/abc/project.zip", "u1", expires, signature, issuedAt)
+		assert.ErrorIs(t, err, storage.ErrSignedURLInvalid)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorIs(t, signer.Verify("/projects/u1/abc/project.zip", "u1", "", signature, issuedAt), storage.ErrSignedURLInvalid)
+		assert.ErrorIs(t, signer.Verify("/projects/u1/abc/project.zip", "u1", expires, "", issuedAt), storage.ErrSignedURLInvalid)
+	})
+}
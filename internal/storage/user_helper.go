@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"invento-service/config"
@@ -11,16 +12,23 @@ import (
 
 type UserHelper struct {
 	pathResolver *PathResolver
+	backend      Backend
 	config       *config.Config
 }
 
 func NewUserHelper(pathResolver *PathResolver, cfg *config.Config) *UserHelper {
 	return &UserHelper{
 		pathResolver: pathResolver,
+		backend:      DefaultBackend(),
 		config:       cfg,
 	}
 }
 
+// SetBackend overrides the backend profile photos are stored in.
+func (uh *UserHelper) SetBackend(backend Backend) {
+	uh.backend = backend
+}
+
 func (uh *UserHelper) BuildProfileData(user *domain.User, jumlahProject, jumlahModul int) *dto.ProfileData {
 	roleName := ""
 	if user.Role != nil {
@@ -29,7 +37,7 @@ func (uh *UserHelper) BuildProfileData(user *domain.User, jumlahProject, jumlahM
 
 	var fotoProfilPath *string
 	if user.FotoProfil != nil && *user.FotoProfil != "" {
-		fotoProfilPath = uh.pathResolver.ConvertToAPIPath(user.FotoProfil)
+		fotoProfilPath = uh.pathResolver.ConvertToAPIPath(user.FotoProfil, user.ID)
 	}
 
 	return &dto.ProfileData{
@@ -75,22 +83,24 @@ func (uh *UserHelper) SaveProfilePhoto(fotoProfil *multipart.FileHeader, userID
 		return nil, err
 	}
 
+	ctx := context.Background()
 	if currentPhotoPath != nil && *currentPhotoPath != "" {
-		if err := DeleteFile(*currentPhotoPath); err != nil {
+		if err := uh.backend.Delete(ctx, *currentPhotoPath); err != nil {
 			return nil, errors.New("gagal menghapus foto profil lama")
 		}
 	}
 
-	profilDir := uh.pathResolver.GetProfilDirectory(userID)
-	if err := uh.pathResolver.EnsureDirectoryExists(profilDir); err != nil {
-		return nil, errors.New("gagal membuat direktori profil")
-	}
-
 	ext := GetFileExtension(fotoProfil.Filename)
 	filename := fmt.Sprintf("profil%s", ext)
 	destPath := uh.pathResolver.GetProfilFilePath(userID, filename)
 
-	if err := SaveUploadedFile(fotoProfil, destPath); err != nil {
+	file, err := fotoProfil.Open()
+	if err != nil {
+		return nil, errors.New("gagal menyimpan foto profil")
+	}
+	defer file.Close()
+
+	if err := uh.backend.Put(ctx, destPath, file, fotoProfil.Size); err != nil {
 		return nil, errors.New("gagal menyimpan foto profil")
 	}
 
@@ -1,11 +1,14 @@
 package storage_test
 
 import (
+	"bytes"
+	"context"
 	"invento-service/config"
 	"invento-service/internal/domain"
 	"invento-service/internal/storage"
 	"io"
 	"mime/multipart"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -329,3 +332,32 @@ func TestUserHelper_Integration(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestUserHelper_SaveProfilePhoto_Backend(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cfg := &config.Config{Upload: config.UploadConfig{PathDevelopment: "/data/invento"}}
+	userHelper := storage.NewUserHelper(storage.NewPathResolver(cfg), cfg)
+	backend := storage.NewMemoryBackend()
+	userHelper.SetBackend(backend)
+
+	oldPath := "/data/invento/profil/u1/profil.jpg"
+	require.NoError(t, backend.Put(ctx, oldPath, bytes.NewReader([]byte("old")), 3))
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+
+	result, err := userHelper.SaveProfilePhoto(form.File["file"][0], "u1", &oldPath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/data/invento", "profil", "u1", "profil.png"), *result)
+	assert.Equal(t, []string{*result}, backend.Keys())
+}
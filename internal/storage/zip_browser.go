@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ErrArchiveEntryNotFound is returned when the requested path is not a file in the archive.
+var ErrArchiveEntryNotFound = errors.New("file tidak ditemukan di dalam arsip")
+
+// archiveReadAhead is how much is fetched per backend request. The central directory
+// and a sequentially decompressed entry are read in small pieces, and without
+// read-ahead every piece would be its own request to object storage.
+const archiveReadAhead = 256 << 10
+
+// backendReaderAt reads a stored object at arbitrary offsets through OpenRange,
+// keeping the last fetched window so neighbouring reads are served from memory.
+type backendReaderAt struct {
+	ctx     context.Context
+	backend Backend
+	key     string
+	size    int64
+
+	mu     sync.Mutex
+	offset int64
+	window []byte
+}
+
+func (r *backendReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for n < len(p) && off < r.size {
+		if off < r.offset || off >= r.offset+int64(len(r.window)) {
+			if err := r.fetch(off, int64(len(p)-n)); err != nil {
+				return n, err
+			}
+		}
+		copied := copy(p[n:], r.window[off-r.offset:])
+		n += copied
+		off += int64(copied)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (r *backendReaderAt) fetch(off, want int64) error {
+	length := min(max(want, archiveReadAhead), r.size-off)
+	object, err := OpenRange(r.ctx, r.backend, r.key, off, length)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	window := make([]byte, length)
+	if _, err := io.ReadFull(object, window); err != nil {
+		return err
+	}
+	r.offset, r.window = off, window
+	return nil
+}
+
+// StoredArchive gives access to the entries of a ZIP kept in a Backend without
+// downloading or extracting it; only the central directory and the bytes of the
+// entries actually opened are fetched.
+type StoredArchive struct {
+	files map[string]*zip.File
+	names []string
+}
+
+// OpenStoredArchive reads the central directory of the ZIP stored under key.
+// It returns ErrObjectNotFound when the object is missing and ErrZipCorrupt when it
+// is not a readable archive.
+func OpenStoredArchive(ctx context.Context, backend Backend, key string) (*StoredArchive, error) {
+	info, err := backend.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(&backendReaderAt{ctx: ctx, backend: backend, key: key, size: info.Size}, info.Size)
+	if errors.Is(err, zip.ErrInsecurePath) && reader != nil {
+		err = nil
+	}
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, err
+		}
+		return nil, ErrZipCorrupt
+	}
+
+	archive := &StoredArchive{files: make(map[string]*zip.File, len(reader.File))}
+	for _, file := range reader.File {
+		// Unsafe names and symlinks are left out rather than failing the whole
+		// listing, so archives stored before uploads were inspected stay browsable.
+		if checkZipEntryName(file.Name) != nil || file.Mode()&fs.ModeSymlink != 0 || file.FileInfo().IsDir() {
+			continue
+		}
+		name := normalizeArchivePath(file.Name)
+		if _, exists := archive.files[name]; exists {
+			continue
+		}
+		archive.files[name] = file
+		archive.names = append(archive.names, name)
+	}
+
+	return archive, nil
+}
+
+// Entries lists the files in the archive in central directory order.
+func (a *StoredArchive) Entries() []domain.ProjectManifestEntry {
+	entries := make([]domain.ProjectManifestEntry, 0, len(a.names))
+	for _, name := range a.names {
+		file := a.files[name]
+		entries = append(entries, domain.ProjectManifestEntry{
+			Path:           name,
+			Size:           int64(file.UncompressedSize64),
+			CompressedSize: int64(file.CompressedSize64),
+			CRC32:          file.CRC32,
+		})
+	}
+	return entries
+}
+
+// ArchiveFile is one decompressed entry of a StoredArchive. Body must be closed.
+type ArchiveFile struct {
+	Path        string
+	Size        int64
+	ContentType string
+	Body        io.ReadCloser
+}
+
+// OpenFile opens the entry at filePath for streaming. The content type comes from the
+// extension, or from sniffing the first bytes when the extension is unknown.
+func (a *StoredArchive) OpenFile(filePath string) (*ArchiveFile, error) {
+	if err := checkZipEntryName(filePath); err != nil {
+		return nil, err
+	}
+
+	name := normalizeArchivePath(filePath)
+	file, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrArchiveEntryNotFound, filePath)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrZipCorrupt, name)
+	}
+
+	body := bufio.NewReaderSize(rc, 512)
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		head, _ := body.Peek(512)
+		contentType = http.DetectContentType(head)
+	}
+
+	return &ArchiveFile{
+		Path:        name,
+		Size:        int64(file.UncompressedSize64),
+		ContentType: contentType,
+		Body: struct {
+			io.Reader
+			io.Closer
+		}{body, rc},
+	}, nil
+}
+
+// normalizeArchivePath turns an entry name into the forward-slash form used for
+// lookups, so "src\main.go" and "./src/main.go" both find src/main.go.
+func normalizeArchivePath(name string) string {
+	return path.Clean(strings.ReplaceAll(name, "\\", "/"))
+}
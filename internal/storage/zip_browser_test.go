@@ -0,0 +1,142 @@
+package storage_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"invento-service/internal/storage"
+	"io"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingBackend records how many bytes were requested through OpenRange.
+type countingBackend struct {
+	*storage.MemoryBackend
+	requested atomic.Int64
+}
+
+func (cb *countingBackend) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	cb.requested.Add(length)
+	return cb.MemoryBackend.OpenRange(ctx, key, offset, length)
+}
+
+func storeTestZip(t *testing.T, backend storage.Backend, entries ...zipTestEntry) string {
+	t.Helper()
+	data, err := os.ReadFile(writeTestZip(t, entries...))
+	require.NoError(t, err)
+	const key = "uploads/projects/1/project.zip"
+	require.NoError(t, backend.Put(context.Background(), key, bytes.NewReader(data), int64(len(data))))
+	return key
+}
+
+func TestStoredArchive_Entries(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewMemoryBackend()
+	key := storeTestZip(t, backend,
+		zipTestEntry{name: "src/", method: zip.Store},
+		zipTestEntry{name: "src/main.go", content: []byte("package main\n"), method: zip.Deflate},
+		zipTestEntry{name: `docs\README.md`, content: []byte("# Dokumentasi\n"), method: zip.Store},
+		zipTestEntry{name: "../evil.sh", content: []byte("rm -rf /"), method: zip.Store},
+	)
+
+	archive, err := storage.OpenStoredArchive(context.Background(), backend, key)
+	require.NoError(t, err)
+
+	entries := archive.Entries()
+	require.Len(t, entries, 2, "directories and unsafe entries are not listed")
+	assert.Equal(t, "src/main.go", entries[0].Path)
+	assert.Equal(t, int64(len("package main\n")), entries[0].Size)
+	assert.Equal(t, "docs/README.md", entries[1].Path)
+}
+
+func TestStoredArchive_OpenFile(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewMemoryBackend()
+	source := bytes.Repeat([]byte("package main\n"), 100)
+	key := storeTestZip(t, backend,
+		zipTestEntry{name: "src/main.go", content: source, method: zip.Deflate},
+		zipTestEntry{name: "index.html", content: []byte("<html><body>hi</body></html>"), method: zip.Store},
+		zipTestEntry{name: "Makefile", content: []byte("build:\n\tgo build ./...\n"), method: zip.Store},
+		zipTestEntry{name: "../evil.sh", content: []byte("x"), method: zip.Store},
+	)
+
+	archive, err := storage.OpenStoredArchive(context.Background(), backend, key)
+	require.NoError(t, err)
+
+	t.Run("streams decompressed entry", func(t *testing.T) {
+		file, err := archive.OpenFile("./src/main.go")
+		require.NoError(t, err)
+		defer file.Body.Close()
+
+		content, err := io.ReadAll(file.Body)
+		require.NoError(t, err)
+		assert.Equal(t, source, content)
+		assert.Equal(t, "src/main.go", file.Path)
+		assert.Equal(t, int64(len(source)), file.Size)
+	})
+
+	t.Run("known extension", func(t *testing.T) {
+		file, err := archive.OpenFile("index.html")
+		require.NoError(t, err)
+		defer file.Body.Close()
+		assert.Contains(t, file.ContentType, "text/html")
+	})
+
+	t.Run("unknown extension is sniffed", func(t *testing.T) {
+		file, err := archive.OpenFile("Makefile")
+		require.NoError(t, err)
+		defer file.Body.Close()
+		assert.Equal(t, "text/plain; charset=utf-8", file.ContentType)
+
+		content, err := io.ReadAll(file.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "build:\n\tgo build ./...\n", string(content), "sniffing does not consume the body")
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		_, err := archive.OpenFile("src/other.go")
+		assert.ErrorIs(t, err, storage.ErrArchiveEntryNotFound)
+	})
+
+	t.Run("unsafe path", func(t *testing.T) {
+		_, err := archive.OpenFile("../evil.sh")
+		assert.ErrorIs(t, err, storage.ErrZipUnsafePath)
+	})
+}
+
+func TestStoredArchive_ReadsOnlyWhatIsNeeded(t *testing.T) {
+	t.Parallel()
+	backend := &countingBackend{MemoryBackend: storage.NewMemoryBackend()}
+	key := storeTestZip(t, backend,
+		zipTestEntry{name: "assets/video.bin", content: bytes.Repeat([]byte{1, 2, 3, 4}, 1<<20), method: zip.Store},
+		zipTestEntry{name: "README.md", content: []byte("# Project\n"), method: zip.Store},
+	)
+
+	archive, err := storage.OpenStoredArchive(context.Background(), backend, key)
+	require.NoError(t, err)
+	file, err := archive.OpenFile("README.md")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file.Body)
+	require.NoError(t, err)
+	require.NoError(t, file.Body.Close())
+
+	assert.Equal(t, "# Project\n", string(content))
+	assert.Less(t, backend.requested.Load(), int64(1<<20), "the 4 MiB entry is never fetched")
+}
+
+func TestOpenStoredArchive_Errors(t *testing.T) {
+	t.Parallel()
+	backend := storage.NewMemoryBackend()
+
+	_, err := storage.OpenStoredArchive(context.Background(), backend, "missing.zip")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	require.NoError(t, backend.Put(context.Background(), "broken.zip", bytes.NewReader([]byte("not a zip")), 9))
+	_, err = storage.OpenStoredArchive(context.Background(), backend, "broken.zip")
+	assert.ErrorIs(t, err, storage.ErrZipCorrupt)
+}
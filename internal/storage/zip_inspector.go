@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+var (
+	// ErrZipCorrupt is returned for files that are not a readable ZIP archive, or
+	// whose entries fail their CRC check.
+	ErrZipCorrupt = errors.New("file bukan arsip ZIP yang valid atau rusak")
+	// ErrZipTooLarge is returned when the archive expands past the configured size
+	// or holds more entries than allowed.
+	ErrZipTooLarge = errors.New("isi arsip ZIP melebihi batas ukuran")
+	// ErrZipCompressionRatio is returned for entries compressed far beyond what real
+	// project files reach, the usual sign of a zip bomb.
+	ErrZipCompressionRatio = errors.New("rasio kompresi arsip ZIP tidak wajar")
+	// ErrZipUnsafePath is returned for entries with an absolute path or one that
+	// climbs out of the archive root with "..".
+	ErrZipUnsafePath = errors.New("arsip ZIP berisi path file yang tidak aman")
+	// ErrZipSymlink is returned for entries stored as symbolic links.
+	ErrZipSymlink = errors.New("arsip ZIP berisi symlink")
+)
+
+// zipRatioAllowance is how much any entry may expand to regardless of its ratio, so
+// small, highly repetitive files (lockfiles, blank-padded data) are not mistaken for bombs.
+const zipRatioAllowance = 1 << 20
+
+// ZipLimits bounds what InspectZip accepts. A zero field disables that limit.
+type ZipLimits struct {
+	MaxUncompressedSize int64
+	MaxCompressionRatio float64
+	MaxEntries          int
+}
+
+// ZipInspection is the result of a successful InspectZip.
+type ZipInspection struct {
+	Entries          []domain.ProjectManifestEntry
+	UncompressedSize int64
+}
+
+// InspectZip opens the ZIP at filePath and reads every entry through, so both the
+// central directory and the data are checked. Sizes are counted from the bytes that
+// actually decompress rather than the declared sizes, which an attacker controls.
+// Rejections wrap one of the ErrZip* errors with the offending entry in the message.
+func InspectZip(filePath string, limits ZipLimits) (*ZipInspection, error) {
+	reader, err := zip.OpenReader(filePath)
+	if errors.Is(err, zip.ErrInsecurePath) && reader != nil {
+		// Only returned with GODEBUG=zipinsecurepath=0; checkZipEntryName reports it.
+		err = nil
+	}
+	if err != nil {
+		return nil, ErrZipCorrupt
+	}
+	defer reader.Close()
+
+	if limits.MaxEntries > 0 && len(reader.File) > limits.MaxEntries {
+		return nil, fmt.Errorf("%w: %d entri, maksimal %d", ErrZipTooLarge, len(reader.File), limits.MaxEntries)
+	}
+
+	inspection := &ZipInspection{Entries: make([]domain.ProjectManifestEntry, 0, len(reader.File))}
+	for _, file := range reader.File {
+		if err := checkZipEntryName(file.Name); err != nil {
+			return nil, err
+		}
+		if file.Mode()&fs.ModeSymlink != 0 {
+			return nil, fmt.Errorf("%w: %s", ErrZipSymlink, file.Name)
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		size, err := inspectZipEntry(file, limits, inspection.UncompressedSize)
+		if err != nil {
+			return nil, err
+		}
+		inspection.UncompressedSize += size
+		inspection.Entries = append(inspection.Entries, domain.ProjectManifestEntry{
+			Path:           file.Name,
+			Size:           size,
+			CompressedSize: int64(file.CompressedSize64),
+			CRC32:          file.CRC32,
+		})
+	}
+
+	return inspection, nil
+}
+
+// checkZipEntryName rejects names that would land outside the extraction directory.
+// Backslashes are treated as separators too, since Windows tools honour them.
+func checkZipEntryName(name string) error {
+	normalized := strings.ReplaceAll(name, "\\", "/")
+	if normalized == "" || strings.HasPrefix(normalized, "/") || (len(normalized) > 1 && normalized[1] == ':') {
+		return fmt.Errorf("%w: %s", ErrZipUnsafePath, name)
+	}
+	for _, part := range strings.Split(normalized, "/") {
+		if part == ".." {
+			return fmt.Errorf("%w: %s", ErrZipUnsafePath, name)
+		}
+	}
+	return nil
+}
+
+// inspectZipEntry decompresses one entry and returns its real size. Reading to EOF
+// makes archive/zip verify the CRC.
+func inspectZipEntry(file *zip.File, limits ZipLimits, usedSoFar int64) (int64, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrZipCorrupt, file.Name)
+	}
+	defer rc.Close()
+
+	// Stop reading one byte past whatever limit is hit first.
+	budget := int64(-1)
+	if limits.MaxUncompressedSize > 0 {
+		budget = limits.MaxUncompressedSize - usedSoFar
+	}
+	if limits.MaxCompressionRatio > 0 {
+		ratioBudget := max(int64(float64(file.CompressedSize64)*limits.MaxCompressionRatio), zipRatioAllowance)
+		if budget < 0 || ratioBudget < budget {
+			budget = ratioBudget
+		}
+	}
+
+	var src io.Reader = rc
+	if budget >= 0 {
+		src = io.LimitReader(rc, budget+1)
+	}
+	size, err := io.Copy(io.Discard, src)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrZipCorrupt, file.Name)
+	}
+
+	if budget >= 0 && size > budget {
+		if limits.MaxUncompressedSize > 0 && usedSoFar+size > limits.MaxUncompressedSize {
+			return 0, fmt.Errorf("%w: lebih dari %s setelah diekstrak", ErrZipTooLarge, FormatFileSize(limits.MaxUncompressedSize))
+		}
+		return 0, fmt.Errorf("%w: %s", ErrZipCompressionRatio, file.Name)
+	}
+	return size, nil
+}
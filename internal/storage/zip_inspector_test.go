@@ -0,0 +1,185 @@
+package storage_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"hash/crc32"
+	"invento-service/internal/storage"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type zipTestEntry struct {
+	name    string
+	content []byte
+	method  uint16
+	mode    fs.FileMode
+}
+
+func writeTestZip(t *testing.T, entries ...zipTestEntry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: entry.method}
+		if entry.mode != 0 {
+			header.SetMode(entry.mode)
+		}
+		w, err := zw.CreateHeader(header)
+		require.NoError(t, err)
+		_, err = w.Write(entry.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	filePath := filepath.Join(t.TempDir(), "project.zip")
+	require.NoError(t, os.WriteFile(filePath, buf.Bytes(), 0o644))
+	return filePath
+}
+
+var defaultZipLimits = storage.ZipLimits{
+	MaxUncompressedSize: 4 << 20,
+	MaxCompressionRatio: 100,
+	MaxEntries:          10,
+}
+
+func TestInspectZip_Manifest(t *testing.T) {
+	t.Parallel()
+	readme := []byte("# Sistem Informasi\n")
+	main := bytes.Repeat([]byte("package main\n"), 50)
+	filePath := writeTestZip(t,
+		zipTestEntry{name: "src/", method: zip.Store},
+		zipTestEntry{name: "README.md", content: readme, method: zip.Store},
+		zipTestEntry{name: "src/main.go", content: main, method: zip.Deflate},
+	)
+
+	inspection, err := storage.InspectZip(filePath, defaultZipLimits)
+	require.NoError(t, err)
+	require.Len(t, inspection.Entries, 2, "directories are not listed")
+	assert.Equal(t, "README.md", inspection.Entries[0].Path)
+	assert.Equal(t, int64(len(readme)), inspection.Entries[0].Size)
+	assert.Equal(t, crc32.ChecksumIEEE(readme), inspection.Entries[0].CRC32)
+	assert.Equal(t, "src/main.go", inspection.Entries[1].Path)
+	assert.Equal(t, int64(len(main)), inspection.Entries[1].Size)
+	assert.Less(t, inspection.Entries[1].CompressedSize, inspection.Entries[1].Size)
+	assert.Equal(t, int64(len(readme)+len(main)), inspection.UncompressedSize)
+}
+
+func TestInspectZip_Rejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []zipTestEntry
+		limits  storage.ZipLimits
+		want    error
+	}{
+		{
+			name:    "parent directory entry",
+			entries: []zipTestEntry{{name: "../evil.sh", content: []byte("x")}},
+			want:    storage.ErrZipUnsafePath,
+		},
+		{
+			name:    "nested parent directory entry",
+			entries: []zipTestEntry{{name: "src/../../evil.sh", content: []byte("x")}},
+			want:    storage.ErrZipUnsafePath,
+		},
+		{
+			name:    "backslash parent directory entry",
+			entries: []zipTestEntry{{name: `src\..\..\evil.sh`, content: []byte("x")}},
+			want:    storage.ErrZipUnsafePath,
+		},
+		{
+			name:    "absolute entry",
+			entries: []zipTestEntry{{name: "/etc/passwd", content: []byte("x")}},
+			want:    storage.ErrZipUnsafePath,
+		},
+		{
+			name:    "drive letter entry",
+			entries: []zipTestEntry{{name: "C:/Windows/evil.dll", content: []byte("x")}},
+			want:    storage.ErrZipUnsafePath,
+		},
+		{
+			name:    "symlink",
+			entries: []zipTestEntry{{name: "link", content: []byte("/etc/passwd"), mode: fs.ModeSymlink | 0o777}},
+			want:    storage.ErrZipSymlink,
+		},
+		{
+			name:    "compression ratio",
+			entries: []zipTestEntry{{name: "zeros.bin", content: make([]byte, 8<<20), method: zip.Deflate}},
+			limits:  storage.ZipLimits{MaxCompressionRatio: 100},
+			want:    storage.ErrZipCompressionRatio,
+		},
+		{
+			name: "total uncompressed size",
+			entries: []zipTestEntry{
+				{name: "a.bin", content: make([]byte, 3<<20), method: zip.Store},
+				{name: "b.bin", content: make([]byte, 3<<20), method: zip.Store},
+			},
+			want: storage.ErrZipTooLarge,
+		},
+		{
+			name: "too many entries",
+			entries: func() []zipTestEntry {
+				entries := make([]zipTestEntry, 11)
+				for i := range entries {
+					entries[i] = zipTestEntry{name: filepath.Join("src", string(rune('a'+i))+".go")}
+				}
+				return entries
+			}(),
+			want: storage.ErrZipTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			limits := tt.limits
+			if limits == (storage.ZipLimits{}) {
+				limits = defaultZipLimits
+			}
+			_, err := storage.InspectZip(writeTestZip(t, tt.entries...), limits)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestInspectZip_SmallRepetitiveFileAllowed(t *testing.T) {
+	t.Parallel()
+	filePath := writeTestZip(t, zipTestEntry{name: "package-lock.json", content: bytes.Repeat([]byte(" "), 512<<10), method: zip.Deflate})
+
+	_, err := storage.InspectZip(filePath, defaultZipLimits)
+	assert.NoError(t, err)
+}
+
+func TestInspectZip_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not a zip", func(t *testing.T) {
+		t.Parallel()
+		filePath := filepath.Join(t.TempDir(), "project.zip")
+		require.NoError(t, os.WriteFile(filePath, []byte("definitely not a zip"), 0o644))
+
+		_, err := storage.InspectZip(filePath, defaultZipLimits)
+		assert.ErrorIs(t, err, storage.ErrZipCorrupt)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		t.Parallel()
+		filePath := writeTestZip(t, zipTestEntry{name: "main.go", content: []byte("package main"), method: zip.Store})
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		idx := bytes.Index(data, []byte("package main"))
+		require.Positive(t, idx)
+		data[idx] = 'P'
+		require.NoError(t, os.WriteFile(filePath, data, 0o644))
+
+		_, err = storage.InspectZip(filePath, defaultZipLimits)
+		assert.ErrorIs(t, err, storage.ErrZipCorrupt)
+	})
+}
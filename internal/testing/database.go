@@ -25,9 +25,21 @@ func SetupTestDatabase() (*gorm.DB, error) {
 		&domain.Permission{},
 		&domain.RolePermission{},
 		&domain.Project{},
+		&domain.ProjectManifest{},
+		&domain.ProjectVersion{},
+		&domain.ProjectReviewer{},
+		&domain.ProjectReviewEvent{},
 		&domain.Modul{},
+		&domain.ModulRevision{},
+		&domain.ModulAllowedRole{},
+		&domain.Class{},
+		&domain.ClassMember{},
+		&domain.Assignment{},
+		&domain.AssignmentSubmission{},
 		&domain.TusUpload{},
 		&domain.TusModulUpload{},
+		&domain.RoleQuota{},
+		&domain.UserQuota{},
 	)
 	if err != nil {
 		return nil, err
@@ -77,7 +89,15 @@ func CleanupTestDatabase(db *gorm.DB) error {
 	tables := []interface{}{
 		&domain.TusModulUpload{},
 		&domain.TusUpload{},
+		&domain.ModulRevision{},
+		&domain.ModulAllowedRole{},
 		&domain.Modul{},
+		&domain.AssignmentSubmission{},
+		&domain.Assignment{},
+		&domain.ClassMember{},
+		&domain.Class{},
+		&domain.ProjectReviewEvent{},
+		&domain.ProjectReviewer{},
 		&domain.Project{},
 		&domain.RolePermission{},
 		&domain.Permission{},
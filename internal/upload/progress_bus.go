@@ -0,0 +1,120 @@
+package upload
+
+import (
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"sync"
+	"time"
+)
+
+// Upload kinds carried in progress events.
+const (
+	ProgressKindProject = "project"
+	ProgressKindModul   = "modul"
+)
+
+// progressBufferSize bounds how many events a slow subscriber can fall behind by.
+const progressBufferSize = 16
+
+// ProgressBus fans upload progress events out to in-process subscribers, such as
+// the SSE streams. Publishing never blocks: when a subscriber's buffer is full its
+// oldest pending event is dropped, since every event carries the full upload state.
+type ProgressBus struct {
+	mu     sync.RWMutex
+	nextID uint64
+	subs   map[uint64]*progressSubscription
+}
+
+type progressSubscription struct {
+	userID   string
+	uploadID string
+	events   chan dto.TusProgressEvent
+}
+
+func NewProgressBus() *ProgressBus {
+	return &ProgressBus{
+		subs: make(map[uint64]*progressSubscription),
+	}
+}
+
+// Subscribe registers for events of userID's uploads. A non-empty uploadID narrows
+// the subscription to that upload. The returned cancel func must be called once the
+// subscriber is done; it closes the channel.
+func (b *ProgressBus) Subscribe(userID, uploadID string) (<-chan dto.TusProgressEvent, func()) {
+	sub := &progressSubscription{
+		userID:   userID,
+		uploadID: uploadID,
+		events:   make(chan dto.TusProgressEvent, progressBufferSize),
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(sub.events)
+		})
+	}
+
+	return sub.events, cancel
+}
+
+// Publish delivers event to every matching subscriber. It is safe to call on a nil bus.
+func (b *ProgressBus) Publish(event dto.TusProgressEvent) {
+	if b == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subs {
+		if sub.userID != event.UserID {
+			continue
+		}
+		if sub.uploadID != "" && sub.uploadID != event.UploadID {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			select {
+			case <-sub.events:
+			default:
+			}
+			select {
+			case sub.events <- event:
+			default:
+			}
+		}
+	}
+}
+
+// SubscriberCount returns the number of open subscriptions.
+func (b *ProgressBus) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subs)
+}
+
+// IsTerminalUploadStatus reports whether no further progress events follow status.
+func IsTerminalUploadStatus(status string) bool {
+	switch status {
+	case domain.UploadStatusCompleted, domain.UploadStatusCancelled, domain.UploadStatusFailed, domain.UploadStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
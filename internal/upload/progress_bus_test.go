@@ -0,0 +1,69 @@
+package upload_test
+
+import (
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/upload"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressBus_FiltersByUserAndUpload(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+
+	all, cancelAll := bus.Subscribe("user-1", "")
+	defer cancelAll()
+	single, cancelSingle := bus.Subscribe("user-1", "upload-2")
+	defer cancelSingle()
+
+	bus.Publish(dto.TusProgressEvent{UploadID: "upload-1", UserID: "user-1", Status: domain.UploadStatusUploading})
+	bus.Publish(dto.TusProgressEvent{UploadID: "upload-2", UserID: "user-1", Status: domain.UploadStatusUploading})
+	bus.Publish(dto.TusProgressEvent{UploadID: "upload-3", UserID: "user-2", Status: domain.UploadStatusUploading})
+
+	require.Len(t, all, 2)
+	assert.Equal(t, "upload-1", (<-all).UploadID)
+	assert.Equal(t, "upload-2", (<-all).UploadID)
+
+	require.Len(t, single, 1)
+	event := <-single
+	assert.Equal(t, "upload-2", event.UploadID)
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+func TestProgressBus_SlowSubscriberKeepsLatestEvents(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+	events, cancel := bus.Subscribe("user-1", "upload-1")
+	defer cancel()
+
+	for offset := int64(1); offset <= 100; offset++ {
+		bus.Publish(dto.TusProgressEvent{UploadID: "upload-1", UserID: "user-1", Offset: offset})
+	}
+
+	var last dto.TusProgressEvent
+	for len(events) > 0 {
+		last = <-events
+	}
+	assert.Equal(t, int64(100), last.Offset)
+}
+
+func TestProgressBus_CancelClosesSubscription(t *testing.T) {
+	t.Parallel()
+	bus := upload.NewProgressBus()
+	events, cancel := bus.Subscribe("user-1", "")
+	require.Equal(t, 1, bus.SubscriberCount())
+
+	cancel()
+	cancel()
+
+	_, open := <-events
+	assert.False(t, open)
+	assert.Equal(t, 0, bus.SubscriberCount())
+	bus.Publish(dto.TusProgressEvent{UploadID: "upload-1", UserID: "user-1"})
+
+	var nilBus *upload.ProgressBus
+	assert.NotPanics(t, func() { nilBus.Publish(dto.TusProgressEvent{}) })
+}
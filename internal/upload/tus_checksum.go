@@ -0,0 +1,103 @@
+package upload
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+const (
+	HeaderUploadChecksum       = "Upload-Checksum"
+	HeaderTusChecksumAlgorithm = "Tus-Checksum-Algorithm"
+
+	// TusChecksumAlgorithms lists the Upload-Checksum algorithms advertised on OPTIONS.
+	TusChecksumAlgorithms = "sha1,sha256,md5"
+
+	// StatusChecksumMismatch is the TUS status for a PATCH body that fails its checksum.
+	StatusChecksumMismatch = 460
+)
+
+// ErrChecksumMismatch is returned by a checksum reader whose data does not match the
+// digest sent by the client.
+var ErrChecksumMismatch = errors.New("checksum chunk tidak cocok")
+
+// TusChecksum is a parsed Upload-Checksum header.
+type TusChecksum struct {
+	Algorithm string
+	Sum       []byte
+}
+
+// ParseUploadChecksum parses an "<algorithm> <base64 digest>" header value. An empty
+// header yields nil, meaning the chunk is not verified.
+func ParseUploadChecksum(header string) (*TusChecksum, error) {
+	if header == "" {
+		return nil, nil
+	}
+
+	algorithm, encoded, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok {
+		return nil, fmt.Errorf("header %s tidak valid", HeaderUploadChecksum)
+	}
+
+	checksum := &TusChecksum{Algorithm: strings.ToLower(algorithm)}
+	if checksum.newHash() == nil {
+		return nil, fmt.Errorf("algoritma checksum %s tidak didukung, gunakan salah satu dari: %s", algorithm, TusChecksumAlgorithms)
+	}
+
+	sum, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(sum) != checksum.newHash().Size() {
+		return nil, fmt.Errorf("header %s tidak valid", HeaderUploadChecksum)
+	}
+	checksum.Sum = sum
+
+	return checksum, nil
+}
+
+func (tc *TusChecksum) newHash() hash.Hash {
+	switch tc.Algorithm {
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "md5":
+		return md5.New()
+	default:
+		return nil
+	}
+}
+
+// NewChecksumReader wraps src so that reaching its end reports ErrChecksumMismatch
+// instead of io.EOF when the data read does not match checksum. TusStore.WriteChunk
+// only commits the new offset after a clean read, so a corrupted chunk never advances
+// the upload. A nil checksum returns src unchanged.
+func NewChecksumReader(src io.Reader, checksum *TusChecksum) io.Reader {
+	if checksum == nil {
+		return src
+	}
+
+	return &checksumReader{src: src, hash: checksum.newHash(), expected: checksum.Sum}
+}
+
+type checksumReader struct {
+	src      io.Reader
+	hash     hash.Hash
+	expected []byte
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.src.Read(p)
+	r.hash.Write(p[:n])
+
+	if errors.Is(err, io.EOF) && !bytes.Equal(r.hash.Sum(nil), r.expected) {
+		return n, ErrChecksumMismatch
+	}
+
+	return n, err
+}
@@ -0,0 +1,77 @@
+package upload
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Header(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestParseUploadChecksum(t *testing.T) {
+	t.Parallel()
+	sum := sha1.Sum([]byte("chunk"))
+
+	checksum, err := ParseUploadChecksum("sha1 " + base64.StdEncoding.EncodeToString(sum[:]))
+	require.NoError(t, err)
+	assert.Equal(t, "sha1", checksum.Algorithm)
+	assert.Equal(t, sum[:], checksum.Sum)
+
+	checksum, err = ParseUploadChecksum("")
+	require.NoError(t, err)
+	assert.Nil(t, checksum)
+
+	for _, header := range []string{"crc32 AAAAAA==", "sha1", "sha1 not-base64!", "md5 " + base64.StdEncoding.EncodeToString(sum[:])} {
+		_, err := ParseUploadChecksum(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestNewChecksumReader(t *testing.T) {
+	t.Parallel()
+	data := []byte("project zip bytes")
+	checksum, err := ParseUploadChecksum(sha256Header(data))
+	require.NoError(t, err)
+
+	read, err := io.ReadAll(NewChecksumReader(bytes.NewReader(data), checksum))
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	_, err = io.ReadAll(NewChecksumReader(bytes.NewReader([]byte("corrupted bytes!!")), checksum))
+	assert.True(t, errors.Is(err, ErrChecksumMismatch))
+}
+
+func TestTusStore_WriteChunk_ChecksumMismatchKeepsOffset(t *testing.T) {
+	t.Parallel()
+	store := newTestTusStore(t, 1024)
+	require.NoError(t, store.NewUpload(TusFileInfo{ID: "up1", Size: 16}))
+
+	good := []byte("abcdefgh")
+	checksum, err := ParseUploadChecksum(sha256Header(good))
+	require.NoError(t, err)
+
+	offset, err := store.WriteChunk("up1", 0, NewChecksumReader(bytes.NewReader([]byte("abcdefgX")), checksum))
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.Equal(t, int64(0), offset)
+	current, err := store.GetOffset("up1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), current)
+	data, err := os.ReadFile(store.pathResolver.GetUploadFilePath("up1"))
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 16), data, "rejected bytes must not stay on disk")
+
+	offset, err = store.WriteChunk("up1", 0, NewChecksumReader(bytes.NewReader(good), checksum))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), offset)
+}
@@ -3,6 +3,8 @@ package upload
 import (
 	"context"
 	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/metrics"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -12,6 +14,8 @@ type TusProjectUploadCleanupRepository interface {
 	GetExpiredUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error)
 	GetAbandonedUploads(ctx context.Context, timeout time.Duration) ([]domain.TusUpload, error)
 	UpdateStatus(ctx context.Context, id, status string) error
+	Promote(ctx context.Context, id string, expiresAt time.Time) error
+	GetUnusedPartialUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error)
 	Delete(ctx context.Context, id string) error
 }
 
@@ -33,6 +37,9 @@ type TusCleanup struct {
 	stopChan        chan bool
 	isRunning       bool
 	logger          zerolog.Logger
+	metrics         *metrics.TusMetrics
+	projectQueue    UploadQueue
+	progress        *ProgressBus
 }
 
 func NewTusCleanup(
@@ -58,6 +65,22 @@ func NewTusCleanup(
 	}
 }
 
+// SetMetrics attaches counters that record how many uploads expired.
+func (tc *TusCleanup) SetMetrics(m *metrics.TusMetrics) {
+	tc.metrics = m
+}
+
+// SetProjectQueue lets the cleanup hand slots held by expired or abandoned project
+// uploads to the next queued upload.
+func (tc *TusCleanup) SetProjectQueue(queue UploadQueue) {
+	tc.projectQueue = queue
+}
+
+// SetProgressBus lets clients following an upload's progress see it expire or fail.
+func (tc *TusCleanup) SetProgressBus(bus *ProgressBus) {
+	tc.progress = bus
+}
+
 func (tc *TusCleanup) Start() {
 	if tc.isRunning {
 		return
@@ -103,6 +126,10 @@ func (tc *TusCleanup) performCleanup() {
 		tc.logger.Error().Err(err).Msg("error cleanup abandoned project")
 	}
 
+	if err := tc.CleanupUnusedPartialProjects(); err != nil {
+		tc.logger.Error().Err(err).Msg("error cleanup unused partial project")
+	}
+
 	if err := tc.CleanupExpiredModuls(); err != nil {
 		tc.logger.Error().Err(err).Msg("error cleanup expired modul")
 	}
@@ -135,8 +162,8 @@ func (tc *TusCleanup) cleanupUploads(
 	newStatus string,
 	label string,
 	updateStatus func(ctx context.Context, id, status string) error,
-) int {
-	cleaned := 0
+) []string {
+	cleaned := make([]string, 0, len(uploadIDs))
 	for _, uploadID := range uploadIDs {
 		if store != nil {
 			if err := store.Terminate(uploadID); err != nil {
@@ -150,12 +177,43 @@ func (tc *TusCleanup) cleanupUploads(
 		}
 
 		tc.logger.Info().Str("type", label).Str("upload_id", uploadID).Msg("upload cleaned up")
-		cleaned++
+		cleaned = append(cleaned, uploadID)
 	}
 
 	return cleaned
 }
 
+// publishCleaned announces the final status of cleaned uploads; owners maps upload ID to user ID.
+func (tc *TusCleanup) publishCleaned(kind, status string, cleaned []string, owners map[string]string) {
+	for _, uploadID := range cleaned {
+		tc.progress.Publish(dto.TusProgressEvent{
+			UploadID: uploadID,
+			UserID:   owners[uploadID],
+			Kind:     kind,
+			Status:   status,
+		})
+	}
+}
+
+func (tc *TusCleanup) releaseProjectSlots(ctx context.Context, uploadIDs []string) {
+	if tc.projectQueue == nil {
+		return
+	}
+
+	for _, uploadID := range uploadIDs {
+		nextID := tc.projectQueue.FinishUpload(uploadID)
+		if nextID == "" {
+			continue
+		}
+
+		if err := tc.projectRepo.Promote(ctx, nextID, time.Now().Add(tc.idleTimeout)); err != nil {
+			tc.logger.Error().Err(err).Str("upload_id", nextID).Msg("failed to promote queued upload")
+			continue
+		}
+		tc.logger.Info().Str("upload_id", nextID).Str("released_by", uploadID).Msg("queued upload promoted")
+	}
+}
+
 func (tc *TusCleanup) CleanupExpiredProjects() error {
 	if tc.projectRepo == nil {
 		return nil
@@ -172,13 +230,20 @@ func (tc *TusCleanup) CleanupExpiredProjects() error {
 	}
 
 	uploadIDs := make([]string, 0, len(expiredUploads))
+	owners := make(map[string]string, len(expiredUploads))
 	for _, upload := range expiredUploads {
 		uploadIDs = append(uploadIDs, upload.ID)
+		owners[upload.ID] = upload.UserID
 	}
 
 	cleaned := tc.cleanupUploads(ctx, uploadIDs, tc.projectStore, domain.UploadStatusExpired, "project", tc.projectRepo.UpdateStatus)
-	if cleaned > 0 {
-		tc.logger.Info().Int("count", cleaned).Msg("cleaned expired project uploads")
+	tc.publishCleaned(ProgressKindProject, domain.UploadStatusExpired, cleaned, owners)
+	tc.releaseProjectSlots(ctx, cleaned)
+	if tc.metrics != nil {
+		tc.metrics.Project.AddExpired(len(cleaned))
+	}
+	if len(cleaned) > 0 {
+		tc.logger.Info().Int("count", len(cleaned)).Msg("cleaned expired project uploads")
 	}
 
 	return nil
@@ -200,13 +265,54 @@ func (tc *TusCleanup) CleanupAbandonedProjects() error {
 	}
 
 	uploadIDs := make([]string, 0, len(abandonedUploads))
+	owners := make(map[string]string, len(abandonedUploads))
 	for _, upload := range abandonedUploads {
 		uploadIDs = append(uploadIDs, upload.ID)
+		owners[upload.ID] = upload.UserID
 	}
 
 	cleaned := tc.cleanupUploads(ctx, uploadIDs, tc.projectStore, domain.UploadStatusFailed, "project abandoned", tc.projectRepo.UpdateStatus)
-	if cleaned > 0 {
-		tc.logger.Info().Int("count", cleaned).Msg("cleaned abandoned project uploads")
+	tc.publishCleaned(ProgressKindProject, domain.UploadStatusFailed, cleaned, owners)
+	tc.releaseProjectSlots(ctx, cleaned)
+	if len(cleaned) > 0 {
+		tc.logger.Info().Int("count", len(cleaned)).Msg("cleaned abandoned project uploads")
+	}
+
+	return nil
+}
+
+// CleanupUnusedPartialProjects expires finished partial uploads that were never
+// concatenated into a final upload. They already gave up their queue slot when they
+// finished, so no slot is released here.
+func (tc *TusCleanup) CleanupUnusedPartialProjects() error {
+	if tc.projectRepo == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	partialUploads, err := tc.projectRepo.GetUnusedPartialUploads(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(partialUploads) == 0 {
+		return nil
+	}
+
+	uploadIDs := make([]string, 0, len(partialUploads))
+	owners := make(map[string]string, len(partialUploads))
+	for _, upload := range partialUploads {
+		uploadIDs = append(uploadIDs, upload.ID)
+		owners[upload.ID] = upload.UserID
+	}
+
+	cleaned := tc.cleanupUploads(ctx, uploadIDs, tc.projectStore, domain.UploadStatusExpired, "project partial", tc.projectRepo.UpdateStatus)
+	tc.publishCleaned(ProgressKindProject, domain.UploadStatusExpired, cleaned, owners)
+	if tc.metrics != nil {
+		tc.metrics.Project.AddExpired(len(cleaned))
+	}
+	if len(cleaned) > 0 {
+		tc.logger.Info().Int("count", len(cleaned)).Msg("cleaned unused partial project uploads")
 	}
 
 	return nil
@@ -228,13 +334,19 @@ func (tc *TusCleanup) CleanupExpiredModuls() error {
 	}
 
 	uploadIDs := make([]string, 0, len(expiredUploads))
+	owners := make(map[string]string, len(expiredUploads))
 	for _, upload := range expiredUploads {
 		uploadIDs = append(uploadIDs, upload.ID)
+		owners[upload.ID] = upload.UserID
 	}
 
 	cleaned := tc.cleanupUploads(ctx, uploadIDs, tc.modulStore, domain.UploadStatusExpired, "modul", tc.modulRepo.UpdateStatus)
-	if cleaned > 0 {
-		tc.logger.Info().Int("count", cleaned).Msg("cleaned expired modul uploads")
+	tc.publishCleaned(ProgressKindModul, domain.UploadStatusExpired, cleaned, owners)
+	if tc.metrics != nil {
+		tc.metrics.Modul.AddExpired(len(cleaned))
+	}
+	if len(cleaned) > 0 {
+		tc.logger.Info().Int("count", len(cleaned)).Msg("cleaned expired modul uploads")
 	}
 
 	return nil
@@ -256,13 +368,16 @@ func (tc *TusCleanup) CleanupAbandonedModuls() error {
 	}
 
 	uploadIDs := make([]string, 0, len(abandonedUploads))
+	owners := make(map[string]string, len(abandonedUploads))
 	for _, upload := range abandonedUploads {
 		uploadIDs = append(uploadIDs, upload.ID)
+		owners[upload.ID] = upload.UserID
 	}
 
 	cleaned := tc.cleanupUploads(ctx, uploadIDs, tc.modulStore, domain.UploadStatusFailed, "modul abandoned", tc.modulRepo.UpdateStatus)
-	if cleaned > 0 {
-		tc.logger.Info().Int("count", cleaned).Msg("cleaned abandoned modul uploads")
+	tc.publishCleaned(ProgressKindModul, domain.UploadStatusFailed, cleaned, owners)
+	if len(cleaned) > 0 {
+		tc.logger.Info().Int("count", len(cleaned)).Msg("cleaned abandoned modul uploads")
 	}
 
 	return nil
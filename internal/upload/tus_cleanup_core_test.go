@@ -2,6 +2,7 @@ package upload_test
 
 import (
 	"invento-service/internal/domain"
+	"invento-service/internal/metrics"
 	"invento-service/internal/storage"
 	"invento-service/internal/upload"
 	"testing"
@@ -119,6 +120,29 @@ func TestTusCleanup_CleanupExpired_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTusCleanup_CleanupExpired_RecordsMetrics(t *testing.T) {
+	t.Parallel()
+	cfg := setupTestConfig()
+	repo := &MockTusUploadRepository{
+		uploads: make(map[string]domain.TusUpload),
+		expired: []domain.TusUpload{
+			{ID: "expired-metrics-1", Status: domain.UploadStatusPending},
+			{ID: "expired-metrics-2", Status: domain.UploadStatusUploading},
+		},
+	}
+	pathResolver := storage.NewPathResolver(cfg)
+	store := upload.NewTusStore(pathResolver, cfg.Upload.MaxSize)
+	cleanup := upload.NewTusCleanup(repo, nil, store, store, 60, 300, zerolog.Nop())
+	tusMetrics := metrics.NewTusMetrics()
+	cleanup.SetMetrics(tusMetrics)
+
+	err := cleanup.CleanupExpiredProjects()
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(2), tusMetrics.Project.Snapshot().Expired)
+	assert.Zero(t, tusMetrics.Modul.Snapshot().Expired)
+}
+
 func TestTusCleanup_CleanupExpired_NoExpired(t *testing.T) {
 	t.Parallel()
 	cfg := setupTestConfig()
@@ -32,6 +32,16 @@ func (m *mockTusUploadRepository) UpdateStatus(ctx context.Context, id, status s
 	return args.Error(0)
 }
 
+func (m *mockTusUploadRepository) Promote(ctx context.Context, id string, expiresAt time.Time) error {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Error(0)
+}
+
+func (m *mockTusUploadRepository) GetUnusedPartialUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]domain.TusUpload), args.Error(1)
+}
+
 func (m *mockTusUploadRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -127,6 +137,42 @@ func TestTusCleanup_CleanupExpiredProjects_UpdatesExpiredStatuses(t *testing.T)
 	projectRepo.AssertExpectations(t)
 }
 
+func TestTusCleanup_CleanupExpiredProjects_PromotesQueuedUpload(t *testing.T) {
+	t.Parallel()
+	cleanup, _, projectRepo, _ := newTestTusCleanup(t)
+
+	queue := NewTusQueue(1)
+	queue.Add("expired")
+	queue.Add("waiting")
+	cleanup.SetProjectQueue(queue)
+
+	projectRepo.On("GetExpiredUploads", mock.Anything, mock.Anything).Return([]domain.TusUpload{{ID: "expired"}}, nil).Once()
+	projectRepo.On("UpdateStatus", mock.Anything, "expired", domain.UploadStatusExpired).Return(nil).Once()
+	projectRepo.On("Promote", mock.Anything, "waiting", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	require.NoError(t, cleanup.CleanupExpiredProjects())
+	projectRepo.AssertExpectations(t)
+	assert.True(t, queue.IsActiveUpload("waiting"))
+	assert.Equal(t, 0, queue.GetQueueLength())
+}
+
+func TestTusCleanup_CleanupUnusedPartialProjects_ExpiresUnclaimedParts(t *testing.T) {
+	t.Parallel()
+	cleanup, store, projectRepo, _ := newTestTusCleanup(t)
+	require.NoError(t, store.NewUpload(TusFileInfo{ID: "part-1", Size: 4}))
+
+	partials := []domain.TusUpload{{ID: "part-1", UserID: "user-1", UploadType: domain.UploadTypeProjectPartial, Status: domain.UploadStatusCompleted}}
+	projectRepo.On("GetUnusedPartialUploads", mock.Anything, mock.Anything).Return(partials, nil).Once()
+	projectRepo.On("UpdateStatus", mock.Anything, "part-1", domain.UploadStatusExpired).Return(nil).Once()
+
+	require.NoError(t, cleanup.CleanupUnusedPartialProjects())
+
+	_, err := store.GetInfo("part-1")
+	assert.Error(t, err)
+	projectRepo.AssertExpectations(t)
+	projectRepo.AssertNotCalled(t, "Promote", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestTusCleanup_CleanupAbandonedProjects_UpdatesFailedForIdleUploads(t *testing.T) {
 	t.Parallel()
 	cleanup, _, projectRepo, _ := newTestTusCleanup(t)
@@ -154,6 +200,26 @@ func TestTusCleanup_CleanupExpiredModuls_UpdatesExpiredStatuses(t *testing.T) {
 	modulRepo.AssertExpectations(t)
 }
 
+func TestTusCleanup_CleanupExpiredModuls_PublishesExpiry(t *testing.T) {
+	t.Parallel()
+	cleanup, _, _, modulRepo := newTestTusCleanup(t)
+	bus := NewProgressBus()
+	cleanup.SetProgressBus(bus)
+	events, cancel := bus.Subscribe("user-1", "")
+	defer cancel()
+
+	expired := []domain.TusModulUpload{{ID: "m1", UserID: "user-1"}}
+	modulRepo.On("GetExpiredUploads", mock.Anything, mock.Anything).Return(expired, nil).Once()
+	modulRepo.On("UpdateStatus", mock.Anything, "m1", domain.UploadStatusExpired).Return(nil).Once()
+
+	require.NoError(t, cleanup.CleanupExpiredModuls())
+
+	event := <-events
+	assert.Equal(t, "m1", event.UploadID)
+	assert.Equal(t, ProgressKindModul, event.Kind)
+	assert.Equal(t, domain.UploadStatusExpired, event.Status)
+}
+
 func TestTusCleanup_CleanupAbandonedModuls_UpdatesFailedStatuses(t *testing.T) {
 	t.Parallel()
 	cleanup, _, _, modulRepo := newTestTusCleanup(t)
@@ -212,6 +278,7 @@ func TestTusCleanup_PerformCleanup_ExecutesCycleAndCleansStaleLocks(t *testing.T
 
 	projectRepo.On("GetExpiredUploads", mock.Anything, mock.Anything).Return([]domain.TusUpload{}, nil).Once()
 	projectRepo.On("GetAbandonedUploads", mock.Anything, cleanup.idleTimeout).Return([]domain.TusUpload{}, nil).Once()
+	projectRepo.On("GetUnusedPartialUploads", mock.Anything, mock.Anything).Return([]domain.TusUpload{}, nil).Once()
 	modulRepo.On("GetExpiredUploads", mock.Anything, mock.Anything).Return([]domain.TusModulUpload{}, nil).Once()
 	modulRepo.On("GetAbandonedUploads", mock.Anything, cleanup.idleTimeout).Return([]domain.TusModulUpload{}, nil).Once()
 
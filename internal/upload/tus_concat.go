@@ -0,0 +1,165 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	HeaderUploadConcat = "Upload-Concat"
+
+	// MaxConcatParts caps how many partial uploads one final upload may combine.
+	MaxConcatParts = 10
+)
+
+// TusConcat is a parsed Upload-Concat header. Partial marks an upload that only
+// carries a slice of the file; otherwise Parts lists the partial upload IDs, in
+// order, that make up a final upload.
+type TusConcat struct {
+	Partial bool
+	Parts   []string
+}
+
+// ParseUploadConcat parses "partial" or "final;<url> <url> ...". Part URLs may be
+// absolute or relative; the upload ID is their last path segment. An empty header
+// yields nil.
+func ParseUploadConcat(header string) (*TusConcat, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, nil
+	}
+
+	if header == "partial" {
+		return &TusConcat{Partial: true}, nil
+	}
+
+	urls, ok := strings.CutPrefix(header, "final;")
+	if !ok {
+		return nil, fmt.Errorf("header %s tidak valid", HeaderUploadConcat)
+	}
+
+	fields := strings.Fields(urls)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("header %s harus berisi minimal satu partial upload", HeaderUploadConcat)
+	}
+	if len(fields) > MaxConcatParts {
+		return nil, fmt.Errorf("maksimal %d partial upload dapat digabungkan", MaxConcatParts)
+	}
+
+	concat := &TusConcat{Parts: make([]string, 0, len(fields))}
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		partURL, err := url.Parse(field)
+		if err != nil {
+			return nil, fmt.Errorf("URL partial upload tidak valid: %s", field)
+		}
+
+		id := path.Base(strings.TrimSuffix(partURL.Path, "/"))
+		if id == "" || id == "." || id == "/" {
+			return nil, fmt.Errorf("URL partial upload tidak valid: %s", field)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("partial upload %s disebutkan lebih dari sekali", id)
+		}
+		seen[id] = true
+		concat.Parts = append(concat.Parts, id)
+	}
+
+	return concat, nil
+}
+
+// Concatenate builds a completed upload finalID out of the finished partial uploads
+// in partIDs, in order. The partial uploads are removed once the final file is
+// written; on error they are left untouched.
+func (ts *TusStore) Concatenate(finalID string, partIDs []string, metadata map[string]string) (int64, error) {
+	parts := make([]TusFileInfo, 0, len(partIDs))
+	var total int64
+	for _, partID := range partIDs {
+		info, err := ts.GetInfo(partID)
+		if err != nil {
+			return 0, fmt.Errorf("partial upload %s: %w", partID, err)
+		}
+		if info.Offset < info.Size {
+			return 0, fmt.Errorf("partial upload %s belum selesai", partID)
+		}
+		parts = append(parts, info)
+		total += info.Size
+	}
+
+	if total > ts.maxFileSize {
+		return 0, fmt.Errorf("ukuran file melebihi batas maksimal %d bytes", ts.maxFileSize)
+	}
+
+	if err := ts.pathResolver.EnsureDirectoryExists(ts.pathResolver.GetUploadPath(finalID)); err != nil {
+		return 0, err
+	}
+
+	if err := ts.appendParts(finalID, parts); err != nil {
+		os.RemoveAll(ts.pathResolver.GetUploadPath(finalID))
+		return 0, err
+	}
+
+	info := TusFileInfo{
+		ID:       finalID,
+		Size:     total,
+		Offset:   total,
+		Metadata: metadata,
+	}
+	if err := ts.saveInfo(info); err != nil {
+		os.RemoveAll(ts.pathResolver.GetUploadPath(finalID))
+		return 0, err
+	}
+
+	for _, part := range parts {
+		_ = ts.Terminate(part.ID)
+	}
+
+	return total, nil
+}
+
+func (ts *TusStore) appendParts(finalID string, parts []TusFileInfo) error {
+	dst, err := os.Create(ts.pathResolver.GetUploadFilePath(finalID))
+	if err != nil {
+		return fmt.Errorf("gagal membuat file: %w", err)
+	}
+	defer dst.Close()
+
+	for _, part := range parts {
+		if err := ts.copyPart(dst, part); err != nil {
+			return err
+		}
+	}
+
+	if err := dst.Sync(); err != nil {
+		return fmt.Errorf("gagal sync file: %w", err)
+	}
+
+	return nil
+}
+
+func (ts *TusStore) copyPart(dst io.Writer, part TusFileInfo) error {
+	lock := ts.getLock(part.ID)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	src, err := os.Open(ts.pathResolver.GetUploadFilePath(part.ID))
+	if err != nil {
+		return fmt.Errorf("gagal membuka partial upload %s: %w", part.ID, err)
+	}
+	defer src.Close()
+
+	written, err := io.CopyN(dst, src, part.Size)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("gagal menggabungkan partial upload %s: %w", part.ID, err)
+	}
+	if written != part.Size {
+		return fmt.Errorf("partial upload %s tidak lengkap", part.ID)
+	}
+
+	return nil
+}
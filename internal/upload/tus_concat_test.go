@@ -0,0 +1,94 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUploadConcat(t *testing.T) {
+	t.Parallel()
+
+	concat, err := ParseUploadConcat("")
+	require.NoError(t, err)
+	assert.Nil(t, concat)
+
+	concat, err = ParseUploadConcat("partial")
+	require.NoError(t, err)
+	assert.True(t, concat.Partial)
+
+	concat, err = ParseUploadConcat("final;/api/v1/project/upload/a https://example.com/api/v1/project/upload/b/")
+	require.NoError(t, err)
+	assert.False(t, concat.Partial)
+	assert.Equal(t, []string{"a", "b"}, concat.Parts)
+
+	tooMany := make([]string, MaxConcatParts+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("/project/upload/part-%d", i)
+	}
+
+	for _, header := range []string{"final", "final;", "final;/a /a", "merge;/a", "final;" + strings.Join(tooMany, " ")} {
+		_, err := ParseUploadConcat(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func writeTestPart(t *testing.T, store *TusStore, id string, data []byte) {
+	t.Helper()
+	require.NoError(t, store.NewUpload(TusFileInfo{ID: id, Size: int64(len(data))}))
+	_, err := store.WriteChunk(id, 0, bytes.NewReader(data))
+	require.NoError(t, err)
+}
+
+func TestTusStore_Concatenate_JoinsPartsInOrder(t *testing.T) {
+	t.Parallel()
+	store := newTestTusStore(t, 1024)
+	writeTestPart(t, store, "part-1", []byte("hello "))
+	writeTestPart(t, store, "part-2", []byte("world"))
+
+	size, err := store.Concatenate("final", []string{"part-1", "part-2"}, map[string]string{"nama_project": "Demo"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), size)
+
+	data, err := os.ReadFile(store.GetFilePath("final"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	info, err := store.GetInfo("final")
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), info.Offset)
+	assert.Equal(t, "Demo", info.Metadata["nama_project"])
+
+	_, err = store.GetInfo("part-1")
+	assert.Error(t, err, "consumed parts are removed")
+}
+
+func TestTusStore_Concatenate_RejectsIncompletePart(t *testing.T) {
+	t.Parallel()
+	store := newTestTusStore(t, 1024)
+	writeTestPart(t, store, "part-1", []byte("done"))
+	require.NoError(t, store.NewUpload(TusFileInfo{ID: "part-2", Size: 8}))
+
+	_, err := store.Concatenate("final", []string{"part-1", "part-2"}, nil)
+	require.Error(t, err)
+
+	_, err = store.GetInfo("final")
+	assert.Error(t, err)
+	_, err = store.GetInfo("part-1")
+	assert.NoError(t, err, "parts survive a failed concatenation")
+}
+
+func TestTusStore_Concatenate_RejectsOversizedResult(t *testing.T) {
+	t.Parallel()
+	store := newTestTusStore(t, 8)
+	writeTestPart(t, store, "part-1", []byte("12345"))
+	writeTestPart(t, store, "part-2", []byte("67890"))
+
+	_, err := store.Concatenate("final", []string{"part-1", "part-2"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package upload
+
+import (
+	"errors"
+	"io"
+)
+
+// DeferredLength is passed as the upload size when the client sent Upload-Defer-Length
+// instead of Upload-Length.
+const DeferredLength int64 = -1
+
+// ErrUploadLengthExceeded is returned while writing a chunk that would grow an upload
+// past the size it is allowed to reach.
+var ErrUploadLengthExceeded = errors.New("ukuran upload melebihi batas yang diizinkan")
+
+// NewLengthLimitReader wraps src so that reading more than limit bytes fails with
+// ErrUploadLengthExceeded. Like the checksum reader, the failure surfaces before
+// TusStore.WriteChunk commits the new offset.
+func NewLengthLimitReader(src io.Reader, limit int64) io.Reader {
+	return &lengthLimitReader{src: src, remaining: limit}
+}
+
+type lengthLimitReader struct {
+	src       io.Reader
+	remaining int64
+}
+
+func (r *lengthLimitReader) Read(p []byte) (int, error) {
+	n, err := r.src.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, ErrUploadLengthExceeded
+	}
+	return n, err
+}
@@ -0,0 +1,130 @@
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreationLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int64
+		wantErr bool
+	}{
+		{"upload length", map[string]string{HeaderUploadLength: "2048"}, 2048, false},
+		{"deferred length", map[string]string{HeaderUploadDeferLength: "1"}, DeferredLength, false},
+		{"missing headers", map[string]string{}, 0, true},
+		{"both headers", map[string]string{HeaderUploadLength: "2048", HeaderUploadDeferLength: "1"}, 0, true},
+		{"invalid defer value", map[string]string{HeaderUploadDeferLength: "yes"}, 0, true},
+		{"zero length", map[string]string{HeaderUploadLength: "0"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			app := fiber.New()
+			app.Post("/", func(c *fiber.Ctx) error {
+				length, err := ParseCreationLength(c)
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, length)
+				}
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			_, err := app.Test(req)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLengthLimitReader(t *testing.T) {
+	t.Parallel()
+
+	data, err := io.ReadAll(NewLengthLimitReader(bytes.NewReader([]byte("abcd")), 4))
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", string(data))
+
+	_, err = io.ReadAll(NewLengthLimitReader(bytes.NewReader([]byte("abcde")), 4))
+	assert.True(t, errors.Is(err, ErrUploadLengthExceeded))
+}
+
+func TestTusStore_DeferredLength(t *testing.T) {
+	t.Parallel()
+
+	t.Run("new upload is not preallocated", func(t *testing.T) {
+		t.Parallel()
+		store := newTestTusStore(t, 1024)
+		require.NoError(t, store.NewUpload(TusFileInfo{ID: "deferred", SizeIsDeferred: true}))
+
+		stat, err := os.Stat(store.GetFilePath("deferred"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), stat.Size())
+
+		complete, err := store.IsComplete("deferred")
+		require.NoError(t, err)
+		assert.False(t, complete)
+	})
+
+	t.Run("chunks grow the file until the length is declared", func(t *testing.T) {
+		t.Parallel()
+		store := newTestTusStore(t, 1024)
+		require.NoError(t, store.NewUpload(TusFileInfo{ID: "deferred", SizeIsDeferred: true}))
+
+		offset, err := store.WriteChunk("deferred", 0, bytes.NewReader([]byte("hello ")))
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), offset)
+
+		assert.Error(t, store.DeclareLength("deferred", 4), "length below the written offset")
+		require.NoError(t, store.DeclareLength("deferred", 11))
+
+		offset, err = store.WriteChunk("deferred", offset, bytes.NewReader([]byte("world")))
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), offset)
+
+		complete, err := store.IsComplete("deferred")
+		require.NoError(t, err)
+		assert.True(t, complete)
+
+		info, err := store.GetInfo("deferred")
+		require.NoError(t, err)
+		assert.False(t, info.SizeIsDeferred)
+		assert.Error(t, store.DeclareLength("deferred", 12), "length is already fixed")
+		assert.NoError(t, store.DeclareLength("deferred", 11))
+	})
+
+	t.Run("chunk past the maximum size is rejected", func(t *testing.T) {
+		t.Parallel()
+		store := newTestTusStore(t, 4)
+		require.NoError(t, store.NewUpload(TusFileInfo{ID: "deferred", SizeIsDeferred: true}))
+
+		offset, err := store.WriteChunk("deferred", 0, bytes.NewReader([]byte("hello")))
+		assert.True(t, errors.Is(err, ErrUploadLengthExceeded))
+		assert.Equal(t, int64(0), offset)
+
+		info, err := store.GetInfo("deferred")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), info.Offset)
+		data, err := os.ReadFile(store.pathResolver.GetUploadFilePath("deferred"))
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+}
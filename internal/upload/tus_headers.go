@@ -2,19 +2,24 @@ package upload
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 const (
-	HeaderTusResumable   = "Tus-Resumable"
-	HeaderUploadOffset   = "Upload-Offset"
-	HeaderUploadLength   = "Upload-Length"
-	HeaderUploadMetadata = "Upload-Metadata"
-	HeaderContentType    = "Content-Type"
-	HeaderContentLength  = "Content-Length"
-	HeaderLocation       = "Location"
+	HeaderTusResumable      = "Tus-Resumable"
+	HeaderUploadOffset      = "Upload-Offset"
+	HeaderUploadLength      = "Upload-Length"
+	HeaderUploadDeferLength = "Upload-Defer-Length"
+	HeaderUploadMetadata    = "Upload-Metadata"
+	HeaderUploadExpires     = "Upload-Expires"
+	HeaderContentType       = "Content-Type"
+	HeaderContentLength     = "Content-Length"
+	HeaderLocation          = "Location"
 
 	TusVersion         = "1.0.0"
 	TusContentType     = "application/offset+octet-stream"
@@ -77,6 +82,32 @@ func SetTusResponseHeaders(c *fiber.Ctx, offset, length int64) {
 	}
 }
 
+// SetTusExpiresHeader sets Upload-Expires for the expiration extension. Uploads that no
+// longer expire, such as completed ones, pass the zero time and get no header.
+func SetTusExpiresHeader(c *fiber.Ctx, expiresAt time.Time) {
+	if expiresAt.IsZero() {
+		return
+	}
+	c.Set(HeaderUploadExpires, expiresAt.UTC().Format(http.TimeFormat))
+}
+
+// UploadExpiresAt returns when an upload touched now expires if it then stays idle for
+// idleTimeout seconds.
+func UploadExpiresAt(idleTimeout int) time.Time {
+	return time.Now().Add(time.Duration(idleTimeout) * time.Second)
+}
+
+// SetTusConcatHeader sets Upload-Concat on a HEAD response: "partial" for a partial
+// upload, or "final;" followed by its part URLs for an upload assembled from them.
+func SetTusConcatHeader(c *fiber.Ctx, partial bool, partURLs []string) {
+	switch {
+	case partial:
+		c.Set(HeaderUploadConcat, "partial")
+	case len(partURLs) > 0:
+		c.Set(HeaderUploadConcat, "final;"+strings.Join(partURLs, " "))
+	}
+}
+
 func SetTusLocationHeader(c *fiber.Ctx, location string) {
 	c.Set(HeaderLocation, location)
 }
@@ -107,3 +138,29 @@ func BuildTusErrorResponse(c *fiber.Ctx, statusCode int, offset int64) error {
 
 	return c.SendStatus(statusCode)
 }
+
+// ParseCreationLength reads the length of a new upload from Upload-Length, or returns
+// DeferredLength when the client sends Upload-Defer-Length: 1 instead.
+func ParseCreationLength(c *fiber.Ctx) (int64, error) {
+	lengthStr := c.Get(HeaderUploadLength)
+	deferStr := c.Get(HeaderUploadDeferLength)
+
+	switch {
+	case lengthStr != "" && deferStr != "":
+		return 0, fmt.Errorf("header %s dan %s tidak boleh dikirim bersamaan", HeaderUploadLength, HeaderUploadDeferLength)
+	case deferStr != "":
+		if deferStr != "1" {
+			return 0, fmt.Errorf("header %s harus bernilai 1", HeaderUploadDeferLength)
+		}
+		return DeferredLength, nil
+	case lengthStr == "":
+		return 0, fmt.Errorf("header %s wajib diisi", HeaderUploadLength)
+	}
+
+	length, err := strconv.ParseInt(lengthStr, 10, 64)
+	if err != nil || length <= 0 {
+		return 0, fmt.Errorf("header %s tidak valid", HeaderUploadLength)
+	}
+
+	return length, nil
+}
@@ -3,6 +3,7 @@ package upload_test
 import (
 	"invento-service/internal/upload"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -313,3 +314,17 @@ func TestValidateChunkSize_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestSetTusExpiresHeader(t *testing.T) {
+	t.Parallel()
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+
+	upload.SetTusExpiresHeader(c, time.Time{})
+	assert.Empty(t, string(c.Response().Header.Peek(upload.HeaderUploadExpires)))
+
+	expiresAt := time.Date(2026, time.March, 4, 17, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+	upload.SetTusExpiresHeader(c, expiresAt)
+	assert.Equal(t, "Wed, 04 Mar 2026 10:30:00 GMT", string(c.Response().Header.Peek(upload.HeaderUploadExpires)))
+}
@@ -1,9 +1,11 @@
 package upload
 
 import (
+	"errors"
 	"fmt"
 	"invento-service/config"
 	"invento-service/internal/dto"
+	"invento-service/internal/metrics"
 	"invento-service/internal/storage"
 	"io"
 	"strconv"
@@ -15,13 +17,15 @@ import (
 
 type TusManager struct {
 	store       *TusStore
-	queue       *TusQueue
+	queue       UploadQueue
 	fileManager *storage.FileManager
 	config      *config.Config
 	logger      zerolog.Logger
+	metrics     *metrics.TusCounters
+	progress    *ProgressBus
 }
 
-func NewTusManager(store *TusStore, queue *TusQueue, fileManager *storage.FileManager, config *config.Config, logger zerolog.Logger) *TusManager {
+func NewTusManager(store *TusStore, queue UploadQueue, fileManager *storage.FileManager, config *config.Config, logger zerolog.Logger) *TusManager {
 	return &TusManager{
 		store:       store,
 		queue:       queue,
@@ -31,6 +35,21 @@ func NewTusManager(store *TusStore, queue *TusQueue, fileManager *storage.FileMa
 	}
 }
 
+// SetMetrics attaches lifecycle counters for the uploads handled by this manager.
+func (tm *TusManager) SetMetrics(counters *metrics.TusCounters) {
+	tm.metrics = counters
+}
+
+// SetProgressBus attaches the bus that PublishProgress sends upload events to.
+func (tm *TusManager) SetProgressBus(bus *ProgressBus) {
+	tm.progress = bus
+}
+
+// PublishProgress notifies progress subscribers. Without a bus it does nothing.
+func (tm *TusManager) PublishProgress(event dto.TusProgressEvent) {
+	tm.progress.Publish(event)
+}
+
 func (tm *TusManager) CheckUploadSlot() *dto.TusUploadSlotResponse {
 	hasActiveUpload := tm.queue.HasActiveUpload()
 	queueLength := tm.queue.GetQueueLength()
@@ -102,27 +121,76 @@ func (tm *TusManager) ValidateModulMetadata(metadata map[string]string) error {
 	return nil
 }
 
+// InitiateUpload creates the upload file. A fileSize of DeferredLength starts an upload
+// whose size is declared later through DeclareUploadLength.
 func (tm *TusManager) InitiateUpload(uploadID string, fileSize int64, metadata map[string]string) error {
 	if fileSize > tm.config.Upload.MaxSize {
 		return apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas maksimal %d MB", tm.config.Upload.MaxSize/(1024*1024)))
 	}
 
-	if fileSize <= 0 {
+	deferred := fileSize == DeferredLength
+	if fileSize <= 0 && !deferred {
 		return apperrors.NewValidationError("ukuran file tidak valid", nil)
 	}
 
 	info := TusFileInfo{
-		ID:       uploadID,
-		Size:     fileSize,
-		Offset:   0,
-		Metadata: metadata,
+		ID:             uploadID,
+		Size:           max(fileSize, 0),
+		Offset:         0,
+		Metadata:       metadata,
+		SizeIsDeferred: deferred,
 	}
 
-	return tm.store.NewUpload(info)
+	if err := tm.store.NewUpload(info); err != nil {
+		return err
+	}
+
+	tm.metrics.IncInitiated()
+	return nil
 }
 
 func (tm *TusManager) HandleChunk(uploadID string, offset int64, chunk io.Reader) (int64, error) {
-	return tm.store.WriteChunk(uploadID, offset, chunk)
+	newOffset, err := tm.store.WriteChunk(uploadID, offset, chunk)
+	if errors.Is(err, ErrChecksumMismatch) {
+		tm.logger.Warn().Str("upload_id", uploadID).Int64("offset", offset).Msg("chunk rejected: checksum mismatch")
+		return offset, apperrors.NewTusChecksumMismatchError()
+	}
+	if errors.Is(err, ErrUploadLengthExceeded) {
+		return offset, apperrors.NewPayloadTooLargeError(err.Error())
+	}
+	if err != nil {
+		return newOffset, err
+	}
+
+	tm.metrics.AddBytesReceived(newOffset - offset)
+	return newOffset, nil
+}
+
+// DeclareUploadLength sets the size of an upload that was started with a deferred length.
+func (tm *TusManager) DeclareUploadLength(uploadID string, size int64) error {
+	if size > tm.config.Upload.MaxSize {
+		return apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas maksimal %d MB", tm.config.Upload.MaxSize/(1024*1024)))
+	}
+	if size <= 0 {
+		return apperrors.NewValidationError("ukuran file tidak valid", nil)
+	}
+
+	if err := tm.store.DeclareLength(uploadID, size); err != nil {
+		return apperrors.NewValidationError(err.Error(), nil)
+	}
+	return nil
+}
+
+// ConcatenateUploads assembles finished partial uploads into the completed upload
+// finalID and returns its size. FinalizeUpload can be called on finalID right after.
+func (tm *TusManager) ConcatenateUploads(finalID string, partIDs []string, metadata map[string]string) (int64, error) {
+	size, err := tm.store.Concatenate(finalID, partIDs, metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	tm.logger.Info().Str("upload_id", finalID).Strs("parts", partIDs).Int64("size", size).Msg("partial uploads concatenated")
+	return size, nil
 }
 
 func (tm *TusManager) GetUploadStatus(uploadID string) (offset, size int64, err error) {
@@ -139,11 +207,33 @@ func (tm *TusManager) GetUploadInfo(uploadID string) (TusFileInfo, error) {
 }
 
 func (tm *TusManager) CancelUpload(uploadID string) error {
+	if err := tm.store.Terminate(uploadID); err != nil {
+		return err
+	}
+
+	tm.metrics.IncCancelled()
+	return nil
+}
+
+// RejectUpload discards the data of a finished upload that failed inspection. Unlike
+// CancelUpload it is not counted as a cancellation.
+func (tm *TusManager) RejectUpload(uploadID string) error {
 	return tm.store.Terminate(uploadID)
 }
 
+// GetUploadFilePath returns where the received bytes of uploadID are kept until
+// FinalizeUpload moves them to storage.
+func (tm *TusManager) GetUploadFilePath(uploadID string) string {
+	return tm.store.GetFilePath(uploadID)
+}
+
 func (tm *TusManager) FinalizeUpload(uploadID, finalPath string) error {
-	return tm.store.FinalizeUpload(uploadID, finalPath)
+	if err := tm.store.FinalizeUpload(uploadID, finalPath); err != nil {
+		return err
+	}
+
+	tm.metrics.IncCompleted()
+	return nil
 }
 
 func (tm *TusManager) IsUploadComplete(uploadID string) (bool, error) {
@@ -166,6 +256,14 @@ func (tm *TusManager) FinishUpload(uploadID string) string {
 	return tm.queue.FinishUpload(uploadID)
 }
 
+func (tm *TusManager) GetQueuePosition(uploadID string) int {
+	return tm.queue.GetQueuePosition(uploadID)
+}
+
+func (tm *TusManager) GetCurrentQueue() []string {
+	return tm.queue.GetCurrentQueue()
+}
+
 func (tm *TusManager) CanAcceptUpload() bool {
 	return tm.queue.CanAcceptUpload()
 }
@@ -2,6 +2,7 @@ package upload_test
 
 import (
 	"bytes"
+	"invento-service/internal/metrics"
 	"invento-service/internal/storage"
 	"invento-service/internal/upload"
 	"os"
@@ -73,7 +74,8 @@ func TestTusManager_InitiateUpload_InvalidSize(t *testing.T) {
 		wantErr  bool
 	}{
 		{"zero size", 0, true},
-		{"negative size", -1, true},
+		{"negative size", -2, true},
+		{"deferred length", upload.DeferredLength, false},
 	}
 
 	for _, tt := range tests {
@@ -352,3 +354,54 @@ func TestTusManager_IsUploadComplete_False(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, isComplete)
 }
+
+func TestTusManager_SetMetrics_RecordsLifecycle(t *testing.T) {
+	t.Parallel()
+	cfg := setupTestConfig()
+	tempDir := t.TempDir()
+	cfg.Upload.PathDevelopment = tempDir
+	cfg.Upload.TempPathDevelopment = filepath.Join(tempDir, "temp")
+	pathResolver := storage.NewPathResolver(cfg)
+	store := upload.NewTusStore(pathResolver, cfg.Upload.MaxSize)
+	queue := upload.NewTusQueue(3)
+	fileManager := storage.NewFileManager(cfg)
+	manager := upload.NewTusManager(store, queue, fileManager, cfg, zerolog.Nop())
+	tusMetrics := metrics.NewTusMetrics()
+	manager.SetMetrics(tusMetrics.Project)
+
+	require.NoError(t, manager.InitiateUpload("metrics-complete", 10, nil))
+	require.NoError(t, manager.InitiateUpload("metrics-cancel", 10, nil))
+
+	_, err := manager.HandleChunk("metrics-complete", 0, bytes.NewReader([]byte("0123456789")))
+	require.NoError(t, err)
+	_, err = manager.HandleChunk("metrics-cancel", 0, bytes.NewReader([]byte("0123")))
+	require.NoError(t, err)
+
+	require.NoError(t, manager.FinalizeUpload("metrics-complete", filepath.Join(tempDir, "final", "file.zip")))
+	require.NoError(t, manager.CancelUpload("metrics-cancel"))
+
+	snapshot := tusMetrics.Project.Snapshot()
+	assert.Equal(t, int64(2), snapshot.Initiated)
+	assert.Equal(t, int64(14), snapshot.BytesReceived)
+	assert.Equal(t, int64(1), snapshot.Completed)
+	assert.Equal(t, int64(1), snapshot.Cancelled)
+	assert.Zero(t, tusMetrics.Modul.Snapshot().Initiated)
+}
+
+func TestTusManager_SetMetrics_IgnoresFailedOperations(t *testing.T) {
+	t.Parallel()
+	cfg := setupTestConfig()
+	pathResolver := storage.NewPathResolver(cfg)
+	store := upload.NewTusStore(pathResolver, cfg.Upload.MaxSize)
+	queue := upload.NewTusQueue(3)
+	fileManager := storage.NewFileManager(cfg)
+	manager := upload.NewTusManager(store, queue, fileManager, cfg, zerolog.Nop())
+	counters := &metrics.TusCounters{}
+	manager.SetMetrics(counters)
+
+	assert.Error(t, manager.InitiateUpload("metrics-invalid", 0, nil))
+	_, err := manager.HandleChunk("metrics-missing", 0, bytes.NewReader([]byte("data")))
+	assert.Error(t, err)
+
+	assert.Equal(t, metrics.TusCountersSnapshot{}, counters.Snapshot())
+}
@@ -5,6 +5,27 @@ import (
 	"sync"
 )
 
+// UploadQueue schedules uploads into a limited number of concurrent slots.
+// TusQueue keeps the state in memory for a single instance; DBTusQueue keeps it
+// in the upload table so it is shared by every instance.
+type UploadQueue interface {
+	Add(uploadID string)
+	GetActiveUploads() []string
+	HasActiveUpload() bool
+	GetQueuePosition(uploadID string) int
+	GetQueueLength() int
+	Remove(uploadID string) error
+	FinishUpload(uploadID string) string
+	Clear()
+	CanAcceptUpload() bool
+	IsActiveUpload(uploadID string) bool
+	GetCurrentQueue() []string
+	LoadFromDB(activeIDs, queuedIDs []string) []string
+	GetActiveCount() int
+}
+
+var _ UploadQueue = (*TusQueue)(nil)
+
 type TusQueue struct {
 	queue         []string
 	activeUploads map[string]bool
@@ -166,7 +187,12 @@ func (tq *TusQueue) GetCurrentQueue() []string {
 	return queueCopy
 }
 
-func (tq *TusQueue) LoadFromDB(activeIDs []string) {
+// LoadFromDB restores the queue after a restart from the uploads stored as active
+// (pending or uploading) and as queued, each in queue order. Active uploads get the
+// slots first; those beyond the limit wait at the front of the queue. Queued uploads
+// that find a free slot are returned so the caller can mark them active in the
+// database as well.
+func (tq *TusQueue) LoadFromDB(activeIDs, queuedIDs []string) []string {
 	tq.mutex.Lock()
 	defer tq.mutex.Unlock()
 
@@ -187,6 +213,24 @@ func (tq *TusQueue) LoadFromDB(activeIDs []string) {
 
 		tq.queue = append(tq.queue, uploadID)
 	}
+
+	var promoted []string
+	for _, uploadID := range queuedIDs {
+		if seen[uploadID] {
+			continue
+		}
+		seen[uploadID] = true
+
+		if len(tq.activeUploads) < tq.maxConcurrent {
+			tq.activeUploads[uploadID] = true
+			promoted = append(promoted, uploadID)
+			continue
+		}
+
+		tq.queue = append(tq.queue, uploadID)
+	}
+
+	return promoted
 }
 
 func (tq *TusQueue) GetActiveCount() int {
@@ -0,0 +1,325 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"invento-service/internal/domain"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Tables that hold the upload records a DBTusQueue schedules.
+const (
+	TusQueueTableProject = "tus_uploads"
+	TusQueueTableModul   = "tus_modul_uploads"
+)
+
+var (
+	dbQueueActiveStatuses = []string{domain.UploadStatusPending, domain.UploadStatusUploading}
+	dbQueueMemberStatuses = []string{domain.UploadStatusQueued, domain.UploadStatusPending, domain.UploadStatusUploading}
+)
+
+// DBTusQueue is an UploadQueue whose state lives in the upload table itself, so every
+// replica sees the same slots. An upload holds a slot while its status is pending or
+// uploading and waits in line while its status is queued, ordered by created_at.
+// Partial uploads of a concatenation never join the queue and hold no slot.
+//
+// Mutations run in a transaction guarded by a Postgres advisory lock scoped to the table,
+// which serializes slot accounting and promotion across instances. Callers must move a
+// record to a terminal status (completed, cancelled, ...) before calling FinishUpload.
+type DBTusQueue struct {
+	db            *gorm.DB
+	table         string
+	maxConcurrent int
+	idleTimeout   time.Duration
+	logger        zerolog.Logger
+}
+
+var _ UploadQueue = (*DBTusQueue)(nil)
+
+// NewDBTusQueue creates a queue over table. Promoted uploads get a fresh expiry of
+// idleTimeout so time spent waiting in line does not count against them.
+func NewDBTusQueue(db *gorm.DB, table string, maxConcurrent int, idleTimeout time.Duration, logger zerolog.Logger) *DBTusQueue {
+	return &DBTusQueue{
+		db:            db,
+		table:         table,
+		maxConcurrent: maxConcurrent,
+		idleTimeout:   idleTimeout,
+		logger:        logger.With().Str("component", "DBTusQueue").Str("table", table).Logger(),
+	}
+}
+
+// Add admits an existing upload record: it keeps or takes a slot when one is free,
+// otherwise the record is marked queued. Free slots are then filled in FIFO order.
+func (q *DBTusQueue) Add(uploadID string) {
+	err := q.withLock(func(tx *gorm.DB) error {
+		status, err := q.statusOf(tx, uploadID)
+		if err != nil {
+			return err
+		}
+
+		if isActiveStatus(status) {
+			active, err := q.countActive(tx, uploadID)
+			if err != nil {
+				return err
+			}
+			if active >= int64(q.maxConcurrent) {
+				if err := q.setStatus(tx, uploadID, domain.UploadStatusQueued); err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err = q.fillSlots(tx)
+		return err
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		q.logger.Error().Err(err).Str("upload_id", uploadID).Msg("failed to add upload to queue")
+	}
+}
+
+func (q *DBTusQueue) GetActiveUploads() []string {
+	var ids []string
+	err := q.slotted(q.db.WithContext(context.Background())).
+		Where("status IN ?", dbQueueActiveStatuses).
+		Order("created_at ASC, id ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		q.logger.Error().Err(err).Msg("failed to list active uploads")
+		return []string{}
+	}
+	return ids
+}
+
+func (q *DBTusQueue) HasActiveUpload() bool {
+	return q.GetActiveCount() > 0
+}
+
+// GetQueuePosition returns 0 for an upload holding a slot, 1..n for a queued upload and -1 otherwise.
+func (q *DBTusQueue) GetQueuePosition(uploadID string) int {
+	var record struct {
+		Status    string
+		CreatedAt time.Time
+	}
+	err := q.db.WithContext(context.Background()).Table(q.table).
+		Select("status", "created_at").
+		Where("id = ?", uploadID).
+		Take(&record).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			q.logger.Error().Err(err).Str("upload_id", uploadID).Msg("failed to get queue position")
+		}
+		return -1
+	}
+
+	if isActiveStatus(record.Status) {
+		return 0
+	}
+	if record.Status != domain.UploadStatusQueued {
+		return -1
+	}
+
+	var ahead int64
+	err = q.db.WithContext(context.Background()).Table(q.table).
+		Where("status = ?", domain.UploadStatusQueued).
+		Where("created_at < ? OR (created_at = ? AND id < ?)", record.CreatedAt, record.CreatedAt, uploadID).
+		Count(&ahead).Error
+	if err != nil {
+		q.logger.Error().Err(err).Str("upload_id", uploadID).Msg("failed to count queued uploads")
+		return -1
+	}
+
+	return int(ahead) + 1
+}
+
+func (q *DBTusQueue) GetQueueLength() int {
+	var count int64
+	err := q.db.WithContext(context.Background()).Table(q.table).
+		Where("status = ?", domain.UploadStatusQueued).
+		Count(&count).Error
+	if err != nil {
+		q.logger.Error().Err(err).Msg("failed to count queued uploads")
+		return 0
+	}
+	return int(count)
+}
+
+// Remove takes an upload out of the queue by marking its record cancelled.
+func (q *DBTusQueue) Remove(uploadID string) error {
+	return q.withLock(func(tx *gorm.DB) error {
+		status, err := q.statusOf(tx, uploadID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if !isMemberStatus(status) {
+			return errors.New("upload tidak ditemukan dalam antrian")
+		}
+		return q.setStatus(tx, uploadID, domain.UploadStatusCancelled)
+	})
+}
+
+// FinishUpload fills any slot freed by uploadID and returns the first promoted upload, if any.
+func (q *DBTusQueue) FinishUpload(uploadID string) string {
+	var promoted []string
+	err := q.withLock(func(tx *gorm.DB) error {
+		var err error
+		promoted, err = q.fillSlots(tx)
+		return err
+	})
+	if err != nil {
+		q.logger.Error().Err(err).Str("upload_id", uploadID).Msg("failed to promote queued upload")
+		return ""
+	}
+
+	if len(promoted) == 0 {
+		return ""
+	}
+	return promoted[0]
+}
+
+// Clear cancels every upload that is holding a slot or waiting for one.
+func (q *DBTusQueue) Clear() {
+	err := q.withLock(func(tx *gorm.DB) error {
+		return q.slotted(tx).
+			Where("status IN ?", dbQueueMemberStatuses).
+			Updates(map[string]interface{}{"status": domain.UploadStatusCancelled, "updated_at": time.Now()}).Error
+	})
+	if err != nil {
+		q.logger.Error().Err(err).Msg("failed to clear queue")
+	}
+}
+
+func (q *DBTusQueue) CanAcceptUpload() bool {
+	return q.GetActiveCount() < q.maxConcurrent
+}
+
+func (q *DBTusQueue) IsActiveUpload(uploadID string) bool {
+	status, err := q.statusOf(q.db.WithContext(context.Background()), uploadID)
+	if err != nil {
+		return false
+	}
+	return isActiveStatus(status)
+}
+
+func (q *DBTusQueue) GetCurrentQueue() []string {
+	var ids []string
+	err := q.db.WithContext(context.Background()).Table(q.table).
+		Where("status = ?", domain.UploadStatusQueued).
+		Order("created_at ASC, id ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		q.logger.Error().Err(err).Msg("failed to list queued uploads")
+		return []string{}
+	}
+	return ids
+}
+
+// LoadFromDB is a no-op: the queue state is always read from the database.
+func (q *DBTusQueue) LoadFromDB(activeIDs, queuedIDs []string) []string { return nil }
+
+func (q *DBTusQueue) GetActiveCount() int {
+	count, err := q.countActive(q.db.WithContext(context.Background()), "")
+	if err != nil {
+		q.logger.Error().Err(err).Msg("failed to count active uploads")
+		return 0
+	}
+	return int(count)
+}
+
+// withLock runs fn in a transaction holding an advisory lock for this table.
+// SQLite (used in tests) has no advisory locks but already serializes writers.
+func (q *DBTusQueue) withLock(fn func(tx *gorm.DB) error) error {
+	return q.db.WithContext(context.Background()).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "tus_queue:"+q.table).Error; err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// fillSlots promotes queued uploads, oldest first, until all slots are taken.
+func (q *DBTusQueue) fillSlots(tx *gorm.DB) ([]string, error) {
+	active, err := q.countActive(tx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	free := q.maxConcurrent - int(active)
+	if free <= 0 {
+		return nil, nil
+	}
+
+	var ids []string
+	query := tx.Table(q.table).
+		Where("status = ?", domain.UploadStatusQueued).
+		Order("created_at ASC, id ASC").
+		Limit(free)
+	if tx.Dialector.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, id := range ids {
+		err := tx.Table(q.table).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"status":     domain.UploadStatusPending,
+				"expires_at": now.Add(q.idleTimeout),
+				"updated_at": now,
+			}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+func (q *DBTusQueue) countActive(tx *gorm.DB, excludeID string) (int64, error) {
+	query := q.slotted(tx).Where("status IN ?", dbQueueActiveStatuses)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// slotted scopes tx to the records that take part in slot accounting.
+func (q *DBTusQueue) slotted(tx *gorm.DB) *gorm.DB {
+	return tx.Table(q.table).Where("upload_type <> ?", domain.UploadTypeProjectPartial)
+}
+
+func (q *DBTusQueue) statusOf(tx *gorm.DB, uploadID string) (string, error) {
+	var statuses []string
+	if err := tx.Table(q.table).Where("id = ?", uploadID).Limit(1).Pluck("status", &statuses).Error; err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return statuses[0], nil
+}
+
+func (q *DBTusQueue) setStatus(tx *gorm.DB, uploadID, status string) error {
+	return tx.Table(q.table).
+		Where("id = ?", uploadID).
+		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error
+}
+
+func isActiveStatus(status string) bool {
+	return status == domain.UploadStatusPending || status == domain.UploadStatusUploading
+}
+
+func isMemberStatus(status string) bool {
+	return status == domain.UploadStatusQueued || isActiveStatus(status)
+}
@@ -0,0 +1,174 @@
+package upload_test
+
+import (
+	"invento-service/internal/domain"
+	"invento-service/internal/upload"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupQueueTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.TusUpload{}))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	return db
+}
+
+// createQueuedRecord inserts a pending upload the way initiateUpload does, spacing
+// created_at so FIFO order is deterministic.
+func createQueuedRecord(t *testing.T, db *gorm.DB, id string, createdAt time.Time) {
+	t.Helper()
+	record := domain.TusUpload{
+		ID:         id,
+		UserID:     "user-" + id,
+		UploadType: domain.UploadTypeProjectCreate,
+		FileSize:   1024,
+		Status:     domain.UploadStatusPending,
+		CreatedAt:  createdAt,
+		ExpiresAt:  createdAt.Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&record).Error)
+}
+
+func statusOf(t *testing.T, db *gorm.DB, id string) string {
+	t.Helper()
+	var record domain.TusUpload
+	require.NoError(t, db.First(&record, "id = ?", id).Error)
+	return record.Status
+}
+
+func TestDBTusQueue_AddAdmitsUntilSlotsAreFull(t *testing.T) {
+	t.Parallel()
+	db := setupQueueTestDB(t)
+	queue := upload.NewDBTusQueue(db, upload.TusQueueTableProject, 1, 10*time.Minute, zerolog.Nop())
+	base := time.Now().Add(-time.Minute)
+
+	createQueuedRecord(t, db, "upload-1", base)
+	queue.Add("upload-1")
+	createQueuedRecord(t, db, "upload-2", base.Add(time.Second))
+	queue.Add("upload-2")
+	createQueuedRecord(t, db, "upload-3", base.Add(2*time.Second))
+	queue.Add("upload-3")
+
+	assert.Equal(t, domain.UploadStatusPending, statusOf(t, db, "upload-1"))
+	assert.Equal(t, domain.UploadStatusQueued, statusOf(t, db, "upload-2"))
+	assert.Equal(t, domain.UploadStatusQueued, statusOf(t, db, "upload-3"))
+
+	assert.Equal(t, 1, queue.GetActiveCount())
+	assert.Equal(t, 2, queue.GetQueueLength())
+	assert.False(t, queue.CanAcceptUpload())
+	assert.True(t, queue.HasActiveUpload())
+	assert.True(t, queue.IsActiveUpload("upload-1"))
+	assert.False(t, queue.IsActiveUpload("upload-2"))
+	assert.Equal(t, []string{"upload-1"}, queue.GetActiveUploads())
+	assert.Equal(t, []string{"upload-2", "upload-3"}, queue.GetCurrentQueue())
+
+	assert.Equal(t, 0, queue.GetQueuePosition("upload-1"))
+	assert.Equal(t, 1, queue.GetQueuePosition("upload-2"))
+	assert.Equal(t, 2, queue.GetQueuePosition("upload-3"))
+	assert.Equal(t, -1, queue.GetQueuePosition("missing"))
+}
+
+func TestDBTusQueue_PartialUploadsHoldNoSlot(t *testing.T) {
+	t.Parallel()
+	db := setupQueueTestDB(t)
+	queue := upload.NewDBTusQueue(db, upload.TusQueueTableProject, 1, 10*time.Minute, zerolog.Nop())
+	base := time.Now().Add(-time.Minute)
+
+	createQueuedRecord(t, db, "part-1", base)
+	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "part-1").
+		Updates(map[string]interface{}{"upload_type": domain.UploadTypeProjectPartial, "status": domain.UploadStatusUploading}).Error)
+
+	assert.Equal(t, 0, queue.GetActiveCount())
+	assert.True(t, queue.CanAcceptUpload())
+	createQueuedRecord(t, db, "upload-1", base.Add(time.Second))
+	queue.Add("upload-1")
+	assert.Equal(t, domain.UploadStatusPending, statusOf(t, db, "upload-1"))
+	assert.Equal(t, []string{"upload-1"}, queue.GetActiveUploads())
+}
+
+func TestDBTusQueue_FinishUploadPromotesInOrder(t *testing.T) {
+	t.Parallel()
+	db := setupQueueTestDB(t)
+	queue := upload.NewDBTusQueue(db, upload.TusQueueTableProject, 1, 10*time.Minute, zerolog.Nop())
+	base := time.Now().Add(-time.Minute)
+
+	for i, id := range []string{"upload-1", "upload-2", "upload-3"} {
+		createQueuedRecord(t, db, id, base.Add(time.Duration(i)*time.Second))
+		queue.Add(id)
+	}
+
+	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "upload-1").Update("status", domain.UploadStatusCompleted).Error)
+	assert.Equal(t, "upload-2", queue.FinishUpload("upload-1"))
+	assert.Equal(t, domain.UploadStatusPending, statusOf(t, db, "upload-2"))
+	assert.Equal(t, 1, queue.GetQueuePosition("upload-3"))
+
+	var promoted domain.TusUpload
+	require.NoError(t, db.First(&promoted, "id = ?", "upload-2").Error)
+	assert.True(t, promoted.ExpiresAt.After(time.Now().Add(9*time.Minute)))
+
+	// Slot still taken by upload-2, so nothing else moves.
+	assert.Empty(t, queue.FinishUpload("upload-1"))
+	assert.Equal(t, domain.UploadStatusQueued, statusOf(t, db, "upload-3"))
+}
+
+func TestDBTusQueue_SharedAcrossInstances(t *testing.T) {
+	t.Parallel()
+	db := setupQueueTestDB(t)
+	instanceA := upload.NewDBTusQueue(db, upload.TusQueueTableProject, 1, 10*time.Minute, zerolog.Nop())
+	instanceB := upload.NewDBTusQueue(db, upload.TusQueueTableProject, 1, 10*time.Minute, zerolog.Nop())
+	base := time.Now().Add(-time.Minute)
+
+	createQueuedRecord(t, db, "upload-1", base)
+	instanceA.Add("upload-1")
+	createQueuedRecord(t, db, "upload-2", base.Add(time.Second))
+	instanceB.Add("upload-2")
+
+	assert.Equal(t, 1, instanceB.GetActiveCount())
+	assert.Equal(t, 1, instanceA.GetQueuePosition("upload-2"))
+
+	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "upload-1").Update("status", domain.UploadStatusCancelled).Error)
+	assert.Equal(t, "upload-2", instanceA.FinishUpload("upload-1"))
+	assert.True(t, instanceB.IsActiveUpload("upload-2"))
+}
+
+func TestDBTusQueue_RemoveAndClear(t *testing.T) {
+	t.Parallel()
+	db := setupQueueTestDB(t)
+	queue := upload.NewDBTusQueue(db, upload.TusQueueTableProject, 1, 10*time.Minute, zerolog.Nop())
+	base := time.Now().Add(-time.Minute)
+
+	for i, id := range []string{"upload-1", "upload-2", "upload-3"} {
+		createQueuedRecord(t, db, id, base.Add(time.Duration(i)*time.Second))
+		queue.Add(id)
+	}
+
+	require.NoError(t, queue.Remove("upload-2"))
+	assert.Equal(t, domain.UploadStatusCancelled, statusOf(t, db, "upload-2"))
+	assert.Equal(t, 1, queue.GetQueuePosition("upload-3"))
+	assert.Error(t, queue.Remove("upload-2"))
+	assert.Error(t, queue.Remove("missing"))
+
+	queue.Clear()
+	assert.Equal(t, 0, queue.GetActiveCount())
+	assert.Equal(t, 0, queue.GetQueueLength())
+	assert.Equal(t, domain.UploadStatusCancelled, statusOf(t, db, "upload-1"))
+	assert.Equal(t, domain.UploadStatusCancelled, statusOf(t, db, "upload-3"))
+}
@@ -169,13 +169,25 @@ func TestTusQueue_Clear_ResetsQueueAndActiveUploads(t *testing.T) {
 func TestTusQueue_LoadFromDB_LoadsActiveThenQueueDeduplicated(t *testing.T) {
 	t.Parallel()
 	queue := NewTusQueue(2)
-	queue.LoadFromDB([]string{"u1", "u1", "u2", "u3", "u4"})
+	promoted := queue.LoadFromDB([]string{"u1", "u1", "u2", "u3", "u4"}, nil)
 
+	assert.Empty(t, promoted)
 	assert.ElementsMatch(t, []string{"u1", "u2"}, queue.GetActiveUploads())
 	assert.Equal(t, []string{"u3", "u4"}, queue.GetCurrentQueue())
 	assert.Equal(t, 2, queue.GetActiveCount())
 }
 
+func TestTusQueue_LoadFromDB_KeepsQueuedUploadsBehindActiveOnes(t *testing.T) {
+	t.Parallel()
+	queue := NewTusQueue(2)
+	// q1 was queued before a1 started, but only stored active uploads own a slot.
+	promoted := queue.LoadFromDB([]string{"a1"}, []string{"q1", "q2", "q3"})
+
+	assert.Equal(t, []string{"q1"}, promoted, "a queued upload taking a free slot must be promoted")
+	assert.ElementsMatch(t, []string{"a1", "q1"}, queue.GetActiveUploads())
+	assert.Equal(t, []string{"q2", "q3"}, queue.GetCurrentQueue())
+}
+
 func TestTusQueue_GetActiveCount_ReturnsCorrectCount(t *testing.T) {
 	t.Parallel()
 	queue := NewTusQueue(3)
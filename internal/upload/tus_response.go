@@ -8,8 +8,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SendTusInitiateResponse(c *fiber.Ctx, uploadID, uploadURL string, fileSize int64) error {
-	SetTusResponseHeaders(c, 0, fileSize)
+func SendTusInitiateResponse(c *fiber.Ctx, uploadID, uploadURL string, offset, fileSize int64) error {
+	SetTusResponseHeaders(c, offset, fileSize)
 	SetTusLocationHeader(c, uploadURL)
 
 	response := map[string]interface{}{
@@ -19,7 +19,7 @@ func SendTusInitiateResponse(c *fiber.Ctx, uploadID, uploadURL string, fileSize
 		"data": map[string]interface{}{
 			"upload_id":  uploadID,
 			"upload_url": uploadURL,
-			"offset":     0,
+			"offset":     offset,
 			"length":     fileSize,
 		},
 		"timestamp": time.Now(),
@@ -28,6 +28,54 @@ func SendTusInitiateResponse(c *fiber.Ctx, uploadID, uploadURL string, fileSize
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
+// SendTusQueuedResponse answers an initiate request whose upload is waiting for a free slot.
+// The client polls the upload info endpoint until the status turns pending before sending chunks;
+// each poll pushes back the Upload-Expires of the queued upload.
+func SendTusQueuedResponse(c *fiber.Ctx, uploadID, uploadURL string, fileSize int64, queuePosition int) error {
+	SetTusResponseHeaders(c, 0, fileSize)
+	SetTusLocationHeader(c, uploadURL)
+
+	response := map[string]interface{}{
+		"status":  "success",
+		"message": "Upload masuk antrian, menunggu slot upload tersedia",
+		"code":    fiber.StatusCreated,
+		"data": map[string]interface{}{
+			"upload_id":      uploadID,
+			"upload_url":     uploadURL,
+			"offset":         0,
+			"length":         fileSize,
+			"status":         "queued",
+			"queue_position": queuePosition,
+		},
+		"timestamp": time.Now(),
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// SendTusConcatenatedResponse answers a final Upload-Concat request. The partial uploads
+// are already joined, so the new upload is reported as fully written.
+func SendTusConcatenatedResponse(c *fiber.Ctx, uploadID, uploadURL string, fileSize int64) error {
+	SetTusResponseHeaders(c, fileSize, fileSize)
+	SetTusLocationHeader(c, uploadURL)
+
+	response := map[string]interface{}{
+		"status":  "success",
+		"message": "Partial upload berhasil digabungkan",
+		"code":    fiber.StatusCreated,
+		"data": map[string]interface{}{
+			"upload_id":  uploadID,
+			"upload_url": uploadURL,
+			"offset":     fileSize,
+			"length":     fileSize,
+			"status":     "completed",
+		},
+		"timestamp": time.Now(),
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
 func SendTusChunkResponse(c *fiber.Ctx, newOffset int64) error {
 	c.Set(HeaderTusResumable, TusVersion)
 	c.Set(HeaderUploadOffset, strconv.FormatInt(newOffset, 10))
@@ -35,10 +83,16 @@ func SendTusChunkResponse(c *fiber.Ctx, newOffset int64) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// SendTusHeadResponse reports the upload offset. A zero length means the client has not
+// declared the length yet, which is signalled with Upload-Defer-Length.
 func SendTusHeadResponse(c *fiber.Ctx, offset, length int64) error {
 	c.Set(HeaderTusResumable, TusVersion)
 	c.Set(HeaderUploadOffset, strconv.FormatInt(offset, 10))
-	c.Set(HeaderUploadLength, strconv.FormatInt(length, 10))
+	if length > 0 {
+		c.Set(HeaderUploadLength, strconv.FormatInt(length, 10))
+	} else {
+		c.Set(HeaderUploadDeferLength, "1")
+	}
 
 	return c.SendStatus(fiber.StatusOK)
 }
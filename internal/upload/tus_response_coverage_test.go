@@ -14,7 +14,7 @@ func TestSendTusInitiateResponse_Success(t *testing.T) {
 	app := fiber.New()
 
 	app.Post("/test", func(c *fiber.Ctx) error {
-		return SendTusInitiateResponse(c, "upload123", "http://example.com/upload/upload123", 1024000)
+		return SendTusInitiateResponse(c, "upload123", "http://example.com/upload/upload123", 0, 1024000)
 	})
 
 	req, _ := http.NewRequest("POST", "/test", http.NoBody)
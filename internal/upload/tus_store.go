@@ -1,19 +1,20 @@
 package upload
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"invento-service/internal/storage"
 	"io"
 	"os"
-	"path/filepath"
 	"sync"
 	"time"
 )
 
 type TusStore struct {
 	pathResolver *storage.PathResolver
+	backend      storage.Backend
 	maxFileSize  int64
 	locks        map[string]*lockEntry
 	locksMutex   sync.RWMutex
@@ -29,16 +30,25 @@ type TusFileInfo struct {
 	Size     int64             `json:"size"`
 	Offset   int64             `json:"offset"`
 	Metadata map[string]string `json:"metadata"`
+	// SizeIsDeferred marks an upload whose Size is still unknown; Size stays 0 until
+	// DeclareLength sets it.
+	SizeIsDeferred bool `json:"size_is_deferred,omitempty"`
 }
 
 func NewTusStore(pathResolver *storage.PathResolver, maxFileSize int64) *TusStore {
 	return &TusStore{
 		pathResolver: pathResolver,
+		backend:      storage.DefaultBackend(),
 		maxFileSize:  maxFileSize,
 		locks:        make(map[string]*lockEntry),
 	}
 }
 
+// SetBackend overrides the backend finished uploads are handed to.
+func (ts *TusStore) SetBackend(backend storage.Backend) {
+	ts.backend = backend
+}
+
 func (ts *TusStore) getLock(uploadID string) *sync.RWMutex {
 	ts.locksMutex.Lock()
 	defer ts.locksMutex.Unlock()
@@ -63,11 +73,15 @@ func (ts *TusStore) removeLock(uploadID string) {
 }
 
 func (ts *TusStore) NewUpload(info TusFileInfo) error {
-	if info.Size <= 0 {
-		return fmt.Errorf("ukuran file tidak valid: harus lebih dari 0 bytes")
-	}
-	if info.Size > ts.maxFileSize {
-		return fmt.Errorf("ukuran file melebihi batas maksimal %d bytes", ts.maxFileSize)
+	if info.SizeIsDeferred {
+		info.Size = 0
+	} else {
+		if info.Size <= 0 {
+			return fmt.Errorf("ukuran file tidak valid: harus lebih dari 0 bytes")
+		}
+		if info.Size > ts.maxFileSize {
+			return fmt.Errorf("ukuran file melebihi batas maksimal %d bytes", ts.maxFileSize)
+		}
 	}
 
 	uploadPath := ts.pathResolver.GetUploadPath(info.ID)
@@ -82,8 +96,11 @@ func (ts *TusStore) NewUpload(info TusFileInfo) error {
 	}
 	defer file.Close()
 
-	if err := file.Truncate(info.Size); err != nil {
-		return fmt.Errorf("gagal preallocate file: %w", err)
+	// Without a known length there is nothing to preallocate; the file grows per chunk.
+	if !info.SizeIsDeferred {
+		if err := file.Truncate(info.Size); err != nil {
+			return fmt.Errorf("gagal preallocate file: %w", err)
+		}
 	}
 
 	if err := ts.saveInfo(info); err != nil {
@@ -110,7 +127,21 @@ func (ts *TusStore) WriteChunk(uploadID string, offset int64, src io.Reader) (in
 		return offset, fmt.Errorf("gagal seek ke offset: %w", err)
 	}
 
+	info, err := ts.GetInfo(uploadID)
+	if err != nil {
+		return offset, fmt.Errorf("gagal membaca info: %w", err)
+	}
+	if info.SizeIsDeferred {
+		src = NewLengthLimitReader(src, ts.maxFileSize-offset)
+	}
+
 	bytesWritten, err := io.Copy(file, src)
+	if errors.Is(err, ErrChecksumMismatch) || errors.Is(err, ErrUploadLengthExceeded) {
+		if discardErr := discardFrom(file, offset, info); discardErr != nil {
+			return offset, fmt.Errorf("gagal membuang chunk yang ditolak: %w", discardErr)
+		}
+		return offset, err
+	}
 	if err != nil {
 		return offset + bytesWritten, fmt.Errorf("gagal menulis chunk: %w", err)
 	}
@@ -120,18 +151,54 @@ func (ts *TusStore) WriteChunk(uploadID string, offset int64, src io.Reader) (in
 	}
 
 	newOffset := offset + bytesWritten
+	info.Offset = newOffset
+	if err := ts.saveInfo(info); err != nil {
+		return newOffset, fmt.Errorf("gagal update offset: %w", err)
+	}
+
+	return newOffset, nil
+}
+
+// discardFrom drops the bytes of a rejected chunk written past offset. A preallocated
+// file is grown back to its full size, which leaves the discarded range zeroed.
+func discardFrom(file *os.File, offset int64, info TusFileInfo) error {
+	if err := file.Truncate(offset); err != nil {
+		return err
+	}
+	if !info.SizeIsDeferred {
+		return file.Truncate(info.Size)
+	}
+	return nil
+}
+
+// DeclareLength fixes the size of an upload created with a deferred length. Declaring
+// the size an upload already has is a no-op.
+func (ts *TusStore) DeclareLength(uploadID string, size int64) error {
+	lock := ts.getLock(uploadID)
+	lock.Lock()
+	defer lock.Unlock()
 
 	info, err := ts.GetInfo(uploadID)
 	if err != nil {
-		return newOffset, fmt.Errorf("gagal membaca info: %w", err)
+		return err
 	}
 
-	info.Offset = newOffset
-	if err := ts.saveInfo(info); err != nil {
-		return newOffset, fmt.Errorf("gagal update offset: %w", err)
+	if !info.SizeIsDeferred {
+		if info.Size != size {
+			return fmt.Errorf("ukuran upload sudah ditetapkan %d bytes", info.Size)
+		}
+		return nil
+	}
+	if size < info.Offset {
+		return fmt.Errorf("ukuran file tidak boleh lebih kecil dari data yang sudah diterima (%d bytes)", info.Offset)
+	}
+	if size > ts.maxFileSize {
+		return fmt.Errorf("ukuran file melebihi batas maksimal %d bytes", ts.maxFileSize)
 	}
 
-	return newOffset, nil
+	info.Size = size
+	info.SizeIsDeferred = false
+	return ts.saveInfo(info)
 }
 
 func (ts *TusStore) GetInfo(uploadID string) (TusFileInfo, error) {
@@ -164,11 +231,7 @@ func (ts *TusStore) FinalizeUpload(uploadID, finalPath string) error {
 		return fmt.Errorf("file temporary tidak ditemukan: %w", err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(finalPath), 0o755); err != nil {
-		return fmt.Errorf("gagal membuat direktori final: %w", err)
-	}
-
-	if err := storage.MoveFile(tempFilePath, finalPath); err != nil {
+	if err := storage.StoreFile(context.Background(), ts.backend, tempFilePath, finalPath); err != nil {
 		return fmt.Errorf("gagal memindahkan file: %w", err)
 	}
 
@@ -205,7 +268,7 @@ func (ts *TusStore) IsComplete(uploadID string) (bool, error) {
 		return false, err
 	}
 
-	return info.Offset >= info.Size, nil
+	return !info.SizeIsDeferred && info.Offset >= info.Size, nil
 }
 
 func (ts *TusStore) Terminate(uploadID string) error {
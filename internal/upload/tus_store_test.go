@@ -2,6 +2,7 @@ package upload
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"invento-service/config"
 	"invento-service/internal/storage"
@@ -180,6 +181,25 @@ func TestTusStore_FinalizeUpload_MovesToDestinationAndCleansTemp(t *testing.T) {
 	assert.Equal(t, 0, store.GetLockCount())
 }
 
+func TestTusStore_FinalizeUpload_StoresInBackend(t *testing.T) {
+	t.Parallel()
+	store := newTestTusStore(t, 1024)
+	backend := storage.NewMemoryBackend()
+	store.SetBackend(backend)
+	require.NoError(t, store.NewUpload(TusFileInfo{ID: "up7", Size: 8, Metadata: map[string]string{}}))
+	_, err := store.WriteChunk("up7", 0, bytes.NewBufferString("12345678"))
+	require.NoError(t, err)
+
+	finalPath := "/data/invento/projects/u1/abc/result.zip"
+	require.NoError(t, store.FinalizeUpload("up7", finalPath))
+
+	info, err := backend.Stat(context.Background(), finalPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+	_, err = os.Stat(store.pathResolver.GetUploadPath("up7"))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestTusStore_FinalizeUpload_MissingTemporaryFileReturnsError(t *testing.T) {
 	t.Parallel()
 	store := newTestTusStore(t, 1024)
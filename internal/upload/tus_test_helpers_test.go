@@ -15,12 +15,13 @@ import (
 
 // Mock repository for TusCleanup tests
 type MockTusUploadRepository struct {
-	uploads     map[string]domain.TusUpload
-	expired     []domain.TusUpload
-	active      []domain.TusUpload
-	updateError bool
-	deleteError bool
-	getError    bool
+	uploads        map[string]domain.TusUpload
+	expired        []domain.TusUpload
+	active         []domain.TusUpload
+	unusedPartials []domain.TusUpload
+	updateError    bool
+	deleteError    bool
+	getError       bool
 }
 
 func (m *MockTusUploadRepository) GetExpiredUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error) {
@@ -55,6 +56,25 @@ func (m *MockTusUploadRepository) UpdateStatus(ctx context.Context, id, status s
 	return nil
 }
 
+func (m *MockTusUploadRepository) Promote(ctx context.Context, id string, expiresAt time.Time) error {
+	if m.updateError {
+		return assert.AnError
+	}
+	if upload, ok := m.uploads[id]; ok {
+		upload.Status = domain.UploadStatusPending
+		upload.ExpiresAt = expiresAt
+		m.uploads[id] = upload
+	}
+	return nil
+}
+
+func (m *MockTusUploadRepository) GetUnusedPartialUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error) {
+	if m.getError {
+		return nil, assert.AnError
+	}
+	return m.unusedPartials, nil
+}
+
 func (m *MockTusUploadRepository) Delete(ctx context.Context, id string) error {
 	if m.deleteError {
 		return assert.AnError
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAssignmentRepository is a mock for AssignmentRepository
+type MockAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentRepository) Create(ctx context.Context, assignment *domain.Assignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentRepository) GetByID(ctx context.Context, id uint) (*domain.Assignment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Assignment), args.Error(1)
+}
+
+func (m *MockAssignmentRepository) GetByMember(ctx context.Context, userID string, classID uint, page, limit int) ([]dto.AssignmentListItem, int, error) {
+	args := m.Called(ctx, userID, classID, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]dto.AssignmentListItem), args.Int(1), args.Error(2)
+}
+
+func (m *MockAssignmentRepository) Update(ctx context.Context, assignment *domain.Assignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentRepository) SaveSubmission(ctx context.Context, submission *domain.AssignmentSubmission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentRepository) GetSubmission(ctx context.Context, assignmentID uint, userID string) (*domain.AssignmentSubmission, error) {
+	args := m.Called(ctx, assignmentID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AssignmentSubmission), args.Error(1)
+}
+
+func (m *MockAssignmentRepository) GetSubmissions(ctx context.Context, assignmentID uint) ([]domain.AssignmentSubmission, error) {
+	args := m.Called(ctx, assignmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AssignmentSubmission), args.Error(1)
+}
@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/httputil"
+	"invento-service/internal/storage"
+	"invento-service/internal/usecase/repo"
+	"path/filepath"
+	"strings"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+)
+
+type AssignmentUsecase interface {
+	Create(ctx context.Context, userID string, req dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error)
+	GetList(ctx context.Context, userID string, params dto.AssignmentListQueryParams) (*dto.AssignmentListData, error)
+	GetByID(ctx context.Context, id uint, userID string) (*dto.AssignmentResponse, error)
+	Update(ctx context.Context, id uint, userID string, req dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error)
+	Delete(ctx context.Context, id uint, userID string) error
+	GetSubmissions(ctx context.Context, id uint, userID string) (*dto.AssignmentSubmissionListData, error)
+	DownloadSubmissions(ctx context.Context, id uint, userID string) (*storage.Download, error)
+	// CheckSubmission and RecordSubmission are called by the project upload flow for
+	// uploads that carry an assignment ID: the first when the upload starts, the
+	// second once its project is stored.
+	CheckSubmission(ctx context.Context, assignmentID uint, userID string, fileSize int64) error
+	RecordSubmission(ctx context.Context, assignmentID uint, userID string, version *domain.ProjectVersion) error
+}
+
+type assignmentUsecase struct {
+	assignmentRepo repo.AssignmentRepository
+	classRepo      repo.ClassRepository
+}
+
+func NewAssignmentUsecase(assignmentRepo repo.AssignmentRepository, classRepo repo.ClassRepository) AssignmentUsecase {
+	return &assignmentUsecase{
+		assignmentRepo: assignmentRepo,
+		classRepo:      classRepo,
+	}
+}
+
+func (uc *assignmentUsecase) Create(ctx context.Context, userID string, req dto.CreateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	class, err := uc.classRepo.GetByID(ctx, req.ClassID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Kelas")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.Create: %w", err))
+	}
+	if err := uc.requireLecturer(ctx, class.ID, userID); err != nil {
+		return nil, err
+	}
+
+	openAt := time.Now()
+	if req.OpenAt != nil {
+		openAt = *req.OpenAt
+	}
+	if !req.DueAt.After(openAt) {
+		return nil, apperrors.NewValidationError("Batas waktu harus setelah waktu tugas dibuka", nil)
+	}
+
+	assignment := &domain.Assignment{
+		ClassID:           class.ID,
+		Judul:             req.Judul,
+		Deskripsi:         req.Deskripsi,
+		OpenAt:            openAt,
+		DueAt:             req.DueAt,
+		LateWindowMinutes: req.LateWindowMinutes,
+		MaxSize:           req.MaxSize,
+		CreatedBy:         userID,
+	}
+	if err := uc.assignmentRepo.Create(ctx, assignment); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.Create: %w", err))
+	}
+	assignment.Class = *class
+
+	return toAssignmentResponse(assignment, domain.ClassRoleDosen), nil
+}
+
+func (uc *assignmentUsecase) GetList(ctx context.Context, userID string, params dto.AssignmentListQueryParams) (*dto.AssignmentListData, error) {
+	paginationParams := httputil.NormalizePaginationParams(params.Page, params.Limit)
+
+	items, total, err := uc.assignmentRepo.GetByMember(ctx, userID, params.ClassID, paginationParams.Page, paginationParams.Limit)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.GetList: %w", err))
+	}
+
+	return &dto.AssignmentListData{
+		Items:      items,
+		Pagination: httputil.CalculatePagination(paginationParams.Page, paginationParams.Limit, total),
+	}, nil
+}
+
+// GetByID describes the assignment to a member of its class, including a mahasiswa's
+// own submission.
+func (uc *assignmentUsecase) GetByID(ctx context.Context, id uint, userID string) (*dto.AssignmentResponse, error) {
+	assignment, member, err := uc.getMemberAssignment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAssignmentResponse(assignment, member.Role)
+	if member.Role != domain.ClassRoleMahasiswa {
+		return response, nil
+	}
+
+	submission, err := uc.assignmentRepo.GetSubmission(ctx, assignment.ID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return response, nil
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.GetByID: %w", err))
+	}
+	item := toAssignmentSubmission(submission)
+	response.Pengumpulan = &item
+
+	return response, nil
+}
+
+// Update changes the assignment. Submissions already made keep the status they were
+// given when they came in.
+func (uc *assignmentUsecase) Update(ctx context.Context, id uint, userID string, req dto.UpdateAssignmentRequest) (*dto.AssignmentResponse, error) {
+	assignment, err := uc.getLecturedAssignment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.DueAt.After(req.OpenAt) {
+		return nil, apperrors.NewValidationError("Batas waktu harus setelah waktu tugas dibuka", nil)
+	}
+
+	assignment.Judul = req.Judul
+	assignment.Deskripsi = req.Deskripsi
+	assignment.OpenAt = req.OpenAt
+	assignment.DueAt = req.DueAt
+	assignment.LateWindowMinutes = req.LateWindowMinutes
+	assignment.MaxSize = req.MaxSize
+	if err := uc.assignmentRepo.Update(ctx, assignment); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.Update: %w", err))
+	}
+
+	return toAssignmentResponse(assignment, domain.ClassRoleDosen), nil
+}
+
+func (uc *assignmentUsecase) Delete(ctx context.Context, id uint, userID string) error {
+	assignment, err := uc.getLecturedAssignment(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.assignmentRepo.Delete(ctx, assignment.ID); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.Delete: %w", err))
+	}
+	return nil
+}
+
+func (uc *assignmentUsecase) GetSubmissions(ctx context.Context, id uint, userID string) (*dto.AssignmentSubmissionListData, error) {
+	assignment, err := uc.getLecturedAssignment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := uc.assignmentRepo.GetSubmissions(ctx, assignment.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.GetSubmissions: %w", err))
+	}
+	members, err := uc.classRepo.GetMembers(ctx, assignment.ClassID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.GetSubmissions: %w", err))
+	}
+
+	result := &dto.AssignmentSubmissionListData{
+		AssignmentID: assignment.ID,
+		Items:        make([]dto.AssignmentSubmission, 0, len(submissions)),
+	}
+	submitted := make(map[string]bool, len(submissions))
+	for i := range submissions {
+		submitted[submissions[i].UserID] = true
+		if submissions[i].Status == domain.SubmissionStatusLate {
+			result.Terlambat++
+		} else {
+			result.TepatWaktu++
+		}
+		result.Items = append(result.Items, toAssignmentSubmission(&submissions[i]))
+	}
+	for i := range members {
+		if members[i].Role != domain.ClassRoleMahasiswa {
+			continue
+		}
+		result.JumlahMahasiswa++
+		if !submitted[members[i].UserID] {
+			result.BelumMengumpul++
+		}
+	}
+
+	return result, nil
+}
+
+// DownloadSubmissions zips the submitted files, one folder per mahasiswa. Each
+// submission contributes the file it was made with, not the project's current one. A
+// submission whose project has since been deleted is listed as missing in the
+// archive manifest.
+func (uc *assignmentUsecase) DownloadSubmissions(ctx context.Context, id uint, userID string) (*storage.Download, error) {
+	assignment, err := uc.getLecturedAssignment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := uc.assignmentRepo.GetSubmissions(ctx, assignment.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.DownloadSubmissions: %w", err))
+	}
+
+	entries := make([]storage.ArchiveEntry, 0, len(submissions))
+	var missing []string
+	for i := range submissions {
+		submission := &submissions[i]
+		project := submission.Project
+		if project.ID == 0 || project.PathFile == "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", submission.User.Name, submission.User.Email))
+			continue
+		}
+
+		pathFile := submission.PathFile
+		if pathFile == "" {
+			// Submissions recorded before the file was kept only know the project.
+			pathFile = project.PathFile
+		}
+		cleanPath := filepath.Clean(pathFile)
+		if strings.Contains(cleanPath, "..") {
+			return nil, apperrors.NewValidationError("path file tidak valid", nil)
+		}
+
+		project.User = submission.User
+		entry := storage.ProjectArchiveEntry(&project, cleanPath)
+		entry.Title = submission.User.Name + " - " + project.NamaProject
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, apperrors.NewNotFoundError("Pengumpulan tugas")
+	}
+
+	fileName := storage.SanitizeArchiveName(assignment.Judul)
+	if fileName == "" {
+		fileName = fmt.Sprintf("tugas-%d", assignment.ID)
+	}
+	download, err := storage.NewArchiveDownload(ctx, storage.DefaultBackend(), fileName+".zip", entries, missing)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrArchiveTooLarge):
+			return nil, apperrors.NewPayloadTooLargeError("ukuran total pengumpulan melebihi batas download")
+		case errors.Is(err, storage.ErrObjectNotFound):
+			return nil, apperrors.NewNotFoundError("file project")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.DownloadSubmissions: %w", err))
+	}
+
+	return download, nil
+}
+
+// CheckSubmission admits an upload of fileSize bytes as userID's submission. A
+// length-deferred upload is refused when the assignment limits the file size, since
+// the limit could not be checked until the upload ends.
+func (uc *assignmentUsecase) CheckSubmission(ctx context.Context, assignmentID uint, userID string, fileSize int64) error {
+	assignment, member, err := uc.getMemberAssignment(ctx, assignmentID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role != domain.ClassRoleMahasiswa {
+		return apperrors.NewForbiddenError("Hanya mahasiswa kelas yang dapat mengumpulkan tugas")
+	}
+
+	now := time.Now()
+	if now.Before(assignment.OpenAt) {
+		return apperrors.NewValidationError("Tugas belum dibuka untuk pengumpulan", nil)
+	}
+	if now.After(assignment.ClosesAt()) {
+		return apperrors.NewValidationError("Batas waktu pengumpulan tugas sudah lewat", nil)
+	}
+
+	if assignment.MaxSize > 0 {
+		if fileSize <= 0 {
+			return apperrors.NewValidationError("Ukuran file wajib diketahui untuk pengumpulan tugas ini", nil)
+		}
+		if fileSize > assignment.MaxSize {
+			return apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas tugas %d MB", assignment.MaxSize/(1024*1024)))
+		}
+	}
+	return nil
+}
+
+// RecordSubmission stores the project file in version as userID's submission. Its
+// status is taken from when the upload finished, so an upload admitted within the
+// late window that ends after it still counts, as late.
+func (uc *assignmentUsecase) RecordSubmission(ctx context.Context, assignmentID uint, userID string, version *domain.ProjectVersion) error {
+	assignment, err := uc.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return fmt.Errorf("AssignmentUsecase.RecordSubmission: %w", err)
+	}
+
+	now := time.Now()
+	submission := &domain.AssignmentSubmission{
+		AssignmentID: assignment.ID,
+		UserID:       userID,
+		ProjectID:    version.ProjectID,
+		Status:       assignment.SubmissionStatus(now),
+		PathFile:     version.PathFile,
+		Checksum:     version.Checksum,
+		FileSize:     version.FileSize,
+		SubmittedAt:  now,
+	}
+	if err := uc.assignmentRepo.SaveSubmission(ctx, submission); err != nil {
+		return fmt.Errorf("AssignmentUsecase.RecordSubmission: %w", err)
+	}
+	return nil
+}
+
+func (uc *assignmentUsecase) getMemberAssignment(ctx context.Context, id uint, userID string) (*domain.Assignment, *domain.ClassMember, error) {
+	assignment, err := uc.assignmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewNotFoundError("Tugas")
+		}
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.getMemberAssignment: %w", err))
+	}
+
+	member, err := uc.classRepo.GetMember(ctx, assignment.ClassID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewForbiddenError("Anda bukan anggota kelas tugas ini")
+		}
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.getMemberAssignment: %w", err))
+	}
+	return assignment, member, nil
+}
+
+func (uc *assignmentUsecase) getLecturedAssignment(ctx context.Context, id uint, userID string) (*domain.Assignment, error) {
+	assignment, member, err := uc.getMemberAssignment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != domain.ClassRoleDosen {
+		return nil, apperrors.NewForbiddenError("Hanya dosen kelas yang dapat mengelola tugas")
+	}
+	return assignment, nil
+}
+
+func (uc *assignmentUsecase) requireLecturer(ctx context.Context, classID uint, userID string) error {
+	member, err := uc.classRepo.GetMember(ctx, classID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return apperrors.NewForbiddenError("Hanya dosen kelas yang dapat mengelola tugas")
+		}
+		return apperrors.NewInternalError(fmt.Errorf("AssignmentUsecase.requireLecturer: %w", err))
+	}
+	if member.Role != domain.ClassRoleDosen {
+		return apperrors.NewForbiddenError("Hanya dosen kelas yang dapat mengelola tugas")
+	}
+	return nil
+}
+
+func toAssignmentResponse(assignment *domain.Assignment, role string) *dto.AssignmentResponse {
+	return &dto.AssignmentResponse{
+		ID:                assignment.ID,
+		ClassID:           assignment.ClassID,
+		NamaKelas:         assignment.Class.Nama,
+		Judul:             assignment.Judul,
+		Deskripsi:         assignment.Deskripsi,
+		OpenAt:            assignment.OpenAt,
+		DueAt:             assignment.DueAt,
+		LateWindowMinutes: assignment.LateWindowMinutes,
+		ClosesAt:          assignment.ClosesAt(),
+		MaxSize:           assignment.MaxSize,
+		Peran:             role,
+		CreatedAt:         assignment.CreatedAt,
+		UpdatedAt:         assignment.UpdatedAt,
+	}
+}
+
+func toAssignmentSubmission(submission *domain.AssignmentSubmission) dto.AssignmentSubmission {
+	return dto.AssignmentSubmission{
+		UserID:      submission.UserID,
+		Name:        submission.User.Name,
+		Email:       submission.User.Email,
+		ProjectID:   submission.ProjectID,
+		NamaProject: submission.Project.NamaProject,
+		Status:      submission.Status,
+		FileSize:    submission.FileSize,
+		SubmittedAt: submission.SubmittedAt,
+	}
+}
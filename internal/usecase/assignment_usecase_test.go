@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectAssignment sets up assignment 1 of class 1, with userID a member in role.
+func expectAssignment(assignmentRepo *MockAssignmentRepository, classRepo *MockClassRepository, assignment *domain.Assignment, userID, role string) {
+	assignment.ID = 1
+	assignment.ClassID = 1
+	assignmentRepo.On("GetByID", mock.Anything, uint(1)).Return(assignment, nil)
+	classRepo.On("GetMember", mock.Anything, uint(1), userID).Return(&domain.ClassMember{ClassID: 1, UserID: userID, Role: role}, nil)
+}
+
+func TestAssignmentUsecase_Create(t *testing.T) {
+	t.Parallel()
+	dueAt := time.Now().Add(7 * 24 * time.Hour)
+
+	t.Run("lecturer sets an assignment", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		assignmentUc := mocks.assignmentUsecase()
+		expectLecturer(mocks.class, classTestLecturerID)
+		mocks.assignment.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.Assignment) bool {
+			return a.ClassID == 1 && a.CreatedBy == classTestLecturerID && !a.OpenAt.IsZero()
+		})).Return(nil)
+
+		result, err := assignmentUc.Create(context.Background(), classTestLecturerID, dto.CreateAssignmentRequest{
+			ClassID: 1, Judul: "Tugas ERD", DueAt: dueAt, LateWindowMinutes: 60,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, dueAt.Add(time.Hour), result.ClosesAt)
+		assert.Equal(t, domain.ClassRoleDosen, result.Peran)
+		mocks.assignment.AssertExpectations(t)
+	})
+
+	t.Run("mahasiswa cannot set one", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		assignmentUc := mocks.assignmentUsecase()
+		mocks.class.On("GetByID", mock.Anything, uint(1)).Return(&domain.Class{ID: 1}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), classTestStudentID).
+			Return(&domain.ClassMember{ClassID: 1, UserID: classTestStudentID, Role: domain.ClassRoleMahasiswa}, nil)
+
+		_, err := assignmentUc.Create(context.Background(), classTestStudentID, dto.CreateAssignmentRequest{ClassID: 1, Judul: "Tugas ERD", DueAt: dueAt})
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("due date before opening", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		assignmentUc := mocks.assignmentUsecase()
+		expectLecturer(mocks.class, classTestLecturerID)
+		openAt := dueAt.Add(time.Hour)
+
+		_, err := assignmentUc.Create(context.Background(), classTestLecturerID, dto.CreateAssignmentRequest{
+			ClassID: 1, Judul: "Tugas ERD", OpenAt: &openAt, DueAt: dueAt,
+		})
+		requireClassAppError(t, err, apperrors.ErrValidation)
+	})
+}
+
+func TestAssignmentUsecase_CheckSubmission(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		assignment domain.Assignment
+		role       string
+		fileSize   int64
+		code       string
+	}{
+		{
+			name:       "open",
+			assignment: domain.Assignment{OpenAt: now.Add(-time.Hour), DueAt: now.Add(time.Hour), MaxSize: 2048},
+			role:       domain.ClassRoleMahasiswa,
+			fileSize:   1024,
+		},
+		{
+			name:       "within late window",
+			assignment: domain.Assignment{OpenAt: now.Add(-2 * time.Hour), DueAt: now.Add(-time.Hour), LateWindowMinutes: 120},
+			role:       domain.ClassRoleMahasiswa,
+			fileSize:   1024,
+		},
+		{
+			name:       "not yet open",
+			assignment: domain.Assignment{OpenAt: now.Add(time.Hour), DueAt: now.Add(2 * time.Hour)},
+			role:       domain.ClassRoleMahasiswa,
+			fileSize:   1024,
+			code:       apperrors.ErrValidation,
+		},
+		{
+			name:       "closed",
+			assignment: domain.Assignment{OpenAt: now.Add(-2 * time.Hour), DueAt: now.Add(-time.Hour), LateWindowMinutes: 30},
+			role:       domain.ClassRoleMahasiswa,
+			fileSize:   1024,
+			code:       apperrors.ErrValidation,
+		},
+		{
+			name:       "too large",
+			assignment: domain.Assignment{OpenAt: now.Add(-time.Hour), DueAt: now.Add(time.Hour), MaxSize: 1024},
+			role:       domain.ClassRoleMahasiswa,
+			fileSize:   2048,
+			code:       apperrors.ErrPayloadTooLarge,
+		},
+		{
+			name:       "deferred length with size limit",
+			assignment: domain.Assignment{OpenAt: now.Add(-time.Hour), DueAt: now.Add(time.Hour), MaxSize: 1024},
+			role:       domain.ClassRoleMahasiswa,
+			fileSize:   -1,
+			code:       apperrors.ErrValidation,
+		},
+		{
+			name:       "lecturer",
+			assignment: domain.Assignment{OpenAt: now.Add(-time.Hour), DueAt: now.Add(time.Hour)},
+			role:       domain.ClassRoleDosen,
+			fileSize:   1024,
+			code:       apperrors.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mocks := newRepoMocks()
+			assignmentUc := mocks.assignmentUsecase()
+			assignment := tt.assignment
+			expectAssignment(mocks.assignment, mocks.class, &assignment, classTestStudentID, tt.role)
+
+			err := assignmentUc.CheckSubmission(context.Background(), 1, classTestStudentID, tt.fileSize)
+			if tt.code == "" {
+				require.NoError(t, err)
+				return
+			}
+			requireClassAppError(t, err, tt.code)
+		})
+	}
+
+	t.Run("not enrolled", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		assignmentUc := mocks.assignmentUsecase()
+		mocks.assignment.On("GetByID", mock.Anything, uint(1)).Return(&domain.Assignment{ID: 1, ClassID: 1}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), classTestStudentID).Return(nil, apperrors.ErrRecordNotFound)
+
+		err := assignmentUc.CheckSubmission(context.Background(), 1, classTestStudentID, 1024)
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+	})
+}
+
+func TestAssignmentUsecase_RecordSubmission(t *testing.T) {
+	t.Parallel()
+
+	for name, dueAt := range map[string]time.Time{
+		domain.SubmissionStatusOnTime: time.Now().Add(time.Hour),
+		domain.SubmissionStatusLate:   time.Now().Add(-time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mocks := newRepoMocks()
+			assignmentUc := mocks.assignmentUsecase()
+			mocks.assignment.On("GetByID", mock.Anything, uint(1)).Return(&domain.Assignment{ID: 1, DueAt: dueAt, LateWindowMinutes: 60}, nil)
+			mocks.assignment.On("SaveSubmission", mock.Anything, mock.MatchedBy(func(s *domain.AssignmentSubmission) bool {
+				return s.AssignmentID == 1 && s.UserID == classTestStudentID && s.ProjectID == 9 && s.Status == name &&
+					s.PathFile == "/tmp/v2.zip" && s.Checksum == "abc123" && s.FileSize == 1024
+			})).Return(nil)
+
+			version := &domain.ProjectVersion{ProjectID: 9, PathFile: "/tmp/v2.zip", Checksum: "abc123", FileSize: 1024}
+			require.NoError(t, assignmentUc.RecordSubmission(context.Background(), 1, classTestStudentID, version))
+			mocks.assignment.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssignmentUsecase_GetSubmissions(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	assignmentUc := mocks.assignmentUsecase()
+	expectAssignment(mocks.assignment, mocks.class, &domain.Assignment{}, classTestLecturerID, domain.ClassRoleDosen)
+	mocks.assignment.On("GetSubmissions", mock.Anything, uint(1)).Return([]domain.AssignmentSubmission{
+		{UserID: "mhs-1", ProjectID: 3, Status: domain.SubmissionStatusOnTime, User: domain.User{Name: "Ani"}, Project: domain.Project{ID: 3, NamaProject: "ERD"}},
+		{UserID: "mhs-2", ProjectID: 4, Status: domain.SubmissionStatusLate, User: domain.User{Name: "Budi"}, Project: domain.Project{ID: 4, NamaProject: "ERD"}},
+	}, nil)
+	mocks.class.On("GetMembers", mock.Anything, uint(1)).Return([]domain.ClassMember{
+		{UserID: classTestLecturerID, Role: domain.ClassRoleDosen},
+		{UserID: "mhs-1", Role: domain.ClassRoleMahasiswa},
+		{UserID: "mhs-2", Role: domain.ClassRoleMahasiswa},
+		{UserID: "mhs-3", Role: domain.ClassRoleMahasiswa},
+	}, nil)
+
+	result, err := assignmentUc.GetSubmissions(context.Background(), 1, classTestLecturerID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.JumlahMahasiswa)
+	assert.Equal(t, 1, result.TepatWaktu)
+	assert.Equal(t, 1, result.Terlambat)
+	assert.Equal(t, 1, result.BelumMengumpul)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, "Ani", result.Items[0].Name)
+
+	t.Run("mahasiswa cannot list", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		assignmentUc := mocks.assignmentUsecase()
+		expectAssignment(mocks.assignment, mocks.class, &domain.Assignment{}, classTestStudentID, domain.ClassRoleMahasiswa)
+
+		_, err := assignmentUc.GetSubmissions(context.Background(), 1, classTestStudentID)
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+	})
+}
+
+func TestAssignmentUsecase_DownloadSubmissions(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	assignmentUc := mocks.assignmentUsecase()
+	expectAssignment(mocks.assignment, mocks.class, &domain.Assignment{Judul: "Tugas ERD"}, classTestLecturerID, domain.ClassRoleDosen)
+
+	dir := t.TempDir()
+	submitted := filepath.Join(dir, "submitted.zip")
+	require.NoError(t, os.WriteFile(submitted, []byte("erd"), 0o644))
+	current := filepath.Join(dir, "current.zip")
+	require.NoError(t, os.WriteFile(current, []byte("erd revisi"), 0o644))
+	// Ani updated the project after submitting; the lecturer still gets the submitted file.
+	mocks.assignment.On("GetSubmissions", mock.Anything, uint(1)).Return([]domain.AssignmentSubmission{
+		{UserID: "mhs-1", ProjectID: 3, PathFile: submitted, User: domain.User{Name: "Ani", Email: "ani@student.polije.ac.id"},
+			Project: domain.Project{ID: 3, UserID: "mhs-1", NamaProject: "ERD Perpustakaan", PathFile: current}},
+		{UserID: "mhs-2", ProjectID: 4, User: domain.User{Name: "Budi", Email: "budi@student.polije.ac.id"}},
+	}, nil)
+
+	download, err := assignmentUc.DownloadSubmissions(context.Background(), 1, classTestLecturerID)
+	require.NoError(t, err)
+	assert.Equal(t, "Tugas ERD.zip", download.FileName)
+
+	var buf bytes.Buffer
+	require.NoError(t, download.WriteArchive(context.Background(), &buf))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 2)
+	assert.Equal(t, "projects/Ani - ERD Perpustakaan/submitted.zip", reader.File[0].Name)
+	submittedFile, err := reader.File[0].Open()
+	require.NoError(t, err)
+	defer submittedFile.Close()
+	content, err := io.ReadAll(submittedFile)
+	require.NoError(t, err)
+	assert.Equal(t, "erd", string(content))
+
+	manifestFile, err := reader.File[1].Open()
+	require.NoError(t, err)
+	defer manifestFile.Close()
+	content, err = io.ReadAll(manifestFile)
+	require.NoError(t, err)
+	var manifest storage.ArchiveManifest
+	require.NoError(t, json.Unmarshal(content, &manifest))
+	assert.Equal(t, []string{"Budi (budi@student.polije.ac.id)"}, manifest.Missing)
+	assert.Equal(t, "Ani", manifest.Files[0].OwnerName)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClassRepository is a mock for ClassRepository
+type MockClassRepository struct {
+	mock.Mock
+}
+
+func (m *MockClassRepository) Create(ctx context.Context, class *domain.Class, lecturerIDs []string) error {
+	args := m.Called(ctx, class, lecturerIDs)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) GetByID(ctx context.Context, id uint) (*domain.Class, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Class), args.Error(1)
+}
+
+func (m *MockClassRepository) GetByKode(ctx context.Context, kode, periode string) (*domain.Class, error) {
+	args := m.Called(ctx, kode, periode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Class), args.Error(1)
+}
+
+func (m *MockClassRepository) GetByJoinCode(ctx context.Context, joinCode string) (*domain.Class, error) {
+	args := m.Called(ctx, joinCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Class), args.Error(1)
+}
+
+func (m *MockClassRepository) GetByMember(ctx context.Context, userID, search, periode string, page, limit int) ([]dto.ClassListItem, int, error) {
+	args := m.Called(ctx, userID, search, periode, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]dto.ClassListItem), args.Int(1), args.Error(2)
+}
+
+func (m *MockClassRepository) Update(ctx context.Context, class *domain.Class) error {
+	args := m.Called(ctx, class)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) UpdateJoinCode(ctx context.Context, id uint, joinCode string) error {
+	args := m.Called(ctx, id, joinCode)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) ReplaceLecturers(ctx context.Context, classID uint, lecturerIDs []string) error {
+	args := m.Called(ctx, classID, lecturerIDs)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) GetMembers(ctx context.Context, classID uint) ([]domain.ClassMember, error) {
+	args := m.Called(ctx, classID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ClassMember), args.Error(1)
+}
+
+func (m *MockClassRepository) GetMember(ctx context.Context, classID uint, userID string) (*domain.ClassMember, error) {
+	args := m.Called(ctx, classID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ClassMember), args.Error(1)
+}
+
+func (m *MockClassRepository) AddMembers(ctx context.Context, members []domain.ClassMember) error {
+	args := m.Called(ctx, members)
+	return args.Error(0)
+}
+
+func (m *MockClassRepository) RemoveMember(ctx context.Context, classID uint, userID string) error {
+	args := m.Called(ctx, classID, userID)
+	return args.Error(0)
+}
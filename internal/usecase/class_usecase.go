@@ -0,0 +1,508 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/helper"
+	"invento-service/internal/httputil"
+	"invento-service/internal/usecase/repo"
+	"slices"
+	"strings"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+type ClassUsecase interface {
+	Create(ctx context.Context, userID string, req dto.CreateClassRequest) (*dto.ClassResponse, error)
+	GetList(ctx context.Context, userID string, params dto.ClassListQueryParams) (*dto.ClassListData, error)
+	GetByID(ctx context.Context, id uint, userID string) (*dto.ClassResponse, error)
+	Update(ctx context.Context, id uint, userID string, req dto.UpdateClassRequest) (*dto.ClassResponse, error)
+	Delete(ctx context.Context, id uint, userID string) error
+	RegenerateJoinCode(ctx context.Context, id uint, userID string) (*dto.ClassJoinCodeData, error)
+	GetMembers(ctx context.Context, id uint, userID string) ([]dto.ClassMemberItem, error)
+	AddMembers(ctx context.Context, id uint, userID string, req dto.AddClassMembersRequest) (*dto.ImportReport, error)
+	ImportMembers(ctx context.Context, id uint, userID string, file *excelize.File) (*dto.ImportReport, error)
+	RemoveMember(ctx context.Context, id uint, userID, memberID string) error
+	Join(ctx context.Context, userID string, req dto.JoinClassRequest) (*dto.ClassResponse, error)
+}
+
+type classUsecase struct {
+	classRepo   repo.ClassRepository
+	userRepo    repo.UserRepository
+	excelHelper *helper.ExcelHelper
+}
+
+func NewClassUsecase(classRepo repo.ClassRepository, userRepo repo.UserRepository, excelHelper *helper.ExcelHelper) ClassUsecase {
+	return &classUsecase{
+		classRepo:   classRepo,
+		userRepo:    userRepo,
+		excelHelper: excelHelper,
+	}
+}
+
+func (uc *classUsecase) Create(ctx context.Context, userID string, req dto.CreateClassRequest) (*dto.ClassResponse, error) {
+	if err := uc.ensureKodeAvailable(ctx, req.Kode, req.Periode, 0); err != nil {
+		return nil, err
+	}
+
+	lecturerIDs := []string{userID}
+	for _, id := range req.DosenIDs {
+		if !slices.Contains(lecturerIDs, id) {
+			lecturerIDs = append(lecturerIDs, id)
+		}
+	}
+	if err := uc.validateLecturers(ctx, lecturerIDs, userID); err != nil {
+		return nil, err
+	}
+
+	joinCode, err := generateJoinCode()
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Create: %w", err))
+	}
+
+	class := &domain.Class{
+		Kode:      req.Kode,
+		Nama:      req.Nama,
+		Periode:   req.Periode,
+		Deskripsi: req.Deskripsi,
+		JoinCode:  joinCode,
+		CreatedBy: userID,
+	}
+	if err := uc.classRepo.Create(ctx, class, lecturerIDs); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Create: %w", err))
+	}
+
+	return uc.buildClassResponse(ctx, class, domain.ClassRoleDosen)
+}
+
+func (uc *classUsecase) GetList(ctx context.Context, userID string, params dto.ClassListQueryParams) (*dto.ClassListData, error) {
+	paginationParams := httputil.NormalizePaginationParams(params.Page, params.Limit)
+
+	items, total, err := uc.classRepo.GetByMember(ctx, userID, params.Search, params.Periode, paginationParams.Page, paginationParams.Limit)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.GetList: %w", err))
+	}
+
+	return &dto.ClassListData{
+		Items:      items,
+		Pagination: httputil.CalculatePagination(paginationParams.Page, paginationParams.Limit, total),
+	}, nil
+}
+
+func (uc *classUsecase) GetByID(ctx context.Context, id uint, userID string) (*dto.ClassResponse, error) {
+	class, member, err := uc.getMemberClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildClassResponse(ctx, class, member.Role)
+}
+
+// Update changes the class details and, when req.DosenIDs is given, who teaches it.
+func (uc *classUsecase) Update(ctx context.Context, id uint, userID string, req dto.UpdateClassRequest) (*dto.ClassResponse, error) {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(class.Kode, req.Kode) || class.Periode != req.Periode {
+		if err := uc.ensureKodeAvailable(ctx, req.Kode, req.Periode, class.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var lecturerIDs []string
+	if len(req.DosenIDs) > 0 {
+		lecturerIDs = slices.Compact(slices.Sorted(slices.Values(req.DosenIDs)))
+		if err := uc.validateLecturers(ctx, lecturerIDs, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	class.Kode = req.Kode
+	class.Nama = req.Nama
+	class.Periode = req.Periode
+	class.Deskripsi = req.Deskripsi
+	if err := uc.classRepo.Update(ctx, class); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Update: %w", err))
+	}
+
+	role := domain.ClassRoleDosen
+	if lecturerIDs != nil {
+		if err := uc.classRepo.ReplaceLecturers(ctx, class.ID, lecturerIDs); err != nil {
+			return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Update: %w", err))
+		}
+		// A lecturer may hand the class over; they keep no role in it afterwards.
+		if !slices.Contains(lecturerIDs, userID) {
+			role = ""
+		}
+	}
+
+	return uc.buildClassResponse(ctx, class, role)
+}
+
+func (uc *classUsecase) Delete(ctx context.Context, id uint, userID string) error {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.classRepo.Delete(ctx, class.ID); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Delete: %w", err))
+	}
+	return nil
+}
+
+// RegenerateJoinCode replaces the join code so the previous one stops working.
+func (uc *classUsecase) RegenerateJoinCode(ctx context.Context, id uint, userID string) (*dto.ClassJoinCodeData, error) {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	joinCode, err := generateJoinCode()
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.RegenerateJoinCode: %w", err))
+	}
+	if err := uc.classRepo.UpdateJoinCode(ctx, class.ID, joinCode); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.RegenerateJoinCode: %w", err))
+	}
+
+	return &dto.ClassJoinCodeData{ClassID: class.ID, JoinCode: joinCode}, nil
+}
+
+func (uc *classUsecase) GetMembers(ctx context.Context, id uint, userID string) ([]dto.ClassMemberItem, error) {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := uc.classRepo.GetMembers(ctx, class.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.GetMembers: %w", err))
+	}
+
+	items := make([]dto.ClassMemberItem, 0, len(members))
+	for i := range members {
+		items = append(items, dto.ClassMemberItem{
+			UserID:    members[i].UserID,
+			Name:      members[i].User.Name,
+			Email:     members[i].User.Email,
+			Peran:     members[i].Role,
+			JoinedVia: members[i].JoinedVia,
+			JoinedAt:  members[i].CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+// AddMembers enrolls the mahasiswa registered under req.Emails. Emails that cannot be
+// enrolled are reported as skipped rather than failing the whole request.
+func (uc *classUsecase) AddMembers(ctx context.Context, id uint, userID string, req dto.AddClassMembersRequest) (*dto.ImportReport, error) {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]dto.ImportClassMemberRow, 0, len(req.Emails))
+	for i, email := range req.Emails {
+		rows = append(rows, dto.ImportClassMemberRow{RowNumber: i + 1, Email: strings.TrimSpace(email)})
+	}
+	return uc.enroll(ctx, class.ID, rows, domain.ClassJoinedManual)
+}
+
+// ImportMembers enrolls the mahasiswa listed in an enrollment template.
+func (uc *classUsecase) ImportMembers(ctx context.Context, id uint, userID string, file *excelize.File) (*dto.ImportReport, error) {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := uc.excelHelper.ParseClassEnrollmentFile(file)
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error(), err)
+	}
+	return uc.enroll(ctx, class.ID, rows, domain.ClassJoinedImport)
+}
+
+// RemoveMember unenrolls a mahasiswa. Lecturers are changed through Update instead.
+func (uc *classUsecase) RemoveMember(ctx context.Context, id uint, userID, memberID string) error {
+	class, err := uc.getLecturedClass(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	member, err := uc.classRepo.GetMember(ctx, class.ID, memberID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return apperrors.NewNotFoundError("Anggota kelas")
+		}
+		return apperrors.NewInternalError(fmt.Errorf("ClassUsecase.RemoveMember: %w", err))
+	}
+	if member.Role == domain.ClassRoleDosen {
+		return apperrors.NewValidationError("Dosen kelas diubah melalui pembaruan data kelas", nil)
+	}
+
+	if err := uc.classRepo.RemoveMember(ctx, class.ID, memberID); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("ClassUsecase.RemoveMember: %w", err))
+	}
+	return nil
+}
+
+// Join enrolls userID in the class whose join code is req.Code.
+func (uc *classUsecase) Join(ctx context.Context, userID string, req dto.JoinClassRequest) (*dto.ClassResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("User")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Join: %w", err))
+	}
+	if user.Role == nil || !strings.EqualFold(user.Role.NamaRole, domain.ClassRoleMahasiswa) {
+		return nil, apperrors.NewForbiddenError("Hanya mahasiswa yang dapat bergabung ke kelas")
+	}
+
+	class, err := uc.classRepo.GetByJoinCode(ctx, strings.ToUpper(strings.TrimSpace(req.Code)))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Kelas")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Join: %w", err))
+	}
+
+	_, err = uc.classRepo.GetMember(ctx, class.ID, userID)
+	if err == nil {
+		return nil, apperrors.NewConflictError("Anda sudah terdaftar di kelas ini")
+	}
+	if !errors.Is(err, apperrors.ErrRecordNotFound) {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Join: %w", err))
+	}
+
+	if err := uc.classRepo.AddMembers(ctx, []domain.ClassMember{{
+		ClassID:   class.ID,
+		UserID:    userID,
+		Role:      domain.ClassRoleMahasiswa,
+		JoinedVia: domain.ClassJoinedJoinCode,
+	}}); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.Join: %w", err))
+	}
+
+	return uc.buildClassResponse(ctx, class, domain.ClassRoleMahasiswa)
+}
+
+// enroll adds the registered mahasiswa among rows to the class, reporting every row
+// that was skipped together with the reason.
+func (uc *classUsecase) enroll(ctx context.Context, classID uint, rows []dto.ImportClassMemberRow, via string) (*dto.ImportReport, error) {
+	report := &dto.ImportReport{
+		TotalBaris: len(rows),
+		Detail:     make([]dto.ImportReportRow, 0, len(rows)),
+	}
+	if len(rows) == 0 {
+		return report, nil
+	}
+
+	emails := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Email != "" {
+			emails = append(emails, strings.ToLower(row.Email))
+		}
+	}
+	users, err := uc.userRepo.FindByEmails(ctx, emails)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.enroll: %w", err))
+	}
+	usersByEmail := make(map[string]domain.User, len(users))
+	for _, user := range users {
+		usersByEmail[strings.ToLower(user.Email)] = user
+	}
+
+	members, err := uc.classRepo.GetMembers(ctx, classID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.enroll: %w", err))
+	}
+	enrolled := make(map[string]bool, len(members))
+	for i := range members {
+		enrolled[members[i].UserID] = true
+	}
+
+	skip := func(row dto.ImportClassMemberRow, reason string) {
+		report.Detail = append(report.Detail, dto.ImportReportRow{
+			Baris:  row.RowNumber,
+			Email:  row.Email,
+			Nama:   row.Nama,
+			Status: "dilewati",
+			Alasan: reason,
+		})
+		report.Dilewati++
+	}
+
+	var newMembers []domain.ClassMember
+	for _, row := range rows {
+		if row.Email == "" {
+			skip(row, "Email wajib diisi")
+			continue
+		}
+		if !emailRegex.MatchString(row.Email) {
+			skip(row, "Format email tidak valid")
+			continue
+		}
+
+		user, ok := usersByEmail[strings.ToLower(row.Email)]
+		switch {
+		case !ok:
+			skip(row, "Email belum terdaftar")
+			continue
+		case user.Role == nil || !strings.EqualFold(user.Role.NamaRole, domain.ClassRoleMahasiswa):
+			skip(row, "User bukan mahasiswa")
+			continue
+		case enrolled[user.ID]:
+			skip(row, "Sudah terdaftar di kelas ini")
+			continue
+		}
+
+		enrolled[user.ID] = true
+		newMembers = append(newMembers, domain.ClassMember{
+			ClassID:   classID,
+			UserID:    user.ID,
+			Role:      domain.ClassRoleMahasiswa,
+			JoinedVia: via,
+		})
+		report.Detail = append(report.Detail, dto.ImportReportRow{
+			Baris:  row.RowNumber,
+			Email:  row.Email,
+			Nama:   user.Name,
+			Status: "berhasil",
+		})
+		report.Berhasil++
+	}
+
+	if err := uc.classRepo.AddMembers(ctx, newMembers); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.enroll: %w", err))
+	}
+	return report, nil
+}
+
+func (uc *classUsecase) getMemberClass(ctx context.Context, id uint, userID string) (*domain.Class, *domain.ClassMember, error) {
+	class, err := uc.classRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewNotFoundError("Kelas")
+		}
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.getMemberClass: %w", err))
+	}
+
+	member, err := uc.classRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewForbiddenError("Anda bukan anggota kelas ini")
+		}
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.getMemberClass: %w", err))
+	}
+	return class, member, nil
+}
+
+func (uc *classUsecase) getLecturedClass(ctx context.Context, id uint, userID string) (*domain.Class, error) {
+	class, member, err := uc.getMemberClass(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != domain.ClassRoleDosen {
+		return nil, apperrors.NewForbiddenError("Hanya dosen kelas yang dapat mengelola kelas ini")
+	}
+	return class, nil
+}
+
+// ensureKodeAvailable fails when another class than exceptID already uses kode in periode.
+func (uc *classUsecase) ensureKodeAvailable(ctx context.Context, kode, periode string, exceptID uint) error {
+	existing, err := uc.classRepo.GetByKode(ctx, kode, periode)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil
+		}
+		return apperrors.NewInternalError(fmt.Errorf("ClassUsecase.ensureKodeAvailable: %w", err))
+	}
+	if existing.ID != exceptID {
+		return apperrors.NewConflictError("Kode kelas sudah digunakan pada periode ini")
+	}
+	return nil
+}
+
+// validateLecturers checks that every lecturer but actorID is an active dosen. The
+// actor already passed the RBAC check for managing classes.
+func (uc *classUsecase) validateLecturers(ctx context.Context, lecturerIDs []string, actorID string) error {
+	others := slices.DeleteFunc(slices.Clone(lecturerIDs), func(id string) bool { return id == actorID })
+	if len(others) == 0 {
+		return nil
+	}
+
+	users, err := uc.userRepo.GetByIDs(ctx, others)
+	if err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("ClassUsecase.validateLecturers: %w", err))
+	}
+	if len(users) != len(others) {
+		return apperrors.NewNotFoundError("Dosen")
+	}
+	for _, user := range users {
+		if user.Role == nil || !strings.EqualFold(user.Role.NamaRole, domain.ClassRoleDosen) {
+			return apperrors.NewValidationError(fmt.Sprintf("%s bukan dosen", user.Name), nil)
+		}
+	}
+	return nil
+}
+
+func (uc *classUsecase) buildClassResponse(ctx context.Context, class *domain.Class, role string) (*dto.ClassResponse, error) {
+	members, err := uc.classRepo.GetMembers(ctx, class.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ClassUsecase.buildClassResponse: %w", err))
+	}
+
+	resp := &dto.ClassResponse{
+		ID:        class.ID,
+		Kode:      class.Kode,
+		Nama:      class.Nama,
+		Periode:   class.Periode,
+		Deskripsi: class.Deskripsi,
+		Peran:     role,
+		Dosen:     []dto.ClassLecturer{},
+		CreatedAt: class.CreatedAt,
+		UpdatedAt: class.UpdatedAt,
+	}
+	if role == domain.ClassRoleDosen {
+		resp.JoinCode = class.JoinCode
+	}
+	for i := range members {
+		if members[i].Role == domain.ClassRoleDosen {
+			resp.Dosen = append(resp.Dosen, dto.ClassLecturer{
+				ID:    members[i].UserID,
+				Name:  members[i].User.Name,
+				Email: members[i].User.Email,
+			})
+			continue
+		}
+		resp.JumlahAnggota++
+	}
+	return resp, nil
+}
+
+// joinCodeAlphabet leaves out characters that are easily confused when a code is
+// read out in class (0/O, 1/I). Its 32 characters divide 256 evenly, so mapping
+// random bytes onto it is unbiased.
+const joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func generateJoinCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = joinCodeAlphabet[int(b[i])%len(joinCodeAlphabet)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/helper"
+	"strings"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	classTestLecturerID = "11111111-1111-1111-1111-111111111111"
+	classTestStudentID  = "22222222-2222-2222-2222-222222222222"
+)
+
+func requireClassAppError(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+// expectLecturer sets up class 1 with userID teaching it.
+func expectLecturer(classRepo *MockClassRepository, userID string) {
+	classRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Class{ID: 1, Kode: "TIF101", Periode: "2025/2026-1", JoinCode: "ABCD2345"}, nil)
+	classRepo.On("GetMember", mock.Anything, uint(1), userID).Return(&domain.ClassMember{ClassID: 1, UserID: userID, Role: domain.ClassRoleDosen}, nil)
+}
+
+func TestClassUsecase_Create(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	classUc := mocks.classUsecase()
+
+	req := dto.CreateClassRequest{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1", DosenIDs: []string{"dosen-2", classTestLecturerID}}
+	mocks.class.On("GetByKode", mock.Anything, "TIF101", "2025/2026-1").Return(nil, apperrors.ErrRecordNotFound)
+	mocks.user.On("GetByIDs", mock.Anything, []string{"dosen-2"}).
+		Return([]*domain.User{{ID: "dosen-2", Name: "Dosen Dua", Role: &domain.Role{NamaRole: "dosen"}}}, nil)
+	mocks.class.On("Create", mock.Anything, mock.AnythingOfType("*domain.Class"), []string{classTestLecturerID, "dosen-2"}).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Class).ID = 1 }).
+		Return(nil)
+	mocks.class.On("GetMembers", mock.Anything, uint(1)).Return([]domain.ClassMember{
+		{UserID: classTestLecturerID, Role: domain.ClassRoleDosen, User: domain.User{Name: "Dosen Satu"}},
+		{UserID: "dosen-2", Role: domain.ClassRoleDosen, User: domain.User{Name: "Dosen Dua"}},
+	}, nil)
+
+	result, err := classUc.Create(context.Background(), classTestLecturerID, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ClassRoleDosen, result.Peran)
+	assert.Len(t, result.JoinCode, 8)
+	assert.Len(t, result.Dosen, 2)
+	assert.Zero(t, result.JumlahAnggota)
+	mocks.class.AssertExpectations(t)
+}
+
+func TestClassUsecase_Create_Rejected(t *testing.T) {
+	t.Parallel()
+
+	t.Run("kode taken in period", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		mocks.class.On("GetByKode", mock.Anything, "TIF101", "2025/2026-1").Return(&domain.Class{ID: 7}, nil)
+
+		_, err := classUc.Create(context.Background(), classTestLecturerID, dto.CreateClassRequest{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1"})
+		requireClassAppError(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("lecturer is not a dosen", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		mocks.class.On("GetByKode", mock.Anything, "TIF101", "2025/2026-1").Return(nil, apperrors.ErrRecordNotFound)
+		mocks.user.On("GetByIDs", mock.Anything, []string{classTestStudentID}).
+			Return([]*domain.User{{ID: classTestStudentID, Name: "Siti", Role: &domain.Role{NamaRole: "mahasiswa"}}}, nil)
+
+		_, err := classUc.Create(context.Background(), classTestLecturerID, dto.CreateClassRequest{
+			Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1", DosenIDs: []string{classTestStudentID},
+		})
+		requireClassAppError(t, err, apperrors.ErrValidation)
+		mocks.class.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestClassUsecase_Access(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non-member cannot view", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		mocks.class.On("GetByID", mock.Anything, uint(1)).Return(&domain.Class{ID: 1}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), "outsider").Return(nil, apperrors.ErrRecordNotFound)
+
+		_, err := classUc.GetByID(context.Background(), 1, "outsider")
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("mahasiswa sees no join code", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		mocks.class.On("GetByID", mock.Anything, uint(1)).Return(&domain.Class{ID: 1, JoinCode: "ABCD2345"}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), classTestStudentID).
+			Return(&domain.ClassMember{Role: domain.ClassRoleMahasiswa}, nil)
+		mocks.class.On("GetMembers", mock.Anything, uint(1)).Return([]domain.ClassMember{
+			{UserID: classTestLecturerID, Role: domain.ClassRoleDosen},
+			{UserID: classTestStudentID, Role: domain.ClassRoleMahasiswa},
+		}, nil)
+
+		result, err := classUc.GetByID(context.Background(), 1, classTestStudentID)
+		require.NoError(t, err)
+		assert.Empty(t, result.JoinCode)
+		assert.Equal(t, 1, result.JumlahAnggota)
+	})
+
+	t.Run("mahasiswa cannot enroll others", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		mocks.class.On("GetByID", mock.Anything, uint(1)).Return(&domain.Class{ID: 1}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), classTestStudentID).
+			Return(&domain.ClassMember{Role: domain.ClassRoleMahasiswa}, nil)
+
+		_, err := classUc.AddMembers(context.Background(), 1, classTestStudentID, dto.AddClassMembersRequest{Emails: []string{"a@student.polije.ac.id"}})
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+	})
+}
+
+func TestClassUsecase_AddMembers_Report(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	classUc := mocks.classUsecase()
+	expectLecturer(mocks.class, classTestLecturerID)
+
+	mahasiswa := &domain.Role{NamaRole: "mahasiswa"}
+	mocks.user.On("FindByEmails", mock.Anything, mock.AnythingOfType("[]string")).Return([]domain.User{
+		{ID: "new-student", Email: "baru@student.polije.ac.id", Name: "Mahasiswa Baru", Role: mahasiswa},
+		{ID: classTestStudentID, Email: "lama@student.polije.ac.id", Name: "Mahasiswa Lama", Role: mahasiswa},
+		{ID: "dosen-2", Email: "dosen@polije.ac.id", Name: "Dosen Dua", Role: &domain.Role{NamaRole: "dosen"}},
+	}, nil)
+	mocks.class.On("GetMembers", mock.Anything, uint(1)).Return([]domain.ClassMember{
+		{UserID: classTestLecturerID, Role: domain.ClassRoleDosen},
+		{UserID: classTestStudentID, Role: domain.ClassRoleMahasiswa},
+	}, nil)
+	mocks.class.On("AddMembers", mock.Anything, []domain.ClassMember{
+		{ClassID: 1, UserID: "new-student", Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedManual},
+	}).Return(nil)
+
+	report, err := classUc.AddMembers(context.Background(), 1, classTestLecturerID, dto.AddClassMembersRequest{Emails: []string{
+		"Baru@student.polije.ac.id",
+		"baru@student.polije.ac.id",
+		"lama@student.polije.ac.id",
+		"dosen@polije.ac.id",
+		"hilang@student.polije.ac.id",
+		"bukan-email",
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, report.TotalBaris)
+	assert.Equal(t, 1, report.Berhasil)
+	assert.Equal(t, 5, report.Dilewati)
+	reasons := make([]string, 0, len(report.Detail))
+	for _, row := range report.Detail {
+		reasons = append(reasons, row.Alasan)
+	}
+	assert.Equal(t, []string{
+		"",
+		"Sudah terdaftar di kelas ini",
+		"Sudah terdaftar di kelas ini",
+		"User bukan mahasiswa",
+		"Email belum terdaftar",
+		"Format email tidak valid",
+	}, reasons)
+	assert.Equal(t, "Mahasiswa Baru", report.Detail[0].Nama)
+	mocks.class.AssertExpectations(t)
+}
+
+func TestClassUsecase_ImportMembers(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	classUc := mocks.classUsecase()
+	expectLecturer(mocks.class, classTestLecturerID)
+
+	f, err := helper.NewExcelHelper().GenerateClassEnrollmentTemplate()
+	require.NoError(t, err)
+	defer f.Close()
+	// Replace the example row with two students, one of them with a blank row in between.
+	require.NoError(t, f.SetSheetRow("Data Mahasiswa", "A2", &[]interface{}{"siti@student.polije.ac.id", "Siti"}))
+	require.NoError(t, f.SetSheetRow("Data Mahasiswa", "A4", &[]interface{}{"", "Tanpa Email"}))
+
+	mocks.user.On("FindByEmails", mock.Anything, []string{"siti@student.polije.ac.id"}).Return([]domain.User{
+		{ID: classTestStudentID, Email: "siti@student.polije.ac.id", Name: "Siti Nurhaliza", Role: &domain.Role{NamaRole: "Mahasiswa"}},
+	}, nil)
+	mocks.class.On("GetMembers", mock.Anything, uint(1)).Return([]domain.ClassMember{}, nil)
+	mocks.class.On("AddMembers", mock.Anything, []domain.ClassMember{
+		{ClassID: 1, UserID: classTestStudentID, Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedImport},
+	}).Return(nil)
+
+	report, err := classUc.ImportMembers(context.Background(), 1, classTestLecturerID, f)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.TotalBaris)
+	assert.Equal(t, 1, report.Berhasil)
+	require.Len(t, report.Detail, 2)
+	assert.Equal(t, 4, report.Detail[1].Baris)
+	assert.Equal(t, "Email wajib diisi", report.Detail[1].Alasan)
+}
+
+func TestClassUsecase_ImportMembers_WrongSheet(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	classUc := mocks.classUsecase()
+	expectLecturer(mocks.class, classTestLecturerID)
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	_, err := classUc.ImportMembers(context.Background(), 1, classTestLecturerID, f)
+	requireClassAppError(t, err, apperrors.ErrValidation)
+}
+
+// expectClassStudent sets up classTestStudentID as an active mahasiswa.
+func expectClassStudent(userRepo *MockUserRepository) {
+	userRepo.On("GetByID", mock.Anything, classTestStudentID).
+		Return(&domain.User{ID: classTestStudentID, Role: &domain.Role{NamaRole: "Mahasiswa"}}, nil)
+}
+
+func TestClassUsecase_Join(t *testing.T) {
+	t.Parallel()
+
+	t.Run("enrolls by code", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		expectClassStudent(mocks.user)
+		mocks.class.On("GetByJoinCode", mock.Anything, "ABCD2345").Return(&domain.Class{ID: 1, JoinCode: "ABCD2345"}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), classTestStudentID).Return(nil, apperrors.ErrRecordNotFound)
+		mocks.class.On("AddMembers", mock.Anything, []domain.ClassMember{
+			{ClassID: 1, UserID: classTestStudentID, Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedJoinCode},
+		}).Return(nil)
+		mocks.class.On("GetMembers", mock.Anything, uint(1)).Return([]domain.ClassMember{
+			{UserID: classTestStudentID, Role: domain.ClassRoleMahasiswa},
+		}, nil)
+
+		result, err := classUc.Join(context.Background(), classTestStudentID, dto.JoinClassRequest{Code: " abcd2345 "})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ClassRoleMahasiswa, result.Peran)
+		assert.Empty(t, result.JoinCode)
+		mocks.class.AssertExpectations(t)
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		expectClassStudent(mocks.user)
+		mocks.class.On("GetByJoinCode", mock.Anything, "ABCD2345").Return(&domain.Class{ID: 1}, nil)
+		mocks.class.On("GetMember", mock.Anything, uint(1), classTestStudentID).Return(&domain.ClassMember{Role: domain.ClassRoleMahasiswa}, nil)
+
+		_, err := classUc.Join(context.Background(), classTestStudentID, dto.JoinClassRequest{Code: "ABCD2345"})
+		requireClassAppError(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		expectClassStudent(mocks.user)
+		mocks.class.On("GetByJoinCode", mock.Anything, "ZZZZ9999").Return(nil, apperrors.ErrRecordNotFound)
+
+		_, err := classUc.Join(context.Background(), classTestStudentID, dto.JoinClassRequest{Code: "ZZZZ9999"})
+		requireClassAppError(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("only mahasiswa", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		classUc := mocks.classUsecase()
+		mocks.user.On("GetByID", mock.Anything, classTestLecturerID).
+			Return(&domain.User{ID: classTestLecturerID, Role: &domain.Role{NamaRole: "Dosen"}}, nil)
+
+		_, err := classUc.Join(context.Background(), classTestLecturerID, dto.JoinClassRequest{Code: "ABCD2345"})
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+		mocks.class.AssertNotCalled(t, "AddMembers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestClassUsecase_RemoveMember_Lecturer(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	classUc := mocks.classUsecase()
+	expectLecturer(mocks.class, classTestLecturerID)
+	mocks.class.On("GetMember", mock.Anything, uint(1), "dosen-2").Return(&domain.ClassMember{UserID: "dosen-2", Role: domain.ClassRoleDosen}, nil)
+
+	err := classUc.RemoveMember(context.Background(), 1, classTestLecturerID, "dosen-2")
+	requireClassAppError(t, err, apperrors.ErrValidation)
+	mocks.class.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateJoinCode(t *testing.T) {
+	t.Parallel()
+	code, err := generateJoinCode()
+	require.NoError(t, err)
+
+	assert.Len(t, code, 8)
+	for _, r := range code {
+		assert.True(t, strings.ContainsRune(joinCodeAlphabet, r), "unexpected character %q", r)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"invento-service/config"
 	"invento-service/internal/dto"
+	"invento-service/internal/metrics"
 	"runtime"
 	"time"
 
@@ -19,16 +20,20 @@ type HealthUsecase interface {
 }
 
 type healthUsecase struct {
-	db        *gorm.DB
-	config    *config.Config
-	startTime time.Time
+	db          *gorm.DB
+	config      *config.Config
+	httpMetrics *metrics.HTTPCollector
+	startTime   time.Time
 }
 
-func NewHealthUsecase(db *gorm.DB, config *config.Config) HealthUsecase {
+// NewHealthUsecase creates a HealthUsecase. httpMetrics may be nil, in which case
+// HTTP metrics are reported as empty.
+func NewHealthUsecase(db *gorm.DB, config *config.Config, httpMetrics *metrics.HTTPCollector) HealthUsecase {
 	return &healthUsecase{
-		db:        db,
-		config:    config,
-		startTime: time.Now(),
+		db:          db,
+		config:      config,
+		httpMetrics: httpMetrics,
+		startTime:   time.Now(),
 	}
 }
 
@@ -178,17 +183,46 @@ func (uc *healthUsecase) getDetailedSystemInfo() dto.DetailedSystemInfo {
 }
 
 func (uc *healthUsecase) getHttpMetrics() dto.HttpMetrics {
+	if uc.httpMetrics == nil {
+		return dto.HttpMetrics{ResponseTimes: toResponseTimes(metrics.HistogramSnapshot{})}
+	}
+
+	snapshot := uc.httpMetrics.Snapshot()
+	routes := make([]dto.RouteMetrics, 0, len(snapshot.Routes))
+	for _, route := range snapshot.Routes {
+		routes = append(routes, dto.RouteMetrics{
+			Method:        route.Method,
+			Route:         route.Route,
+			TotalRequests: route.Requests,
+			StatusCodes:   route.StatusClasses,
+			ResponseTimes: toResponseTimes(route.Latency),
+		})
+	}
+
 	return dto.HttpMetrics{
-		TotalRequests:  5420,
-		ActiveRequests: 3,
-		ResponseTimes: dto.ResponseTimes{
-			Min: "5ms",
-			Max: "150ms",
-			Avg: "25ms",
-		},
+		TotalRequests:  snapshot.TotalRequests,
+		ActiveRequests: int(snapshot.InFlight),
+		StatusCodes:    snapshot.StatusClasses,
+		ResponseTimes:  toResponseTimes(snapshot.Latency),
+		Routes:         routes,
 	}
 }
 
+func toResponseTimes(latency metrics.HistogramSnapshot) dto.ResponseTimes {
+	return dto.ResponseTimes{
+		Min: formatSeconds(latency.Min),
+		Max: formatSeconds(latency.Max),
+		Avg: formatSeconds(latency.Mean()),
+		P50: formatSeconds(latency.Quantile(0.50)),
+		P95: formatSeconds(latency.Quantile(0.95)),
+		P99: formatSeconds(latency.Quantile(0.99)),
+	}
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.2fms", seconds*1000)
+}
+
 func (uc *healthUsecase) getServicesStatus(ctx context.Context) dto.ServicesStatus {
 	dbStatus := uc.getDatabaseStatus(ctx)
 	emailStatus := uc.getEmailServiceStatus()
@@ -4,11 +4,13 @@ import (
 	"context"
 	"invento-service/config"
 	"invento-service/internal/dto"
+	"invento-service/internal/metrics"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -53,7 +55,7 @@ func TestHealthUsecase_GetBasicHealth_Success(t *testing.T) {
 		},
 	}
 
-	healthUsecase := NewHealthUsecase(nil, cfg)
+	healthUsecase := NewHealthUsecase(nil, cfg, nil)
 	result := healthUsecase.GetBasicHealth(context.Background())
 
 	assert.NotNil(t, result)
@@ -71,7 +73,7 @@ func TestHealthUsecase_GetComprehensiveHealth_Success(t *testing.T) {
 		},
 	}
 
-	healthUsecase := NewHealthUsecase(nil, cfg)
+	healthUsecase := NewHealthUsecase(nil, cfg, nil)
 	result := healthUsecase.GetComprehensiveHealth(context.Background())
 
 	assert.NotNil(t, result)
@@ -94,7 +96,7 @@ func TestHealthUsecase_GetSystemMetrics_Success(t *testing.T) {
 		},
 	}
 
-	healthUsecase := NewHealthUsecase(nil, cfg)
+	healthUsecase := NewHealthUsecase(nil, cfg, nil)
 	result := healthUsecase.GetSystemMetrics(context.Background())
 
 	assert.NotNil(t, result)
@@ -107,10 +109,42 @@ func TestHealthUsecase_GetSystemMetrics_Success(t *testing.T) {
 	assert.GreaterOrEqual(t, result.System.CPU.Goroutines, 0)
 	assert.NotEmpty(t, result.System.Runtime.GoVersion)
 	assert.NotEmpty(t, result.System.Runtime.OS)
-	assert.Greater(t, result.Http.TotalRequests, int64(0))
+	assert.Equal(t, int64(0), result.Http.TotalRequests)
 	assert.Equal(t, dto.ServiceStatusError, result.Database.Status)
 }
 
+func TestHealthUsecase_GetSystemMetrics_LiveHttpMetrics(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		App: config.AppConfig{
+			Name: "test-app",
+			Env:  "test",
+		},
+	}
+
+	collector := metrics.NewHTTPCollector()
+	collector.Observe("GET", "/api/v1/project/:id", 200, 10*time.Millisecond)
+	collector.Observe("GET", "/api/v1/project/:id", 404, 30*time.Millisecond)
+	collector.Observe("POST", "/api/v1/project/upload", 201, 200*time.Millisecond)
+
+	healthUsecase := NewHealthUsecase(nil, cfg, collector)
+	result := healthUsecase.GetSystemMetrics(context.Background())
+
+	assert.Equal(t, int64(3), result.Http.TotalRequests)
+	assert.Equal(t, 0, result.Http.ActiveRequests)
+	assert.Equal(t, int64(2), result.Http.StatusCodes["2xx"])
+	assert.Equal(t, int64(1), result.Http.StatusCodes["4xx"])
+	assert.Equal(t, "10.00ms", result.Http.ResponseTimes.Min)
+	assert.Equal(t, "200.00ms", result.Http.ResponseTimes.Max)
+	assert.NotEmpty(t, result.Http.ResponseTimes.P99)
+
+	require.Len(t, result.Http.Routes, 2)
+	assert.Equal(t, "/api/v1/project/:id", result.Http.Routes[0].Route)
+	assert.Equal(t, "GET", result.Http.Routes[0].Method)
+	assert.Equal(t, int64(2), result.Http.Routes[0].TotalRequests)
+	assert.Equal(t, "/api/v1/project/upload", result.Http.Routes[1].Route)
+}
+
 func TestHealthUsecase_GetApplicationStatus_Success(t *testing.T) {
 	t.Parallel()
 	cfg := &config.Config{
@@ -120,7 +154,7 @@ func TestHealthUsecase_GetApplicationStatus_Success(t *testing.T) {
 		},
 	}
 
-	healthUsecase := NewHealthUsecase(nil, cfg)
+	healthUsecase := NewHealthUsecase(nil, cfg, nil)
 	result := healthUsecase.GetApplicationStatus(context.Background())
 
 	assert.NotNil(t, result)
@@ -151,7 +185,7 @@ func TestHealthUsecase_FormatDuration(t *testing.T) {
 		},
 	}
 
-	healthUsecase := NewHealthUsecase(nil, cfg)
+	healthUsecase := NewHealthUsecase(nil, cfg, nil)
 
 	basicHealth := healthUsecase.GetBasicHealth(context.Background())
 	assert.NotNil(t, basicHealth)
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"slices"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+)
+
+// libraryDateLayout is the format of the published_from and published_to filters.
+const libraryDateLayout = "2006-01-02"
+
+// GetLibrary lists the published moduls shared with userID, including their own.
+func (uc *modulUsecase) GetLibrary(ctx context.Context, userID string, params dto.ModulLibraryQueryParams) (*dto.ModulLibraryData, error) {
+	page, limit := params.Page, params.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	filter := dto.ModulLibraryFilter{
+		Search:   params.Search,
+		MimeType: params.FilterType,
+		OwnerID:  params.OwnerID,
+	}
+	if params.PublishedFrom != "" {
+		from, err := time.Parse(libraryDateLayout, params.PublishedFrom)
+		if err != nil {
+			return nil, apperrors.NewValidationError("Format published_from harus YYYY-MM-DD", err)
+		}
+		filter.PublishedFrom = &from
+	}
+	if params.PublishedTo != "" {
+		to, err := time.Parse(libraryDateLayout, params.PublishedTo)
+		if err != nil {
+			return nil, apperrors.NewValidationError("Format published_to harus YYYY-MM-DD", err)
+		}
+		// The filter names a whole day, so moduls published during it are included.
+		to = to.AddDate(0, 0, 1)
+		filter.PublishedTo = &to
+	}
+
+	moduls, total, err := uc.modulRepo.GetLibrary(ctx, userID, filter, page, limit)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.GetLibrary: %w", err))
+	}
+
+	items := make([]dto.ModulLibraryItem, 0, len(moduls))
+	for i := range moduls {
+		items = append(items, dto.ModulLibraryItem{
+			ID:          moduls[i].ID,
+			Judul:       moduls[i].Judul,
+			Deskripsi:   moduls[i].Deskripsi,
+			FileName:    moduls[i].FileName,
+			MimeType:    moduls[i].MimeType,
+			FileSize:    moduls[i].FileSize,
+			OwnerID:     moduls[i].UserID,
+			OwnerName:   moduls[i].User.Name,
+			PublishedAt: moduls[i].PublishedAt,
+		})
+	}
+
+	return &dto.ModulLibraryData{
+		Items: items,
+		Pagination: dto.PaginationData{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// UpdateVisibility sets who besides the owner may see the modul once it is published.
+// Roles only apply to ModulVisibilityRoles and are cleared for the other levels.
+func (uc *modulUsecase) UpdateVisibility(ctx context.Context, modulID, userID string, req dto.UpdateModulVisibilityRequest) (*dto.ModulVisibilityData, error) {
+	if _, err := uc.getOwnedModul(ctx, modulID, userID); err != nil {
+		return nil, err
+	}
+
+	roleIDs := []uint{}
+	if req.Visibility == domain.ModulVisibilityRoles {
+		if len(req.RoleIDs) == 0 {
+			return nil, apperrors.NewValidationError("Pilih minimal satu role untuk visibilitas roles", nil)
+		}
+		roleIDs = slices.Clone(req.RoleIDs)
+		slices.Sort(roleIDs)
+		roleIDs = slices.Compact(roleIDs)
+	}
+
+	if err := uc.modulRepo.UpdateVisibility(ctx, modulID, req.Visibility, roleIDs); err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Role")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.UpdateVisibility: %w", err))
+	}
+
+	return &dto.ModulVisibilityData{
+		ModulID:    modulID,
+		Visibility: req.Visibility,
+		RoleIDs:    roleIDs,
+	}, nil
+}
+
+// canView reports whether userID, who does not own modul, may see it.
+func (uc *modulUsecase) canView(ctx context.Context, modul *domain.Modul, userID string) (bool, error) {
+	if modul.Status != domain.ModulStatusPublished {
+		return false, nil
+	}
+	visible, err := uc.modulRepo.GetVisibleByIDs(ctx, []string{modul.ID}, userID)
+	if err != nil {
+		return false, err
+	}
+	return len(visible) > 0, nil
+}
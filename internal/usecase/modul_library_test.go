@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulUsecase_GetLibrary(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := dto.ModulLibraryFilter{Search: "basis", MimeType: "application/pdf", OwnerID: "user-1", PublishedFrom: &from, PublishedTo: &to}
+
+	publishedAt := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	mockModulRepo.On("GetLibrary", mock.Anything, "user-2", filter, 1, 10).Return([]domain.Modul{
+		{ID: lifecycleTestModulID, UserID: "user-1", Judul: "Basis Data", PublishedAt: &publishedAt, User: domain.User{Name: "Dosen Satu"}},
+	}, 1, nil)
+
+	result, err := modulUc.GetLibrary(context.Background(), "user-2", dto.ModulLibraryQueryParams{
+		Search:        "basis",
+		FilterType:    "application/pdf",
+		OwnerID:       "user-1",
+		PublishedFrom: "2025-01-01",
+		PublishedTo:   "2025-01-31",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Dosen Satu", result.Items[0].OwnerName)
+	assert.Equal(t, &publishedAt, result.Items[0].PublishedAt)
+	assert.Equal(t, 1, result.Pagination.TotalPages)
+	mockModulRepo.AssertExpectations(t)
+}
+
+func TestModulUsecase_GetLibrary_InvalidDate(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	_, err := modulUc.GetLibrary(context.Background(), "user-2", dto.ModulLibraryQueryParams{PublishedTo: "31-01-2025"})
+
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperrors.ErrValidation, appErr.Code)
+	mockModulRepo.AssertNotCalled(t, "GetLibrary", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModulUsecase_UpdateVisibility(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		req       dto.UpdateModulVisibilityRequest
+		wantRoles []uint
+		repoErr   error
+		wantCode  string
+	}{
+		{
+			name:      "roles are sorted and deduplicated",
+			req:       dto.UpdateModulVisibilityRequest{Visibility: domain.ModulVisibilityRoles, RoleIDs: []uint{3, 1, 3}},
+			wantRoles: []uint{1, 3},
+		},
+		{
+			name:      "roles are dropped for other levels",
+			req:       dto.UpdateModulVisibilityRequest{Visibility: domain.ModulVisibilityAuthenticated, RoleIDs: []uint{2}},
+			wantRoles: []uint{},
+		},
+		{
+			name:     "roles level needs a role",
+			req:      dto.UpdateModulVisibilityRequest{Visibility: domain.ModulVisibilityRoles},
+			wantCode: apperrors.ErrValidation,
+		},
+		{
+			name:      "unknown role",
+			req:       dto.UpdateModulVisibilityRequest{Visibility: domain.ModulVisibilityRoles, RoleIDs: []uint{99}},
+			wantRoles: []uint{99},
+			repoErr:   apperrors.ErrRecordNotFound,
+			wantCode:  apperrors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockModulRepo := new(MockModulRepository)
+			modulUc := NewModulUsecase(mockModulRepo, 0)
+
+			mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).
+				Return(&domain.Modul{ID: lifecycleTestModulID, UserID: "user-1"}, nil)
+			if tt.wantRoles != nil {
+				mockModulRepo.On("UpdateVisibility", mock.Anything, lifecycleTestModulID, tt.req.Visibility, tt.wantRoles).Return(tt.repoErr)
+			}
+
+			result, err := modulUc.UpdateVisibility(context.Background(), lifecycleTestModulID, "user-1", tt.req)
+			if tt.wantCode != "" {
+				var appErr *apperrors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.req.Visibility, result.Visibility)
+			assert.Equal(t, tt.wantRoles, result.RoleIDs)
+			mockModulRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModulUsecase_GetByID_OwnerSeesRoles(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).
+		Return(&domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusPublished, Visibility: domain.ModulVisibilityRoles}, nil)
+	mockModulRepo.On("GetAllowedRoleIDs", mock.Anything, lifecycleTestModulID).Return([]uint{2, 3}, nil)
+
+	result, err := modulUc.GetByID(context.Background(), lifecycleTestModulID, "user-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ModulVisibilityRoles, result.Visibility)
+	assert.Equal(t, []uint{2, 3}, result.RoleIDs)
+}
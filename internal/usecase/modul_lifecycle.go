@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"slices"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+)
+
+// Review decisions accepted by ModulUsecase.Review.
+const (
+	modulReviewApprove = "approve"
+	modulReviewReject  = "reject"
+)
+
+// Submit puts a draft up for review. Until a reviewer decides, the modul stays
+// invisible to other users.
+func (uc *modulUsecase) Submit(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	modul, err := uc.getOwnedModul(ctx, modulID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transitionModul(ctx, modul, domain.ModulStatusInReview, "diajukan untuk review", domain.ModulStatusDraft)
+}
+
+// Publish makes a draft visible to everyone without going through review.
+func (uc *modulUsecase) Publish(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	modul, err := uc.getOwnedModul(ctx, modulID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transitionModul(ctx, modul, domain.ModulStatusPublished, "dipublikasikan", domain.ModulStatusDraft)
+}
+
+// Withdraw takes a modul back to draft, whether it is waiting for review, published
+// or archived.
+func (uc *modulUsecase) Withdraw(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	modul, err := uc.getOwnedModul(ctx, modulID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transitionModul(ctx, modul, domain.ModulStatusDraft, "dikembalikan ke draft",
+		domain.ModulStatusInReview, domain.ModulStatusPublished, domain.ModulStatusArchived)
+}
+
+// Archive hides a published modul from other users while keeping it for its owner.
+func (uc *modulUsecase) Archive(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+	modul, err := uc.getOwnedModul(ctx, modulID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transitionModul(ctx, modul, domain.ModulStatusArchived, "diarsipkan", domain.ModulStatusPublished)
+}
+
+// Review records the reviewer's decision on a submitted modul: approving publishes
+// it, rejecting returns it to draft with the reviewer's note for the owner.
+func (uc *modulUsecase) Review(ctx context.Context, modulID, reviewerID string, req dto.ReviewModulRequest) (*dto.ModulResponse, error) {
+	modul, err := uc.modulRepo.GetByID(ctx, modulID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Modul")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Review: %w", err))
+	}
+
+	if modul.UserID == reviewerID {
+		return nil, apperrors.NewForbiddenError("Tidak dapat mereview modul milik sendiri")
+	}
+
+	if domain.NormalizeModulStatus(modul.Status) != domain.ModulStatusInReview {
+		return nil, apperrors.NewConflictError("Modul tidak sedang menunggu review")
+	}
+
+	now := time.Now()
+	modul.ReviewedAt = &now
+	modul.ReviewedBy = &reviewerID
+	modul.CatatanReview = req.Catatan
+
+	switch req.Decision {
+	case modulReviewApprove:
+		return uc.transitionModul(ctx, modul, domain.ModulStatusPublished, "dipublikasikan", domain.ModulStatusInReview)
+	case modulReviewReject:
+		return uc.transitionModul(ctx, modul, domain.ModulStatusDraft, "dikembalikan ke draft", domain.ModulStatusInReview)
+	}
+	return nil, apperrors.NewValidationError("Keputusan review tidak valid", nil)
+}
+
+// GetReviewQueue lists the moduls waiting for review, longest waiting first.
+func (uc *modulUsecase) GetReviewQueue(ctx context.Context, page, limit int) (*dto.ModulReviewListData, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	moduls, total, err := uc.modulRepo.GetByStatus(ctx, domain.ModulStatusInReview, page, limit)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.GetReviewQueue: %w", err))
+	}
+
+	items := make([]dto.ModulReviewItem, 0, len(moduls))
+	for i := range moduls {
+		items = append(items, dto.ModulReviewItem{
+			ID:          moduls[i].ID,
+			Judul:       moduls[i].Judul,
+			Deskripsi:   moduls[i].Deskripsi,
+			FileName:    moduls[i].FileName,
+			MimeType:    moduls[i].MimeType,
+			FileSize:    moduls[i].FileSize,
+			OwnerID:     moduls[i].UserID,
+			OwnerName:   moduls[i].User.Name,
+			SubmittedAt: moduls[i].SubmittedAt,
+		})
+	}
+
+	return &dto.ModulReviewListData{
+		Items: items,
+		Pagination: dto.PaginationData{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// transitionModul moves modul to status if its current status is one of from,
+// stamping the transition. verb completes the conflict message for other states. It
+// also fails with a conflict when the stored status changed since modul was read.
+func (uc *modulUsecase) transitionModul(ctx context.Context, modul *domain.Modul, status, verb string, from ...string) (*dto.ModulResponse, error) {
+	current := domain.NormalizeModulStatus(modul.Status)
+	if !slices.Contains(from, current) {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("Modul berstatus %s tidak dapat %s", current, verb))
+	}
+
+	now := time.Now()
+	modul.Status = status
+	modul.StatusChangedAt = &now
+	switch status {
+	case domain.ModulStatusInReview:
+		modul.SubmittedAt = &now
+		// A new submission starts a new review; the previous outcome no longer applies.
+		modul.ReviewedAt, modul.ReviewedBy, modul.CatatanReview = nil, nil, ""
+	case domain.ModulStatusPublished:
+		modul.PublishedAt = &now
+	case domain.ModulStatusArchived:
+		modul.ArchivedAt = &now
+	}
+
+	updated, err := uc.modulRepo.UpdateStatus(ctx, modul, current)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.transitionModul: %w", err))
+	}
+	if !updated {
+		return nil, apperrors.NewConflictError("Status modul sudah berubah, muat ulang lalu coba lagi")
+	}
+
+	return toModulResponse(modul), nil
+}
+
+func toModulResponse(modul *domain.Modul) *dto.ModulResponse {
+	return &dto.ModulResponse{
+		ID:              modul.ID,
+		Judul:           modul.Judul,
+		Deskripsi:       modul.Deskripsi,
+		FileName:        modul.FileName,
+		MimeType:        modul.MimeType,
+		FileSize:        modul.FileSize,
+		Status:          domain.NormalizeModulStatus(modul.Status),
+		Visibility:      modul.Visibility,
+		StatusChangedAt: modul.StatusChangedAt,
+		SubmittedAt:     modul.SubmittedAt,
+		PublishedAt:     modul.PublishedAt,
+		ArchivedAt:      modul.ArchivedAt,
+		ReviewedAt:      modul.ReviewedAt,
+		ReviewedBy:      modul.ReviewedBy,
+		CatatanReview:   modul.CatatanReview,
+		CreatedAt:       modul.CreatedAt,
+		UpdatedAt:       modul.UpdatedAt,
+	}
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const lifecycleTestModulID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestNormalizeModulStatus(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, domain.ModulStatusDraft, domain.NormalizeModulStatus("pending"))
+	assert.Equal(t, domain.ModulStatusDraft, domain.NormalizeModulStatus("completed"))
+	assert.Equal(t, domain.ModulStatusDraft, domain.NormalizeModulStatus(""))
+	assert.Equal(t, domain.ModulStatusPublished, domain.NormalizeModulStatus(domain.ModulStatusPublished))
+}
+
+func TestModulUsecase_Transitions(t *testing.T) {
+	t.Parallel()
+
+	type transition func(uc ModulUsecase, ctx context.Context, modulID, userID string) (*dto.ModulResponse, error)
+	submit := func(uc ModulUsecase, ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+		return uc.Submit(ctx, modulID, userID)
+	}
+	publish := func(uc ModulUsecase, ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+		return uc.Publish(ctx, modulID, userID)
+	}
+	withdraw := func(uc ModulUsecase, ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+		return uc.Withdraw(ctx, modulID, userID)
+	}
+	archive := func(uc ModulUsecase, ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
+		return uc.Archive(ctx, modulID, userID)
+	}
+
+	tests := []struct {
+		name      string
+		run       transition
+		from      string
+		want      string
+		conflict  bool
+		timestamp func(m *domain.Modul) *time.Time
+	}{
+		{name: "submit draft", run: submit, from: domain.ModulStatusDraft, want: domain.ModulStatusInReview,
+			timestamp: func(m *domain.Modul) *time.Time { return m.SubmittedAt }},
+		{name: "submit legacy status", run: submit, from: "completed", want: domain.ModulStatusInReview,
+			timestamp: func(m *domain.Modul) *time.Time { return m.SubmittedAt }},
+		{name: "publish draft", run: publish, from: domain.ModulStatusDraft, want: domain.ModulStatusPublished,
+			timestamp: func(m *domain.Modul) *time.Time { return m.PublishedAt }},
+		{name: "archive published", run: archive, from: domain.ModulStatusPublished, want: domain.ModulStatusArchived,
+			timestamp: func(m *domain.Modul) *time.Time { return m.ArchivedAt }},
+		{name: "withdraw archived", run: withdraw, from: domain.ModulStatusArchived, want: domain.ModulStatusDraft,
+			timestamp: func(m *domain.Modul) *time.Time { return m.StatusChangedAt }},
+		{name: "publish skips pending review", run: publish, from: domain.ModulStatusInReview, conflict: true},
+		{name: "archive draft", run: archive, from: domain.ModulStatusDraft, conflict: true},
+		{name: "withdraw draft", run: withdraw, from: domain.ModulStatusDraft, conflict: true},
+		{name: "submit published", run: submit, from: domain.ModulStatusPublished, conflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockModulRepo := new(MockModulRepository)
+			modulUc := NewModulUsecase(mockModulRepo, 0)
+
+			modul := &domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Judul: "Basis Data", Status: tt.from}
+			mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).Return(modul, nil)
+			if !tt.conflict {
+				mockModulRepo.On("UpdateStatus", mock.Anything, modul, domain.NormalizeModulStatus(tt.from)).Return(true, nil)
+			}
+
+			result, err := tt.run(modulUc, context.Background(), lifecycleTestModulID, "user-1")
+			if tt.conflict {
+				var appErr *apperrors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+				mockModulRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Status)
+			assert.Equal(t, tt.want, modul.Status)
+			assert.NotNil(t, modul.StatusChangedAt)
+			assert.NotNil(t, tt.timestamp(modul))
+			mockModulRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModulUsecase_Transition_LostRace(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	// Another request published the modul after it was read as draft.
+	modul := &domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusDraft}
+	mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).Return(modul, nil)
+	mockModulRepo.On("UpdateStatus", mock.Anything, modul, domain.ModulStatusDraft).Return(false, nil).Once()
+
+	_, err := modulUc.Submit(context.Background(), lifecycleTestModulID, "user-1")
+
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+	mockModulRepo.AssertExpectations(t)
+}
+
+func TestModulUsecase_Transition_NotOwner(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).
+		Return(&domain.Modul{ID: lifecycleTestModulID, UserID: "user-2", Status: domain.ModulStatusDraft}, nil)
+
+	_, err := modulUc.Publish(context.Background(), lifecycleTestModulID, "user-1")
+
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperrors.ErrForbidden, appErr.Code)
+	mockModulRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModulUsecase_Submit_ClearsPreviousReview(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	reviewedAt := time.Now().Add(-time.Hour)
+	reviewer := "reviewer-1"
+	modul := &domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusDraft,
+		ReviewedAt: &reviewedAt, ReviewedBy: &reviewer, CatatanReview: "Lengkapi daftar pustaka"}
+	mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).Return(modul, nil)
+	mockModulRepo.On("UpdateStatus", mock.Anything, modul, domain.ModulStatusDraft).Return(true, nil)
+
+	result, err := modulUc.Submit(context.Background(), lifecycleTestModulID, "user-1")
+	require.NoError(t, err)
+
+	assert.Nil(t, result.ReviewedAt)
+	assert.Nil(t, result.ReviewedBy)
+	assert.Empty(t, result.CatatanReview)
+}
+
+func TestModulUsecase_Review(t *testing.T) {
+	t.Parallel()
+
+	t.Run("approve publishes", func(t *testing.T) {
+		t.Parallel()
+		mockModulRepo := new(MockModulRepository)
+		modulUc := NewModulUsecase(mockModulRepo, 0)
+
+		modul := &domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusInReview}
+		mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).Return(modul, nil)
+		mockModulRepo.On("UpdateStatus", mock.Anything, modul, domain.ModulStatusInReview).Return(true, nil)
+
+		result, err := modulUc.Review(context.Background(), lifecycleTestModulID, "reviewer-1", dto.ReviewModulRequest{Decision: "approve"})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.ModulStatusPublished, result.Status)
+		assert.NotNil(t, result.PublishedAt)
+		assert.NotNil(t, result.ReviewedAt)
+		require.NotNil(t, result.ReviewedBy)
+		assert.Equal(t, "reviewer-1", *result.ReviewedBy)
+	})
+
+	t.Run("reject returns to draft with note", func(t *testing.T) {
+		t.Parallel()
+		mockModulRepo := new(MockModulRepository)
+		modulUc := NewModulUsecase(mockModulRepo, 0)
+
+		modul := &domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusInReview}
+		mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).Return(modul, nil)
+		mockModulRepo.On("UpdateStatus", mock.Anything, modul, domain.ModulStatusInReview).Return(true, nil)
+
+		result, err := modulUc.Review(context.Background(), lifecycleTestModulID, "reviewer-1",
+			dto.ReviewModulRequest{Decision: "reject", Catatan: "Lengkapi daftar pustaka"})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.ModulStatusDraft, result.Status)
+		assert.Nil(t, result.PublishedAt)
+		assert.Equal(t, "Lengkapi daftar pustaka", result.CatatanReview)
+	})
+
+	t.Run("own modul", func(t *testing.T) {
+		t.Parallel()
+		mockModulRepo := new(MockModulRepository)
+		modulUc := NewModulUsecase(mockModulRepo, 0)
+
+		mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).
+			Return(&domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusInReview}, nil)
+
+		_, err := modulUc.Review(context.Background(), lifecycleTestModulID, "user-1", dto.ReviewModulRequest{Decision: "approve"})
+
+		var appErr *apperrors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, apperrors.ErrForbidden, appErr.Code)
+	})
+
+	t.Run("not in review", func(t *testing.T) {
+		t.Parallel()
+		mockModulRepo := new(MockModulRepository)
+		modulUc := NewModulUsecase(mockModulRepo, 0)
+
+		mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).
+			Return(&domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: domain.ModulStatusDraft}, nil)
+
+		_, err := modulUc.Review(context.Background(), lifecycleTestModulID, "reviewer-1", dto.ReviewModulRequest{Decision: "approve"})
+
+		var appErr *apperrors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+		mockModulRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestModulUsecase_GetByID_Visibility(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  string
+		userID  string
+		shared  bool
+		allowed bool
+	}{
+		{name: "owner sees draft", status: domain.ModulStatusDraft, userID: "user-1", allowed: true},
+		{name: "other user sees published", status: domain.ModulStatusPublished, userID: "user-2", shared: true, allowed: true},
+		{name: "other user cannot see published private", status: domain.ModulStatusPublished, userID: "user-2"},
+		{name: "other user cannot see draft", status: domain.ModulStatusDraft, userID: "user-2"},
+		{name: "other user cannot see review", status: domain.ModulStatusInReview, userID: "user-2"},
+		{name: "other user cannot see archived", status: domain.ModulStatusArchived, userID: "user-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockModulRepo := new(MockModulRepository)
+			modulUc := NewModulUsecase(mockModulRepo, 0)
+
+			mockModulRepo.On("GetByID", mock.Anything, lifecycleTestModulID).
+				Return(&domain.Modul{ID: lifecycleTestModulID, UserID: "user-1", Status: tt.status}, nil)
+			if tt.status == domain.ModulStatusPublished && tt.userID != "user-1" {
+				var visible []domain.Modul
+				if tt.shared {
+					visible = []domain.Modul{{ID: lifecycleTestModulID}}
+				}
+				mockModulRepo.On("GetVisibleByIDs", mock.Anything, []string{lifecycleTestModulID}, tt.userID).Return(visible, nil)
+			}
+
+			result, err := modulUc.GetByID(context.Background(), lifecycleTestModulID, tt.userID)
+			if tt.allowed {
+				require.NoError(t, err)
+				assert.Equal(t, tt.status, result.Status)
+				return
+			}
+
+			var appErr *apperrors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, apperrors.ErrForbidden, appErr.Code)
+		})
+	}
+}
+
+func TestModulUsecase_GetReviewQueue(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	submittedAt := time.Now()
+	mockModulRepo.On("GetByStatus", mock.Anything, domain.ModulStatusInReview, 1, 10).Return([]domain.Modul{
+		{ID: lifecycleTestModulID, UserID: "user-1", Judul: "Basis Data", SubmittedAt: &submittedAt, User: domain.User{Name: "Dosen Satu"}},
+	}, 11, nil)
+
+	result, err := modulUc.GetReviewQueue(context.Background(), 0, 0)
+	require.NoError(t, err)
+
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Dosen Satu", result.Items[0].OwnerName)
+	assert.Equal(t, "user-1", result.Items[0].OwnerID)
+	assert.Equal(t, 2, result.Pagination.TotalPages)
+}
@@ -34,6 +34,22 @@ func (m *MockModulRepository) GetByIDs(ctx context.Context, ids []string, userID
 	return args.Get(0).([]domain.Modul), args.Error(1)
 }
 
+func (m *MockModulRepository) GetVisibleByIDs(ctx context.Context, ids []string, userID string) ([]domain.Modul, error) {
+	args := m.Called(ctx, ids, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Modul), args.Error(1)
+}
+
+func (m *MockModulRepository) GetLibrary(ctx context.Context, userID string, filter dto.ModulLibraryFilter, page, limit int) ([]domain.Modul, int, error) {
+	args := m.Called(ctx, userID, filter, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.Modul), args.Int(1), args.Error(2)
+}
+
 func (m *MockModulRepository) GetByUserID(ctx context.Context, userID, search, filterType, filterStatus string, page, limit int) ([]dto.ModulListItem, int, error) {
 	args := m.Called(ctx, userID, search, filterType, filterStatus, page, limit)
 	if args.Get(0) == nil {
@@ -61,3 +77,55 @@ func (m *MockModulRepository) UpdateMetadata(ctx context.Context, modul *domain.
 	args := m.Called(ctx, modul)
 	return args.Error(0)
 }
+
+func (m *MockModulRepository) UpdateStatus(ctx context.Context, modul *domain.Modul, fromStatus string) (bool, error) {
+	args := m.Called(ctx, modul, fromStatus)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockModulRepository) GetByStatus(ctx context.Context, status string, page, limit int) ([]domain.Modul, int, error) {
+	args := m.Called(ctx, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.Modul), args.Int(1), args.Error(2)
+}
+
+func (m *MockModulRepository) UpdateVisibility(ctx context.Context, modulID, visibility string, roleIDs []uint) error {
+	args := m.Called(ctx, modulID, visibility, roleIDs)
+	return args.Error(0)
+}
+
+func (m *MockModulRepository) GetAllowedRoleIDs(ctx context.Context, modulID string) ([]uint, error) {
+	args := m.Called(ctx, modulID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockModulRepository) CreateRevision(ctx context.Context, revision *domain.ModulRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+func (m *MockModulRepository) GetRevisions(ctx context.Context, modulID string) ([]domain.ModulRevision, error) {
+	args := m.Called(ctx, modulID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ModulRevision), args.Error(1)
+}
+
+func (m *MockModulRepository) GetRevision(ctx context.Context, modulID string, revision int) (*domain.ModulRevision, error) {
+	args := m.Called(ctx, modulID, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ModulRevision), args.Error(1)
+}
+
+func (m *MockModulRepository) DeleteRevisions(ctx context.Context, ids []uint) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
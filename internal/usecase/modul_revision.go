@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"invento-service/internal/usecase/repo"
+
+	apperrors "invento-service/internal/errors"
+
+	zlog "github.com/rs/zerolog/log"
+)
+
+func (uc *modulUsecase) GetRevisions(ctx context.Context, modulID, userID string) (*dto.ModulRevisionListData, error) {
+	modul, err := uc.getOwnedModul(ctx, modulID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.modulRepo.GetRevisions(ctx, modulID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.GetRevisions: %w", err))
+	}
+
+	result := &dto.ModulRevisionListData{
+		ModulID: modulID,
+		Items:   make([]dto.ModulRevisionItem, 0, len(revisions)),
+	}
+	for i := range revisions {
+		item := toModulRevisionItem(&revisions[i])
+		// Revisions are snapshots, so the newest one describes the modul as it is now
+		// unless it was changed before revisions were recorded.
+		item.IsCurrent = i == 0 && len(diffModul(modul, modulFromRevision(modul, &revisions[i]))) == 0
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+func (uc *modulUsecase) DownloadRevision(ctx context.Context, modulID, userID string, revision int) (*storage.Download, error) {
+	modul, modulRevision, err := uc.getOwnedRevision(ctx, modulID, userID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	if modulRevision.FilePath == "" {
+		return nil, apperrors.NewNotFoundError("File revisi modul")
+	}
+
+	backend := storage.DefaultBackend()
+	if _, statErr := backend.Stat(ctx, modulRevision.FilePath); errors.Is(statErr, storage.ErrObjectNotFound) {
+		return nil, apperrors.NewNotFoundError("File revisi modul")
+	}
+
+	entry := storage.ModulArchiveEntry(modulFromRevision(modul, modulRevision), modulRevision.FilePath)
+	return storage.NewFileDownload(backend, modulRevision.FilePath, entry.DownloadName()), nil
+}
+
+// Rollback returns the modul's file and metadata to what they were at revision. The
+// rollback is recorded as a new revision, so the state it replaced stays in history.
+func (uc *modulUsecase) Rollback(ctx context.Context, modulID, userID string, revision int, req dto.RollbackModulRequest) (*dto.ModulRevisionItem, error) {
+	modul, modulRevision, err := uc.getOwnedRevision(ctx, modulID, userID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	target := modulFromRevision(modul, modulRevision)
+	if len(diffModul(modul, target)) == 0 {
+		return nil, apperrors.NewConflictError("modul sudah sama dengan revisi ini")
+	}
+
+	if target.FilePath != modul.FilePath {
+		if _, statErr := storage.DefaultBackend().Stat(ctx, target.FilePath); statErr != nil {
+			if errors.Is(statErr, storage.ErrObjectNotFound) {
+				return nil, apperrors.NewNotFoundError("File revisi modul")
+			}
+			return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Rollback: stat: %w", statErr))
+		}
+	}
+
+	if err := ensureModulBaseline(ctx, uc.modulRepo, modul); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Rollback: baseline revision: %w", err))
+	}
+
+	if err := uc.modulRepo.Update(ctx, target); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Rollback: %w", err))
+	}
+
+	catatan := req.Catatan
+	if catatan == "" {
+		catatan = fmt.Sprintf("Dikembalikan ke revisi %d", modulRevision.Revision)
+	}
+	rollback := newModulRevision(target, domain.ModulRevisionRollback, diffModul(modul, target), userID, catatan)
+	if err := uc.modulRepo.CreateRevision(ctx, rollback); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Rollback: record revision: %w", err))
+	}
+	if err := pruneModulRevisions(ctx, uc.modulRepo, target.ID, target.FilePath, uc.revisionRetention); err != nil {
+		// The rollback is already recorded; only the pruning is lost.
+		zlog.Warn().Err(err).Str("modul_id", target.ID).Msg("ModulUsecase.Rollback: failed to prune modul revisions")
+	}
+
+	item := toModulRevisionItem(rollback)
+	item.IsCurrent = true
+	return &item, nil
+}
+
+func (uc *modulUsecase) getOwnedRevision(ctx context.Context, modulID, userID string, revision int) (*domain.Modul, *domain.ModulRevision, error) {
+	modul, err := uc.getOwnedModul(ctx, modulID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modulRevision, err := uc.modulRepo.GetRevision(ctx, modulID, revision)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewNotFoundError("Revisi modul")
+		}
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.getOwnedRevision: %w", err))
+	}
+
+	return modul, modulRevision, nil
+}
+
+// ensureModulBaseline gives a modul created before revisions were recorded a first
+// revision for its current state, so that state is not lost once it is changed.
+func ensureModulBaseline(ctx context.Context, modulRepo repo.ModulRepository, modul *domain.Modul) error {
+	revisions, err := modulRepo.GetRevisions(ctx, modul.ID)
+	if err != nil || len(revisions) > 0 {
+		return err
+	}
+
+	baseline := newModulRevision(modul, domain.ModulRevisionCreate, diffModul(&domain.Modul{}, modul), modul.UserID, "")
+	baseline.CreatedAt = modul.UpdatedAt
+	return modulRepo.CreateRevision(ctx, baseline)
+}
+
+// recordModulRevision stores the change from before to after as a new revision and
+// then prunes the revisions beyond retention. The modul itself is already saved, so a
+// failure only costs the history entry or the pruning and is logged rather than returned.
+func recordModulRevision(ctx context.Context, modulRepo repo.ModulRepository, before, after *domain.Modul, action, changedBy, catatan string, retention int) {
+	revision := newModulRevision(after, action, diffModul(before, after), changedBy, catatan)
+	if err := modulRepo.CreateRevision(ctx, revision); err != nil {
+		zlog.Warn().Err(err).Str("modul_id", after.ID).Str("action", action).Msg("failed to record modul revision")
+		return
+	}
+	if err := pruneModulRevisions(ctx, modulRepo, after.ID, after.FilePath, retention); err != nil {
+		zlog.Warn().Err(err).Str("modul_id", after.ID).Msg("failed to prune modul revisions")
+	}
+}
+
+// pruneModulRevisions removes every revision after the newest retention ones. A
+// revision's file is deleted only when neither the current file nor a kept revision
+// points at it, since metadata changes and rollbacks make revisions share files.
+func pruneModulRevisions(ctx context.Context, modulRepo repo.ModulRepository, modulID, currentPath string, retention int) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	revisions, err := modulRepo.GetRevisions(ctx, modulID)
+	if err != nil {
+		return err
+	}
+	if len(revisions) <= retention {
+		return nil
+	}
+
+	kept := map[string]bool{currentPath: true}
+	for i := range revisions[:retention] {
+		kept[revisions[i].FilePath] = true
+	}
+
+	pruned := revisions[retention:]
+	ids := make([]uint, 0, len(pruned))
+	for i := range pruned {
+		ids = append(ids, pruned[i].ID)
+	}
+	if err := modulRepo.DeleteRevisions(ctx, ids); err != nil {
+		return err
+	}
+
+	for i := range pruned {
+		if pruned[i].FilePath == "" || kept[pruned[i].FilePath] {
+			continue
+		}
+		kept[pruned[i].FilePath] = true
+		if err := storage.DeleteFile(pruned[i].FilePath); err != nil {
+			zlog.Warn().Err(err).Str("file", pruned[i].FilePath).Str("modul_id", modulID).Msg("failed to delete pruned modul revision file")
+		}
+	}
+
+	return nil
+}
+
+// prunedRevisionBytes is what recording one more revision of modul deletes from disk:
+// the files of the revisions it pushes out of retention that no kept revision points
+// at. The replaced file itself stays in a revision, so it only counts once retention
+// leaves no room for it.
+func prunedRevisionBytes(ctx context.Context, modulRepo repo.ModulRepository, modul *domain.Modul, retention int) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	revisions, err := modulRepo.GetRevisions(ctx, modul.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(revisions) == 0 {
+		// The update records the current state as the first revision before replacing it.
+		revisions = []domain.ModulRevision{{FilePath: modul.FilePath, FileSize: modul.FileSize}}
+	}
+	// The new revision takes the first of the retention places.
+	if len(revisions) < retention {
+		return 0, nil
+	}
+
+	kept := make(map[string]bool, retention)
+	for i := range revisions[:retention-1] {
+		kept[revisions[i].FilePath] = true
+	}
+
+	var freed int64
+	for i := range revisions[retention-1:] {
+		revision := &revisions[retention-1+i]
+		if revision.FilePath == "" || kept[revision.FilePath] {
+			continue
+		}
+		kept[revision.FilePath] = true
+		freed += revision.FileSize
+	}
+	return freed, nil
+}
+
+func newModulRevision(modul *domain.Modul, action string, changes []domain.ModulRevisionChange, changedBy, catatan string) *domain.ModulRevision {
+	return &domain.ModulRevision{
+		ModulID:   modul.ID,
+		Action:    action,
+		Judul:     modul.Judul,
+		Deskripsi: modul.Deskripsi,
+		FilePath:  modul.FilePath,
+		FileName:  modul.FileName,
+		FileSize:  modul.FileSize,
+		MimeType:  modul.MimeType,
+		Changes:   changes,
+		Catatan:   catatan,
+		ChangedBy: changedBy,
+	}
+}
+
+// modulFromRevision returns a copy of modul with the content recorded in revision.
+func modulFromRevision(modul *domain.Modul, revision *domain.ModulRevision) *domain.Modul {
+	restored := *modul
+	restored.Judul = revision.Judul
+	restored.Deskripsi = revision.Deskripsi
+	restored.FilePath = revision.FilePath
+	restored.FileName = revision.FileName
+	restored.FileSize = revision.FileSize
+	restored.MimeType = revision.MimeType
+	return &restored
+}
+
+// diffModul lists the fields that differ between before and after. A replaced file
+// always shows up as a file_name change, since every upload gets its own name.
+func diffModul(before, after *domain.Modul) []domain.ModulRevisionChange {
+	changes := []domain.ModulRevisionChange{}
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, domain.ModulRevisionChange{Field: field, Before: oldValue, After: newValue})
+		}
+	}
+
+	add("judul", before.Judul, after.Judul)
+	add("deskripsi", before.Deskripsi, after.Deskripsi)
+	add("file_name", before.FileName, after.FileName)
+	if before.FileSize != after.FileSize {
+		add("file_size", formatModulSize(before), formatModulSize(after))
+	}
+	add("mime_type", before.MimeType, after.MimeType)
+	return changes
+}
+
+func formatModulSize(modul *domain.Modul) string {
+	if modul.FilePath == "" && modul.FileSize == 0 {
+		return ""
+	}
+	return storage.FormatFileSize(modul.FileSize)
+}
+
+func toModulRevisionItem(revision *domain.ModulRevision) dto.ModulRevisionItem {
+	changes := make([]dto.ModulRevisionChange, 0, len(revision.Changes))
+	for _, change := range revision.Changes {
+		changes = append(changes, dto.ModulRevisionChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+
+	return dto.ModulRevisionItem{
+		Revision:  revision.Revision,
+		Action:    revision.Action,
+		Judul:     revision.Judul,
+		Deskripsi: revision.Deskripsi,
+		FileName:  revision.FileName,
+		FileSize:  revision.FileSize,
+		MimeType:  revision.MimeType,
+		Changes:   changes,
+		Catatan:   revision.Catatan,
+		ChangedBy: revision.ChangedBy,
+		CreatedAt: revision.CreatedAt,
+	}
+}
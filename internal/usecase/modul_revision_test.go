@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const revisionTestModulID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestDiffModul(t *testing.T) {
+	t.Parallel()
+	before := &domain.Modul{Judul: "Basis Data", Deskripsi: "2024", FileName: "a.dat", FilePath: "/a.dat", FileSize: 1024, MimeType: "application/pdf"}
+	after := &domain.Modul{Judul: "Basis Data", Deskripsi: "2025", FileName: "b.dat", FilePath: "/b.dat", FileSize: 2048, MimeType: "application/pdf"}
+
+	assert.Equal(t, []domain.ModulRevisionChange{
+		{Field: "deskripsi", Before: "2024", After: "2025"},
+		{Field: "file_name", Before: "a.dat", After: "b.dat"},
+		{Field: "file_size", Before: "1.00KB", After: "2.00KB"},
+	}, diffModul(before, after))
+	assert.Empty(t, diffModul(before, before))
+
+	created := diffModul(&domain.Modul{}, before)
+	assert.Contains(t, created, domain.ModulRevisionChange{Field: "judul", Before: "", After: "Basis Data"})
+}
+
+func TestModulUsecase_GetRevisions(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	modul := &domain.Modul{ID: revisionTestModulID, UserID: "user-1", Judul: "Basis Data 2025", FilePath: "/v2.dat", FileName: "v2.dat", FileSize: 20}
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(modul, nil)
+	mockModulRepo.On("GetRevisions", mock.Anything, revisionTestModulID).Return([]domain.ModulRevision{
+		{Revision: 2, Action: domain.ModulRevisionFile, Judul: "Basis Data 2025", FilePath: "/v2.dat", FileName: "v2.dat", FileSize: 20,
+			Changes: []domain.ModulRevisionChange{{Field: "judul", Before: "Basis Data 2024", After: "Basis Data 2025"}}, ChangedBy: "user-1"},
+		{Revision: 1, Action: domain.ModulRevisionCreate, Judul: "Basis Data 2024", FilePath: "/v1.dat", FileName: "v1.dat", FileSize: 10},
+	}, nil)
+
+	result, err := modulUc.GetRevisions(context.Background(), revisionTestModulID, "user-1")
+	require.NoError(t, err)
+
+	require.Len(t, result.Items, 2)
+	assert.True(t, result.Items[0].IsCurrent)
+	assert.False(t, result.Items[1].IsCurrent)
+	assert.Equal(t, []dto.ModulRevisionChange{{Field: "judul", Before: "Basis Data 2024", After: "Basis Data 2025"}}, result.Items[0].Changes)
+	assert.Equal(t, "user-1", result.Items[0].ChangedBy)
+}
+
+func TestModulUsecase_GetRevisions_Forbidden(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(&domain.Modul{ID: revisionTestModulID, UserID: "user-2"}, nil)
+
+	_, err := modulUc.GetRevisions(context.Background(), revisionTestModulID, "user-1")
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, 403, appErr.HTTPStatus)
+	mockModulRepo.AssertNotCalled(t, "GetRevisions", mock.Anything, mock.Anything)
+}
+
+func TestModulUsecase_DownloadRevision(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	oldFile := filepath.Join(t.TempDir(), "v1.dat")
+	require.NoError(t, os.WriteFile(oldFile, []byte("versi lama"), 0o644))
+
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(&domain.Modul{ID: revisionTestModulID, UserID: "user-1", FilePath: "/v2.dat"}, nil)
+	mockModulRepo.On("GetRevision", mock.Anything, revisionTestModulID, 1).Return(&domain.ModulRevision{Revision: 1, FilePath: oldFile, FileName: "materi.pdf"}, nil)
+	mockModulRepo.On("GetRevision", mock.Anything, revisionTestModulID, 9).Return(nil, apperrors.ErrRecordNotFound)
+
+	download, err := modulUc.DownloadRevision(context.Background(), revisionTestModulID, "user-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "materi.pdf", download.FileName)
+
+	_, err = modulUc.DownloadRevision(context.Background(), revisionTestModulID, "user-1", 9)
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, 404, appErr.HTTPStatus)
+}
+
+func TestModulUsecase_Rollback(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	oldFile := filepath.Join(t.TempDir(), "v1.dat")
+	require.NoError(t, os.WriteFile(oldFile, []byte("versi lama"), 0o644))
+
+	modul := &domain.Modul{ID: revisionTestModulID, UserID: "user-1", Judul: "Basis Data 2025", FilePath: "/v2.dat", FileName: "v2.dat", FileSize: 20, UpdatedAt: time.Now()}
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(modul, nil)
+	mockModulRepo.On("GetRevision", mock.Anything, revisionTestModulID, 1).Return(&domain.ModulRevision{
+		Revision: 1, Judul: "Basis Data 2024", FilePath: oldFile, FileName: "v1.dat", FileSize: 10,
+	}, nil)
+	mockModulRepo.On("GetRevisions", mock.Anything, revisionTestModulID).Return([]domain.ModulRevision{{Revision: 2}, {Revision: 1}}, nil)
+	mockModulRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.Modul) bool {
+		return m.Judul == "Basis Data 2024" && m.FilePath == oldFile && m.FileSize == 10
+	})).Return(nil).Once()
+	mockModulRepo.On("CreateRevision", mock.Anything, mock.MatchedBy(func(r *domain.ModulRevision) bool {
+		return r.Action == domain.ModulRevisionRollback && r.FilePath == oldFile && r.Catatan == "Dikembalikan ke revisi 1" && r.ChangedBy == "user-1"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ModulRevision).Revision = 3
+	}).Return(nil).Once()
+
+	item, err := modulUc.Rollback(context.Background(), revisionTestModulID, "user-1", 1, dto.RollbackModulRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, item.Revision)
+	assert.True(t, item.IsCurrent)
+	assert.Contains(t, item.Changes, dto.ModulRevisionChange{Field: "judul", Before: "Basis Data 2025", After: "Basis Data 2024"})
+	mockModulRepo.AssertExpectations(t)
+}
+
+func TestModulUsecase_Rollback_PrunesRevisions(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 2)
+
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "v1.dat")
+	middleFile := filepath.Join(dir, "v2.dat")
+	require.NoError(t, os.WriteFile(oldFile, []byte("versi lama"), 0o644))
+	require.NoError(t, os.WriteFile(middleFile, []byte("versi tengah"), 0o644))
+
+	modul := &domain.Modul{ID: revisionTestModulID, UserID: "user-1", Judul: "Basis Data", FilePath: "/v3.dat", FileName: "v3.dat", FileSize: 30}
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(modul, nil)
+	mockModulRepo.On("GetRevision", mock.Anything, revisionTestModulID, 1).Return(&domain.ModulRevision{
+		Revision: 1, Judul: "Basis Data", FilePath: oldFile, FileName: "v1.dat", FileSize: 10,
+	}, nil)
+	mockModulRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Modul")).Return(nil).Once()
+	mockModulRepo.On("CreateRevision", mock.Anything, mock.AnythingOfType("*domain.ModulRevision")).Return(nil).Once()
+	mockModulRepo.On("GetRevisions", mock.Anything, revisionTestModulID).Return([]domain.ModulRevision{
+		{ID: 4, Revision: 4, FilePath: oldFile},
+		{ID: 3, Revision: 3, FilePath: "/v3.dat"},
+		{ID: 2, Revision: 2, FilePath: middleFile},
+		{ID: 1, Revision: 1, FilePath: oldFile},
+	}, nil)
+	mockModulRepo.On("DeleteRevisions", mock.Anything, []uint{2, 1}).Return(nil).Once()
+
+	_, err := modulUc.Rollback(context.Background(), revisionTestModulID, "user-1", 1, dto.RollbackModulRequest{})
+	require.NoError(t, err)
+
+	assert.FileExists(t, oldFile)
+	assert.NoFileExists(t, middleFile)
+	mockModulRepo.AssertExpectations(t)
+}
+
+func TestModulUsecase_Rollback_Errors(t *testing.T) {
+	t.Parallel()
+	current := &domain.Modul{ID: revisionTestModulID, UserID: "user-1", Judul: "Basis Data", FilePath: "/v2.dat", FileName: "v2.dat", FileSize: 20}
+
+	tests := []struct {
+		name     string
+		revision *domain.ModulRevision
+		wantCode int
+	}{
+		{
+			name:     "already matches",
+			revision: &domain.ModulRevision{Revision: 2, Judul: "Basis Data", FilePath: "/v2.dat", FileName: "v2.dat", FileSize: 20},
+			wantCode: 409,
+		},
+		{
+			name:     "revision file missing",
+			revision: &domain.ModulRevision{Revision: 1, Judul: "Basis Data", FilePath: filepath.Join(os.TempDir(), "missing-revision.dat"), FileName: "v1.dat", FileSize: 10},
+			wantCode: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockModulRepo := new(MockModulRepository)
+			modulUc := NewModulUsecase(mockModulRepo, 0)
+			modul := *current
+			mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(&modul, nil)
+			mockModulRepo.On("GetRevision", mock.Anything, revisionTestModulID, tt.revision.Revision).Return(tt.revision, nil)
+
+			_, err := modulUc.Rollback(context.Background(), revisionTestModulID, "user-1", tt.revision.Revision, dto.RollbackModulRequest{})
+
+			var appErr *apperrors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.wantCode, appErr.HTTPStatus)
+			mockModulRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestModulUsecase_UpdateMetadata_Unchanged(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(&domain.Modul{ID: revisionTestModulID, UserID: "user-1", Judul: "Basis Data"}, nil)
+	mockModulRepo.On("UpdateMetadata", mock.Anything, mock.AnythingOfType("*domain.Modul")).Return(nil)
+
+	require.NoError(t, modulUc.UpdateMetadata(context.Background(), revisionTestModulID, "user-1", dto.UpdateModulRequest{Judul: "Basis Data"}))
+	mockModulRepo.AssertNotCalled(t, "CreateRevision", mock.Anything, mock.Anything)
+}
+
+func TestModulUsecase_Delete_RemovesRevisionFiles(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
+
+	dir := t.TempDir()
+	current := filepath.Join(dir, "v2.dat")
+	older := filepath.Join(dir, "v1.dat")
+	require.NoError(t, os.WriteFile(current, []byte("v2"), 0o644))
+	require.NoError(t, os.WriteFile(older, []byte("v1"), 0o644))
+
+	mockModulRepo.On("GetByID", mock.Anything, revisionTestModulID).Return(&domain.Modul{ID: revisionTestModulID, UserID: "user-1", FilePath: current}, nil)
+	mockModulRepo.On("GetRevisions", mock.Anything, revisionTestModulID).Return([]domain.ModulRevision{
+		{Revision: 2, FilePath: current},
+		{Revision: 1, FilePath: older},
+	}, nil)
+	mockModulRepo.On("Delete", mock.Anything, revisionTestModulID).Return(nil)
+
+	require.NoError(t, modulUc.Delete(context.Background(), revisionTestModulID, "user-1"))
+	assert.NoFileExists(t, current)
+	assert.NoFileExists(t, older)
+}
+
+func TestPruneModulRevisions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, name := range []string{"v1", "v2", "v3"} {
+		files[name] = filepath.Join(dir, name+".dat")
+		require.NoError(t, os.WriteFile(files[name], []byte(name), 0o644))
+	}
+
+	modulRepo := new(MockModulRepository)
+	// Revision 5 rolled back to v1 and revision 2 only changed the title, so v1's file
+	// is still in use and v2's is shared by a pruned and a kept revision.
+	modulRepo.On("GetRevisions", mock.Anything, revisionTestModulID).Return([]domain.ModulRevision{
+		{ID: 5, Revision: 5, FilePath: files["v1"]},
+		{ID: 4, Revision: 4, FilePath: files["v2"]},
+		{ID: 3, Revision: 3, FilePath: files["v3"]},
+		{ID: 2, Revision: 2, FilePath: files["v2"]},
+		{ID: 1, Revision: 1, FilePath: files["v1"]},
+	}, nil)
+	modulRepo.On("DeleteRevisions", mock.Anything, []uint{3, 2, 1}).Return(nil).Once()
+
+	require.NoError(t, pruneModulRevisions(context.Background(), modulRepo, revisionTestModulID, files["v1"], 2))
+
+	assert.FileExists(t, files["v1"])
+	assert.FileExists(t, files["v2"])
+	assert.NoFileExists(t, files["v3"])
+	modulRepo.AssertExpectations(t)
+}
+
+func TestPruneModulRevisions_KeepAll(t *testing.T) {
+	t.Parallel()
+	modulRepo := new(MockModulRepository)
+
+	require.NoError(t, pruneModulRevisions(context.Background(), modulRepo, revisionTestModulID, "/tmp/v1.dat", 0))
+	modulRepo.AssertNotCalled(t, "GetRevisions", mock.Anything, mock.Anything)
+}
@@ -4,11 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"invento-service/internal/domain"
 	"invento-service/internal/dto"
 	"invento-service/internal/storage"
 	"invento-service/internal/usecase/repo"
-	"os"
-	"path/filepath"
 
 	apperrors "invento-service/internal/errors"
 
@@ -20,16 +19,31 @@ type ModulUsecase interface {
 	GetByID(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error)
 	UpdateMetadata(ctx context.Context, modulID, userID string, req dto.UpdateModulRequest) error
 	Delete(ctx context.Context, modulID, userID string) error
-	Download(ctx context.Context, userID string, modulIDs []string) (string, error)
+	Download(ctx context.Context, userID string, modulIDs []string) (*storage.Download, error)
+	GetRevisions(ctx context.Context, modulID, userID string) (*dto.ModulRevisionListData, error)
+	DownloadRevision(ctx context.Context, modulID, userID string, revision int) (*storage.Download, error)
+	Rollback(ctx context.Context, modulID, userID string, revision int, req dto.RollbackModulRequest) (*dto.ModulRevisionItem, error)
+	Submit(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error)
+	Publish(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error)
+	Withdraw(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error)
+	Archive(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error)
+	Review(ctx context.Context, modulID, reviewerID string, req dto.ReviewModulRequest) (*dto.ModulResponse, error)
+	GetReviewQueue(ctx context.Context, page, limit int) (*dto.ModulReviewListData, error)
+	GetLibrary(ctx context.Context, userID string, params dto.ModulLibraryQueryParams) (*dto.ModulLibraryData, error)
+	UpdateVisibility(ctx context.Context, modulID, userID string, req dto.UpdateModulVisibilityRequest) (*dto.ModulVisibilityData, error)
 }
 
 type modulUsecase struct {
-	modulRepo repo.ModulRepository
+	modulRepo         repo.ModulRepository
+	revisionRetention int
 }
 
-func NewModulUsecase(modulRepo repo.ModulRepository) ModulUsecase {
+// NewModulUsecase builds a ModulUsecase that keeps the newest revisionRetention
+// revisions of a modul; 0 keeps all.
+func NewModulUsecase(modulRepo repo.ModulRepository, revisionRetention int) ModulUsecase {
 	return &modulUsecase{
-		modulRepo: modulRepo,
+		modulRepo:         modulRepo,
+		revisionRetention: revisionRetention,
 	}
 }
 
@@ -46,6 +60,10 @@ func (uc *modulUsecase) GetList(ctx context.Context, userID, search, filterType,
 		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.GetList: %w", err))
 	}
 
+	for i := range moduls {
+		moduls[i].Status = domain.NormalizeModulStatus(moduls[i].Status)
+	}
+
 	totalPages := (total + limit - 1) / limit
 
 	return &dto.ModulListData{
@@ -59,6 +77,8 @@ func (uc *modulUsecase) GetList(ctx context.Context, userID, search, filterType,
 	}, nil
 }
 
+// GetByID returns a modul to its owner in any status, and to other users only once
+// it is published and shared with them.
 func (uc *modulUsecase) GetByID(ctx context.Context, modulID, userID string) (*dto.ModulResponse, error) {
 	modul, err := uc.modulRepo.GetByID(ctx, modulID)
 	if err != nil {
@@ -69,20 +89,24 @@ func (uc *modulUsecase) GetByID(ctx context.Context, modulID, userID string) (*d
 	}
 
 	if modul.UserID != userID {
-		return nil, apperrors.NewForbiddenError("Tidak memiliki akses ke modul ini")
+		visible, err := uc.canView(ctx, modul, userID)
+		if err != nil {
+			return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.GetByID: %w", err))
+		}
+		if !visible {
+			return nil, apperrors.NewForbiddenError("Tidak memiliki akses ke modul ini")
+		}
+		return toModulResponse(modul), nil
 	}
 
-	return &dto.ModulResponse{
-		ID:        modul.ID,
-		Judul:     modul.Judul,
-		Deskripsi: modul.Deskripsi,
-		FileName:  modul.FileName,
-		MimeType:  modul.MimeType,
-		FileSize:  modul.FileSize,
-		Status:    modul.Status,
-		CreatedAt: modul.CreatedAt,
-		UpdatedAt: modul.UpdatedAt,
-	}, nil
+	response := toModulResponse(modul)
+	if modul.Visibility == domain.ModulVisibilityRoles {
+		if response.RoleIDs, err = uc.modulRepo.GetAllowedRoleIDs(ctx, modulID); err != nil {
+			return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.GetByID: roles: %w", err))
+		}
+	}
+
+	return response, nil
 }
 
 func (uc *modulUsecase) Delete(ctx context.Context, modulID, userID string) error {
@@ -98,65 +122,75 @@ func (uc *modulUsecase) Delete(ctx context.Context, modulID, userID string) erro
 		return apperrors.NewForbiddenError("Tidak memiliki akses ke modul ini")
 	}
 
+	revisions, err := uc.modulRepo.GetRevisions(ctx, modulID)
+	if err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Delete: revisions: %w", err))
+	}
+
 	if err := uc.modulRepo.Delete(ctx, modulID); err != nil {
 		return apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Delete: %w", err))
 	}
 
-	if modul.FilePath != "" {
-		if err := storage.DeleteFile(modul.FilePath); err != nil {
+	files := []string{modul.FilePath}
+	for i := range revisions {
+		files = append(files, revisions[i].FilePath)
+	}
+	deleted := map[string]bool{"": true}
+	for _, file := range files {
+		if deleted[file] {
+			continue
+		}
+		deleted[file] = true
+		if err := storage.DeleteFile(file); err != nil {
 			// File deletion after DB delete is critical but non-blocking;
 			// log the error so it can be investigated.
-			zlog.Warn().Err(err).Str("file", modul.FilePath).Msg("ModulUsecase.Delete: failed to delete modul file")
+			zlog.Warn().Err(err).Str("file", file).Msg("ModulUsecase.Delete: failed to delete modul file")
 		}
 	}
 
 	return nil
 }
 
-func (uc *modulUsecase) Download(ctx context.Context, userID string, modulIDs []string) (string, error) {
+func (uc *modulUsecase) Download(ctx context.Context, userID string, modulIDs []string) (*storage.Download, error) {
 	if len(modulIDs) == 0 {
-		return "", apperrors.NewValidationError("ID modul tidak boleh kosong", nil)
+		return nil, apperrors.NewValidationError("ID modul tidak boleh kosong", nil)
 	}
 
-	moduls, err := uc.modulRepo.GetByIDs(ctx, modulIDs, userID)
+	moduls, err := uc.modulRepo.GetVisibleByIDs(ctx, modulIDs, userID)
 	if err != nil {
-		return "", apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Download: %w", err))
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Download: %w", err))
 	}
 
 	if len(moduls) == 0 {
-		return "", apperrors.NewNotFoundError("Modul")
+		return nil, apperrors.NewNotFoundError("Modul")
 	}
 
+	backend := storage.DefaultBackend()
 	if len(moduls) == 1 {
-		if _, statErr := os.Stat(moduls[0].FilePath); os.IsNotExist(statErr) {
-			return "", apperrors.NewNotFoundError("File modul")
+		if _, statErr := backend.Stat(ctx, moduls[0].FilePath); errors.Is(statErr, storage.ErrObjectNotFound) {
+			return nil, apperrors.NewNotFoundError("File modul")
 		}
-		return moduls[0].FilePath, nil
+		entry := storage.ModulArchiveEntry(&moduls[0], moduls[0].FilePath)
+		return storage.NewFileDownload(backend, moduls[0].FilePath, entry.DownloadName()), nil
 	}
 
-	var filePaths []string
-	for _, modul := range moduls {
-		filePaths = append(filePaths, modul.FilePath)
+	entries := make([]storage.ArchiveEntry, 0, len(moduls))
+	for i := range moduls {
+		entries = append(entries, storage.ModulArchiveEntry(&moduls[i], moduls[i].FilePath))
 	}
 
-	tempDir := "./uploads/temp"
-	if err = os.MkdirAll(tempDir, 0o755); err != nil {
-		return "", apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Download: %w", err))
-	}
-
-	identifier, err := storage.GenerateUniqueIdentifier(8)
+	download, err := storage.NewArchiveDownload(ctx, backend, "moduls.zip", entries, nil)
 	if err != nil {
-		return "", apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Download: %w", err))
-	}
-
-	zipFileName := fmt.Sprintf("moduls_%s.zip", identifier)
-	zipFilePath := filepath.Join(tempDir, zipFileName)
-
-	if err := storage.CreateZipArchive(filePaths, zipFilePath); err != nil {
-		return "", apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Download: %w", err))
+		switch {
+		case errors.Is(err, storage.ErrArchiveTooLarge):
+			return nil, apperrors.NewPayloadTooLargeError("Ukuran total modul melebihi batas download")
+		case errors.Is(err, storage.ErrObjectNotFound):
+			return nil, apperrors.NewNotFoundError("File modul")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.Download: %w", err))
 	}
 
-	return zipFilePath, nil
+	return download, nil
 }
 
 func (uc *modulUsecase) UpdateMetadata(ctx context.Context, modulID, userID string, req dto.UpdateModulRequest) error {
@@ -172,6 +206,7 @@ func (uc *modulUsecase) UpdateMetadata(ctx context.Context, modulID, userID stri
 		return apperrors.NewForbiddenError("Tidak memiliki akses ke modul ini")
 	}
 
+	before := *modul
 	if req.Judul != "" {
 		modul.Judul = req.Judul
 	}
@@ -179,9 +214,36 @@ func (uc *modulUsecase) UpdateMetadata(ctx context.Context, modulID, userID stri
 		modul.Deskripsi = req.Deskripsi
 	}
 
+	changed := len(diffModul(&before, modul)) > 0
+	if changed {
+		if err := ensureModulBaseline(ctx, uc.modulRepo, &before); err != nil {
+			return apperrors.NewInternalError(fmt.Errorf("ModulUsecase.UpdateMetadata: baseline revision: %w", err))
+		}
+	}
+
 	if err := uc.modulRepo.UpdateMetadata(ctx, modul); err != nil {
 		return apperrors.NewInternalError(fmt.Errorf("ModulUsecase.UpdateMetadata: %w", err))
 	}
 
+	if changed {
+		recordModulRevision(ctx, uc.modulRepo, &before, modul, domain.ModulRevisionMetadata, userID, "", uc.revisionRetention)
+	}
+
 	return nil
 }
+
+func (uc *modulUsecase) getOwnedModul(ctx context.Context, modulID, userID string) (*domain.Modul, error) {
+	modul, err := uc.modulRepo.GetByID(ctx, modulID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Modul")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ModulUsecase.getOwnedModul: %w", err))
+	}
+
+	if modul.UserID != userID {
+		return nil, apperrors.NewForbiddenError("Tidak memiliki akses ke modul ini")
+	}
+
+	return modul, nil
+}
@@ -23,7 +23,7 @@ func TestCreateModul_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	_ = NewModulUsecase(mockModulRepo)
+	_ = NewModulUsecase(mockModulRepo, 0)
 
 	modul := &domain.Modul{
 		ID:        "550e8400-e29b-41d4-a716-446655440000",
@@ -53,7 +53,7 @@ func TestGetModulByID_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	_ = NewModulUsecase(mockModulRepo)
+	_ = NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440000"
 	userID := "user-1"
@@ -89,7 +89,7 @@ func TestGetModulByID_NotFound(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	_ = NewModulUsecase(mockModulRepo)
+	_ = NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440999"
 
@@ -108,7 +108,7 @@ func TestListModuls_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	_ = NewModulUsecase(mockModulRepo)
+	_ = NewModulUsecase(mockModulRepo, 0)
 
 	userID := "user-1"
 	search := ""
@@ -161,7 +161,7 @@ func TestListModulsByProject_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	_ = NewModulUsecase(mockModulRepo)
+	_ = NewModulUsecase(mockModulRepo, 0)
 
 	userID := "user-1"
 	ids := []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002", "550e8400-e29b-41d4-a716-446655440003"}
@@ -212,7 +212,7 @@ func TestUpdateModul_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	modulUc := NewModulUsecase(mockModulRepo)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
 
 	existingModul := &domain.Modul{
 		ID:        "550e8400-e29b-41d4-a716-446655440000",
@@ -234,7 +234,14 @@ func TestUpdateModul_Success(t *testing.T) {
 	}
 
 	mockModulRepo.On("GetByID", mock.Anything, "550e8400-e29b-41d4-a716-446655440000").Return(existingModul, nil)
+	mockModulRepo.On("GetRevisions", mock.Anything, "550e8400-e29b-41d4-a716-446655440000").Return([]domain.ModulRevision{}, nil)
+	mockModulRepo.On("CreateRevision", mock.Anything, mock.MatchedBy(func(r *domain.ModulRevision) bool {
+		return r.Action == domain.ModulRevisionCreate && r.Judul == "Old Judul"
+	})).Return(nil).Once()
 	mockModulRepo.On("UpdateMetadata", mock.Anything, mock.AnythingOfType("*domain.Modul")).Return(nil)
+	mockModulRepo.On("CreateRevision", mock.Anything, mock.MatchedBy(func(r *domain.ModulRevision) bool {
+		return r.Action == domain.ModulRevisionMetadata && r.ChangedBy == "user-1" && len(r.Changes) == 2
+	})).Return(nil).Once()
 
 	err := modulUc.UpdateMetadata(context.Background(), "550e8400-e29b-41d4-a716-446655440000", "user-1", req)
 
@@ -242,11 +249,33 @@ func TestUpdateModul_Success(t *testing.T) {
 	mockModulRepo.AssertExpectations(t)
 }
 
+func TestUpdateModulMetadata_PrunesRevisions(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUc := NewModulUsecase(mockModulRepo, 1)
+
+	modulID := "550e8400-e29b-41d4-a716-446655440000"
+	existingModul := &domain.Modul{ID: modulID, UserID: "user-1", Judul: "Old Judul", FilePath: "/uploads/test.pdf"}
+	mockModulRepo.On("GetByID", mock.Anything, modulID).Return(existingModul, nil)
+	mockModulRepo.On("GetRevisions", mock.Anything, modulID).Return([]domain.ModulRevision{
+		{ID: 2, Revision: 2, FilePath: "/uploads/test.pdf"},
+		{ID: 1, Revision: 1, FilePath: "/uploads/test.pdf"},
+	}, nil)
+	mockModulRepo.On("UpdateMetadata", mock.Anything, mock.AnythingOfType("*domain.Modul")).Return(nil)
+	mockModulRepo.On("CreateRevision", mock.Anything, mock.AnythingOfType("*domain.ModulRevision")).Return(nil).Once()
+	mockModulRepo.On("DeleteRevisions", mock.Anything, []uint{1}).Return(nil).Once()
+
+	err := modulUc.UpdateMetadata(context.Background(), modulID, "user-1", dto.UpdateModulRequest{Judul: "Updated Judul"})
+
+	assert.NoError(t, err)
+	mockModulRepo.AssertExpectations(t)
+}
+
 func TestDeleteModul_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
 
-	modulUc := NewModulUsecase(mockModulRepo)
+	modulUc := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440000"
 	userID := "user-1"
@@ -266,6 +295,7 @@ func TestDeleteModul_Success(t *testing.T) {
 	}
 
 	mockModulRepo.On("GetByID", mock.Anything, modulID).Return(existingModul, nil)
+	mockModulRepo.On("GetRevisions", mock.Anything, modulID).Return([]domain.ModulRevision{}, nil)
 	mockModulRepo.On("Delete", mock.Anything, modulID).Return(nil)
 
 	err := modulUc.Delete(context.Background(), modulID, userID)
@@ -291,7 +321,7 @@ func TestCheckUploadSlot_Success(t *testing.T) {
 	fileManager := storage.NewFileManager(cfg)
 	tusManager := upload.NewTusManager(tusStore, tusQueue, fileManager, cfg, zerolog.Nop())
 
-	_ = NewTusModulUsecase(mockTusModulUploadRepo, mockModulRepo, tusManager, fileManager, cfg)
+	_ = NewTusModulUsecase(mockTusModulUploadRepo, mockModulRepo, tusManager, fileManager, nil, cfg)
 
 	userID := "user-1"
 
@@ -325,7 +355,7 @@ func TestInitiateUpload_Success(t *testing.T) {
 	tusManager := upload.NewTusManager(tusStore, tusQueue, nil, cfg, zerolog.Nop())
 	fileManager := storage.NewFileManager(cfg)
 
-	tusModulUc := NewTusModulUsecase(mockTusModulUploadRepo, mockModulRepo, tusManager, fileManager, cfg)
+	tusModulUc := NewTusModulUsecase(mockTusModulUploadRepo, mockModulRepo, tusManager, fileManager, nil, cfg)
 
 	userID := "user-1"
 	fileSize := int64(1024 * 1024) // 1 MB
@@ -368,7 +398,7 @@ func TestUploadChunk_Success(t *testing.T) {
 	fileManager := storage.NewFileManager(cfg)
 	tusManager := upload.NewTusManager(tusStore, tusQueue, fileManager, cfg, zerolog.Nop())
 
-	_ = NewTusModulUsecase(mockTusModulUploadRepo, mockModulRepo, tusManager, fileManager, cfg)
+	_ = NewTusModulUsecase(mockTusModulUploadRepo, mockModulRepo, tusManager, fileManager, nil, cfg)
 
 	uploadID := "test-upload-id"
 	userID := "user-1"
@@ -392,14 +422,14 @@ func TestUploadChunk_Success(t *testing.T) {
 	progress := 50.0
 
 	mockTusModulUploadRepo.On("GetByID", mock.Anything, uploadID).Return(existingUpload, nil)
-	mockTusModulUploadRepo.On("UpdateOffset", mock.Anything, uploadID, newOffset, progress).Return(nil)
+	mockTusModulUploadRepo.On("UpdateOffset", mock.Anything, uploadID, newOffset, progress, mock.AnythingOfType("time.Time")).Return(nil)
 
 	ctx := context.Background()
 	upload, err := mockTusModulUploadRepo.GetByID(ctx, uploadID)
 	assert.NoError(t, err)
 	assert.NotNil(t, upload)
 
-	err = mockTusModulUploadRepo.UpdateOffset(ctx, uploadID, newOffset, progress)
+	err = mockTusModulUploadRepo.UpdateOffset(ctx, uploadID, newOffset, progress, time.Now())
 	assert.NoError(t, err)
 
 	mockTusModulUploadRepo.AssertExpectations(t)
@@ -409,7 +439,7 @@ func TestUploadChunk_Success(t *testing.T) {
 func TestModulUsecase_GetList_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	userID := "user-1"
 	search := ""
@@ -460,7 +490,7 @@ func TestModulUsecase_GetList_Success(t *testing.T) {
 func TestModulUsecase_GetList_Error(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	mockModulRepo.On("GetByUserID", mock.Anything, "user-1", "", "", "", 1, 10).
 		Return(nil, 0, assert.AnError)
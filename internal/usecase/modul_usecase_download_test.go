@@ -6,6 +6,7 @@ import (
 	"invento-service/internal/domain"
 	"invento-service/internal/dto"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -19,7 +20,7 @@ import (
 func TestModulUsecase_GetList_WithFilters(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	expectedModuls := []dto.ModulListItem{
 		{
@@ -50,7 +51,7 @@ func TestModulUsecase_GetList_WithFilters(t *testing.T) {
 func TestModulUsecase_GetByID_Success(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440000"
 	userID := "user-1"
@@ -85,7 +86,7 @@ func TestModulUsecase_GetByID_Success(t *testing.T) {
 func TestModulUsecase_GetByID_NotFound(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440999"
 	userID := "user-1"
@@ -107,7 +108,7 @@ func TestModulUsecase_GetByID_NotFound(t *testing.T) {
 func TestModulUsecase_GetByID_Unauthorized(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440000"
 	userID := "user-2"
@@ -143,7 +144,7 @@ func TestModulUsecase_GetByID_Unauthorized(t *testing.T) {
 func TestModulUsecase_Download_SingleFile(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	userID := "user-1"
 	modulIDs := []string{"550e8400-e29b-41d4-a716-446655440000"}
@@ -163,12 +164,45 @@ func TestModulUsecase_Download_SingleFile(t *testing.T) {
 		},
 	}
 
-	mockModulRepo.On("GetByIDs", mock.Anything, modulIDs, userID).Return(expectedModuls, nil)
+	mockModulRepo.On("GetVisibleByIDs", mock.Anything, modulIDs, userID).Return(expectedModuls, nil)
 
 	result, err := modulUC.Download(context.Background(), userID, modulIDs)
 
 	assert.NoError(t, err)
-	assert.Equal(t, tempFile.Name(), result)
+	require.NotNil(t, result)
+	assert.Equal(t, tempFile.Name(), result.Key)
+
+	mockModulRepo.AssertExpectations(t)
+}
+
+func TestModulUsecase_Download_MultipleFiles(t *testing.T) {
+	t.Parallel()
+	mockModulRepo := new(MockModulRepository)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
+
+	userID := "user-1"
+	modulIDs := []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"}
+
+	tempDir := t.TempDir()
+	file1 := filepath.Join(tempDir, "a.pdf")
+	file2 := filepath.Join(tempDir, "b.pdf")
+	require.NoError(t, os.WriteFile(file1, []byte("modul-a"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("modul-b"), 0o644))
+
+	mockModulRepo.On("GetVisibleByIDs", mock.Anything, modulIDs, userID).Return([]domain.Modul{
+		{ID: modulIDs[0], UserID: userID, Judul: "Pertemuan 1", FileName: "a.pdf", FilePath: file1},
+		{ID: modulIDs[1], UserID: userID, Judul: "Pertemuan 2", FileName: "b.pdf", FilePath: file2},
+	}, nil)
+
+	result, err := modulUC.Download(context.Background(), userID, modulIDs)
+	require.NoError(t, err)
+	require.True(t, result.IsArchive())
+	assert.Equal(t, "moduls.zip", result.FileName)
+	assert.Equal(t, int64(14), result.Size)
+
+	entries, err := os.ReadDir(tempDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "the archive is streamed, not written to disk")
 
 	mockModulRepo.AssertExpectations(t)
 }
@@ -177,7 +211,7 @@ func TestModulUsecase_Download_SingleFile(t *testing.T) {
 func TestModulUsecase_Download_EmptyIDs(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	userID := "user-1"
 	modulIDs := []string{}
@@ -195,12 +229,12 @@ func TestModulUsecase_Download_EmptyIDs(t *testing.T) {
 func TestModulUsecase_Download_NotFound(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	userID := "user-1"
 	modulIDs := []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"}
 
-	mockModulRepo.On("GetByIDs", mock.Anything, modulIDs, userID).Return([]domain.Modul{}, nil)
+	mockModulRepo.On("GetVisibleByIDs", mock.Anything, modulIDs, userID).Return([]domain.Modul{}, nil)
 
 	result, err := modulUC.Download(context.Background(), userID, modulIDs)
 
@@ -216,7 +250,7 @@ func TestModulUsecase_Download_NotFound(t *testing.T) {
 func TestModulUsecase_UpdateMetadata_NotFound(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440999"
 	userID := "user-1"
@@ -236,7 +270,7 @@ func TestModulUsecase_UpdateMetadata_NotFound(t *testing.T) {
 func TestModulUsecase_UpdateMetadata_Unauthorized(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440001"
 	req := dto.UpdateModulRequest{Judul: "Baru"}
@@ -256,7 +290,7 @@ func TestModulUsecase_UpdateMetadata_Unauthorized(t *testing.T) {
 func TestModulUsecase_Delete_NotFound(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440999"
 	mockModulRepo.On("GetByID", mock.Anything, modulID).Return(nil, apperrors.ErrRecordNotFound).Once()
@@ -273,7 +307,7 @@ func TestModulUsecase_Delete_NotFound(t *testing.T) {
 func TestModulUsecase_Delete_Unauthorized(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440001"
 	mockModulRepo.On("GetByID", mock.Anything, modulID).Return(&domain.Modul{ID: modulID, UserID: "owner"}, nil).Once()
@@ -291,10 +325,10 @@ func TestModulUsecase_Delete_Unauthorized(t *testing.T) {
 func TestModulUsecase_Download_RepositoryError(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulIDs := []string{"550e8400-e29b-41d4-a716-446655440001"}
-	mockModulRepo.On("GetByIDs", mock.Anything, modulIDs, "user-1").Return(nil, assert.AnError).Once()
+	mockModulRepo.On("GetVisibleByIDs", mock.Anything, modulIDs, "user-1").Return(nil, assert.AnError).Once()
 
 	result, err := modulUC.Download(context.Background(), "user-1", modulIDs)
 	require.Error(t, err)
@@ -309,7 +343,7 @@ func TestModulUsecase_Download_RepositoryError(t *testing.T) {
 func TestModulUsecase_GetList_InvalidPagination(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	mockModulRepo.On("GetByUserID", mock.Anything, "user-1", "", "", "", 1, 10).
 		Return([]dto.ModulListItem{}, 0, nil).Once()
@@ -326,7 +360,7 @@ func TestModulUsecase_GetList_InvalidPagination(t *testing.T) {
 func TestModulUsecase_GetByID_RepositoryError(t *testing.T) {
 	t.Parallel()
 	mockModulRepo := new(MockModulRepository)
-	modulUC := NewModulUsecase(mockModulRepo)
+	modulUC := NewModulUsecase(mockModulRepo, 0)
 
 	modulID := "550e8400-e29b-41d4-a716-446655440001"
 	mockModulRepo.On("GetByID", mock.Anything, modulID).Return(nil, assert.AnError).Once()
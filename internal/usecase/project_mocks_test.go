@@ -56,3 +56,84 @@ func (m *MockProjectRepository) CountByUserID(ctx context.Context, userID string
 	args := m.Called(ctx, userID)
 	return args.Int(0), args.Error(1)
 }
+
+func (m *MockProjectRepository) SaveManifest(ctx context.Context, manifest *domain.ProjectManifest) error {
+	args := m.Called(ctx, manifest)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetManifest(ctx context.Context, projectID uint) (*domain.ProjectManifest, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProjectManifest), args.Error(1)
+}
+
+func (m *MockProjectRepository) CreateVersion(ctx context.Context, version *domain.ProjectVersion) error {
+	args := m.Called(ctx, version)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetVersions(ctx context.Context, projectID uint) ([]domain.ProjectVersion, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProjectVersion), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetVersion(ctx context.Context, projectID uint, version int) (*domain.ProjectVersion, error) {
+	args := m.Called(ctx, projectID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProjectVersion), args.Error(1)
+}
+
+func (m *MockProjectRepository) DeleteVersions(ctx context.Context, ids []uint) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetSubmittedPaths(ctx context.Context, projectID uint) ([]string, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockProjectRepository) ReplaceReviewers(ctx context.Context, projectID uint, reviewers []domain.ProjectReviewer) error {
+	args := m.Called(ctx, projectID, reviewers)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetReviewers(ctx context.Context, projectID uint) ([]domain.ProjectReviewer, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProjectReviewer), args.Error(1)
+}
+
+func (m *MockProjectRepository) UpdateReviewStatus(ctx context.Context, project *domain.Project, event *domain.ProjectReviewEvent) (bool, error) {
+	args := m.Called(ctx, project, event)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetReviewEvents(ctx context.Context, projectID uint) ([]domain.ProjectReviewEvent, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProjectReviewEvent), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetReviewQueue(ctx context.Context, reviewerID string, statuses []string, page, limit int) ([]domain.Project, int, error) {
+	args := m.Called(ctx, reviewerID, statuses, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Project), args.Int(1), args.Error(2)
+}
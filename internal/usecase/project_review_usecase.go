@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"invento-service/internal/usecase/repo"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+)
+
+// Steps a reviewer can take, accepted by ProjectReviewUsecase.Review.
+const (
+	projectReviewStart           = "start"
+	projectReviewRequestRevision = "request_revision"
+	projectReviewApprove         = "approve"
+)
+
+// projectReviewSteps maps each reviewer step to the status it leaves and the status
+// it moves the project to.
+var projectReviewSteps = map[string]struct{ from, to, verb string }{
+	projectReviewStart:           {domain.ProjectReviewSubmitted, domain.ProjectReviewUnderReview, "mulai direview"},
+	projectReviewRequestRevision: {domain.ProjectReviewUnderReview, domain.ProjectReviewRevisionRequested, "diminta revisi"},
+	projectReviewApprove:         {domain.ProjectReviewUnderReview, domain.ProjectReviewApproved, "disetujui"},
+}
+
+// maxResubmitAttempts bounds how often resubmitProjectReview retries after losing a
+// race with a reviewer.
+const maxResubmitAttempts = 3
+
+type ProjectReviewUsecase interface {
+	AssignReviewers(ctx context.Context, projectID uint, userID string, req dto.AssignProjectReviewersRequest) (*dto.ProjectReviewResponse, error)
+	GetReview(ctx context.Context, projectID uint, userID string) (*dto.ProjectReviewResponse, error)
+	Review(ctx context.Context, projectID uint, reviewerID string, req dto.ReviewProjectRequest) (*dto.ProjectReviewResponse, error)
+	GetQueue(ctx context.Context, reviewerID string, params dto.ProjectReviewQueueParams) (*dto.ProjectReviewListData, error)
+	Download(ctx context.Context, projectID uint, userID string) (*storage.Download, error)
+}
+
+type projectReviewUsecase struct {
+	projectRepo repo.ProjectRepository
+	userRepo    repo.UserRepository
+}
+
+func NewProjectReviewUsecase(projectRepo repo.ProjectRepository, userRepo repo.UserRepository) ProjectReviewUsecase {
+	return &projectReviewUsecase{
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// AssignReviewers lets the owner choose which dosen review the project, replacing
+// the earlier choice. A verdict belongs to the reviewers who gave it, so changing
+// them puts the review back to submitted.
+func (uc *projectReviewUsecase) AssignReviewers(ctx context.Context, projectID uint, userID string, req dto.AssignProjectReviewersRequest) (*dto.ProjectReviewResponse, error) {
+	project, err := uc.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.UserID != userID {
+		return nil, apperrors.NewForbiddenError("Hanya pemilik project yang dapat memilih reviewer")
+	}
+
+	dosenIDs := slices.Compact(slices.Sorted(slices.Values(req.DosenIDs)))
+	if slices.Contains(dosenIDs, userID) {
+		return nil, apperrors.NewValidationError("Tidak dapat menjadi reviewer project milik sendiri", nil)
+	}
+
+	users, err := uc.userRepo.GetByIDs(ctx, dosenIDs)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.AssignReviewers: %w", err))
+	}
+	if len(users) != len(dosenIDs) {
+		return nil, apperrors.NewNotFoundError("Dosen")
+	}
+	for _, user := range users {
+		if user.Role == nil || !strings.EqualFold(user.Role.NamaRole, domain.ClassRoleDosen) {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("%s bukan dosen", user.Name), nil)
+		}
+	}
+
+	current, err := uc.projectRepo.GetReviewers(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.AssignReviewers: %w", err))
+	}
+	currentIDs := make([]string, 0, len(current))
+	for i := range current {
+		currentIDs = append(currentIDs, current[i].UserID)
+	}
+	slices.Sort(currentIDs)
+
+	// The status goes back first: should replacing the reviewers then fail, the old
+	// reviewers only have to look at the project again.
+	if !slices.Equal(currentIDs, dosenIDs) {
+		if err := resubmitProjectReview(ctx, uc.projectRepo, project, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	reviewers := make([]domain.ProjectReviewer, 0, len(dosenIDs))
+	for _, id := range dosenIDs {
+		reviewers = append(reviewers, domain.ProjectReviewer{ProjectID: project.ID, UserID: id, AssignedBy: userID})
+	}
+	if err := uc.projectRepo.ReplaceReviewers(ctx, project.ID, reviewers); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.AssignReviewers: %w", err))
+	}
+
+	return uc.buildReviewResponse(ctx, project)
+}
+
+// GetReview shows the review status, reviewers and history of a project to its
+// owner and its reviewers.
+func (uc *projectReviewUsecase) GetReview(ctx context.Context, projectID uint, userID string) (*dto.ProjectReviewResponse, error) {
+	project, err := uc.getParticipatingProject(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildReviewResponse(ctx, project)
+}
+
+// Review takes one reviewer step on a project. Only the dosen the owner assigned may
+// act, and a revision request must say what to revise.
+func (uc *projectReviewUsecase) Review(ctx context.Context, projectID uint, reviewerID string, req dto.ReviewProjectRequest) (*dto.ProjectReviewResponse, error) {
+	step, ok := projectReviewSteps[req.Action]
+	if !ok {
+		return nil, apperrors.NewValidationError("Aksi review tidak valid", nil)
+	}
+
+	komentar := strings.TrimSpace(req.Komentar)
+	if req.Action == projectReviewRequestRevision && komentar == "" {
+		return nil, apperrors.NewValidationError("Komentar wajib diisi saat meminta revisi", nil)
+	}
+
+	project, err := uc.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	isReviewer, err := uc.isReviewer(ctx, project.ID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !isReviewer {
+		return nil, apperrors.NewForbiddenError("Hanya reviewer project yang dapat mereview project ini")
+	}
+
+	current := domain.NormalizeProjectReviewStatus(project.ReviewStatus)
+	if current != step.from {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("Project berstatus %s tidak dapat %s", current, step.verb))
+	}
+
+	if err := transitionProjectReview(ctx, uc.projectRepo, project, reviewerID, step.to, komentar); err != nil {
+		return nil, err
+	}
+
+	return uc.buildReviewResponse(ctx, project)
+}
+
+// GetQueue lists the projects assigned to reviewerID. Without a status filter it
+// lists those waiting on the reviewer: submitted and under review.
+func (uc *projectReviewUsecase) GetQueue(ctx context.Context, reviewerID string, params dto.ProjectReviewQueueParams) (*dto.ProjectReviewListData, error) {
+	statuses := []string{domain.ProjectReviewSubmitted, domain.ProjectReviewUnderReview}
+	switch params.Status {
+	case "":
+	case domain.ProjectReviewSubmitted, domain.ProjectReviewUnderReview, domain.ProjectReviewRevisionRequested, domain.ProjectReviewApproved:
+		statuses = []string{params.Status}
+	default:
+		return nil, apperrors.NewValidationError("Status review tidak valid", nil)
+	}
+
+	page, limit := params.Page, params.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	projects, total, err := uc.projectRepo.GetReviewQueue(ctx, reviewerID, statuses, page, limit)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.GetQueue: %w", err))
+	}
+
+	items := make([]dto.ProjectReviewItem, 0, len(projects))
+	for i := range projects {
+		items = append(items, dto.ProjectReviewItem{
+			ID:              projects[i].ID,
+			NamaProject:     projects[i].NamaProject,
+			Kategori:        projects[i].Kategori,
+			Semester:        projects[i].Semester,
+			Ukuran:          projects[i].Ukuran,
+			OwnerID:         projects[i].UserID,
+			OwnerName:       projects[i].User.Name,
+			ReviewStatus:    domain.NormalizeProjectReviewStatus(projects[i].ReviewStatus),
+			StatusChangedAt: projects[i].ReviewStatusChangedAt,
+		})
+	}
+
+	return &dto.ProjectReviewListData{
+		Items: items,
+		Pagination: dto.PaginationData{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// Download gives reviewers the current file of the project they review; owners may
+// use it too.
+func (uc *projectReviewUsecase) Download(ctx context.Context, projectID uint, userID string) (*storage.Download, error) {
+	project, err := uc.getParticipatingProject(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := storage.ProjectArchiveEntry(project, project.PathFile)
+	return storage.NewFileDownload(storage.DefaultBackend(), project.PathFile, entry.DownloadName()), nil
+}
+
+func (uc *projectReviewUsecase) getProject(ctx context.Context, projectID uint) (*domain.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Project")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.getProject: %w", err))
+	}
+	return project, nil
+}
+
+// getParticipatingProject returns the project if userID owns or reviews it.
+func (uc *projectReviewUsecase) getParticipatingProject(ctx context.Context, projectID uint, userID string) (*domain.Project, error) {
+	project, err := uc.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.UserID == userID {
+		return project, nil
+	}
+
+	isReviewer, err := uc.isReviewer(ctx, project.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isReviewer {
+		return nil, apperrors.NewForbiddenError("Anda tidak memiliki akses ke review project ini")
+	}
+	return project, nil
+}
+
+func (uc *projectReviewUsecase) isReviewer(ctx context.Context, projectID uint, userID string) (bool, error) {
+	reviewers, err := uc.projectRepo.GetReviewers(ctx, projectID)
+	if err != nil {
+		return false, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.isReviewer: %w", err))
+	}
+	return slices.ContainsFunc(reviewers, func(r domain.ProjectReviewer) bool { return r.UserID == userID }), nil
+}
+
+func (uc *projectReviewUsecase) buildReviewResponse(ctx context.Context, project *domain.Project) (*dto.ProjectReviewResponse, error) {
+	reviewers, err := uc.projectRepo.GetReviewers(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.buildReviewResponse: reviewers: %w", err))
+	}
+	events, err := uc.projectRepo.GetReviewEvents(ctx, project.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("ProjectReviewUsecase.buildReviewResponse: events: %w", err))
+	}
+
+	resp := &dto.ProjectReviewResponse{
+		ProjectID:       project.ID,
+		NamaProject:     project.NamaProject,
+		OwnerID:         project.UserID,
+		ReviewStatus:    domain.NormalizeProjectReviewStatus(project.ReviewStatus),
+		StatusChangedAt: project.ReviewStatusChangedAt,
+		Reviewers:       make([]dto.ProjectReviewer, 0, len(reviewers)),
+		History:         make([]dto.ProjectReviewEvent, 0, len(events)),
+	}
+	for i := range reviewers {
+		resp.Reviewers = append(resp.Reviewers, dto.ProjectReviewer{
+			UserID: reviewers[i].UserID,
+			Name:   reviewers[i].User.Name,
+			Email:  reviewers[i].User.Email,
+		})
+	}
+	for i := range events {
+		resp.History = append(resp.History, dto.ProjectReviewEvent{
+			ActorID:    events[i].ActorID,
+			ActorName:  events[i].Actor.Name,
+			FromStatus: events[i].FromStatus,
+			ToStatus:   events[i].ToStatus,
+			Komentar:   events[i].Komentar,
+			CreatedAt:  events[i].CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// transitionProjectReview moves project to status on behalf of actorID and records
+// the change in the project's review history. It fails with a conflict when the
+// stored status changed since project was read.
+func transitionProjectReview(ctx context.Context, projectRepo repo.ProjectRepository, project *domain.Project, actorID, status, komentar string) error {
+	from := domain.NormalizeProjectReviewStatus(project.ReviewStatus)
+	previous, previousChangedAt := project.ReviewStatus, project.ReviewStatusChangedAt
+	now := time.Now()
+	project.ReviewStatus = status
+	project.ReviewStatusChangedAt = &now
+
+	updated, err := projectRepo.UpdateReviewStatus(ctx, project, &domain.ProjectReviewEvent{
+		ProjectID:  project.ID,
+		ActorID:    actorID,
+		FromStatus: from,
+		ToStatus:   status,
+		Komentar:   komentar,
+		CreatedAt:  now,
+	})
+	if err != nil || !updated {
+		project.ReviewStatus, project.ReviewStatusChangedAt = previous, previousChangedAt
+	}
+	if err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("transitionProjectReview: %w", err))
+	}
+	if !updated {
+		return apperrors.NewConflictError("Status review project sudah berubah, muat ulang lalu coba lagi")
+	}
+	return nil
+}
+
+// resubmitProjectReview puts project back to submitted once what the reviewers
+// concluded no longer applies, because its file or its reviewers changed. A reviewer
+// acting at the same time only changes the status it starts from, so on a conflict
+// the status is read again and the transition retried.
+func resubmitProjectReview(ctx context.Context, projectRepo repo.ProjectRepository, project *domain.Project, actorID string) error {
+	for attempt := 1; ; attempt++ {
+		if domain.NormalizeProjectReviewStatus(project.ReviewStatus) == domain.ProjectReviewSubmitted {
+			return nil
+		}
+
+		err := transitionProjectReview(ctx, projectRepo, project, actorID, domain.ProjectReviewSubmitted, "")
+		var appErr *apperrors.AppError
+		if err == nil || attempt == maxResubmitAttempts || !errors.As(err, &appErr) || appErr.Code != apperrors.ErrConflict {
+			return err
+		}
+
+		current, err := projectRepo.GetByID(ctx, project.ID)
+		if err != nil {
+			return apperrors.NewInternalError(fmt.Errorf("resubmitProjectReview: %w", err))
+		}
+		project.ReviewStatus, project.ReviewStatusChangedAt = current.ReviewStatus, current.ReviewStatusChangedAt
+	}
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	reviewTestOwnerID    = "11111111-1111-1111-1111-111111111111"
+	reviewTestReviewerID = "22222222-2222-2222-2222-222222222222"
+)
+
+// expectReviewedProject sets up project 1 in status with reviewTestReviewerID as its
+// only reviewer and an empty history.
+func expectReviewedProject(projectRepo *MockProjectRepository, status string) *domain.Project {
+	project := &domain.Project{ID: 1, UserID: reviewTestOwnerID, NamaProject: "Sistem Inventaris", ReviewStatus: status}
+	projectRepo.On("GetByID", mock.Anything, uint(1)).Return(project, nil)
+	projectRepo.On("GetReviewers", mock.Anything, uint(1)).
+		Return([]domain.ProjectReviewer{{ProjectID: 1, UserID: reviewTestReviewerID}}, nil)
+	projectRepo.On("GetReviewEvents", mock.Anything, uint(1)).Return([]domain.ProjectReviewEvent{}, nil)
+	return project
+}
+
+func TestProjectReviewUsecase_AssignReviewers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("owner picks dosen", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		reviewUc := mocks.projectReviewUsecase()
+		expectReviewedProject(mocks.project, "")
+		mocks.user.On("GetByIDs", mock.Anything, []string{reviewTestReviewerID}).Return([]*domain.User{
+			{ID: reviewTestReviewerID, Name: "Dosen Satu", Role: &domain.Role{NamaRole: "Dosen"}},
+		}, nil)
+		mocks.project.On("ReplaceReviewers", mock.Anything, uint(1), []domain.ProjectReviewer{
+			{ProjectID: 1, UserID: reviewTestReviewerID, AssignedBy: reviewTestOwnerID},
+		}).Return(nil)
+
+		result, err := reviewUc.AssignReviewers(context.Background(), 1, reviewTestOwnerID, dto.AssignProjectReviewersRequest{
+			DosenIDs: []string{reviewTestReviewerID, reviewTestReviewerID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProjectReviewSubmitted, result.ReviewStatus)
+		mocks.project.AssertExpectations(t)
+	})
+
+	t.Run("new reviewers start the review over", func(t *testing.T) {
+		t.Parallel()
+		const otherDosenID = "33333333-3333-3333-3333-333333333333"
+		mocks := newRepoMocks()
+		reviewUc := mocks.projectReviewUsecase()
+		expectReviewedProject(mocks.project, domain.ProjectReviewApproved)
+		mocks.user.On("GetByIDs", mock.Anything, []string{otherDosenID}).Return([]*domain.User{
+			{ID: otherDosenID, Name: "Dosen Dua", Role: &domain.Role{NamaRole: "Dosen"}},
+		}, nil)
+		mocks.project.On("UpdateReviewStatus", mock.Anything, mock.Anything, mock.MatchedBy(func(e *domain.ProjectReviewEvent) bool {
+			return e.FromStatus == domain.ProjectReviewApproved && e.ToStatus == domain.ProjectReviewSubmitted && e.ActorID == reviewTestOwnerID
+		})).Return(true, nil).Once()
+		mocks.project.On("ReplaceReviewers", mock.Anything, uint(1), []domain.ProjectReviewer{
+			{ProjectID: 1, UserID: otherDosenID, AssignedBy: reviewTestOwnerID},
+		}).Return(nil)
+
+		result, err := reviewUc.AssignReviewers(context.Background(), 1, reviewTestOwnerID, dto.AssignProjectReviewersRequest{
+			DosenIDs: []string{otherDosenID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProjectReviewSubmitted, result.ReviewStatus)
+		mocks.project.AssertExpectations(t)
+	})
+
+	t.Run("the same reviewers keep the review", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		reviewUc := mocks.projectReviewUsecase()
+		expectReviewedProject(mocks.project, domain.ProjectReviewApproved)
+		mocks.user.On("GetByIDs", mock.Anything, []string{reviewTestReviewerID}).Return([]*domain.User{
+			{ID: reviewTestReviewerID, Name: "Dosen Satu", Role: &domain.Role{NamaRole: "Dosen"}},
+		}, nil)
+		mocks.project.On("ReplaceReviewers", mock.Anything, uint(1), mock.Anything).Return(nil)
+
+		result, err := reviewUc.AssignReviewers(context.Background(), 1, reviewTestOwnerID, dto.AssignProjectReviewersRequest{
+			DosenIDs: []string{reviewTestReviewerID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProjectReviewApproved, result.ReviewStatus)
+		mocks.project.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("only the owner", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		reviewUc := mocks.projectReviewUsecase()
+		expectReviewedProject(mocks.project, "")
+
+		_, err := reviewUc.AssignReviewers(context.Background(), 1, reviewTestReviewerID, dto.AssignProjectReviewersRequest{
+			DosenIDs: []string{reviewTestReviewerID},
+		})
+		requireClassAppError(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("reviewers must be dosen", func(t *testing.T) {
+		t.Parallel()
+		mocks := newRepoMocks()
+		reviewUc := mocks.projectReviewUsecase()
+		expectReviewedProject(mocks.project, "")
+		mocks.user.On("GetByIDs", mock.Anything, []string{reviewTestReviewerID}).Return([]*domain.User{
+			{ID: reviewTestReviewerID, Name: "Budi", Role: &domain.Role{NamaRole: "mahasiswa"}},
+		}, nil)
+
+		_, err := reviewUc.AssignReviewers(context.Background(), 1, reviewTestOwnerID, dto.AssignProjectReviewersRequest{
+			DosenIDs: []string{reviewTestReviewerID},
+		})
+		requireClassAppError(t, err, apperrors.ErrValidation)
+		mocks.project.AssertNotCalled(t, "ReplaceReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProjectReviewUsecase_Review(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   string
+		actorID  string
+		req      dto.ReviewProjectRequest
+		wantCode string
+		want     string
+	}{
+		{"start a submitted project", "", reviewTestReviewerID, dto.ReviewProjectRequest{Action: "start"}, "", domain.ProjectReviewUnderReview},
+		{"approve", domain.ProjectReviewUnderReview, reviewTestReviewerID, dto.ReviewProjectRequest{Action: "approve"}, "", domain.ProjectReviewApproved},
+		{"request a revision", domain.ProjectReviewUnderReview, reviewTestReviewerID,
+			dto.ReviewProjectRequest{Action: "request_revision", Komentar: "Lengkapi ERD"}, "", domain.ProjectReviewRevisionRequested},
+		{"revision without comment", domain.ProjectReviewUnderReview, reviewTestReviewerID,
+			dto.ReviewProjectRequest{Action: "request_revision", Komentar: "  "}, apperrors.ErrValidation, ""},
+		{"not an assigned reviewer", domain.ProjectReviewUnderReview, reviewTestOwnerID, dto.ReviewProjectRequest{Action: "approve"}, apperrors.ErrForbidden, ""},
+		{"approve before review starts", domain.ProjectReviewSubmitted, reviewTestReviewerID, dto.ReviewProjectRequest{Action: "approve"}, apperrors.ErrConflict, ""},
+		{"start an approved project", domain.ProjectReviewApproved, reviewTestReviewerID, dto.ReviewProjectRequest{Action: "start"}, apperrors.ErrConflict, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mocks := newRepoMocks()
+			reviewUc := mocks.projectReviewUsecase()
+			expectReviewedProject(mocks.project, tt.status)
+			if tt.wantCode == "" {
+				mocks.project.On("UpdateReviewStatus", mock.Anything, mock.Anything, mock.MatchedBy(func(e *domain.ProjectReviewEvent) bool {
+					return e.ActorID == tt.actorID && e.ToStatus == tt.want && e.Komentar == tt.req.Komentar
+				})).Return(true, nil)
+			}
+
+			result, err := reviewUc.Review(context.Background(), 1, tt.actorID, tt.req)
+			if tt.wantCode != "" {
+				requireClassAppError(t, err, tt.wantCode)
+				mocks.project.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.ReviewStatus)
+			assert.NotNil(t, result.StatusChangedAt)
+			mocks.project.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectReviewUsecase_Review_ConcurrentStep(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	reviewUc := mocks.projectReviewUsecase()
+	project := expectReviewedProject(mocks.project, domain.ProjectReviewUnderReview)
+	// Another reviewer concluded the review after the project was read.
+	mocks.project.On("UpdateReviewStatus", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	_, err := reviewUc.Review(context.Background(), 1, reviewTestReviewerID, dto.ReviewProjectRequest{Action: "approve"})
+	requireClassAppError(t, err, apperrors.ErrConflict)
+	assert.Equal(t, domain.ProjectReviewUnderReview, project.ReviewStatus)
+}
+
+func TestProjectReviewUsecase_GetReview(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	reviewUc := mocks.projectReviewUsecase()
+	expectReviewedProject(mocks.project, domain.ProjectReviewRevisionRequested)
+
+	result, err := reviewUc.GetReview(context.Background(), 1, reviewTestOwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectReviewRevisionRequested, result.ReviewStatus)
+	require.Len(t, result.Reviewers, 1)
+
+	_, err = reviewUc.GetReview(context.Background(), 1, "33333333-3333-3333-3333-333333333333")
+	requireClassAppError(t, err, apperrors.ErrForbidden)
+}
+
+func TestProjectReviewUsecase_GetQueue(t *testing.T) {
+	t.Parallel()
+	mocks := newRepoMocks()
+	reviewUc := mocks.projectReviewUsecase()
+	mocks.project.On("GetReviewQueue", mock.Anything, reviewTestReviewerID,
+		[]string{domain.ProjectReviewSubmitted, domain.ProjectReviewUnderReview}, 1, 10).
+		Return([]domain.Project{{ID: 1, UserID: reviewTestOwnerID, User: domain.User{Name: "Budi"}}}, 1, nil)
+
+	result, err := reviewUc.GetQueue(context.Background(), reviewTestReviewerID, dto.ProjectReviewQueueParams{})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Budi", result.Items[0].OwnerName)
+	assert.Equal(t, domain.ProjectReviewSubmitted, result.Items[0].ReviewStatus)
+
+	_, err = reviewUc.GetQueue(context.Background(), reviewTestReviewerID, dto.ProjectReviewQueueParams{Status: "draft"})
+	requireClassAppError(t, err, apperrors.ErrValidation)
+}
@@ -8,10 +8,10 @@ import (
 	"invento-service/internal/dto"
 	"invento-service/internal/storage"
 	"invento-service/internal/usecase/repo"
-	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
-	"time"
 
 	apperrors "invento-service/internal/errors"
 
@@ -23,7 +23,12 @@ type ProjectUsecase interface {
 	GetByID(ctx context.Context, projectID uint, userID string) (*dto.ProjectResponse, error)
 	UpdateMetadata(ctx context.Context, projectID uint, userID string, req dto.UpdateProjectRequest) error
 	Delete(ctx context.Context, projectID uint, userID string) error
-	Download(ctx context.Context, userID string, projectIDs []uint) (string, error)
+	Download(ctx context.Context, userID string, projectIDs []uint) (*storage.Download, error)
+	GetTree(ctx context.Context, projectID uint, userID string) (*dto.ProjectTreeData, error)
+	OpenFile(ctx context.Context, projectID uint, userID, filePath string) (*storage.ArchiveFile, error)
+	GetVersions(ctx context.Context, projectID uint, userID string) (*dto.ProjectVersionListData, error)
+	DownloadVersion(ctx context.Context, projectID uint, userID string, version int) (*storage.Download, error)
+	RestoreVersion(ctx context.Context, projectID uint, userID string, version int, req dto.RestoreProjectVersionRequest) (*dto.ProjectVersionItem, error)
 }
 
 type projectUsecase struct {
@@ -71,14 +76,18 @@ func (uc *projectUsecase) GetByID(ctx context.Context, projectID uint, userID st
 	}
 
 	return &dto.ProjectResponse{
-		ID:          project.ID,
-		NamaProject: project.NamaProject,
-		Kategori:    project.Kategori,
-		Semester:    project.Semester,
-		Ukuran:      project.Ukuran,
-		PathFile:    project.PathFile,
-		CreatedAt:   project.CreatedAt,
-		UpdatedAt:   project.UpdatedAt,
+		ID:                 project.ID,
+		NamaProject:        project.NamaProject,
+		Kategori:           project.Kategori,
+		KategoriTerdeteksi: project.KategoriTerdeteksi,
+		KategoriConfidence: project.KategoriConfidence,
+		KategoriSignals:    project.KategoriSignals,
+		Semester:           project.Semester,
+		Ukuran:             project.Ukuran,
+		PathFile:           project.PathFile,
+		ReviewStatus:       domain.NormalizeProjectReviewStatus(project.ReviewStatus),
+		CreatedAt:          project.CreatedAt,
+		UpdatedAt:          project.UpdatedAt,
 	}, nil
 }
 
@@ -111,102 +120,234 @@ func (uc *projectUsecase) Delete(ctx context.Context, projectID uint, userID str
 		return err
 	}
 
+	versions, err := uc.projectRepo.GetVersions(ctx, projectID)
+	if err != nil {
+		return newInternalError("gagal menghapus project", fmt.Errorf("ProjectUsecase.Delete: versions: %w", err))
+	}
+	// A submission keeps its file after the version it was made with is pruned.
+	submitted, err := uc.projectRepo.GetSubmittedPaths(ctx, projectID)
+	if err != nil {
+		return newInternalError("gagal menghapus project", fmt.Errorf("ProjectUsecase.Delete: submissions: %w", err))
+	}
+
 	if err := uc.projectRepo.Delete(ctx, projectID); err != nil {
 		return newInternalError("gagal menghapus project", fmt.Errorf("ProjectUsecase.Delete: %w", err))
 	}
 
-	if project.PathFile != "" {
-		if err := storage.DeleteFile(project.PathFile); err != nil {
+	files := append([]string{project.PathFile}, submitted...)
+	for i := range versions {
+		files = append(files, versions[i].PathFile)
+	}
+	deleted := map[string]bool{"": true}
+	for _, file := range files {
+		if deleted[file] {
+			continue
+		}
+		deleted[file] = true
+		if err := storage.DeleteFile(file); err != nil {
 			// File deletion after DB delete is critical but non-blocking;
 			// log the error so it can be investigated.
-			zlog.Warn().Err(err).Str("file", project.PathFile).Msg("ProjectUsecase.Delete: failed to delete project file")
+			zlog.Warn().Err(err).Str("file", file).Msg("ProjectUsecase.Delete: failed to delete project file")
 		}
 	}
 
 	return nil
 }
 
-func (uc *projectUsecase) Download(ctx context.Context, userID string, projectIDs []uint) (string, error) {
+func (uc *projectUsecase) Download(ctx context.Context, userID string, projectIDs []uint) (*storage.Download, error) {
 	if len(projectIDs) == 0 {
-		return "", apperrors.NewValidationError("id project tidak boleh kosong", nil)
+		return nil, apperrors.NewValidationError("id project tidak boleh kosong", nil)
 	}
 
 	if len(projectIDs) == 1 {
 		project, err := uc.getOwnedProject(ctx, projectIDs[0], userID)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		if project.PathFile == "" {
-			return "", apperrors.NewNotFoundError("file project")
+			return nil, apperrors.NewNotFoundError("file project")
 		}
 
 		cleanPath := filepath.Clean(project.PathFile)
 		if strings.Contains(cleanPath, "..") {
-			return "", apperrors.NewValidationError("path file tidak valid", nil)
+			return nil, apperrors.NewValidationError("path file tidak valid", nil)
 		}
 
-		return cleanPath, nil
+		entry := storage.ProjectArchiveEntry(project, cleanPath)
+		return storage.NewFileDownload(storage.DefaultBackend(), cleanPath, entry.DownloadName()), nil
 	}
 
 	projects, err := uc.projectRepo.GetByIDs(ctx, projectIDs, userID)
 	if err != nil {
-		return "", newInternalError("gagal mengambil data project", fmt.Errorf("ProjectUsecase.Download: %w", err))
+		return nil, newInternalError("gagal mengambil data project", fmt.Errorf("ProjectUsecase.Download: %w", err))
 	}
 
 	if len(projects) == 0 {
-		return "", apperrors.NewNotFoundError("project")
+		return nil, apperrors.NewNotFoundError("project")
 	}
 
-	var filePaths []string
-	for _, project := range projects {
-		if project.PathFile == "" {
-			return "", apperrors.NewNotFoundError("file project")
+	entries := make([]storage.ArchiveEntry, 0, len(projects))
+	for i := range projects {
+		if projects[i].PathFile == "" {
+			return nil, apperrors.NewNotFoundError("file project")
 		}
 
-		cleanPath := filepath.Clean(project.PathFile)
+		cleanPath := filepath.Clean(projects[i].PathFile)
 		if strings.Contains(cleanPath, "..") {
-			return "", apperrors.NewValidationError("path file tidak valid", nil)
+			return nil, apperrors.NewValidationError("path file tidak valid", nil)
 		}
 
-		filePaths = append(filePaths, cleanPath)
+		entries = append(entries, storage.ProjectArchiveEntry(&projects[i], cleanPath))
 	}
 
-	tempDir := "./uploads/temp"
-	if err = os.MkdirAll(tempDir, 0o755); err != nil {
-		return "", newInternalError("gagal membuat direktori temp", fmt.Errorf("ProjectUsecase.Download: %w", err))
+	download, err := storage.NewArchiveDownload(ctx, storage.DefaultBackend(), "projects.zip", entries, nil)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrArchiveTooLarge):
+			return nil, apperrors.NewPayloadTooLargeError("ukuran total project melebihi batas download")
+		case errors.Is(err, storage.ErrObjectNotFound):
+			return nil, apperrors.NewNotFoundError("file project")
+		}
+		return nil, newInternalError("gagal menyiapkan file zip", fmt.Errorf("ProjectUsecase.Download: %w", err))
 	}
 
-	identifier, err := storage.GenerateUniqueIdentifier(8)
+	return download, nil
+}
+
+func (uc *projectUsecase) GetTree(ctx context.Context, projectID uint, userID string) (*dto.ProjectTreeData, error) {
+	archive, err := uc.openProjectArchive(ctx, projectID, userID)
 	if err != nil {
-		return "", newInternalError("gagal generate identifier", fmt.Errorf("ProjectUsecase.Download: %w", err))
+		return nil, err
+	}
+
+	entries := archive.Entries()
+	result := &dto.ProjectTreeData{
+		ProjectID:  projectID,
+		TotalFiles: len(entries),
+		Items:      buildProjectTree(entries),
+	}
+	for i := range entries {
+		result.TotalSize += entries[i].Size
 	}
 
-	zipFileName := fmt.Sprintf("projects_%s.zip", identifier)
-	zipFilePath := filepath.Join(tempDir, zipFileName)
+	return result, nil
+}
 
-	if err := storage.CreateZipArchive(filePaths, zipFilePath); err != nil {
-		return "", newInternalError("gagal membuat file zip", fmt.Errorf("ProjectUsecase.Download: %w", err))
+func (uc *projectUsecase) OpenFile(ctx context.Context, projectID uint, userID, filePath string) (*storage.ArchiveFile, error) {
+	if strings.TrimSpace(filePath) == "" {
+		return nil, apperrors.NewValidationError("path file wajib diisi", nil)
 	}
 
-	go func(zipPath string) {
-		time.Sleep(5 * time.Minute)
-		if err := os.Remove(zipPath); err != nil && !errors.Is(err, os.ErrNotExist) {
-			zlog.Warn().Err(err).Str("file", zipPath).Msg("failed to delete temporary zip file")
+	archive, err := uc.openProjectArchive(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := archive.OpenFile(filePath)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrZipUnsafePath):
+			return nil, apperrors.NewValidationError("path file tidak valid", nil)
+		case errors.Is(err, storage.ErrArchiveEntryNotFound):
+			return nil, apperrors.NewNotFoundError("file di dalam project")
+		case errors.Is(err, storage.ErrZipCorrupt):
+			return nil, apperrors.NewArchiveRejectedError("file project tidak dapat dibaca sebagai arsip ZIP")
 		}
-	}(zipFilePath)
+		return nil, newInternalError("gagal membaca file project", fmt.Errorf("ProjectUsecase.OpenFile: %w", err))
+	}
 
-	return zipFilePath, nil
+	return file, nil
 }
 
-func (uc *projectUsecase) getOwnedProject(ctx context.Context, projectID uint, userID string) (*domain.Project, error) {
-	project, err := uc.projectRepo.GetByID(ctx, projectID)
+// openProjectArchive checks that userID owns or reviews the project and reads the
+// central directory of its stored ZIP.
+func (uc *projectUsecase) openProjectArchive(ctx context.Context, projectID uint, userID string) (*storage.StoredArchive, error) {
+	project, err := uc.getViewableProject(ctx, projectID, userID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return nil, apperrors.NewNotFoundError("project")
+		return nil, err
+	}
+
+	if project.PathFile == "" {
+		return nil, apperrors.NewNotFoundError("file project")
+	}
+
+	cleanPath := filepath.Clean(project.PathFile)
+	if strings.Contains(cleanPath, "..") {
+		return nil, apperrors.NewValidationError("path file tidak valid", nil)
+	}
+
+	archive, err := storage.OpenStoredArchive(ctx, storage.DefaultBackend(), cleanPath)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrObjectNotFound):
+			return nil, apperrors.NewNotFoundError("file project")
+		case errors.Is(err, storage.ErrZipCorrupt):
+			return nil, apperrors.NewArchiveRejectedError("file project tidak dapat dibaca sebagai arsip ZIP")
+		}
+		return nil, newInternalError("gagal membaca file project", fmt.Errorf("ProjectUsecase.openProjectArchive: %w", err))
+	}
+
+	return archive, nil
+}
+
+// buildProjectTree nests flat archive paths into directories, listing directories
+// before files and each group by name.
+func buildProjectTree(entries []domain.ProjectManifestEntry) []dto.ProjectTreeNode {
+	type treeDir struct {
+		node dto.ProjectTreeNode
+		dirs map[string]*treeDir
+	}
+	newDir := func(name, dirPath string) *treeDir {
+		return &treeDir{node: dto.ProjectTreeNode{Name: name, Path: dirPath, Type: "dir"}, dirs: map[string]*treeDir{}}
+	}
+
+	root := newDir("", "")
+	for _, entry := range entries {
+		parts := strings.Split(entry.Path, "/")
+		dir := root
+		dir.node.Size += entry.Size
+		for i, part := range parts[:len(parts)-1] {
+			child, ok := dir.dirs[part]
+			if !ok {
+				child = newDir(part, strings.Join(parts[:i+1], "/"))
+				dir.dirs[part] = child
+			}
+			child.node.Size += entry.Size
+			dir = child
 		}
+		dir.node.Children = append(dir.node.Children, dto.ProjectTreeNode{
+			Name: parts[len(parts)-1],
+			Path: entry.Path,
+			Type: "file",
+			Size: entry.Size,
+		})
+	}
+
+	var collect func(dir *treeDir) []dto.ProjectTreeNode
+	collect = func(dir *treeDir) []dto.ProjectTreeNode {
+		nodes := make([]dto.ProjectTreeNode, 0, len(dir.dirs)+len(dir.node.Children))
+		for _, child := range dir.dirs {
+			child.node.Children = collect(child)
+			nodes = append(nodes, child.node)
+		}
+		nodes = append(nodes, dir.node.Children...)
+		sort.Slice(nodes, func(i, j int) bool {
+			if nodes[i].Type != nodes[j].Type {
+				return nodes[i].Type == "dir"
+			}
+			return nodes[i].Name < nodes[j].Name
+		})
+		return nodes
+	}
+
+	return collect(root)
+}
 
-		return nil, newInternalError("gagal mengambil data project", fmt.Errorf("ProjectUsecase.getOwnedProject: %w", err))
+func (uc *projectUsecase) getOwnedProject(ctx context.Context, projectID uint, userID string) (*domain.Project, error) {
+	project, err := uc.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
 	}
 
 	if project.UserID != userID {
@@ -216,6 +357,42 @@ func (uc *projectUsecase) getOwnedProject(ctx context.Context, projectID uint, u
 	return project, nil
 }
 
+// getViewableProject returns the project if userID owns it or is assigned to review
+// it. Reviewers may look at the project's files but not change it.
+func (uc *projectUsecase) getViewableProject(ctx context.Context, projectID uint, userID string) (*domain.Project, error) {
+	project, err := uc.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.UserID == userID {
+		return project, nil
+	}
+
+	reviewers, err := uc.projectRepo.GetReviewers(ctx, project.ID)
+	if err != nil {
+		return nil, newInternalError("gagal mengambil data project", fmt.Errorf("ProjectUsecase.getViewableProject: %w", err))
+	}
+	if !slices.ContainsFunc(reviewers, func(r domain.ProjectReviewer) bool { return r.UserID == userID }) {
+		return nil, apperrors.NewForbiddenError("anda tidak memiliki akses ke project ini")
+	}
+
+	return project, nil
+}
+
+func (uc *projectUsecase) getProject(ctx context.Context, projectID uint) (*domain.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("project")
+		}
+
+		return nil, newInternalError("gagal mengambil data project", fmt.Errorf("ProjectUsecase.getProject: %w", err))
+	}
+
+	return project, nil
+}
+
 func newInternalError(message string, err error) *apperrors.AppError {
 	appErr := apperrors.NewInternalError(err)
 	appErr.Message = message
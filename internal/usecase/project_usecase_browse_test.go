@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"invento-service/config"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBrowseTestZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "project.zip")
+	out, err := os.Create(filePath)
+	require.NoError(t, err)
+	zw := zip.NewWriter(out)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, out.Close())
+	return filePath
+}
+
+func TestProjectUsecase_GetTree(t *testing.T) {
+	t.Parallel()
+	mockProjectRepo := new(MockProjectRepository)
+	projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+
+	zipPath := writeBrowseTestZip(t, map[string]string{
+		"README.md":            "# Project\n",
+		"src/main.go":          "package main\n",
+		"src/handler/user.go":  "package handler\n",
+		"src/handler/admin.go": "package handler\n",
+	})
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: zipPath}, nil)
+
+	result, err := projectUC.GetTree(context.Background(), 1, "user-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.TotalFiles)
+	assert.Equal(t, int64(10+13+16+16), result.TotalSize)
+	require.Len(t, result.Items, 2)
+
+	src := result.Items[0]
+	assert.Equal(t, dto.ProjectTreeNode{Name: "README.md", Path: "README.md", Type: "file", Size: 10}, result.Items[1])
+	assert.Equal(t, "src", src.Path)
+	assert.Equal(t, "dir", src.Type)
+	assert.Equal(t, int64(13+16+16), src.Size)
+	require.Len(t, src.Children, 2)
+	assert.Equal(t, "src/handler", src.Children[0].Path, "directories come before files")
+	assert.Equal(t, "src/main.go", src.Children[1].Path)
+	require.Len(t, src.Children[0].Children, 2)
+	assert.Equal(t, "admin.go", src.Children[0].Children[0].Name)
+	assert.Equal(t, "user.go", src.Children[0].Children[1].Name)
+}
+
+func TestProjectUsecase_GetTree_Reviewer(t *testing.T) {
+	t.Parallel()
+	mockProjectRepo := new(MockProjectRepository)
+	projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+
+	zipPath := writeBrowseTestZip(t, map[string]string{"README.md": "# Project\n"})
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: zipPath}, nil)
+	mockProjectRepo.On("GetReviewers", mock.Anything, uint(1)).Return([]domain.ProjectReviewer{{ProjectID: 1, UserID: "dosen-1"}}, nil)
+
+	result, err := projectUC.GetTree(context.Background(), 1, "dosen-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalFiles)
+}
+
+func TestProjectUsecase_GetTree_Errors(t *testing.T) {
+	t.Parallel()
+
+	notZip := filepath.Join(t.TempDir(), "project.zip")
+	require.NoError(t, os.WriteFile(notZip, []byte("bukan zip"), 0o644))
+
+	tests := []struct {
+		name    string
+		project *domain.Project
+		code    string
+	}{
+		{name: "other owner", project: &domain.Project{ID: 1, UserID: "user-2", PathFile: notZip}, code: apperrors.ErrForbidden},
+		{name: "no file", project: &domain.Project{ID: 1, UserID: "user-1"}, code: apperrors.ErrNotFound},
+		{name: "file missing", project: &domain.Project{ID: 1, UserID: "user-1", PathFile: filepath.Join(t.TempDir(), "gone.zip")}, code: apperrors.ErrNotFound},
+		{name: "not a zip", project: &domain.Project{ID: 1, UserID: "user-1", PathFile: notZip}, code: apperrors.ErrArchiveRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockProjectRepo := new(MockProjectRepository)
+			projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+			mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(tt.project, nil)
+			mockProjectRepo.On("GetReviewers", mock.Anything, uint(1)).Return([]domain.ProjectReviewer{{ProjectID: 1, UserID: "dosen-1"}}, nil).Maybe()
+
+			_, err := projectUC.GetTree(context.Background(), 1, "user-1")
+			var appErr *apperrors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
+
+func TestProjectUsecase_OpenFile(t *testing.T) {
+	t.Parallel()
+	zipPath := writeBrowseTestZip(t, map[string]string{"src/main.go": "package main\n"})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		mockProjectRepo := new(MockProjectRepository)
+		projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+		mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: zipPath}, nil)
+
+		file, err := projectUC.OpenFile(context.Background(), 1, "user-1", "src/main.go")
+		require.NoError(t, err)
+		defer file.Body.Close()
+
+		content, err := io.ReadAll(file.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", string(content))
+		assert.Equal(t, int64(len(content)), file.Size)
+	})
+
+	for _, tt := range []struct {
+		name string
+		path string
+		code string
+	}{
+		{name: "empty path", path: " ", code: apperrors.ErrValidation},
+		{name: "unsafe path", path: "../../etc/passwd", code: apperrors.ErrValidation},
+		{name: "missing entry", path: "src/other.go", code: apperrors.ErrNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockProjectRepo := new(MockProjectRepository)
+			projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+			mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: zipPath}, nil).Maybe()
+
+			_, err := projectUC.OpenFile(context.Background(), 1, "user-1", tt.path)
+			var appErr *apperrors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
@@ -383,6 +383,8 @@ func TestProjectUsecase_DeleteProject_Success(t *testing.T) {
 	}
 
 	mockProjectRepo.On("GetByID", mock.Anything, projectID).Return(project, nil)
+	mockProjectRepo.On("GetVersions", mock.Anything, projectID).Return([]domain.ProjectVersion{}, nil)
+	mockProjectRepo.On("GetSubmittedPaths", mock.Anything, projectID).Return([]string{}, nil)
 	mockProjectRepo.On("Delete", mock.Anything, projectID).Return(nil)
 
 	err := projectUC.Delete(context.Background(), projectID, userID)
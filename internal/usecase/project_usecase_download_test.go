@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"invento-service/config"
 	"invento-service/internal/domain"
@@ -40,7 +43,10 @@ func TestProjectUsecase_Download_SingleFile(t *testing.T) {
 	result, err := projectUC.Download(context.Background(), userID, projectIDs)
 
 	assert.NoError(t, err)
-	assert.Equal(t, "/uploads/project1.pdf", result)
+	require.NotNil(t, result)
+	assert.False(t, result.IsArchive())
+	assert.Equal(t, "/uploads/project1.pdf", result.Key)
+	assert.Equal(t, "project1.pdf", result.FileName)
 
 	mockProjectRepo.AssertExpectations(t)
 }
@@ -225,14 +231,60 @@ func TestProjectUsecase_Download_MultipleFiles_Success(t *testing.T) {
 
 	result, err := projectUC.Download(context.Background(), userID, projectIDs)
 
-	assert.NoError(t, err)
-	assert.NotEmpty(t, result)
-	assert.FileExists(t, result)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, result.IsArchive())
+	assert.Equal(t, "projects.zip", result.FileName)
 
-	require.NoError(t, os.Remove(result))
 	mockProjectRepo.AssertExpectations(t)
 }
 
+func TestProjectUsecase_Download_MultipleFiles_StructuredArchive(t *testing.T) {
+	t.Parallel()
+	mockProjectRepo := new(MockProjectRepository)
+	projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+
+	file1 := filepath.Join(t.TempDir(), "project.zip")
+	file2 := filepath.Join(t.TempDir(), "project.zip")
+	require.NoError(t, os.WriteFile(file1, []byte("file-1"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("file-2"), 0o644))
+
+	userID := "user-1"
+	projectIDs := []uint{1, 2}
+	mockProjectRepo.On("GetByIDs", mock.Anything, projectIDs, userID).Return([]domain.Project{
+		{ID: 1, UserID: userID, NamaProject: "Sistem Informasi", PathFile: file1, User: domain.User{Name: "Budi"}},
+		{ID: 2, UserID: userID, NamaProject: "Sistem Informasi", PathFile: file2, User: domain.User{Name: "Budi"}},
+	}, nil)
+
+	result, err := projectUC.Download(context.Background(), userID, projectIDs)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteArchive(context.Background(), &buf))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(reader.File))
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{
+		"projects/Sistem Informasi/project.zip",
+		"projects/Sistem Informasi (2)/project.zip",
+		storage.ManifestFileName,
+	}, names)
+
+	manifestFile, err := reader.Open(storage.ManifestFileName)
+	require.NoError(t, err)
+	defer manifestFile.Close()
+	var manifest storage.ArchiveManifest
+	require.NoError(t, json.NewDecoder(manifestFile).Decode(&manifest))
+	require.Len(t, manifest.Files, 2)
+	for _, file := range manifest.Files {
+		assert.Equal(t, "Budi", file.OwnerName)
+	}
+}
+
 func TestProjectUsecase_Download_MultipleFiles_PartialFound(t *testing.T) {
 	t.Parallel()
 	mockProjectRepo := new(MockProjectRepository)
@@ -255,11 +307,11 @@ func TestProjectUsecase_Download_MultipleFiles_PartialFound(t *testing.T) {
 
 	result, err := projectUC.Download(context.Background(), userID, projectIDs)
 
-	assert.NoError(t, err)
-	assert.NotEmpty(t, result)
-	assert.FileExists(t, result)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, result.IsArchive())
+	assert.Equal(t, "projects.zip", result.FileName)
 
-	require.NoError(t, os.Remove(result))
 	mockProjectRepo.AssertExpectations(t)
 }
 
@@ -286,7 +338,35 @@ func TestProjectUsecase_Download_MultipleFiles_NonexistentFile(t *testing.T) {
 	assert.Empty(t, result)
 	var appErr *apperrors.AppError
 	assert.True(t, errors.As(err, &appErr))
-	assert.Equal(t, apperrors.ErrInternal, appErr.Code)
+	assert.Equal(t, apperrors.ErrNotFound, appErr.Code)
 
 	mockProjectRepo.AssertExpectations(t)
 }
+
+func TestProjectUsecase_Download_MultipleFiles_TooLarge(t *testing.T) {
+	previous := storage.MaxArchiveSize()
+	t.Cleanup(func() { storage.SetMaxArchiveSize(previous) })
+	storage.SetMaxArchiveSize(10)
+
+	mockProjectRepo := new(MockProjectRepository)
+	projectUC := NewProjectUsecase(mockProjectRepo, storage.NewFileManager(&config.Config{}))
+
+	tempDir := t.TempDir()
+	file1 := filepath.Join(tempDir, "project1.zip")
+	file2 := filepath.Join(tempDir, "project2.zip")
+	require.NoError(t, os.WriteFile(file1, []byte("file-1"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("file-2"), 0o644))
+
+	projectIDs := []uint{1, 2}
+	mockProjectRepo.On("GetByIDs", mock.Anything, projectIDs, "user-1").Return([]domain.Project{
+		{ID: 1, UserID: "user-1", PathFile: file1},
+		{ID: 2, UserID: "user-1", PathFile: file2},
+	}, nil)
+
+	result, err := projectUC.Download(context.Background(), "user-1", projectIDs)
+
+	assert.Nil(t, result)
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperrors.ErrPayloadTooLarge, appErr.Code)
+}
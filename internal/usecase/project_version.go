@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"invento-service/internal/usecase/repo"
+	"path/filepath"
+	"strings"
+
+	apperrors "invento-service/internal/errors"
+
+	zlog "github.com/rs/zerolog/log"
+)
+
+func (uc *projectUsecase) GetVersions(ctx context.Context, projectID uint, userID string) (*dto.ProjectVersionListData, error) {
+	project, err := uc.getOwnedProject(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := uc.projectRepo.GetVersions(ctx, projectID)
+	if err != nil {
+		return nil, newInternalError("gagal mengambil versi project", fmt.Errorf("ProjectUsecase.GetVersions: %w", err))
+	}
+
+	result := &dto.ProjectVersionListData{
+		ProjectID: projectID,
+		Retention: uc.fileManager.ProjectVersionRetention(),
+		Items:     make([]dto.ProjectVersionItem, 0, len(versions)),
+	}
+	currentFound := false
+	for i := range versions {
+		item := toProjectVersionItem(&versions[i])
+		// After a restore several versions share the current file; the newest of
+		// them is the one in use.
+		if !currentFound && versions[i].PathFile == project.PathFile {
+			item.IsCurrent = true
+			currentFound = true
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+func (uc *projectUsecase) DownloadVersion(ctx context.Context, projectID uint, userID string, version int) (*storage.Download, error) {
+	project, projectVersion, err := uc.getOwnedVersion(ctx, projectID, userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := storage.ProjectArchiveEntry(project, projectVersion.PathFile)
+	entry.Title = fmt.Sprintf("%s v%d", project.NamaProject, projectVersion.Version)
+	return storage.NewFileDownload(storage.DefaultBackend(), projectVersion.PathFile, entry.DownloadName()), nil
+}
+
+// RestoreVersion makes an older version the project's current file. The restore is
+// itself recorded as a new version sharing the restored file, so the history keeps
+// what was current before and the restore can be undone the same way. Like a new
+// upload, it puts a reviewed project back to submitted.
+func (uc *projectUsecase) RestoreVersion(ctx context.Context, projectID uint, userID string, version int, req dto.RestoreProjectVersionRequest) (*dto.ProjectVersionItem, error) {
+	project, projectVersion, err := uc.getOwnedVersion(ctx, projectID, userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectVersion.PathFile == project.PathFile {
+		return nil, apperrors.NewConflictError("versi ini sudah menjadi versi aktif project")
+	}
+
+	if _, err := storage.DefaultBackend().Stat(ctx, projectVersion.PathFile); err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, apperrors.NewNotFoundError("file versi project")
+		}
+		return nil, newInternalError("gagal memulihkan versi project", fmt.Errorf("ProjectUsecase.RestoreVersion: stat: %w", err))
+	}
+
+	project.PathFile = projectVersion.PathFile
+	project.FileSize = projectVersion.FileSize
+	project.Ukuran = storage.FormatFileSize(projectVersion.FileSize)
+	if err := uc.projectRepo.Update(ctx, project); err != nil {
+		return nil, newInternalError("gagal memulihkan versi project", fmt.Errorf("ProjectUsecase.RestoreVersion: %w", err))
+	}
+
+	// The restored file is current either way; only the review status lags behind it.
+	if err := resubmitProjectReview(ctx, uc.projectRepo, project, userID); err != nil {
+		zlog.Error().Err(err).Uint("project_id", project.ID).Msg("ProjectUsecase.RestoreVersion: failed to resubmit project review")
+	}
+
+	catatan := req.Catatan
+	if catatan == "" {
+		catatan = fmt.Sprintf("Dipulihkan dari versi %d", projectVersion.Version)
+	}
+	restored := &domain.ProjectVersion{
+		ProjectID:  projectID,
+		PathFile:   projectVersion.PathFile,
+		FileSize:   projectVersion.FileSize,
+		Checksum:   projectVersion.Checksum,
+		UploadedBy: userID,
+		Catatan:    catatan,
+	}
+	if err := recordProjectVersion(ctx, uc.projectRepo, restored, uc.fileManager.ProjectVersionRetention()); err != nil {
+		return nil, newInternalError("gagal mencatat versi project", fmt.Errorf("ProjectUsecase.RestoreVersion: %w", err))
+	}
+
+	uc.refreshManifest(ctx, project)
+
+	item := toProjectVersionItem(restored)
+	item.IsCurrent = true
+	return &item, nil
+}
+
+func (uc *projectUsecase) getOwnedVersion(ctx context.Context, projectID uint, userID string, version int) (*domain.Project, *domain.ProjectVersion, error) {
+	project, err := uc.getOwnedProject(ctx, projectID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projectVersion, err := uc.projectRepo.GetVersion(ctx, projectID, version)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewNotFoundError("versi project")
+		}
+		return nil, nil, newInternalError("gagal mengambil versi project", fmt.Errorf("ProjectUsecase.getOwnedVersion: %w", err))
+	}
+
+	if strings.Contains(filepath.Clean(projectVersion.PathFile), "..") {
+		return nil, nil, apperrors.NewValidationError("path file tidak valid", nil)
+	}
+
+	return project, projectVersion, nil
+}
+
+// refreshManifest rebuilds the stored manifest from the restored archive's central
+// directory. The manifest is informational, so failures are only logged.
+func (uc *projectUsecase) refreshManifest(ctx context.Context, project *domain.Project) {
+	archive, err := storage.OpenStoredArchive(ctx, storage.DefaultBackend(), project.PathFile)
+	if err != nil {
+		zlog.Warn().Err(err).Uint("project_id", project.ID).Msg("ProjectUsecase.refreshManifest: failed to read archive")
+		return
+	}
+
+	manifest := &domain.ProjectManifest{ProjectID: project.ID, Entries: archive.Entries()}
+	manifest.EntryCount = len(manifest.Entries)
+	for i := range manifest.Entries {
+		manifest.UncompressedSize += manifest.Entries[i].Size
+	}
+	if err := uc.projectRepo.SaveManifest(ctx, manifest); err != nil {
+		zlog.Warn().Err(err).Uint("project_id", project.ID).Msg("ProjectUsecase.refreshManifest: failed to save manifest")
+	}
+}
+
+// recordProjectVersion stores version as the newest version of its project and then
+// prunes the versions beyond retention. The new version's file must already be the
+// project's current file.
+func recordProjectVersion(ctx context.Context, projectRepo repo.ProjectRepository, version *domain.ProjectVersion, retention int) error {
+	if err := projectRepo.CreateVersion(ctx, version); err != nil {
+		return err
+	}
+	return pruneProjectVersions(ctx, projectRepo, version.ProjectID, version.PathFile, retention)
+}
+
+// pruneProjectVersions removes every version after the newest retention ones. A
+// version's file is deleted only when neither the current file, a kept version nor
+// an assignment submission points at it, since restores make versions share files.
+func pruneProjectVersions(ctx context.Context, projectRepo repo.ProjectRepository, projectID uint, currentPath string, retention int) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	versions, err := projectRepo.GetVersions(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if len(versions) <= retention {
+		return nil
+	}
+
+	submitted, err := projectRepo.GetSubmittedPaths(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	kept := map[string]bool{currentPath: true}
+	for i := range versions[:retention] {
+		kept[versions[i].PathFile] = true
+	}
+	for _, pathFile := range submitted {
+		kept[pathFile] = true
+	}
+
+	pruned := versions[retention:]
+	ids := make([]uint, 0, len(pruned))
+	for i := range pruned {
+		ids = append(ids, pruned[i].ID)
+	}
+	if err := projectRepo.DeleteVersions(ctx, ids); err != nil {
+		return err
+	}
+
+	for i := range pruned {
+		if kept[pruned[i].PathFile] {
+			continue
+		}
+		kept[pruned[i].PathFile] = true
+		if err := storage.DeleteFile(pruned[i].PathFile); err != nil {
+			zlog.Warn().Err(err).Str("file", pruned[i].PathFile).Uint("project_id", projectID).Msg("failed to delete pruned project version file")
+		}
+	}
+
+	return nil
+}
+
+// prunedVersionBytes is what recording one more version of project deletes from disk:
+// the files of the versions it pushes out of retention that no kept version or
+// assignment submission points at. The replaced file itself stays as a version, so it
+// only counts once retention leaves no room for it.
+func prunedVersionBytes(ctx context.Context, projectRepo repo.ProjectRepository, project *domain.Project, retention int) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	versions, err := projectRepo.GetVersions(ctx, project.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 && project.PathFile != "" {
+		// The update records the current file as the first version before replacing it.
+		size := project.FileSize
+		if size == 0 {
+			size = storage.ParseFileSize(project.Ukuran)
+		}
+		versions = []domain.ProjectVersion{{PathFile: project.PathFile, FileSize: size}}
+	}
+	// The new version takes the first of the retention places.
+	if len(versions) < retention {
+		return 0, nil
+	}
+
+	submitted, err := projectRepo.GetSubmittedPaths(ctx, project.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	kept := make(map[string]bool, retention+len(submitted))
+	for i := range versions[:retention-1] {
+		kept[versions[i].PathFile] = true
+	}
+	for _, pathFile := range submitted {
+		kept[pathFile] = true
+	}
+
+	var freed int64
+	for i := range versions[retention-1:] {
+		version := &versions[retention-1+i]
+		if kept[version.PathFile] {
+			continue
+		}
+		kept[version.PathFile] = true
+		freed += version.FileSize
+	}
+	return freed, nil
+}
+
+func toProjectVersionItem(version *domain.ProjectVersion) dto.ProjectVersionItem {
+	return dto.ProjectVersionItem{
+		Version:    version.Version,
+		FileSize:   version.FileSize,
+		Ukuran:     storage.FormatFileSize(version.FileSize),
+		Checksum:   version.Checksum,
+		UploadedBy: version.UploadedBy,
+		Catatan:    version.Catatan,
+		CreatedAt:  version.CreatedAt,
+	}
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"invento-service/config"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionTestUsecase(retention int) (ProjectUsecase, *MockProjectRepository) {
+	mockProjectRepo := new(MockProjectRepository)
+	cfg := &config.Config{}
+	cfg.Upload.ProjectVersionRetention = retention
+	return NewProjectUsecase(mockProjectRepo, storage.NewFileManager(cfg)), mockProjectRepo
+}
+
+func TestProjectUsecase_GetVersions(t *testing.T) {
+	t.Parallel()
+	projectUC, mockProjectRepo := newVersionTestUsecase(5)
+
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: "/tmp/v1.zip"}, nil)
+	mockProjectRepo.On("GetVersions", mock.Anything, uint(1)).Return([]domain.ProjectVersion{
+		{Version: 3, PathFile: "/tmp/v1.zip", FileSize: 2048, Catatan: "Dipulihkan dari versi 1"},
+		{Version: 2, PathFile: "/tmp/v2.zip", FileSize: 4096},
+		{Version: 1, PathFile: "/tmp/v1.zip", FileSize: 2048},
+	}, nil)
+
+	result, err := projectUC.GetVersions(context.Background(), 1, "user-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, result.Retention)
+	require.Len(t, result.Items, 3)
+	assert.True(t, result.Items[0].IsCurrent)
+	assert.False(t, result.Items[1].IsCurrent)
+	assert.False(t, result.Items[2].IsCurrent, "only the newest version of the current file is marked")
+	assert.Equal(t, "2.00KB", result.Items[0].Ukuran)
+}
+
+func TestProjectUsecase_RestoreVersion(t *testing.T) {
+	t.Parallel()
+	projectUC, mockProjectRepo := newVersionTestUsecase(0)
+
+	oldZip := writeBrowseTestZip(t, map[string]string{"README.md": "# v1\n"})
+	info, err := os.Stat(oldZip)
+	require.NoError(t, err)
+
+	project := &domain.Project{ID: 1, UserID: "user-1", NamaProject: "Alpha", PathFile: "/tmp/v2.zip", FileSize: 4096}
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(project, nil)
+	mockProjectRepo.On("GetVersion", mock.Anything, uint(1), 1).Return(&domain.ProjectVersion{
+		ID: 10, ProjectID: 1, Version: 1, PathFile: oldZip, FileSize: info.Size(), Checksum: "abc",
+	}, nil)
+	mockProjectRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+		return p.PathFile == oldZip && p.FileSize == info.Size() && p.Ukuran != ""
+	})).Return(nil).Once()
+	mockProjectRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.ProjectVersion) bool {
+		return v.PathFile == oldZip && v.Checksum == "abc" && v.UploadedBy == "user-1" && v.Catatan == "Dipulihkan dari versi 1"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ProjectVersion).Version = 3
+	}).Return(nil).Once()
+	mockProjectRepo.On("SaveManifest", mock.Anything, mock.MatchedBy(func(m *domain.ProjectManifest) bool {
+		return m.ProjectID == 1 && m.EntryCount == 1 && m.Entries[0].Path == "README.md"
+	})).Return(nil).Once()
+
+	item, err := projectUC.RestoreVersion(context.Background(), 1, "user-1", 1, dto.RestoreProjectVersionRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, item.Version)
+	assert.True(t, item.IsCurrent)
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestProjectUsecase_RestoreVersion_ResubmitsReview(t *testing.T) {
+	t.Parallel()
+	projectUC, mockProjectRepo := newVersionTestUsecase(0)
+
+	oldZip := writeBrowseTestZip(t, map[string]string{"README.md": "# v1\n"})
+	project := &domain.Project{ID: 1, UserID: "user-1", PathFile: "/tmp/v2.zip", ReviewStatus: domain.ProjectReviewApproved}
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(project, nil)
+	mockProjectRepo.On("GetVersion", mock.Anything, uint(1), 1).Return(&domain.ProjectVersion{ID: 10, ProjectID: 1, Version: 1, PathFile: oldZip}, nil)
+	update := mockProjectRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	mockProjectRepo.On("UpdateReviewStatus", mock.Anything, mock.Anything, mock.MatchedBy(func(e *domain.ProjectReviewEvent) bool {
+		return e.ActorID == "user-1" && e.FromStatus == domain.ProjectReviewApproved && e.ToStatus == domain.ProjectReviewSubmitted
+	})).Return(true, nil).Once().NotBefore(update)
+	mockProjectRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil).Once()
+	mockProjectRepo.On("SaveManifest", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := projectUC.RestoreVersion(context.Background(), 1, "user-1", 1, dto.RestoreProjectVersionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectReviewSubmitted, project.ReviewStatus)
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestProjectUsecase_RestoreVersion_FailedUpdateKeepsReview(t *testing.T) {
+	t.Parallel()
+	projectUC, mockProjectRepo := newVersionTestUsecase(0)
+
+	oldZip := writeBrowseTestZip(t, map[string]string{"README.md": "# v1\n"})
+	project := &domain.Project{ID: 1, UserID: "user-1", PathFile: "/tmp/v2.zip", ReviewStatus: domain.ProjectReviewApproved}
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(project, nil)
+	mockProjectRepo.On("GetVersion", mock.Anything, uint(1), 1).Return(&domain.ProjectVersion{ID: 10, ProjectID: 1, Version: 1, PathFile: oldZip}, nil)
+	mockProjectRepo.On("Update", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+	_, err := projectUC.RestoreVersion(context.Background(), 1, "user-1", 1, dto.RestoreProjectVersionRequest{})
+	require.Error(t, err)
+	assert.Equal(t, domain.ProjectReviewApproved, project.ReviewStatus)
+	mockProjectRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProjectUsecase_RestoreVersion_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		version    *domain.ProjectVersion
+		versionErr error
+		wantCode   int
+	}{
+		{
+			name:     "already current",
+			version:  &domain.ProjectVersion{Version: 2, PathFile: "/tmp/current.zip"},
+			wantCode: 409,
+		},
+		{
+			name:       "version not found",
+			versionErr: apperrors.ErrRecordNotFound,
+			wantCode:   404,
+		},
+		{
+			name:     "version file missing",
+			version:  &domain.ProjectVersion{Version: 1, PathFile: filepath.Join(os.TempDir(), "missing-version.zip")},
+			wantCode: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			projectUC, mockProjectRepo := newVersionTestUsecase(0)
+			mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: "/tmp/current.zip"}, nil)
+			if tt.versionErr != nil {
+				mockProjectRepo.On("GetVersion", mock.Anything, uint(1), mock.Anything).Return(nil, tt.versionErr)
+			} else {
+				mockProjectRepo.On("GetVersion", mock.Anything, uint(1), mock.Anything).Return(tt.version, nil)
+			}
+
+			_, err := projectUC.RestoreVersion(context.Background(), 1, "user-1", 1, dto.RestoreProjectVersionRequest{})
+
+			var appErr *apperrors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.wantCode, appErr.HTTPStatus)
+			mockProjectRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPruneProjectVersions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, name := range []string{"v1", "v2", "v3", "v4"} {
+		files[name] = filepath.Join(dir, name+".zip")
+		require.NoError(t, os.WriteFile(files[name], []byte(name), 0o644))
+	}
+
+	mockProjectRepo := new(MockProjectRepository)
+	// v5 restored v1, so v1's file is still in use after v1 itself is pruned, and v3
+	// was handed in for an assignment.
+	mockProjectRepo.On("GetVersions", mock.Anything, uint(1)).Return([]domain.ProjectVersion{
+		{ID: 5, Version: 5, PathFile: files["v1"]},
+		{ID: 4, Version: 4, PathFile: files["v4"]},
+		{ID: 3, Version: 3, PathFile: files["v3"]},
+		{ID: 2, Version: 2, PathFile: files["v2"]},
+		{ID: 1, Version: 1, PathFile: files["v1"]},
+	}, nil)
+	mockProjectRepo.On("GetSubmittedPaths", mock.Anything, uint(1)).Return([]string{files["v3"]}, nil)
+	mockProjectRepo.On("DeleteVersions", mock.Anything, []uint{3, 2, 1}).Return(nil).Once()
+
+	require.NoError(t, pruneProjectVersions(context.Background(), mockProjectRepo, 1, files["v1"], 2))
+
+	assert.FileExists(t, files["v1"])
+	assert.FileExists(t, files["v4"])
+	assert.FileExists(t, files["v3"])
+	assert.NoFileExists(t, files["v2"])
+	mockProjectRepo.AssertExpectations(t)
+}
+
+func TestPruneProjectVersions_KeepAll(t *testing.T) {
+	t.Parallel()
+	mockProjectRepo := new(MockProjectRepository)
+
+	require.NoError(t, pruneProjectVersions(context.Background(), mockProjectRepo, 1, "/tmp/v1.zip", 0))
+	mockProjectRepo.AssertNotCalled(t, "GetVersions", mock.Anything, mock.Anything)
+}
+
+func TestProjectUsecase_Delete_RemovesVersionFiles(t *testing.T) {
+	t.Parallel()
+	projectUC, mockProjectRepo := newVersionTestUsecase(0)
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current.zip")
+	older := filepath.Join(dir, "older.zip")
+	submitted := filepath.Join(dir, "submitted.zip")
+	require.NoError(t, os.WriteFile(current, []byte("current"), 0o644))
+	require.NoError(t, os.WriteFile(older, []byte("older"), 0o644))
+	require.NoError(t, os.WriteFile(submitted, []byte("submitted"), 0o644))
+
+	mockProjectRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Project{ID: 1, UserID: "user-1", PathFile: current}, nil)
+	mockProjectRepo.On("GetVersions", mock.Anything, uint(1)).Return([]domain.ProjectVersion{
+		{Version: 2, PathFile: current},
+		{Version: 1, PathFile: older},
+	}, nil)
+	// The version submitted.zip was made with has been pruned already.
+	mockProjectRepo.On("GetSubmittedPaths", mock.Anything, uint(1)).Return([]string{submitted, current}, nil)
+	mockProjectRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
+
+	require.NoError(t, projectUC.Delete(context.Background(), 1, "user-1"))
+	assert.NoFileExists(t, current)
+	assert.NoFileExists(t, older)
+	assert.NoFileExists(t, submitted)
+}
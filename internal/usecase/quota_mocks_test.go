@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQuotaRepository is a mock for QuotaRepository
+type MockQuotaRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuotaRepository) GetRoleQuota(ctx context.Context, roleID uint) (*domain.RoleQuota, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RoleQuota), args.Error(1)
+}
+
+func (m *MockQuotaRepository) SaveRoleQuota(ctx context.Context, quota *domain.RoleQuota) error {
+	args := m.Called(ctx, quota)
+	return args.Error(0)
+}
+
+func (m *MockQuotaRepository) GetUserQuota(ctx context.Context, userID string) (*domain.UserQuota, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserQuota), args.Error(1)
+}
+
+func (m *MockQuotaRepository) SaveUserQuota(ctx context.Context, quota *domain.UserQuota) error {
+	args := m.Called(ctx, quota)
+	return args.Error(0)
+}
+
+func (m *MockQuotaRepository) DeleteUserQuota(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockQuotaRepository) GetUsage(ctx context.Context, userID string) (*domain.StorageUsage, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StorageUsage), args.Error(1)
+}
+
+// MockQuotaUsecase is a mock for QuotaUsecase
+type MockQuotaUsecase struct {
+	mock.Mock
+}
+
+func (m *MockQuotaUsecase) GetQuota(ctx context.Context, userID string) (*dto.QuotaData, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.QuotaData), args.Error(1)
+}
+
+func (m *MockQuotaUsecase) CheckUpload(ctx context.Context, userID string, check dto.QuotaCheck) error {
+	args := m.Called(ctx, userID, check)
+	return args.Error(0)
+}
+
+func (m *MockQuotaUsecase) UpdateUserQuota(ctx context.Context, userID string, req dto.UpdateUserQuotaRequest) (*dto.QuotaData, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.QuotaData), args.Error(1)
+}
+
+func (m *MockQuotaUsecase) GetRoleQuota(ctx context.Context, roleID uint) (*dto.RoleQuotaData, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleQuotaData), args.Error(1)
+}
+
+func (m *MockQuotaUsecase) UpdateRoleQuota(ctx context.Context, roleID uint, req dto.UpdateRoleQuotaRequest) (*dto.RoleQuotaData, error) {
+	args := m.Called(ctx, roleID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleQuotaData), args.Error(1)
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/config"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/storage"
+	"invento-service/internal/usecase/repo"
+
+	apperrors "invento-service/internal/errors"
+
+	"gorm.io/gorm"
+)
+
+// QuotaUsecase accounts for the bytes and files each user keeps in projects and
+// moduls. Each limit is resolved separately: the user's override if one is set, then
+// the quota of the user's role, then QUOTA_DEFAULT_MAX_BYTES / QUOTA_DEFAULT_MAX_FILES.
+type QuotaUsecase interface {
+	GetQuota(ctx context.Context, userID string) (*dto.QuotaData, error)
+	CheckUpload(ctx context.Context, userID string, check dto.QuotaCheck) error
+	UpdateUserQuota(ctx context.Context, userID string, req dto.UpdateUserQuotaRequest) (*dto.QuotaData, error)
+	GetRoleQuota(ctx context.Context, roleID uint) (*dto.RoleQuotaData, error)
+	UpdateRoleQuota(ctx context.Context, roleID uint, req dto.UpdateRoleQuotaRequest) (*dto.RoleQuotaData, error)
+}
+
+type quotaUsecase struct {
+	quotaRepo repo.QuotaRepository
+	userRepo  repo.UserRepository
+	roleRepo  repo.RoleRepository
+	config    *config.Config
+}
+
+func NewQuotaUsecase(quotaRepo repo.QuotaRepository, userRepo repo.UserRepository, roleRepo repo.RoleRepository, cfg *config.Config) QuotaUsecase {
+	return &quotaUsecase{
+		quotaRepo: quotaRepo,
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		config:    cfg,
+	}
+}
+
+func (uc *quotaUsecase) GetQuota(ctx context.Context, userID string) (*dto.QuotaData, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("User")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.GetQuota: %w", err))
+	}
+
+	quota := &dto.QuotaData{
+		UserID:   userID,
+		MaxBytes: uc.config.Quota.DefaultMaxBytes,
+		MaxFiles: uc.config.Quota.DefaultMaxFiles,
+	}
+
+	if user.RoleID != nil {
+		roleQuota, err := uc.quotaRepo.GetRoleQuota(ctx, uint(*user.RoleID))
+		switch {
+		case err == nil:
+			quota.MaxBytes = roleQuota.MaxBytes
+			quota.MaxFiles = roleQuota.MaxFiles
+		case !errors.Is(err, apperrors.ErrRecordNotFound):
+			return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.GetQuota: role quota: %w", err))
+		}
+	}
+
+	override, err := uc.quotaRepo.GetUserQuota(ctx, userID)
+	switch {
+	case err == nil:
+		quota.Override = &dto.QuotaOverride{MaxBytes: override.MaxBytes, MaxFiles: override.MaxFiles}
+		if override.MaxBytes != nil {
+			quota.MaxBytes = *override.MaxBytes
+		}
+		if override.MaxFiles != nil {
+			quota.MaxFiles = *override.MaxFiles
+		}
+	case !errors.Is(err, apperrors.ErrRecordNotFound):
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.GetQuota: user quota: %w", err))
+	}
+
+	usage, err := uc.quotaRepo.GetUsage(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.GetQuota: usage: %w", err))
+	}
+	quota.UsedBytes = usage.Bytes
+	quota.UsedFiles = usage.Files
+	quota.PendingBytes = usage.PendingBytes
+
+	return quota, nil
+}
+
+// CheckUpload rejects an upload that can never fit in the quota with 413, and one
+// that does not fit in what is left of it with 507.
+func (uc *quotaUsecase) CheckUpload(ctx context.Context, userID string, check dto.QuotaCheck) error {
+	quota, err := uc.GetQuota(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if quota.MaxBytes > 0 {
+		if check.Bytes > quota.MaxBytes {
+			return apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file %s melebihi kuota penyimpanan %s", storage.FormatFileSize(check.Bytes), storage.FormatFileSize(quota.MaxBytes)))
+		}
+		used := max(quota.UsedBytes-check.FreedBytes, 0)
+		if used+check.Bytes > quota.MaxBytes {
+			return apperrors.NewQuotaExceededError(fmt.Sprintf("kuota penyimpanan tidak mencukupi, sisa %s dari %s", storage.FormatFileSize(max(quota.MaxBytes-used, 0)), storage.FormatFileSize(quota.MaxBytes)))
+		}
+	}
+
+	if check.NewFile && quota.MaxFiles > 0 && quota.UsedFiles >= quota.MaxFiles {
+		return apperrors.NewQuotaExceededError(fmt.Sprintf("jumlah file sudah mencapai batas kuota %d file", quota.MaxFiles))
+	}
+
+	return nil
+}
+
+func (uc *quotaUsecase) UpdateUserQuota(ctx context.Context, userID string, req dto.UpdateUserQuotaRequest) (*dto.QuotaData, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("User")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.UpdateUserQuota: %w", err))
+	}
+
+	if req.MaxBytes == nil && req.MaxFiles == nil {
+		if err := uc.quotaRepo.DeleteUserQuota(ctx, userID); err != nil {
+			return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.UpdateUserQuota: %w", err))
+		}
+		return uc.GetQuota(ctx, userID)
+	}
+
+	override := &domain.UserQuota{
+		UserID:   userID,
+		MaxBytes: req.MaxBytes,
+		MaxFiles: req.MaxFiles,
+	}
+	if err := uc.quotaRepo.SaveUserQuota(ctx, override); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.UpdateUserQuota: %w", err))
+	}
+
+	return uc.GetQuota(ctx, userID)
+}
+
+func (uc *quotaUsecase) GetRoleQuota(ctx context.Context, roleID uint) (*dto.RoleQuotaData, error) {
+	role, err := uc.getRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &dto.RoleQuotaData{
+		RoleID:   role.ID,
+		NamaRole: role.NamaRole,
+		MaxBytes: uc.config.Quota.DefaultMaxBytes,
+		MaxFiles: uc.config.Quota.DefaultMaxFiles,
+	}
+
+	quota, err := uc.quotaRepo.GetRoleQuota(ctx, roleID)
+	switch {
+	case err == nil:
+		data.MaxBytes = quota.MaxBytes
+		data.MaxFiles = quota.MaxFiles
+	case !errors.Is(err, apperrors.ErrRecordNotFound):
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.GetRoleQuota: %w", err))
+	}
+
+	return data, nil
+}
+
+func (uc *quotaUsecase) UpdateRoleQuota(ctx context.Context, roleID uint, req dto.UpdateRoleQuotaRequest) (*dto.RoleQuotaData, error) {
+	role, err := uc.getRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &domain.RoleQuota{
+		RoleID:   role.ID,
+		MaxBytes: req.MaxBytes,
+		MaxFiles: req.MaxFiles,
+	}
+	if err := uc.quotaRepo.SaveRoleQuota(ctx, quota); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.UpdateRoleQuota: %w", err))
+	}
+
+	return &dto.RoleQuotaData{
+		RoleID:   role.ID,
+		NamaRole: role.NamaRole,
+		MaxBytes: quota.MaxBytes,
+		MaxFiles: quota.MaxFiles,
+	}, nil
+}
+
+func (uc *quotaUsecase) getRole(ctx context.Context, roleID uint) (*domain.Role, error) {
+	role, err := uc.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Role")
+		}
+		return nil, apperrors.NewInternalError(fmt.Errorf("QuotaUsecase.getRole: %w", err))
+	}
+	return role, nil
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"invento-service/config"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newQuotaTestUsecase() (QuotaUsecase, *MockQuotaRepository, *MockUserRepository, *MockRoleRepository) {
+	quotaRepo := new(MockQuotaRepository)
+	userRepo := new(MockUserRepository)
+	roleRepo := new(MockRoleRepository)
+	cfg := &config.Config{
+		Quota: config.QuotaConfig{
+			DefaultMaxBytes: 10000,
+			DefaultMaxFiles: 10,
+		},
+	}
+	return NewQuotaUsecase(quotaRepo, userRepo, roleRepo, cfg), quotaRepo, userRepo, roleRepo
+}
+
+func TestQuotaUsecase_GetQuota_Resolution(t *testing.T) {
+	t.Parallel()
+	roleID := 2
+	overrideFiles := 3
+
+	tests := []struct {
+		name      string
+		roleID    *int
+		roleQuota *domain.RoleQuota
+		override  *domain.UserQuota
+		wantBytes int64
+		wantFiles int
+	}{
+		{name: "config default", wantBytes: 10000, wantFiles: 10},
+		{name: "role without quota", roleID: &roleID, wantBytes: 10000, wantFiles: 10},
+		{name: "role quota", roleID: &roleID, roleQuota: &domain.RoleQuota{RoleID: 2, MaxBytes: 500, MaxFiles: 0}, wantBytes: 500, wantFiles: 0},
+		{
+			name:      "partial user override",
+			roleID:    &roleID,
+			roleQuota: &domain.RoleQuota{RoleID: 2, MaxBytes: 500, MaxFiles: 5},
+			override:  &domain.UserQuota{UserID: "user-1", MaxFiles: &overrideFiles},
+			wantBytes: 500,
+			wantFiles: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			uc, quotaRepo, userRepo, _ := newQuotaTestUsecase()
+			ctx := context.Background()
+
+			userRepo.On("GetByID", ctx, "user-1").Return(&domain.User{ID: "user-1", RoleID: tt.roleID}, nil)
+			if tt.roleID != nil {
+				if tt.roleQuota != nil {
+					quotaRepo.On("GetRoleQuota", ctx, uint(*tt.roleID)).Return(tt.roleQuota, nil)
+				} else {
+					quotaRepo.On("GetRoleQuota", ctx, uint(*tt.roleID)).Return(nil, apperrors.ErrRecordNotFound)
+				}
+			}
+			if tt.override != nil {
+				quotaRepo.On("GetUserQuota", ctx, "user-1").Return(tt.override, nil)
+			} else {
+				quotaRepo.On("GetUserQuota", ctx, "user-1").Return(nil, apperrors.ErrRecordNotFound)
+			}
+			quotaRepo.On("GetUsage", ctx, "user-1").Return(&domain.StorageUsage{Bytes: 120, Files: 2, PendingBytes: 20}, nil)
+
+			quota, err := uc.GetQuota(ctx, "user-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBytes, quota.MaxBytes)
+			assert.Equal(t, tt.wantFiles, quota.MaxFiles)
+			assert.Equal(t, int64(120), quota.UsedBytes)
+			assert.Equal(t, 2, quota.UsedFiles)
+			assert.Equal(t, int64(20), quota.PendingBytes)
+			assert.Equal(t, tt.override != nil, quota.Override != nil)
+		})
+	}
+}
+
+func TestQuotaUsecase_GetQuota_UserNotFound(t *testing.T) {
+	t.Parallel()
+	uc, _, userRepo, _ := newQuotaTestUsecase()
+	userRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := uc.GetQuota(context.Background(), "missing")
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.ErrNotFound, appErr.Code)
+}
+
+func TestQuotaUsecase_CheckUpload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		check    dto.QuotaCheck
+		wantCode string
+	}{
+		{name: "fits", check: dto.QuotaCheck{Bytes: 2000, NewFile: true}},
+		{name: "larger than the whole quota", check: dto.QuotaCheck{Bytes: 10001, NewFile: true}, wantCode: apperrors.ErrPayloadTooLarge},
+		{name: "larger than what is left", check: dto.QuotaCheck{Bytes: 3000, NewFile: true}, wantCode: apperrors.ErrQuotaExceeded},
+		{name: "update frees the pruned files", check: dto.QuotaCheck{Bytes: 3000, FreedBytes: 1000}},
+		{name: "slice adds no file", check: dto.QuotaCheck{Bytes: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			uc, quotaRepo, userRepo, _ := newQuotaTestUsecase()
+			userRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil)
+			quotaRepo.On("GetUserQuota", mock.Anything, "user-1").Return(nil, apperrors.ErrRecordNotFound)
+			quotaRepo.On("GetUsage", mock.Anything, "user-1").Return(&domain.StorageUsage{Bytes: 8000, Files: 4}, nil)
+
+			err := uc.CheckUpload(context.Background(), "user-1", tt.check)
+			if tt.wantCode == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
+
+func TestQuotaUsecase_CheckUpload_FileLimit(t *testing.T) {
+	t.Parallel()
+	uc, quotaRepo, userRepo, _ := newQuotaTestUsecase()
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil)
+	quotaRepo.On("GetUserQuota", mock.Anything, "user-1").Return(nil, apperrors.ErrRecordNotFound)
+	quotaRepo.On("GetUsage", mock.Anything, "user-1").Return(&domain.StorageUsage{Bytes: 100, Files: 10}, nil)
+
+	err := uc.CheckUpload(context.Background(), "user-1", dto.QuotaCheck{Bytes: 10, NewFile: true})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.ErrQuotaExceeded, appErr.Code)
+
+	assert.NoError(t, uc.CheckUpload(context.Background(), "user-1", dto.QuotaCheck{Bytes: 10, FreedBytes: 10}))
+}
+
+func TestQuotaUsecase_UpdateUserQuota(t *testing.T) {
+	t.Parallel()
+
+	t.Run("saves override", func(t *testing.T) {
+		t.Parallel()
+		uc, quotaRepo, userRepo, _ := newQuotaTestUsecase()
+		maxBytes := int64(20000)
+		userRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil)
+		quotaRepo.On("SaveUserQuota", mock.Anything, mock.MatchedBy(func(q *domain.UserQuota) bool {
+			return q.UserID == "user-1" && q.MaxBytes != nil && *q.MaxBytes == maxBytes && q.MaxFiles == nil
+		})).Return(nil)
+		quotaRepo.On("GetUserQuota", mock.Anything, "user-1").Return(&domain.UserQuota{UserID: "user-1", MaxBytes: &maxBytes}, nil)
+		quotaRepo.On("GetUsage", mock.Anything, "user-1").Return(&domain.StorageUsage{}, nil)
+
+		quota, err := uc.UpdateUserQuota(context.Background(), "user-1", dto.UpdateUserQuotaRequest{MaxBytes: &maxBytes})
+		require.NoError(t, err)
+		assert.Equal(t, maxBytes, quota.MaxBytes)
+		assert.Equal(t, 10, quota.MaxFiles)
+		quotaRepo.AssertExpectations(t)
+	})
+
+	t.Run("clearing both limits removes the override", func(t *testing.T) {
+		t.Parallel()
+		uc, quotaRepo, userRepo, _ := newQuotaTestUsecase()
+		userRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil)
+		quotaRepo.On("DeleteUserQuota", mock.Anything, "user-1").Return(nil)
+		quotaRepo.On("GetUserQuota", mock.Anything, "user-1").Return(nil, apperrors.ErrRecordNotFound)
+		quotaRepo.On("GetUsage", mock.Anything, "user-1").Return(&domain.StorageUsage{}, nil)
+
+		quota, err := uc.UpdateUserQuota(context.Background(), "user-1", dto.UpdateUserQuotaRequest{})
+		require.NoError(t, err)
+		assert.Nil(t, quota.Override)
+		quotaRepo.AssertNotCalled(t, "SaveUserQuota", mock.Anything, mock.Anything)
+	})
+}
+
+func TestQuotaUsecase_UpdateRoleQuota(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		uc, quotaRepo, _, roleRepo := newQuotaTestUsecase()
+		roleRepo.On("GetByID", mock.Anything, uint(2)).Return(&domain.Role{ID: 2, NamaRole: "mahasiswa"}, nil)
+		quotaRepo.On("SaveRoleQuota", mock.Anything, &domain.RoleQuota{RoleID: 2, MaxBytes: 1 << 30, MaxFiles: 20}).Return(nil)
+
+		data, err := uc.UpdateRoleQuota(context.Background(), 2, dto.UpdateRoleQuotaRequest{MaxBytes: 1 << 30, MaxFiles: 20})
+		require.NoError(t, err)
+		assert.Equal(t, &dto.RoleQuotaData{RoleID: 2, NamaRole: "mahasiswa", MaxBytes: 1 << 30, MaxFiles: 20}, data)
+	})
+
+	t.Run("role not found", func(t *testing.T) {
+		t.Parallel()
+		uc, _, _, roleRepo := newQuotaTestUsecase()
+		roleRepo.On("GetByID", mock.Anything, uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := uc.UpdateRoleQuota(context.Background(), 9, dto.UpdateRoleQuotaRequest{})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrNotFound, appErr.Code)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		uc, quotaRepo, _, roleRepo := newQuotaTestUsecase()
+		roleRepo.On("GetByID", mock.Anything, uint(2)).Return(&domain.Role{ID: 2, NamaRole: "mahasiswa"}, nil)
+		quotaRepo.On("SaveRoleQuota", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+		_, err := uc.UpdateRoleQuota(context.Background(), 2, dto.UpdateRoleQuotaRequest{})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrInternal, appErr.Code)
+	})
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type assignmentRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewAssignmentRepository(db *gorm.DB, logger zerolog.Logger) AssignmentRepository {
+	return &assignmentRepository{
+		db:     db,
+		logger: logger.With().Str("component", "AssignmentRepository").Logger(),
+	}
+}
+
+func (r *assignmentRepository) Create(ctx context.Context, assignment *domain.Assignment) error {
+	if err := r.db.WithContext(ctx).Create(assignment).Error; err != nil {
+		return fmt.Errorf("AssignmentRepository.Create: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns the assignment with its class.
+func (r *assignmentRepository) GetByID(ctx context.Context, id uint) (*domain.Assignment, error) {
+	var assignment domain.Assignment
+	err := r.db.WithContext(ctx).Preload("Class").First(&assignment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("AssignmentRepository.GetByID: %w", err)
+	}
+	return &assignment, nil
+}
+
+// GetByMember lists the assignments of the classes userID belongs to, latest due
+// date first. A non-zero classID narrows the list to that class.
+func (r *assignmentRepository) GetByMember(ctx context.Context, userID string, classID uint, page, limit int) ([]dto.AssignmentListItem, int, error) {
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Assignment{}).
+		Joins("JOIN classes ON classes.id = assignments.class_id").
+		Joins("JOIN class_members ON class_members.class_id = assignments.class_id AND class_members.user_id = ?", userID)
+	if classID != 0 {
+		query = query.Where("assignments.class_id = ?", classID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error().Err(err).Str("user_id", userID).Msg("assignment count query failed")
+		return nil, 0, fmt.Errorf("AssignmentRepository.GetByMember: count: %w", err)
+	}
+
+	items := []dto.AssignmentListItem{}
+	err := query.
+		Select(`assignments.id, assignments.class_id, classes.nama AS nama_kelas, assignments.judul,
+			class_members.role AS peran, assignments.open_at, assignments.due_at,
+			(SELECT COUNT(*) FROM assignment_submissions s WHERE s.assignment_id = assignments.id) AS jumlah_pengumpulan,
+			COALESCE((SELECT s.status FROM assignment_submissions s WHERE s.assignment_id = assignments.id AND s.user_id = ?), '') AS status_pengumpulan`,
+			userID).
+		Order("assignments.due_at DESC, assignments.id DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Scan(&items).Error
+	if err != nil {
+		r.logger.Error().Err(err).Str("user_id", userID).Msg("assignment data query failed")
+		return nil, 0, fmt.Errorf("AssignmentRepository.GetByMember: %w", err)
+	}
+
+	return items, int(total), nil
+}
+
+func (r *assignmentRepository) Update(ctx context.Context, assignment *domain.Assignment) error {
+	if err := r.db.WithContext(ctx).Model(&domain.Assignment{ID: assignment.ID}).
+		Select("judul", "deskripsi", "open_at", "due_at", "late_window_minutes", "max_size").
+		Updates(assignment).Error; err != nil {
+		return fmt.Errorf("AssignmentRepository.Update: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the assignment and its submissions. The submitted projects stay
+// with the mahasiswa who uploaded them.
+func (r *assignmentRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("assignment_id = ?", id).Delete(&domain.AssignmentSubmission{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Assignment{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("AssignmentRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+// SaveSubmission records submission, replacing the mahasiswa's earlier one.
+func (r *assignmentRepository) SaveSubmission(ctx context.Context, submission *domain.AssignmentSubmission) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "assignment_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"project_id", "status", "path_file", "checksum", "file_size", "submitted_at", "updated_at"}),
+	}).Create(submission).Error
+	if err != nil {
+		return fmt.Errorf("AssignmentRepository.SaveSubmission: %w", err)
+	}
+	return nil
+}
+
+func (r *assignmentRepository) GetSubmission(ctx context.Context, assignmentID uint, userID string) (*domain.AssignmentSubmission, error) {
+	var submission domain.AssignmentSubmission
+	err := r.db.WithContext(ctx).
+		Preload("Project").
+		Where("assignment_id = ? AND user_id = ?", assignmentID, userID).
+		First(&submission).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("AssignmentRepository.GetSubmission: %w", err)
+	}
+	return &submission, nil
+}
+
+// GetSubmissions returns the submissions of an assignment with their mahasiswa and
+// projects, ordered by name. A submission whose project was deleted since keeps a
+// zero Project.
+func (r *assignmentRepository) GetSubmissions(ctx context.Context, assignmentID uint) ([]domain.AssignmentSubmission, error) {
+	submissions := []domain.AssignmentSubmission{}
+	err := r.db.WithContext(ctx).
+		Joins("User").
+		Preload("Project").
+		Where("assignment_submissions.assignment_id = ?", assignmentID).
+		Order(`"User".name ASC`).
+		Find(&submissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("AssignmentRepository.GetSubmissions: %w", err)
+	}
+	return submissions, nil
+}
@@ -0,0 +1,98 @@
+package repo_test
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/usecase/repo"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+	testhelper "invento-service/internal/testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssignmentRepository_Submissions tests listing assignments per member and replacing submissions
+func TestAssignmentRepository_Submissions(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	classRepo := repo.NewClassRepository(db, zerolog.Nop())
+	assignmentRepo := repo.NewAssignmentRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	require.NoError(t, db.Create(&[]domain.User{
+		{ID: "dosen-1", Email: "dosen1@polije.ac.id", Name: "Dosen Satu"},
+		{ID: "mhs-1", Email: "budi@student.polije.ac.id", Name: "Budi"},
+		{ID: "mhs-2", Email: "ani@student.polije.ac.id", Name: "Ani"},
+	}).Error)
+	projects := []domain.Project{
+		{UserID: "mhs-1", NamaProject: "ERD Budi", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "a.zip"},
+		{UserID: "mhs-1", NamaProject: "ERD Budi Revisi", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "b.zip"},
+		{UserID: "mhs-2", NamaProject: "ERD Ani", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "c.zip"},
+	}
+	require.NoError(t, db.Create(&projects).Error)
+
+	class := &domain.Class{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1", JoinCode: "ABCD2345", CreatedBy: "dosen-1"}
+	require.NoError(t, classRepo.Create(ctx, class, []string{"dosen-1"}))
+	require.NoError(t, classRepo.AddMembers(ctx, []domain.ClassMember{
+		{ClassID: class.ID, UserID: "mhs-1", Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedManual},
+		{ClassID: class.ID, UserID: "mhs-2", Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedManual},
+	}))
+
+	now := time.Now()
+	erd := &domain.Assignment{ClassID: class.ID, Judul: "Tugas ERD", OpenAt: now, DueAt: now.Add(24 * time.Hour), CreatedBy: "dosen-1"}
+	require.NoError(t, assignmentRepo.Create(ctx, erd))
+	normalisasi := &domain.Assignment{ClassID: class.ID, Judul: "Normalisasi", OpenAt: now, DueAt: now.Add(48 * time.Hour), CreatedBy: "dosen-1"}
+	require.NoError(t, assignmentRepo.Create(ctx, normalisasi))
+
+	require.NoError(t, assignmentRepo.SaveSubmission(ctx, &domain.AssignmentSubmission{
+		AssignmentID: erd.ID, UserID: "mhs-1", ProjectID: projects[0].ID, Status: domain.SubmissionStatusOnTime, SubmittedAt: now,
+	}))
+	require.NoError(t, assignmentRepo.SaveSubmission(ctx, &domain.AssignmentSubmission{
+		AssignmentID: erd.ID, UserID: "mhs-2", ProjectID: projects[2].ID, Status: domain.SubmissionStatusOnTime, SubmittedAt: now,
+	}))
+	// Submitting again replaces the earlier submission.
+	require.NoError(t, assignmentRepo.SaveSubmission(ctx, &domain.AssignmentSubmission{
+		AssignmentID: erd.ID, UserID: "mhs-1", ProjectID: projects[1].ID, Status: domain.SubmissionStatusLate, SubmittedAt: now.Add(25 * time.Hour),
+	}))
+
+	submissions, err := assignmentRepo.GetSubmissions(ctx, erd.ID)
+	require.NoError(t, err)
+	require.Len(t, submissions, 2)
+	assert.Equal(t, "Ani", submissions[0].User.Name, "ordered by name")
+	assert.Equal(t, "ERD Budi Revisi", submissions[1].Project.NamaProject)
+	assert.Equal(t, domain.SubmissionStatusLate, submissions[1].Status)
+
+	items, total, err := assignmentRepo.GetByMember(ctx, "mhs-1", 0, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Normalisasi", items[0].Judul, "latest due date first")
+	assert.Empty(t, items[0].StatusPengumpulan)
+	assert.Equal(t, domain.SubmissionStatusLate, items[1].StatusPengumpulan)
+	assert.Equal(t, 2, items[1].JumlahPengumpulan)
+	assert.Equal(t, "Basis Data", items[1].NamaKelas)
+	assert.Equal(t, domain.ClassRoleMahasiswa, items[1].Peran)
+
+	_, total, err = assignmentRepo.GetByMember(ctx, "mhs-1", class.ID+1, 1, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	require.NoError(t, assignmentRepo.Delete(ctx, normalisasi.ID))
+	_, err = assignmentRepo.GetByID(ctx, normalisasi.ID)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	// Deleting the class takes its assignments and submissions along.
+	require.NoError(t, classRepo.Delete(ctx, class.ID))
+	_, err = assignmentRepo.GetByID(ctx, erd.ID)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+	var remaining int64
+	require.NoError(t, db.Model(&domain.AssignmentSubmission{}).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
@@ -0,0 +1,217 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"strings"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type classRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewClassRepository(db *gorm.DB, logger zerolog.Logger) ClassRepository {
+	return &classRepository{
+		db:     db,
+		logger: logger.With().Str("component", "ClassRepository").Logger(),
+	}
+}
+
+// Create saves class together with its lecturers.
+func (r *classRepository) Create(ctx context.Context, class *domain.Class, lecturerIDs []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(class).Error; err != nil {
+			return err
+		}
+		return tx.Create(lecturerMembers(class.ID, lecturerIDs)).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ClassRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *classRepository) GetByID(ctx context.Context, id uint) (*domain.Class, error) {
+	return r.first(ctx, "ClassRepository.GetByID", "id = ?", id)
+}
+
+func (r *classRepository) GetByKode(ctx context.Context, kode, periode string) (*domain.Class, error) {
+	return r.first(ctx, "ClassRepository.GetByKode", "LOWER(kode) = LOWER(?) AND periode = ?", kode, periode)
+}
+
+func (r *classRepository) GetByJoinCode(ctx context.Context, joinCode string) (*domain.Class, error) {
+	return r.first(ctx, "ClassRepository.GetByJoinCode", "join_code = ?", joinCode)
+}
+
+func (r *classRepository) first(ctx context.Context, op, query string, args ...interface{}) (*domain.Class, error) {
+	var class domain.Class
+	err := r.db.WithContext(ctx).Where(query, args...).First(&class).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &class, nil
+}
+
+// GetByMember lists the classes userID teaches or is enrolled in, newest period first.
+func (r *classRepository) GetByMember(ctx context.Context, userID, search, periode string, page, limit int) ([]dto.ClassListItem, int, error) {
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Class{}).
+		Joins("JOIN class_members ON class_members.class_id = classes.id AND class_members.user_id = ?", userID)
+	if search != "" {
+		pattern := "%" + strings.ToLower(search) + "%"
+		query = query.Where("(LOWER(classes.kode) LIKE ? OR LOWER(classes.nama) LIKE ?)", pattern, pattern)
+	}
+	if periode != "" {
+		query = query.Where("classes.periode = ?", periode)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error().Err(err).Str("user_id", userID).Msg("class count query failed")
+		return nil, 0, fmt.Errorf("ClassRepository.GetByMember: count: %w", err)
+	}
+
+	items := []dto.ClassListItem{}
+	err := query.
+		Select(`classes.id, classes.kode, classes.nama, classes.periode, class_members.role AS peran,
+			classes.created_at AS tanggal_dibuat,
+			(SELECT COUNT(*) FROM class_members m WHERE m.class_id = classes.id AND m.role = ?) AS jumlah_dosen,
+			(SELECT COUNT(*) FROM class_members m WHERE m.class_id = classes.id AND m.role = ?) AS jumlah_anggota`,
+			domain.ClassRoleDosen, domain.ClassRoleMahasiswa).
+		Order("classes.periode DESC, classes.nama ASC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Scan(&items).Error
+	if err != nil {
+		r.logger.Error().Err(err).Str("user_id", userID).Msg("class data query failed")
+		return nil, 0, fmt.Errorf("ClassRepository.GetByMember: %w", err)
+	}
+
+	return items, int(total), nil
+}
+
+func (r *classRepository) Update(ctx context.Context, class *domain.Class) error {
+	if err := r.db.WithContext(ctx).Model(&domain.Class{ID: class.ID}).
+		Select("kode", "nama", "periode", "deskripsi").
+		Updates(class).Error; err != nil {
+		return fmt.Errorf("ClassRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *classRepository) UpdateJoinCode(ctx context.Context, id uint, joinCode string) error {
+	if err := r.db.WithContext(ctx).Model(&domain.Class{}).
+		Where("id = ?", id).
+		Update("join_code", joinCode).Error; err != nil {
+		return fmt.Errorf("ClassRepository.UpdateJoinCode: %w", err)
+	}
+	return nil
+}
+
+// ReplaceLecturers makes lecturerIDs the only dosen of the class. A lecturer who was
+// enrolled as mahasiswa stops being one.
+func (r *classRepository) ReplaceLecturers(ctx context.Context, classID uint, lecturerIDs []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("class_id = ? AND (role = ? OR user_id IN ?)", classID, domain.ClassRoleDosen, lecturerIDs).
+			Delete(&domain.ClassMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(lecturerMembers(classID, lecturerIDs)).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ClassRepository.ReplaceLecturers: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the class with its members, assignments and their submissions.
+func (r *classRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assignments := tx.Model(&domain.Assignment{}).Select("id").Where("class_id = ?", id)
+		if err := tx.Where("assignment_id IN (?)", assignments).Delete(&domain.AssignmentSubmission{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("class_id = ?", id).Delete(&domain.Assignment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("class_id = ?", id).Delete(&domain.ClassMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Class{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ClassRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+// GetMembers returns the members of the class with their profiles, lecturers first.
+func (r *classRepository) GetMembers(ctx context.Context, classID uint) ([]domain.ClassMember, error) {
+	members := []domain.ClassMember{}
+	err := r.db.WithContext(ctx).
+		Joins("User").
+		Where("class_members.class_id = ?", classID).
+		Order("class_members.role ASC").
+		Order(`"User".name ASC`).
+		Find(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("ClassRepository.GetMembers: %w", err)
+	}
+	return members, nil
+}
+
+func (r *classRepository) GetMember(ctx context.Context, classID uint, userID string) (*domain.ClassMember, error) {
+	var member domain.ClassMember
+	err := r.db.WithContext(ctx).Where("class_id = ? AND user_id = ?", classID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("ClassRepository.GetMember: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *classRepository) AddMembers(ctx context.Context, members []domain.ClassMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&members).Error; err != nil {
+		return fmt.Errorf("ClassRepository.AddMembers: %w", err)
+	}
+	return nil
+}
+
+func (r *classRepository) RemoveMember(ctx context.Context, classID uint, userID string) error {
+	if err := r.db.WithContext(ctx).
+		Where("class_id = ? AND user_id = ?", classID, userID).
+		Delete(&domain.ClassMember{}).Error; err != nil {
+		return fmt.Errorf("ClassRepository.RemoveMember: %w", err)
+	}
+	return nil
+}
+
+func lecturerMembers(classID uint, lecturerIDs []string) []domain.ClassMember {
+	members := make([]domain.ClassMember, 0, len(lecturerIDs))
+	for _, id := range lecturerIDs {
+		members = append(members, domain.ClassMember{
+			ClassID:   classID,
+			UserID:    id,
+			Role:      domain.ClassRoleDosen,
+			JoinedVia: domain.ClassJoinedManual,
+		})
+	}
+	return members
+}
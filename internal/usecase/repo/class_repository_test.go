@@ -0,0 +1,127 @@
+package repo_test
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/usecase/repo"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+	testhelper "invento-service/internal/testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClassRepository_Membership tests creating classes with lecturers, enrollment and listing per member
+func TestClassRepository_Membership(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	classRepo := repo.NewClassRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	require.NoError(t, db.Create(&[]domain.User{
+		{ID: "dosen-1", Email: "dosen1@polije.ac.id", Name: "Dosen Satu"},
+		{ID: "dosen-2", Email: "dosen2@polije.ac.id", Name: "Dosen Dua"},
+		{ID: "mhs-1", Email: "ani@student.polije.ac.id", Name: "Ani"},
+		{ID: "mhs-2", Email: "budi@student.polije.ac.id", Name: "Budi"},
+	}).Error)
+
+	basisData := &domain.Class{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1", JoinCode: "ABCD2345", CreatedBy: "dosen-1"}
+	require.NoError(t, classRepo.Create(ctx, basisData, []string{"dosen-1", "dosen-2"}))
+	jaringan := &domain.Class{Kode: "TIF202", Nama: "Jaringan Komputer", Periode: "2024/2025-2", JoinCode: "WXYZ6789", CreatedBy: "dosen-1"}
+	require.NoError(t, classRepo.Create(ctx, jaringan, []string{"dosen-1"}))
+
+	found, err := classRepo.GetByKode(ctx, "tif101", "2025/2026-1")
+	require.NoError(t, err)
+	assert.Equal(t, basisData.ID, found.ID)
+	_, err = classRepo.GetByKode(ctx, "TIF101", "2024/2025-2")
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	found, err = classRepo.GetByJoinCode(ctx, "WXYZ6789")
+	require.NoError(t, err)
+	assert.Equal(t, jaringan.ID, found.ID)
+
+	require.NoError(t, classRepo.AddMembers(ctx, []domain.ClassMember{
+		{ClassID: basisData.ID, UserID: "mhs-2", Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedImport},
+		{ClassID: basisData.ID, UserID: "mhs-1", Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedJoinCode},
+	}))
+
+	members, err := classRepo.GetMembers(ctx, basisData.ID)
+	require.NoError(t, err)
+	names := make([]string, 0, len(members))
+	for i := range members {
+		names = append(names, members[i].User.Name)
+	}
+	assert.Equal(t, []string{"Dosen Dua", "Dosen Satu", "Ani", "Budi"}, names, "lecturers first, then by name")
+
+	items, total, err := classRepo.GetByMember(ctx, "dosen-1", "", "", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "TIF101", items[0].Kode, "newest period first")
+	assert.Equal(t, domain.ClassRoleDosen, items[0].Peran)
+	assert.Equal(t, 2, items[0].JumlahDosen)
+	assert.Equal(t, 2, items[0].JumlahAnggota)
+
+	items, total, err = classRepo.GetByMember(ctx, "mhs-1", "basis", "", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, domain.ClassRoleMahasiswa, items[0].Peran)
+
+	_, total, err = classRepo.GetByMember(ctx, "dosen-1", "", "2024/2025-2", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	// Promoting an enrolled mahasiswa to lecturer replaces their enrollment.
+	require.NoError(t, classRepo.ReplaceLecturers(ctx, basisData.ID, []string{"dosen-1", "mhs-2"}))
+	member, err := classRepo.GetMember(ctx, basisData.ID, "mhs-2")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ClassRoleDosen, member.Role)
+	_, err = classRepo.GetMember(ctx, basisData.ID, "dosen-2")
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, classRepo.RemoveMember(ctx, basisData.ID, "mhs-1"))
+	_, err = classRepo.GetMember(ctx, basisData.ID, "mhs-1")
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, classRepo.UpdateJoinCode(ctx, basisData.ID, "NEWC0DE2"))
+	_, err = classRepo.GetByJoinCode(ctx, "ABCD2345")
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, classRepo.Delete(ctx, basisData.ID))
+	_, err = classRepo.GetByID(ctx, basisData.ID)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+	var remaining int64
+	require.NoError(t, db.Model(&domain.ClassMember{}).Where("class_id = ?", basisData.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
+
+func TestClassRepository_Update(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	classRepo := repo.NewClassRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	class := &domain.Class{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1", JoinCode: "ABCD2345", CreatedBy: "dosen-1"}
+	require.NoError(t, classRepo.Create(ctx, class, []string{"dosen-1"}))
+
+	class.Nama = "Basis Data Lanjut"
+	class.JoinCode = "IGNORED1"
+	require.NoError(t, classRepo.Update(ctx, class))
+
+	stored, err := classRepo.GetByID(ctx, class.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Basis Data Lanjut", stored.Nama)
+	assert.Equal(t, "ABCD2345", stored.JoinCode, "Update leaves the join code alone")
+
+	duplicate := &domain.Class{Kode: "TIF101", Nama: "Basis Data B", Periode: "2025/2026-1", JoinCode: "EFGH2345", CreatedBy: "dosen-1"}
+	assert.Error(t, classRepo.Create(ctx, duplicate, []string{"dosen-1"}), "kode is unique per period")
+}
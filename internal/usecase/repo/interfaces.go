@@ -59,17 +59,66 @@ type ProjectRepository interface {
 	CountByUserID(ctx context.Context, userID string) (int, error)
 	Update(ctx context.Context, project *domain.Project) error
 	Delete(ctx context.Context, id uint) error
+	SaveManifest(ctx context.Context, manifest *domain.ProjectManifest) error
+	GetManifest(ctx context.Context, projectID uint) (*domain.ProjectManifest, error)
+	CreateVersion(ctx context.Context, version *domain.ProjectVersion) error
+	GetVersions(ctx context.Context, projectID uint) ([]domain.ProjectVersion, error)
+	GetVersion(ctx context.Context, projectID uint, version int) (*domain.ProjectVersion, error)
+	DeleteVersions(ctx context.Context, ids []uint) error
+	GetSubmittedPaths(ctx context.Context, projectID uint) ([]string, error)
+	ReplaceReviewers(ctx context.Context, projectID uint, reviewers []domain.ProjectReviewer) error
+	GetReviewers(ctx context.Context, projectID uint) ([]domain.ProjectReviewer, error)
+	UpdateReviewStatus(ctx context.Context, project *domain.Project, event *domain.ProjectReviewEvent) (updated bool, err error)
+	GetReviewEvents(ctx context.Context, projectID uint) ([]domain.ProjectReviewEvent, error)
+	GetReviewQueue(ctx context.Context, reviewerID string, statuses []string, page, limit int) ([]domain.Project, int, error)
 }
 
 type ModulRepository interface {
 	Create(ctx context.Context, modul *domain.Modul) error
 	GetByID(ctx context.Context, id string) (*domain.Modul, error)
 	GetByIDs(ctx context.Context, ids []string, userID string) ([]domain.Modul, error)
+	GetVisibleByIDs(ctx context.Context, ids []string, userID string) ([]domain.Modul, error)
+	GetLibrary(ctx context.Context, userID string, filter dto.ModulLibraryFilter, page, limit int) ([]domain.Modul, int, error)
 	GetByUserID(ctx context.Context, userID, search, filterType, filterStatus string, page, limit int) ([]dto.ModulListItem, int, error)
 	CountByUserID(ctx context.Context, userID string) (int, error)
 	Update(ctx context.Context, modul *domain.Modul) error
 	Delete(ctx context.Context, id string) error
 	UpdateMetadata(ctx context.Context, modul *domain.Modul) error
+	UpdateStatus(ctx context.Context, modul *domain.Modul, fromStatus string) (updated bool, err error)
+	GetByStatus(ctx context.Context, status string, page, limit int) ([]domain.Modul, int, error)
+	UpdateVisibility(ctx context.Context, modulID, visibility string, roleIDs []uint) error
+	GetAllowedRoleIDs(ctx context.Context, modulID string) ([]uint, error)
+	CreateRevision(ctx context.Context, revision *domain.ModulRevision) error
+	GetRevisions(ctx context.Context, modulID string) ([]domain.ModulRevision, error)
+	GetRevision(ctx context.Context, modulID string, revision int) (*domain.ModulRevision, error)
+	DeleteRevisions(ctx context.Context, ids []uint) error
+}
+
+type ClassRepository interface {
+	Create(ctx context.Context, class *domain.Class, lecturerIDs []string) error
+	GetByID(ctx context.Context, id uint) (*domain.Class, error)
+	GetByKode(ctx context.Context, kode, periode string) (*domain.Class, error)
+	GetByJoinCode(ctx context.Context, joinCode string) (*domain.Class, error)
+	GetByMember(ctx context.Context, userID, search, periode string, page, limit int) ([]dto.ClassListItem, int, error)
+	Update(ctx context.Context, class *domain.Class) error
+	UpdateJoinCode(ctx context.Context, id uint, joinCode string) error
+	ReplaceLecturers(ctx context.Context, classID uint, lecturerIDs []string) error
+	Delete(ctx context.Context, id uint) error
+	GetMembers(ctx context.Context, classID uint) ([]domain.ClassMember, error)
+	GetMember(ctx context.Context, classID uint, userID string) (*domain.ClassMember, error)
+	AddMembers(ctx context.Context, members []domain.ClassMember) error
+	RemoveMember(ctx context.Context, classID uint, userID string) error
+}
+
+type AssignmentRepository interface {
+	Create(ctx context.Context, assignment *domain.Assignment) error
+	GetByID(ctx context.Context, id uint) (*domain.Assignment, error)
+	GetByMember(ctx context.Context, userID string, classID uint, page, limit int) ([]dto.AssignmentListItem, int, error)
+	Update(ctx context.Context, assignment *domain.Assignment) error
+	Delete(ctx context.Context, id uint) error
+	SaveSubmission(ctx context.Context, submission *domain.AssignmentSubmission) error
+	GetSubmission(ctx context.Context, assignmentID uint, userID string) (*domain.AssignmentSubmission, error)
+	GetSubmissions(ctx context.Context, assignmentID uint) ([]domain.AssignmentSubmission, error)
 }
 
 type TusUploadRepository interface {
@@ -78,21 +127,30 @@ type TusUploadRepository interface {
 	GetByUserID(ctx context.Context, userID string) ([]domain.TusUpload, error)
 	GetActiveByUserID(ctx context.Context, userID string) ([]domain.TusUpload, error)
 	CountActiveByUserID(ctx context.Context, userID string) (int64, error)
-	UpdateOffset(ctx context.Context, id string, offset int64, progress float64) error
+	UpdateOffset(ctx context.Context, id string, offset int64, progress float64, expiresAt time.Time) error
+	DeclareLength(ctx context.Context, id string, fileSize int64) error
 	UpdateStatus(ctx context.Context, id, status string) error
+	Promote(ctx context.Context, id string, expiresAt time.Time) error
 	Complete(ctx context.Context, id string, projectID uint, filePath string) error
+	CompletePartial(ctx context.Context, id string, expiresAt time.Time) error
+	Fail(ctx context.Context, id, reason string) error
+	ClaimPartials(ctx context.Context, finalID, userID string, partIDs []string) (bool, error)
+	ReleasePartials(ctx context.Context, finalID string) error
 	GetExpiredUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error)
 	GetAbandonedUploads(ctx context.Context, timeout time.Duration) ([]domain.TusUpload, error)
+	GetUnusedPartialUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error)
 	Delete(ctx context.Context, id string) error
 	ListActive(ctx context.Context) ([]domain.TusUpload, error)
 	GetActiveUploadIDs(ctx context.Context) ([]string, error)
+	GetQueuedUploadIDs(ctx context.Context) ([]string, error)
 }
 
 type TusModulUploadRepository interface {
 	Create(ctx context.Context, upload *domain.TusModulUpload) error
 	GetByID(ctx context.Context, id string) (*domain.TusModulUpload, error)
 	GetByUserID(ctx context.Context, userID string) ([]domain.TusModulUpload, error)
-	UpdateOffset(ctx context.Context, id string, offset int64, progress float64) error
+	UpdateOffset(ctx context.Context, id string, offset int64, progress float64, expiresAt time.Time) error
+	DeclareLength(ctx context.Context, id string, fileSize int64) error
 	UpdateStatus(ctx context.Context, id, status string) error
 	Complete(ctx context.Context, id, modulID, filePath string) error
 	Delete(ctx context.Context, id string) error
@@ -102,3 +160,12 @@ type TusModulUploadRepository interface {
 	GetActiveByUserID(ctx context.Context, userID string) ([]domain.TusModulUpload, error)
 	GetActiveUploadIDs(ctx context.Context) ([]string, error)
 }
+
+type QuotaRepository interface {
+	GetRoleQuota(ctx context.Context, roleID uint) (*domain.RoleQuota, error)
+	SaveRoleQuota(ctx context.Context, quota *domain.RoleQuota) error
+	GetUserQuota(ctx context.Context, userID string) (*domain.UserQuota, error)
+	SaveUserQuota(ctx context.Context, quota *domain.UserQuota) error
+	DeleteUserQuota(ctx context.Context, userID string) error
+	GetUsage(ctx context.Context, userID string) (*domain.StorageUsage, error)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"invento-service/internal/domain"
 	"invento-service/internal/dto"
+	"strings"
 
 	apperrors "invento-service/internal/errors"
 
@@ -46,13 +47,84 @@ func (r *modulRepository) GetByID(ctx context.Context, id string) (*domain.Modul
 
 func (r *modulRepository) GetByIDs(ctx context.Context, ids []string, userID string) ([]domain.Modul, error) {
 	var moduls []domain.Modul
-	err := r.db.WithContext(ctx).Where("id IN ? AND user_id = ?", ids, userID).Find(&moduls).Error
+	err := r.db.WithContext(ctx).Preload("User").Where("id IN ? AND user_id = ?", ids, userID).Find(&moduls).Error
 	if err != nil {
 		return nil, fmt.Errorf("ModulRepository.GetByIDs: %w", err)
 	}
 	return moduls, nil
 }
 
+// sharedWithUser is the condition under which a published modul of another owner is
+// visible to userID: shared with every authenticated user, or with userID's role.
+const sharedWithUser = `(moduls.visibility = ? OR (moduls.visibility = ? AND EXISTS (
+	SELECT 1 FROM modul_allowed_roles
+	JOIN user_profiles ON user_profiles.role_id = modul_allowed_roles.role_id
+	WHERE modul_allowed_roles.modul_id = moduls.id AND user_profiles.id = ?)))`
+
+func sharedWithUserArgs(userID string) []interface{} {
+	return []interface{}{domain.ModulVisibilityAuthenticated, domain.ModulVisibilityRoles, userID}
+}
+
+// GetVisibleByIDs returns the moduls among ids that userID owns or that are published
+// and shared with them.
+func (r *modulRepository) GetVisibleByIDs(ctx context.Context, ids []string, userID string) ([]domain.Modul, error) {
+	var moduls []domain.Modul
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("moduls.id IN ?", ids).
+		Where(r.db.Where("moduls.user_id = ?", userID).
+			Or(r.db.Where("moduls.status = ?", domain.ModulStatusPublished).Where(sharedWithUser, sharedWithUserArgs(userID)...))).
+		Find(&moduls).Error
+	if err != nil {
+		return nil, fmt.Errorf("ModulRepository.GetVisibleByIDs: %w", err)
+	}
+	return moduls, nil
+}
+
+// GetLibrary lists the published moduls userID may see, their own included, newest
+// publication first.
+func (r *modulRepository) GetLibrary(ctx context.Context, userID string, filter dto.ModulLibraryFilter, page, limit int) ([]domain.Modul, int, error) {
+	var moduls []domain.Modul
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Modul{}).
+		Where("moduls.status = ?", domain.ModulStatusPublished).
+		Where(r.db.Where("moduls.user_id = ?", userID).Or(sharedWithUser, sharedWithUserArgs(userID)...))
+	if filter.Search != "" {
+		query = query.Where("(LOWER(moduls.judul) LIKE ? OR LOWER(moduls.deskripsi) LIKE ?)",
+			"%"+strings.ToLower(filter.Search)+"%", "%"+strings.ToLower(filter.Search)+"%")
+	}
+	if filter.MimeType != "" {
+		query = query.Where("moduls.mime_type = ?", filter.MimeType)
+	}
+	if filter.OwnerID != "" {
+		query = query.Where("moduls.user_id = ?", filter.OwnerID)
+	}
+	if filter.PublishedFrom != nil {
+		query = query.Where("moduls.published_at >= ?", *filter.PublishedFrom)
+	}
+	if filter.PublishedTo != nil {
+		query = query.Where("moduls.published_at < ?", *filter.PublishedTo)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error().Err(err).Str("user_id", userID).Str("search", filter.Search).Msg("library count query failed")
+		return nil, 0, fmt.Errorf("ModulRepository.GetLibrary: count: %w", err)
+	}
+
+	if err := query.Preload("User").
+		Order("moduls.published_at DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&moduls).Error; err != nil {
+		r.logger.Error().Err(err).Str("user_id", userID).Str("search", filter.Search).Msg("library data query failed")
+		return nil, 0, fmt.Errorf("ModulRepository.GetLibrary: %w", err)
+	}
+
+	return moduls, int(total), nil
+}
+
+// GetByUserID lists the moduls of userID. Filtering on draft also matches the
+// statuses moduls had before the lifecycle existed.
 func (r *modulRepository) GetByUserID(ctx context.Context, userID, search, filterType, filterStatus string, page, limit int) ([]dto.ModulListItem, int, error) {
 	var modulListItems []dto.ModulListItem
 	var total int64
@@ -65,10 +137,10 @@ func (r *modulRepository) GetByUserID(ctx context.Context, userID, search, filte
 		WHERE user_id = ?
 			AND (? = '' OR LOWER(judul) LIKE '%' || LOWER(?) || '%' OR LOWER(deskripsi) LIKE '%' || LOWER(?) || '%')
 			AND (? = '' OR mime_type = ?)
-			AND (? = '' OR status = ?)
+			AND (? = '' OR status = ? OR (? = 'draft' AND status IN ('pending', 'completed')))
 	`
 
-	if err := r.db.WithContext(ctx).Raw(countQuery, userID, search, search, search, filterType, filterType, filterStatus, filterStatus).Scan(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(countQuery, userID, search, search, search, filterType, filterType, filterStatus, filterStatus, filterStatus).Scan(&total).Error; err != nil {
 		r.logger.Error().Err(err).Str("user_id", userID).Str("search", search).Str("filter_type", filterType).Str("filter_status", filterStatus).Msg("count query failed")
 		return nil, 0, fmt.Errorf("ModulRepository.GetByUserID: count query: %w", err)
 	}
@@ -87,12 +159,12 @@ func (r *modulRepository) GetByUserID(ctx context.Context, userID, search, filte
 		WHERE user_id = ?
 			AND (? = '' OR LOWER(judul) LIKE '%' || LOWER(?) || '%' OR LOWER(deskripsi) LIKE '%' || LOWER(?) || '%')
 			AND (? = '' OR mime_type = ?)
-			AND (? = '' OR status = ?)
+			AND (? = '' OR status = ? OR (? = 'draft' AND status IN ('pending', 'completed')))
 		ORDER BY updated_at DESC
 		LIMIT ? OFFSET ?
 	`
 
-	if err := r.db.WithContext(ctx).Raw(dataQuery, userID, search, search, search, filterType, filterType, filterStatus, filterStatus, limit, offset).Scan(&modulListItems).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(dataQuery, userID, search, search, search, filterType, filterType, filterStatus, filterStatus, filterStatus, limit, offset).Scan(&modulListItems).Error; err != nil {
 		r.logger.Error().Err(err).Str("user_id", userID).Str("search", search).Str("filter_type", filterType).Str("filter_status", filterStatus).Int("limit", limit).Int("offset", offset).Msg("data query failed")
 		return nil, 0, fmt.Errorf("ModulRepository.GetByUserID: data query: %w", err)
 	}
@@ -117,7 +189,16 @@ func (r *modulRepository) Update(ctx context.Context, modul *domain.Modul) error
 }
 
 func (r *modulRepository) Delete(ctx context.Context, id string) error {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.Modul{}).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("modul_id = ?", id).Delete(&domain.ModulRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("modul_id = ?", id).Delete(&domain.ModulAllowedRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Modul{}).Error
+	})
+	if err != nil {
 		return fmt.Errorf("ModulRepository.Delete: %w", err)
 	}
 	return nil
@@ -134,3 +215,148 @@ func (r *modulRepository) UpdateMetadata(ctx context.Context, modul *domain.Modu
 	}
 	return nil
 }
+
+// UpdateStatus saves the lifecycle status of modul together with its transition
+// timestamps and review outcome. The status is only changed while the stored one
+// still normalizes to fromStatus, so of two concurrent transitions one wins; updated
+// reports whether this one did.
+func (r *modulRepository) UpdateStatus(ctx context.Context, modul *domain.Modul, fromStatus string) (updated bool, err error) {
+	query := r.db.WithContext(ctx).Model(&domain.Modul{ID: modul.ID})
+	if fromStatus == domain.ModulStatusDraft {
+		// Moduls stored before the lifecycle existed carry other statuses that count as draft.
+		query = query.Where("COALESCE(status, '') NOT IN ?", []string{domain.ModulStatusInReview, domain.ModulStatusPublished, domain.ModulStatusArchived})
+	} else {
+		query = query.Where("status = ?", fromStatus)
+	}
+
+	result := query.
+		Select("status", "status_changed_at", "submitted_at", "published_at", "archived_at", "reviewed_at", "reviewed_by", "catatan_review").
+		Updates(modul)
+	if result.Error != nil {
+		return false, fmt.Errorf("ModulRepository.UpdateStatus: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateVisibility sets who may see modul and replaces the roles it is shared with.
+// It returns apperrors.ErrRecordNotFound when one of roleIDs does not exist.
+func (r *modulRepository) UpdateVisibility(ctx context.Context, modulID, visibility string, roleIDs []uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(roleIDs) > 0 {
+			var found int64
+			if err := tx.Model(&domain.Role{}).Where("id IN ?", roleIDs).Count(&found).Error; err != nil {
+				return err
+			}
+			if int(found) != len(roleIDs) {
+				return apperrors.ErrRecordNotFound
+			}
+		}
+
+		if err := tx.Model(&domain.Modul{}).Where("id = ?", modulID).Update("visibility", visibility).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("modul_id = ?", modulID).Delete(&domain.ModulAllowedRole{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+
+		allowed := make([]domain.ModulAllowedRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			allowed = append(allowed, domain.ModulAllowedRole{ModulID: modulID, RoleID: roleID})
+		}
+		return tx.Create(&allowed).Error
+	})
+	if errors.Is(err, apperrors.ErrRecordNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("ModulRepository.UpdateVisibility: %w", err)
+	}
+	return nil
+}
+
+func (r *modulRepository) GetAllowedRoleIDs(ctx context.Context, modulID string) ([]uint, error) {
+	roleIDs := []uint{}
+	err := r.db.WithContext(ctx).Model(&domain.ModulAllowedRole{}).
+		Where("modul_id = ?", modulID).
+		Order("role_id").
+		Pluck("role_id", &roleIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("ModulRepository.GetAllowedRoleIDs: %w", err)
+	}
+	return roleIDs, nil
+}
+
+// GetByStatus lists the moduls in status with their owners, longest waiting first.
+func (r *modulRepository) GetByStatus(ctx context.Context, status string, page, limit int) ([]domain.Modul, int, error) {
+	var moduls []domain.Modul
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Modul{}).Where("status = ?", status)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("ModulRepository.GetByStatus: count: %w", err)
+	}
+
+	if err := query.Preload("User").
+		Order("status_changed_at ASC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&moduls).Error; err != nil {
+		return nil, 0, fmt.Errorf("ModulRepository.GetByStatus: %w", err)
+	}
+
+	return moduls, int(total), nil
+}
+
+// CreateRevision stores revision as the next revision number of its modul.
+func (r *modulRepository) CreateRevision(ctx context.Context, revision *domain.ModulRevision) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&domain.ModulRevision{}).
+			Select("COALESCE(MAX(revision), 0)").
+			Where("modul_id = ?", revision.ModulID).
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		revision.Revision = latest + 1
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ModulRepository.CreateRevision: %w", err)
+	}
+	return nil
+}
+
+// GetRevisions lists the revisions of a modul, newest first.
+func (r *modulRepository) GetRevisions(ctx context.Context, modulID string) ([]domain.ModulRevision, error) {
+	var revisions []domain.ModulRevision
+	err := r.db.WithContext(ctx).Where("modul_id = ?", modulID).Order("revision DESC").Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("ModulRepository.GetRevisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *modulRepository) GetRevision(ctx context.Context, modulID string, revision int) (*domain.ModulRevision, error) {
+	var modulRevision domain.ModulRevision
+	err := r.db.WithContext(ctx).Where("modul_id = ? AND revision = ?", modulID, revision).First(&modulRevision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("ModulRepository.GetRevision: %w", err)
+	}
+	return &modulRevision, nil
+}
+
+func (r *modulRepository) DeleteRevisions(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Delete(&domain.ModulRevision{}, ids).Error; err != nil {
+		return fmt.Errorf("ModulRepository.DeleteRevisions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repo_test
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/dto"
+	"invento-service/internal/usecase/repo"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+	testhelper "invento-service/internal/testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModulRepository_Library tests which published moduls each role sees and the catalog filters
+func TestModulRepository_Library(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	modulRepo := repo.NewModulRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	dosen, mahasiswa := &domain.Role{NamaRole: "dosen"}, &domain.Role{NamaRole: "mahasiswa"}
+	require.NoError(t, db.Create(dosen).Error)
+	require.NoError(t, db.Create(mahasiswa).Error)
+	dosenRole, mahasiswaRole := int(dosen.ID), int(mahasiswa.ID)
+	require.NoError(t, db.Create(&[]domain.User{
+		{ID: "owner-1", Email: "dosen@example.com", Name: "Dosen Satu", RoleID: &dosenRole},
+		{ID: "student-1", Email: "mhs@example.com", Name: "Mahasiswa Satu", RoleID: &mahasiswaRole},
+		{ID: "lecturer-2", Email: "dosen2@example.com", Name: "Dosen Dua", RoleID: &dosenRole},
+	}).Error)
+
+	january := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	newModul := func(judul, status, visibility, mimeType string, publishedAt *time.Time) *domain.Modul {
+		modul := &domain.Modul{Judul: judul, UserID: "owner-1", Status: status, Visibility: visibility, MimeType: mimeType, PublishedAt: publishedAt}
+		require.NoError(t, modulRepo.Create(ctx, modul))
+		return modul
+	}
+	everyone := newModul("Basis Data", domain.ModulStatusPublished, domain.ModulVisibilityAuthenticated, "application/pdf", &january)
+	forStudents := newModul("Praktikum Jaringan", domain.ModulStatusPublished, domain.ModulVisibilityRoles, "video/mp4", &march)
+	private := newModul("Soal UAS", domain.ModulStatusPublished, domain.ModulVisibilityPrivate, "application/pdf", &march)
+	draft := newModul("Draft Algoritma", domain.ModulStatusDraft, domain.ModulVisibilityAuthenticated, "application/pdf", nil)
+
+	require.NoError(t, modulRepo.UpdateVisibility(ctx, forStudents.ID, domain.ModulVisibilityRoles, []uint{mahasiswa.ID}))
+	roleIDs, err := modulRepo.GetAllowedRoleIDs(ctx, forStudents.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{mahasiswa.ID}, roleIDs)
+
+	titles := func(moduls []domain.Modul) []string {
+		result := make([]string, 0, len(moduls))
+		for i := range moduls {
+			result = append(result, moduls[i].Judul)
+		}
+		return result
+	}
+
+	library, total, err := modulRepo.GetLibrary(ctx, "student-1", dto.ModulLibraryFilter{}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"Praktikum Jaringan", "Basis Data"}, titles(library))
+	assert.Equal(t, "Dosen Satu", library[0].User.Name)
+
+	library, _, err = modulRepo.GetLibrary(ctx, "lecturer-2", dto.ModulLibraryFilter{}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Basis Data"}, titles(library))
+
+	library, _, err = modulRepo.GetLibrary(ctx, "owner-1", dto.ModulLibraryFilter{}, 1, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Basis Data", "Praktikum Jaringan", "Soal UAS"}, titles(library), "owners see their own published moduls")
+
+	from, to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	library, _, err = modulRepo.GetLibrary(ctx, "student-1", dto.ModulLibraryFilter{PublishedFrom: &from, PublishedTo: &to}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Basis Data"}, titles(library))
+
+	library, _, err = modulRepo.GetLibrary(ctx, "student-1", dto.ModulLibraryFilter{Search: "JARINGAN", MimeType: "video/mp4", OwnerID: "owner-1"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Praktikum Jaringan"}, titles(library))
+
+	visible, err := modulRepo.GetVisibleByIDs(ctx, []string{everyone.ID, forStudents.ID, private.ID, draft.ID}, "lecturer-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Basis Data"}, titles(visible))
+	assert.Equal(t, "Dosen Satu", visible[0].User.Name, "archive manifests name the owner")
+
+	// Widening to everyone drops the role list.
+	require.NoError(t, modulRepo.UpdateVisibility(ctx, forStudents.ID, domain.ModulVisibilityAuthenticated, nil))
+	roleIDs, err = modulRepo.GetAllowedRoleIDs(ctx, forStudents.ID)
+	require.NoError(t, err)
+	assert.Empty(t, roleIDs)
+
+	err = modulRepo.UpdateVisibility(ctx, forStudents.ID, domain.ModulVisibilityRoles, []uint{mahasiswa.ID, 999})
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+	stored, err := modulRepo.GetByID(ctx, forStudents.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModulVisibilityAuthenticated, stored.Visibility, "a failed update changes nothing")
+}
@@ -0,0 +1,127 @@
+package repo_test
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/usecase/repo"
+	"testing"
+	"time"
+
+	testhelper "invento-service/internal/testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModulRepository_UpdateStatus tests that a transition saves the status and its timestamps only
+func TestModulRepository_UpdateStatus(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	modulRepo := repo.NewModulRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	modul := &domain.Modul{Judul: "Basis Data", UserID: "user-1", FileName: "a.pdf", FilePath: "/a.pdf"}
+	require.NoError(t, modulRepo.Create(ctx, modul))
+
+	stored, err := modulRepo.GetByID(ctx, modul.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModulStatusDraft, stored.Status)
+
+	now := time.Now()
+	reviewer := "reviewer-1"
+	modul.Judul = "Tidak ikut tersimpan"
+	modul.Status = domain.ModulStatusPublished
+	modul.StatusChangedAt = &now
+	modul.PublishedAt = &now
+	modul.ReviewedAt = &now
+	modul.ReviewedBy = &reviewer
+	modul.CatatanReview = "Sudah lengkap"
+	updated, err := modulRepo.UpdateStatus(ctx, modul, domain.ModulStatusDraft)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	stored, err = modulRepo.GetByID(ctx, modul.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModulStatusPublished, stored.Status)
+	assert.Equal(t, "Basis Data", stored.Judul)
+	require.NotNil(t, stored.PublishedAt)
+	assert.WithinDuration(t, now, *stored.PublishedAt, time.Second)
+	require.NotNil(t, stored.ReviewedBy)
+	assert.Equal(t, reviewer, *stored.ReviewedBy)
+	assert.Equal(t, "Sudah lengkap", stored.CatatanReview)
+
+	// Clearing the review outcome writes NULLs rather than skipping the columns.
+	modul.Status = domain.ModulStatusInReview
+	modul.ReviewedAt, modul.ReviewedBy, modul.CatatanReview = nil, nil, ""
+	updated, err = modulRepo.UpdateStatus(ctx, modul, domain.ModulStatusPublished)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	stored, err = modulRepo.GetByID(ctx, modul.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.ReviewedAt)
+	assert.Nil(t, stored.ReviewedBy)
+	assert.Empty(t, stored.CatatanReview)
+
+	// A transition from a status the modul has already left loses.
+	modul.Status = domain.ModulStatusArchived
+	updated, err = modulRepo.UpdateStatus(ctx, modul, domain.ModulStatusPublished)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	stored, err = modulRepo.GetByID(ctx, modul.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModulStatusInReview, stored.Status)
+}
+
+// TestModulRepository_Visibility tests which moduls are listed for owners, other users and reviewers
+func TestModulRepository_Visibility(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	modulRepo := repo.NewModulRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	require.NoError(t, db.Create(&domain.User{ID: "user-1", Email: "dosen@example.com", Name: "Dosen Satu"}).Error)
+
+	earlier, later := time.Now().Add(-time.Hour), time.Now()
+	moduls := []*domain.Modul{
+		{Judul: "Legacy", UserID: "user-1", Status: "completed"},
+		{Judul: "Draft", UserID: "user-1", Status: domain.ModulStatusDraft},
+		{Judul: "Published", UserID: "user-1", Status: domain.ModulStatusPublished},
+		{Judul: "Review Baru", UserID: "user-1", Status: domain.ModulStatusInReview, StatusChangedAt: &later},
+		{Judul: "Review Lama", UserID: "user-1", Status: domain.ModulStatusInReview, StatusChangedAt: &earlier},
+	}
+	ids := make([]string, 0, len(moduls))
+	for _, modul := range moduls {
+		require.NoError(t, modulRepo.Create(ctx, modul))
+		ids = append(ids, modul.ID)
+	}
+
+	visible, err := modulRepo.GetVisibleByIDs(ctx, ids, "user-2")
+	require.NoError(t, err)
+	require.Len(t, visible, 1)
+	assert.Equal(t, "Published", visible[0].Judul)
+
+	visible, err = modulRepo.GetVisibleByIDs(ctx, ids, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, visible, len(moduls))
+
+	drafts, total, err := modulRepo.GetByUserID(ctx, "user-1", "", "", domain.ModulStatusDraft, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total, "legacy statuses count as draft")
+	assert.Len(t, drafts, 2)
+
+	queue, total, err := modulRepo.GetByStatus(ctx, domain.ModulStatusInReview, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, queue, 2)
+	assert.Equal(t, "Review Lama", queue[0].Judul)
+	assert.Equal(t, "Dosen Satu", queue[0].User.Name)
+}
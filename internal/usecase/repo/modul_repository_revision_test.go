@@ -0,0 +1,66 @@
+package repo_test
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/usecase/repo"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+	testhelper "invento-service/internal/testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModulRepository_Revisions tests revision numbering, ordering, pruning and removal with the modul
+func TestModulRepository_Revisions(t *testing.T) {
+	t.Parallel()
+	db, err := testhelper.SetupTestDatabase()
+	require.NoError(t, err)
+	defer testhelper.TeardownTestDatabase(db)
+
+	modulRepo := repo.NewModulRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	modul := &domain.Modul{Judul: "Basis Data", UserID: "user-1", FileName: "v1.dat", FilePath: "/v1.dat", FileSize: 10, Status: "completed"}
+	require.NoError(t, modulRepo.Create(ctx, modul))
+
+	first := &domain.ModulRevision{ModulID: modul.ID, Action: domain.ModulRevisionCreate, Judul: "Basis Data", FilePath: "/v1.dat", ChangedBy: "user-1"}
+	require.NoError(t, modulRepo.CreateRevision(ctx, first))
+	second := &domain.ModulRevision{
+		ModulID:   modul.ID,
+		Action:    domain.ModulRevisionMetadata,
+		Judul:     "Basis Data Lanjut",
+		FilePath:  "/v1.dat",
+		Changes:   []domain.ModulRevisionChange{{Field: "judul", Before: "Basis Data", After: "Basis Data Lanjut"}},
+		ChangedBy: "user-1",
+	}
+	require.NoError(t, modulRepo.CreateRevision(ctx, second))
+	assert.Equal(t, 1, first.Revision)
+	assert.Equal(t, 2, second.Revision)
+
+	revisions, err := modulRepo.GetRevisions(ctx, modul.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, second.Changes, revisions[0].Changes)
+
+	revision, err := modulRepo.GetRevision(ctx, modul.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Basis Data", revision.Judul)
+	_, err = modulRepo.GetRevision(ctx, modul.ID, 5)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, modulRepo.DeleteRevisions(ctx, []uint{first.ID}))
+	revisions, err = modulRepo.GetRevisions(ctx, modul.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, 2, revisions[0].Revision)
+
+	require.NoError(t, modulRepo.Delete(ctx, modul.ID))
+	revisions, err = modulRepo.GetRevisions(ctx, modul.ID)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
@@ -11,8 +11,13 @@ import (
 	apperrors "invento-service/internal/errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// errReviewStatusChanged rolls back a review transition whose starting status is no
+// longer the stored one.
+var errReviewStatusChanged = errors.New("review status changed")
+
 type projectRepository struct {
 	db *gorm.DB
 }
@@ -45,7 +50,7 @@ func (r *projectRepository) GetByIDs(ctx context.Context, ids []uint, userID str
 	if len(ids) == 0 {
 		return projects, nil
 	}
-	err := r.db.WithContext(ctx).Where("id IN ? AND user_id = ?", ids, userID).Find(&projects).Error
+	err := r.db.WithContext(ctx).Preload("User").Where("id IN ? AND user_id = ?", ids, userID).Find(&projects).Error
 	if err != nil {
 		return nil, fmt.Errorf("ProjectRepository.GetByIDs: %w", err)
 	}
@@ -85,6 +90,7 @@ func (r *projectRepository) GetByUserID(ctx context.Context, userID, search stri
 			semester,
 			ukuran,
 			path_file,
+			COALESCE(NULLIF(review_status, ''), 'submitted') as review_status,
 		updated_at as terakhir_diperbarui
 		FROM projects
 		WHERE user_id = ?
@@ -112,21 +118,222 @@ func (r *projectRepository) CountByUserID(ctx context.Context, userID string) (i
 }
 
 func (r *projectRepository) Update(ctx context.Context, project *domain.Project) error {
-	if err := r.db.WithContext(ctx).Model(project).Updates(map[string]interface{}{
-		"nama_project": project.NamaProject,
-		"kategori":     project.Kategori,
-		"semester":     project.Semester,
-		"ukuran":       project.Ukuran,
-		"path_file":    project.PathFile,
-	}).Error; err != nil {
+	// Selecting the columns keeps map-style "update these, zero or not" semantics while
+	// letting GORM apply the JSON serializer to kategori_signals.
+	if err := r.db.WithContext(ctx).Model(project).Select(
+		"nama_project",
+		"kategori",
+		"kategori_terdeteksi",
+		"kategori_confidence",
+		"kategori_signals",
+		"semester",
+		"ukuran",
+		"file_size",
+		"path_file",
+	).Updates(project).Error; err != nil {
 		return fmt.Errorf("ProjectRepository.Update: %w", err)
 	}
 	return nil
 }
 
 func (r *projectRepository) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Project{}, id).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.ProjectManifest{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.ProjectVersion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.ProjectReviewer{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.ProjectReviewEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Project{}, id).Error
+	})
+	if err != nil {
 		return fmt.Errorf("ProjectRepository.Delete: %w", err)
 	}
 	return nil
 }
+
+// SaveManifest stores the archive manifest of a project, replacing any earlier one.
+func (r *projectRepository) SaveManifest(ctx context.Context, manifest *domain.ProjectManifest) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entry_count", "uncompressed_size", "entries", "updated_at"}),
+	}).Create(manifest).Error
+	if err != nil {
+		return fmt.Errorf("ProjectRepository.SaveManifest: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRepository) GetManifest(ctx context.Context, projectID uint) (*domain.ProjectManifest, error) {
+	var manifest domain.ProjectManifest
+	err := r.db.WithContext(ctx).First(&manifest, projectID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("ProjectRepository.GetManifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// CreateVersion stores version as the next version number of its project.
+func (r *projectRepository) CreateVersion(ctx context.Context, version *domain.ProjectVersion) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&domain.ProjectVersion{}).
+			Select("COALESCE(MAX(version), 0)").
+			Where("project_id = ?", version.ProjectID).
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		version.Version = latest + 1
+		return tx.Create(version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ProjectRepository.CreateVersion: %w", err)
+	}
+	return nil
+}
+
+// GetVersions lists the versions of a project, newest first.
+func (r *projectRepository) GetVersions(ctx context.Context, projectID uint) ([]domain.ProjectVersion, error) {
+	var versions []domain.ProjectVersion
+	err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("version DESC").Find(&versions).Error
+	if err != nil {
+		return nil, fmt.Errorf("ProjectRepository.GetVersions: %w", err)
+	}
+	return versions, nil
+}
+
+func (r *projectRepository) GetVersion(ctx context.Context, projectID uint, version int) (*domain.ProjectVersion, error) {
+	var projectVersion domain.ProjectVersion
+	err := r.db.WithContext(ctx).Where("project_id = ? AND version = ?", projectID, version).First(&projectVersion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("ProjectRepository.GetVersion: %w", err)
+	}
+	return &projectVersion, nil
+}
+
+func (r *projectRepository) DeleteVersions(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Delete(&domain.ProjectVersion{}, ids).Error; err != nil {
+		return fmt.Errorf("ProjectRepository.DeleteVersions: %w", err)
+	}
+	return nil
+}
+
+// GetSubmittedPaths returns the files of the project that assignment submissions
+// were made with.
+func (r *projectRepository) GetSubmittedPaths(ctx context.Context, projectID uint) ([]string, error) {
+	paths := []string{}
+	err := r.db.WithContext(ctx).Model(&domain.AssignmentSubmission{}).
+		Where("project_id = ? AND path_file <> ''", projectID).
+		Distinct().
+		Pluck("path_file", &paths).Error
+	if err != nil {
+		return nil, fmt.Errorf("ProjectRepository.GetSubmittedPaths: %w", err)
+	}
+	return paths, nil
+}
+
+// ReplaceReviewers makes reviewers the only reviewers of the project.
+func (r *projectRepository) ReplaceReviewers(ctx context.Context, projectID uint, reviewers []domain.ProjectReviewer) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&domain.ProjectReviewer{}).Error; err != nil {
+			return err
+		}
+		if len(reviewers) == 0 {
+			return nil
+		}
+		return tx.Omit("User").Create(&reviewers).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ProjectRepository.ReplaceReviewers: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRepository) GetReviewers(ctx context.Context, projectID uint) ([]domain.ProjectReviewer, error) {
+	var reviewers []domain.ProjectReviewer
+	err := r.db.WithContext(ctx).Preload("User").Where("project_id = ?", projectID).Order("created_at, user_id").Find(&reviewers).Error
+	if err != nil {
+		return nil, fmt.Errorf("ProjectRepository.GetReviewers: %w", err)
+	}
+	return reviewers, nil
+}
+
+// UpdateReviewStatus saves the review status of project and records event for it in
+// the same transaction. The status is only changed while the stored one is still
+// event.FromStatus, so of two concurrent transitions one wins; updated reports
+// whether this one did.
+func (r *projectRepository) UpdateReviewStatus(ctx context.Context, project *domain.Project, event *domain.ProjectReviewEvent) (updated bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Project{ID: project.ID}).
+			Where("COALESCE(NULLIF(review_status, ''), ?) = ?", domain.ProjectReviewSubmitted, event.FromStatus).
+			Select("review_status", "review_status_changed_at").
+			Updates(project)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errReviewStatusChanged
+		}
+		updated = true
+		return tx.Omit("Actor").Create(event).Error
+	})
+	if errors.Is(err, errReviewStatusChanged) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ProjectRepository.UpdateReviewStatus: %w", err)
+	}
+	return updated, nil
+}
+
+// GetReviewEvents lists the review history of a project, oldest first.
+func (r *projectRepository) GetReviewEvents(ctx context.Context, projectID uint) ([]domain.ProjectReviewEvent, error) {
+	var events []domain.ProjectReviewEvent
+	err := r.db.WithContext(ctx).Preload("Actor").Where("project_id = ?", projectID).Order("created_at, id").Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("ProjectRepository.GetReviewEvents: %w", err)
+	}
+	return events, nil
+}
+
+// GetReviewQueue lists the projects reviewerID is assigned to whose review status is
+// one of statuses, the ones waiting longest first. Projects stored before reviews
+// existed have no status and count as submitted.
+func (r *projectRepository) GetReviewQueue(ctx context.Context, reviewerID string, statuses []string, page, limit int) ([]domain.Project, int, error) {
+	var projects []domain.Project
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Project{}).
+		Joins("JOIN project_reviewers ON project_reviewers.project_id = projects.id").
+		Where("project_reviewers.user_id = ?", reviewerID).
+		Where("COALESCE(NULLIF(projects.review_status, ''), ?) IN ?", domain.ProjectReviewSubmitted, statuses)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("ProjectRepository.GetReviewQueue: count: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Select("projects.*").Preload("User").
+		Order("COALESCE(projects.review_status_changed_at, projects.created_at) ASC, projects.id ASC").
+		Limit(limit).Offset(offset).
+		Find(&projects).Error; err != nil {
+		return nil, 0, fmt.Errorf("ProjectRepository.GetReviewQueue: %w", err)
+	}
+
+	return projects, int(total), nil
+}
@@ -70,6 +70,7 @@ func TestProjectRepository_GetByIDs_Success(t *testing.T) {
 	defer testhelper.TeardownTestDatabase(db)
 
 	userID := "user-1"
+	require.NoError(t, db.Create(&domain.User{ID: userID, Email: "budi@student.polije.ac.id", Name: "Budi"}).Error)
 
 	projects := []domain.Project{
 		{NamaProject: "Project 1", UserID: userID, Kategori: "website", Semester: 1, Ukuran: "small", PathFile: "/test1"},
@@ -86,7 +87,8 @@ func TestProjectRepository_GetByIDs_Success(t *testing.T) {
 	ids := []uint{projects[0].ID, projects[1].ID}
 	result, err := projectRepo.GetByIDs(ctx, ids, userID)
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
+	require.Len(t, result, 2)
+	assert.Equal(t, "Budi", result[0].User.Name, "archive manifests name the owner")
 }
 
 func TestProjectRepository_GetByIDsForUser_Success(t *testing.T) {
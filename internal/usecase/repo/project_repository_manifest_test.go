@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"testing"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupProjectTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}, &domain.Project{}, &domain.ProjectManifest{}, &domain.ProjectVersion{},
+		&domain.ProjectReviewer{}, &domain.ProjectReviewEvent{}, &domain.AssignmentSubmission{}))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	return db
+}
+
+func TestProjectRepository_Manifest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	repository := NewProjectRepository(db)
+
+	project := &domain.Project{UserID: "user-1", NamaProject: "Alpha", Kategori: "website", Semester: 1, Ukuran: "1 KB", PathFile: "/tmp/alpha.zip"}
+	require.NoError(t, repository.Create(ctx, project))
+
+	_, err := repository.GetManifest(ctx, project.ID)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, repository.SaveManifest(ctx, &domain.ProjectManifest{
+		ProjectID:        project.ID,
+		EntryCount:       1,
+		UncompressedSize: 10,
+		Entries:          []domain.ProjectManifestEntry{{Path: "README.md", Size: 10, CompressedSize: 8, CRC32: 42}},
+	}))
+	require.NoError(t, repository.SaveManifest(ctx, &domain.ProjectManifest{
+		ProjectID:        project.ID,
+		EntryCount:       2,
+		UncompressedSize: 30,
+		Entries: []domain.ProjectManifestEntry{
+			{Path: "README.md", Size: 10, CompressedSize: 8, CRC32: 42},
+			{Path: "src/main.go", Size: 20, CompressedSize: 15, CRC32: 7},
+		},
+	}))
+
+	manifest, err := repository.GetManifest(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, manifest.EntryCount)
+	assert.Equal(t, int64(30), manifest.UncompressedSize)
+	require.Len(t, manifest.Entries, 2)
+	assert.Equal(t, "src/main.go", manifest.Entries[1].Path)
+	assert.Equal(t, uint32(7), manifest.Entries[1].CRC32)
+
+	require.NoError(t, repository.Delete(ctx, project.ID))
+	_, err = repository.GetManifest(ctx, project.ID)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+}
+
+func TestProjectRepository_Update_CategoryDetection(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repository := NewProjectRepository(setupProjectTestDB(t))
+
+	project := &domain.Project{UserID: "user-1", NamaProject: "Beta", Kategori: "website", Semester: 2, Ukuran: "1 KB", PathFile: "/tmp/beta.zip"}
+	require.NoError(t, repository.Create(ctx, project))
+
+	project.Kategori = domain.KategoriMobile
+	project.KategoriTerdeteksi = domain.KategoriMobile
+	project.KategoriConfidence = 0.67
+	project.KategoriSignals = []string{"pubspec.yaml (pubspec.yaml)", "*.dart (lib/main.dart)"}
+	require.NoError(t, repository.Update(ctx, project))
+
+	stored, err := repository.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.KategoriMobile, stored.Kategori)
+	assert.Equal(t, domain.KategoriMobile, stored.KategoriTerdeteksi)
+	assert.InDelta(t, 0.67, stored.KategoriConfidence, 0.001)
+	assert.Equal(t, project.KategoriSignals, stored.KategoriSignals)
+}
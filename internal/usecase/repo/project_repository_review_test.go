@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRepository_Reviews(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	repository := NewProjectRepository(db)
+
+	require.NoError(t, db.Create(&[]domain.User{
+		{ID: "mhs-1", Email: "budi@student.polije.ac.id", Name: "Budi"},
+		{ID: "dosen-1", Email: "dosen1@polije.ac.id", Name: "Dosen Satu"},
+		{ID: "dosen-2", Email: "dosen2@polije.ac.id", Name: "Dosen Dua"},
+	}).Error)
+
+	waiting := &domain.Project{UserID: "mhs-1", NamaProject: "Alpha", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "/tmp/a.zip"}
+	reviewed := &domain.Project{UserID: "mhs-1", NamaProject: "Beta", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "/tmp/b.zip"}
+	unassigned := &domain.Project{UserID: "mhs-1", NamaProject: "Gamma", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "/tmp/c.zip"}
+	for _, project := range []*domain.Project{waiting, reviewed, unassigned} {
+		require.NoError(t, repository.Create(ctx, project))
+	}
+
+	require.NoError(t, repository.ReplaceReviewers(ctx, waiting.ID, []domain.ProjectReviewer{{ProjectID: waiting.ID, UserID: "dosen-2", AssignedBy: "mhs-1"}}))
+	// Replacing drops dosen-2 from the first project.
+	require.NoError(t, repository.ReplaceReviewers(ctx, waiting.ID, []domain.ProjectReviewer{{ProjectID: waiting.ID, UserID: "dosen-1", AssignedBy: "mhs-1"}}))
+	require.NoError(t, repository.ReplaceReviewers(ctx, reviewed.ID, []domain.ProjectReviewer{{ProjectID: reviewed.ID, UserID: "dosen-1", AssignedBy: "mhs-1"}}))
+
+	reviewers, err := repository.GetReviewers(ctx, waiting.ID)
+	require.NoError(t, err)
+	require.Len(t, reviewers, 1)
+	assert.Equal(t, "Dosen Satu", reviewers[0].User.Name)
+
+	now := time.Now()
+	reviewed.ReviewStatus = domain.ProjectReviewUnderReview
+	reviewed.ReviewStatusChangedAt = &now
+	updated, err := repository.UpdateReviewStatus(ctx, reviewed, &domain.ProjectReviewEvent{
+		ProjectID: reviewed.ID, ActorID: "dosen-1", FromStatus: domain.ProjectReviewSubmitted, ToStatus: domain.ProjectReviewUnderReview,
+	})
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// A second reviewer starting from the same stale status loses.
+	updated, err = repository.UpdateReviewStatus(ctx, reviewed, &domain.ProjectReviewEvent{
+		ProjectID: reviewed.ID, ActorID: "dosen-1", FromStatus: domain.ProjectReviewSubmitted, ToStatus: domain.ProjectReviewUnderReview,
+	})
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	stored, err := repository.GetByID(ctx, reviewed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectReviewUnderReview, stored.ReviewStatus)
+	events, err := repository.GetReviewEvents(ctx, reviewed.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Dosen Satu", events[0].Actor.Name)
+
+	queue, total, err := repository.GetReviewQueue(ctx, "dosen-1", []string{domain.ProjectReviewSubmitted, domain.ProjectReviewUnderReview}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, queue, 2)
+	assert.Equal(t, "Alpha", queue[0].NamaProject, "waiting longest first")
+	assert.Equal(t, "mhs-1", queue[0].UserID)
+	assert.Equal(t, "Budi", queue[0].User.Name)
+
+	queue, total, err = repository.GetReviewQueue(ctx, "dosen-1", []string{domain.ProjectReviewUnderReview}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Beta", queue[0].NamaProject)
+
+	_, total, err = repository.GetReviewQueue(ctx, "dosen-2", []string{domain.ProjectReviewSubmitted}, 1, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	require.NoError(t, repository.Delete(ctx, reviewed.ID))
+	reviewers, err = repository.GetReviewers(ctx, reviewed.ID)
+	require.NoError(t, err)
+	assert.Empty(t, reviewers)
+	events, err = repository.GetReviewEvents(ctx, reviewed.ID)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
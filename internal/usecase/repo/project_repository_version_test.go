@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRepository_Versions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	repository := NewProjectRepository(db)
+
+	project := &domain.Project{UserID: "user-1", NamaProject: "Gamma", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "/tmp/v1.zip"}
+	other := &domain.Project{UserID: "user-1", NamaProject: "Delta", Kategori: "website", Semester: 3, Ukuran: "1 KB", PathFile: "/tmp/other.zip"}
+	require.NoError(t, repository.Create(ctx, project))
+	require.NoError(t, repository.Create(ctx, other))
+
+	for _, pathFile := range []string{"/tmp/v1.zip", "/tmp/v2.zip", "/tmp/v3.zip"} {
+		require.NoError(t, repository.CreateVersion(ctx, &domain.ProjectVersion{ProjectID: project.ID, PathFile: pathFile, UploadedBy: "user-1"}))
+	}
+	otherVersion := &domain.ProjectVersion{ProjectID: other.ID, PathFile: "/tmp/other.zip", UploadedBy: "user-1"}
+	require.NoError(t, repository.CreateVersion(ctx, otherVersion))
+	assert.Equal(t, 1, otherVersion.Version, "numbering is per project")
+
+	versions, err := repository.GetVersions(ctx, project.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, 3, versions[0].Version)
+	assert.Equal(t, "/tmp/v3.zip", versions[0].PathFile)
+	assert.Equal(t, 1, versions[2].Version)
+
+	version, err := repository.GetVersion(ctx, project.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/v2.zip", version.PathFile)
+	_, err = repository.GetVersion(ctx, project.ID, 9)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, repository.DeleteVersions(ctx, []uint{versions[2].ID}))
+	next := &domain.ProjectVersion{ProjectID: project.ID, PathFile: "/tmp/v4.zip", UploadedBy: "user-1"}
+	require.NoError(t, repository.CreateVersion(ctx, next))
+	assert.Equal(t, 4, next.Version, "pruned numbers are not reused")
+
+	require.NoError(t, db.Create(&[]domain.AssignmentSubmission{
+		{AssignmentID: 1, UserID: "user-1", ProjectID: project.ID, Status: domain.SubmissionStatusOnTime, PathFile: "/tmp/v2.zip", SubmittedAt: time.Now()},
+		{AssignmentID: 2, UserID: "user-1", ProjectID: project.ID, Status: domain.SubmissionStatusOnTime, PathFile: "/tmp/v2.zip", SubmittedAt: time.Now()},
+		{AssignmentID: 3, UserID: "user-1", ProjectID: project.ID, Status: domain.SubmissionStatusOnTime, SubmittedAt: time.Now()},
+	}).Error)
+	submitted, err := repository.GetSubmittedPaths(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/v2.zip"}, submitted)
+
+	require.NoError(t, repository.Delete(ctx, project.ID))
+	versions, err = repository.GetVersions(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+	versions, err = repository.GetVersions(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+}
@@ -0,0 +1,170 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"invento-service/internal/domain"
+	"invento-service/internal/storage"
+
+	apperrors "invento-service/internal/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type quotaRepository struct {
+	db *gorm.DB
+}
+
+func NewQuotaRepository(db *gorm.DB) QuotaRepository {
+	return &quotaRepository{db: db}
+}
+
+func (r *quotaRepository) GetRoleQuota(ctx context.Context, roleID uint) (*domain.RoleQuota, error) {
+	var quota domain.RoleQuota
+	err := r.db.WithContext(ctx).Where("role_id = ?", roleID).First(&quota).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("QuotaRepository.GetRoleQuota: %w", err)
+	}
+	return &quota, nil
+}
+
+func (r *quotaRepository) SaveRoleQuota(ctx context.Context, quota *domain.RoleQuota) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_bytes", "max_files", "updated_at"}),
+	}).Create(quota).Error
+	if err != nil {
+		return fmt.Errorf("QuotaRepository.SaveRoleQuota: %w", err)
+	}
+	return nil
+}
+
+func (r *quotaRepository) GetUserQuota(ctx context.Context, userID string) (*domain.UserQuota, error) {
+	var quota domain.UserQuota
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&quota).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("QuotaRepository.GetUserQuota: %w", err)
+	}
+	return &quota, nil
+}
+
+func (r *quotaRepository) SaveUserQuota(ctx context.Context, quota *domain.UserQuota) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_bytes", "max_files", "updated_at"}),
+	}).Create(quota).Error
+	if err != nil {
+		return fmt.Errorf("QuotaRepository.SaveUserQuota: %w", err)
+	}
+	return nil
+}
+
+func (r *quotaRepository) DeleteUserQuota(ctx context.Context, userID string) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.UserQuota{}).Error; err != nil {
+		return fmt.Errorf("QuotaRepository.DeleteUserQuota: %w", err)
+	}
+	return nil
+}
+
+// usageRow is one SUM/COUNT aggregate in GetUsage.
+type usageRow struct {
+	Bytes int64
+	Files int
+}
+
+// GetUsage adds up the user's projects and moduls and the uploads that are still in
+// progress. Slices of a concatenated upload stay on disk until they are combined, so
+// completed slices that no final upload has claimed are counted as well. Older project
+// versions and modul revisions keep their files, and so do assignment submissions
+// after their version is pruned, so each retained file other than the current one
+// adds its bytes, once however many versions, revisions or submissions share it.
+func (r *quotaRepository) GetUsage(ctx context.Context, userID string) (*domain.StorageUsage, error) {
+	db := r.db.WithContext(ctx)
+	activeStatuses := []string{domain.UploadStatusQueued, domain.UploadStatusPending, domain.UploadStatusUploading}
+	usage := &domain.StorageUsage{}
+
+	var projects []struct {
+		FileSize int64
+		Ukuran   string
+	}
+	if err := db.Model(&domain.Project{}).Select("file_size, ukuran").Where("user_id = ?", userID).Scan(&projects).Error; err != nil {
+		return nil, fmt.Errorf("QuotaRepository.GetUsage: projects: %w", err)
+	}
+	for _, project := range projects {
+		size := project.FileSize
+		if size == 0 {
+			// Projects stored before file_size existed only have the formatted size.
+			size = storage.ParseFileSize(project.Ukuran)
+		}
+		usage.Bytes += size
+		usage.Files++
+	}
+
+	var versions usageRow
+	if err := db.Raw(`SELECT COALESCE(SUM(file_size), 0) AS bytes FROM (
+		SELECT project_versions.path_file, project_versions.file_size
+		FROM project_versions
+		JOIN projects ON projects.id = project_versions.project_id
+		WHERE projects.user_id = ? AND project_versions.path_file <> projects.path_file
+		UNION
+		SELECT assignment_submissions.path_file, assignment_submissions.file_size
+		FROM assignment_submissions
+		JOIN projects ON projects.id = assignment_submissions.project_id
+		WHERE projects.user_id = ? AND assignment_submissions.path_file <> '' AND assignment_submissions.path_file <> projects.path_file
+	) AS retained`, userID, userID).Scan(&versions).Error; err != nil {
+		return nil, fmt.Errorf("QuotaRepository.GetUsage: project versions: %w", err)
+	}
+	usage.Bytes += versions.Bytes
+
+	var moduls usageRow
+	if err := db.Model(&domain.Modul{}).
+		Select("COALESCE(SUM(file_size), 0) AS bytes, COUNT(*) AS files").
+		Where("user_id = ?", userID).
+		Scan(&moduls).Error; err != nil {
+		return nil, fmt.Errorf("QuotaRepository.GetUsage: moduls: %w", err)
+	}
+	usage.Bytes += moduls.Bytes
+	usage.Files += moduls.Files
+
+	var revisions usageRow
+	if err := db.Raw(`SELECT COALESCE(SUM(file_size), 0) AS bytes FROM (
+		SELECT DISTINCT modul_revisions.file_path, modul_revisions.file_size
+		FROM modul_revisions
+		JOIN moduls ON moduls.id = modul_revisions.modul_id
+		WHERE moduls.user_id = ? AND modul_revisions.file_path <> '' AND modul_revisions.file_path <> moduls.file_path
+	) AS retained`, userID).Scan(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("QuotaRepository.GetUsage: modul revisions: %w", err)
+	}
+	usage.Bytes += revisions.Bytes
+
+	var projectUploads usageRow
+	if err := db.Model(&domain.TusUpload{}).
+		Select("COALESCE(SUM(file_size), 0) AS bytes, COUNT(CASE WHEN upload_type = ? THEN 1 END) AS files", domain.UploadTypeProjectCreate).
+		Where("user_id = ?", userID).
+		Where(db.Where("status IN ?", activeStatuses).
+			Or("upload_type = ? AND status = ? AND final_upload_id IS NULL", domain.UploadTypeProjectPartial, domain.UploadStatusCompleted)).
+		Scan(&projectUploads).Error; err != nil {
+		return nil, fmt.Errorf("QuotaRepository.GetUsage: project uploads: %w", err)
+	}
+
+	var modulUploads usageRow
+	if err := db.Model(&domain.TusModulUpload{}).
+		Select("COALESCE(SUM(file_size), 0) AS bytes, COUNT(CASE WHEN upload_type = ? THEN 1 END) AS files", domain.UploadTypeModulCreate).
+		Where("user_id = ? AND status IN ?", userID, activeStatuses).
+		Scan(&modulUploads).Error; err != nil {
+		return nil, fmt.Errorf("QuotaRepository.GetUsage: modul uploads: %w", err)
+	}
+
+	usage.PendingBytes = projectUploads.Bytes + modulUploads.Bytes
+	usage.Bytes += usage.PendingBytes
+	usage.Files += projectUploads.Files + modulUploads.Files
+	return usage, nil
+}
@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"invento-service/internal/domain"
+	"testing"
+	"time"
+
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupQuotaTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Project{}, &domain.ProjectVersion{}, &domain.AssignmentSubmission{}, &domain.Modul{}, &domain.ModulRevision{}, &domain.TusUpload{}, &domain.TusModulUpload{}, &domain.RoleQuota{}, &domain.UserQuota{}))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	return db
+}
+
+func TestQuotaRepository_RoleQuota(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repository := NewQuotaRepository(setupQuotaTestDB(t))
+
+	_, err := repository.GetRoleQuota(ctx, 2)
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+
+	require.NoError(t, repository.SaveRoleQuota(ctx, &domain.RoleQuota{RoleID: 2, MaxBytes: 1000, MaxFiles: 5}))
+	require.NoError(t, repository.SaveRoleQuota(ctx, &domain.RoleQuota{RoleID: 2, MaxBytes: 2000, MaxFiles: 10}))
+
+	quota, err := repository.GetRoleQuota(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), quota.MaxBytes)
+	assert.Equal(t, 10, quota.MaxFiles)
+}
+
+func TestQuotaRepository_UserQuota(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repository := NewQuotaRepository(setupQuotaTestDB(t))
+
+	maxBytes := int64(4096)
+	require.NoError(t, repository.SaveUserQuota(ctx, &domain.UserQuota{UserID: "user-1", MaxBytes: &maxBytes}))
+
+	quota, err := repository.GetUserQuota(ctx, "user-1")
+	require.NoError(t, err)
+	require.NotNil(t, quota.MaxBytes)
+	assert.Equal(t, maxBytes, *quota.MaxBytes)
+	assert.Nil(t, quota.MaxFiles)
+
+	maxFiles := 3
+	require.NoError(t, repository.SaveUserQuota(ctx, &domain.UserQuota{UserID: "user-1", MaxFiles: &maxFiles}))
+	quota, err = repository.GetUserQuota(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Nil(t, quota.MaxBytes)
+	require.NotNil(t, quota.MaxFiles)
+	assert.Equal(t, 3, *quota.MaxFiles)
+
+	require.NoError(t, repository.DeleteUserQuota(ctx, "user-1"))
+	_, err = repository.GetUserQuota(ctx, "user-1")
+	assert.ErrorIs(t, err, apperrors.ErrRecordNotFound)
+}
+
+func TestQuotaRepository_GetUsage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupQuotaTestDB(t)
+	repository := NewQuotaRepository(db)
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, db.Create([]domain.Project{
+		{UserID: "user-1", NamaProject: "A", Kategori: "website", Semester: 1, Ukuran: "1.00KB", FileSize: 1000, PathFile: "/a.zip"},
+		{UserID: "user-1", NamaProject: "B", Kategori: "website", Semester: 1, Ukuran: "2.00KB", PathFile: "/b.zip"},
+		{UserID: "user-2", NamaProject: "C", Kategori: "website", Semester: 1, Ukuran: "1.00GB", FileSize: 1 << 30, PathFile: "/c.zip"},
+	}).Error)
+	// Project A was replaced twice and restored once: /a.zip is current, /a-v1.zip is
+	// shared by two versions and /a-v2.zip by one.
+	var projectA domain.Project
+	require.NoError(t, db.Where("path_file = ?", "/a.zip").First(&projectA).Error)
+	require.NoError(t, db.Create([]domain.ProjectVersion{
+		{ProjectID: projectA.ID, Version: 1, PathFile: "/a-v1.zip", FileSize: 400, UploadedBy: "user-1"},
+		{ProjectID: projectA.ID, Version: 2, PathFile: "/a-v2.zip", FileSize: 100, UploadedBy: "user-1"},
+		{ProjectID: projectA.ID, Version: 3, PathFile: "/a-v1.zip", FileSize: 400, UploadedBy: "user-1"},
+		{ProjectID: projectA.ID, Version: 4, PathFile: "/a.zip", FileSize: 1000, UploadedBy: "user-1"},
+	}).Error)
+	// A submission made with /a-v0.zip keeps that file after its version was pruned;
+	// the other two submissions point at files that are counted already.
+	require.NoError(t, db.Create([]domain.AssignmentSubmission{
+		{AssignmentID: 1, UserID: "user-1", ProjectID: projectA.ID, Status: domain.SubmissionStatusOnTime, PathFile: "/a-v0.zip", FileSize: 250, SubmittedAt: time.Now()},
+		{AssignmentID: 2, UserID: "user-1", ProjectID: projectA.ID, Status: domain.SubmissionStatusOnTime, PathFile: "/a-v1.zip", FileSize: 400, SubmittedAt: time.Now()},
+		{AssignmentID: 3, UserID: "user-1", ProjectID: projectA.ID, Status: domain.SubmissionStatusOnTime, PathFile: "/a.zip", FileSize: 1000, SubmittedAt: time.Now()},
+	}).Error)
+	moduls := []domain.Modul{
+		{UserID: "user-1", Judul: "M1", FilePath: "/m1-v2.pdf", FileSize: 300},
+		{UserID: "user-1", Judul: "M2", FilePath: "/m2.pdf", FileSize: 200},
+	}
+	require.NoError(t, db.Create(moduls).Error)
+	// M1 had its file replaced once and its title changed once, so /m1-v1.pdf is
+	// shared by two revisions.
+	require.NoError(t, db.Create([]domain.ModulRevision{
+		{ModulID: moduls[0].ID, Revision: 1, Action: domain.ModulRevisionCreate, Judul: "M1", FilePath: "/m1-v1.pdf", FileSize: 150, ChangedBy: "user-1"},
+		{ModulID: moduls[0].ID, Revision: 2, Action: domain.ModulRevisionMetadata, Judul: "M1", FilePath: "/m1-v1.pdf", FileSize: 150, ChangedBy: "user-1"},
+		{ModulID: moduls[0].ID, Revision: 3, Action: domain.ModulRevisionFile, Judul: "M1", FilePath: "/m1-v2.pdf", FileSize: 300, ChangedBy: "user-1"},
+		{ModulID: moduls[1].ID, Revision: 1, Action: domain.ModulRevisionCreate, Judul: "M2", FilePath: "/m2.pdf", FileSize: 200, ChangedBy: "user-1"},
+	}).Error)
+
+	finalID := "final"
+	projectUploads := []domain.TusUpload{
+		newTusUpload("create", "user-1", domain.UploadStatusUploading, expiresAt),
+		newTusUpload("done", "user-1", domain.UploadStatusCompleted, expiresAt),
+		newTusUpload("update", "user-1", domain.UploadStatusQueued, expiresAt),
+		newTusUpload("part-open", "user-1", domain.UploadStatusCompleted, expiresAt),
+		newTusUpload("part-used", "user-1", domain.UploadStatusCompleted, expiresAt),
+	}
+	projectUploads[2].UploadType = domain.UploadTypeProjectUpdate
+	projectUploads[3].UploadType = domain.UploadTypeProjectPartial
+	projectUploads[4].UploadType = domain.UploadTypeProjectPartial
+	projectUploads[4].FinalUploadID = &finalID
+	require.NoError(t, db.Create(projectUploads).Error)
+
+	require.NoError(t, db.Create(&domain.TusModulUpload{
+		ID: "modul-create", UserID: "user-1", UploadType: domain.UploadTypeModulCreate,
+		FileSize: 50, Status: domain.UploadStatusPending, ExpiresAt: expiresAt,
+	}).Error)
+
+	usage, err := repository.GetUsage(ctx, "user-1")
+	require.NoError(t, err)
+
+	// Projects 1000 + 2048 (parsed from Ukuran), older versions 400 + 100, the submitted
+	// file 250, moduls 500, older revisions 150, open uploads 3*1024 + 50.
+	assert.Equal(t, int64(3*1024+50), usage.PendingBytes)
+	assert.Equal(t, int64(1000+2048+500+250+500+150)+usage.PendingBytes, usage.Bytes)
+	// Two projects, two moduls and the two uploads that will create new files.
+	assert.Equal(t, 6, usage.Files)
+
+	empty, err := repository.GetUsage(ctx, "user-3")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.StorageUsage{}, empty)
+}
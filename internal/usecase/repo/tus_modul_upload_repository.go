@@ -43,16 +43,29 @@ func (r *tusModulUploadRepository) GetByUserID(ctx context.Context, userID strin
 	return uploads, err
 }
 
-func (r *tusModulUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64) error {
+// UpdateOffset records a written chunk and slides the upload's expiry forward.
+func (r *tusModulUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64, expiresAt time.Time) error {
 	return r.db.WithContext(ctx).Model(&domain.TusModulUpload{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"current_offset": offset,
 			"progress":       progress,
+			"expires_at":     expiresAt,
 			"updated_at":     time.Now(),
 		}).Error
 }
 
+// DeclareLength records the size of an upload that was started with a deferred length.
+func (r *tusModulUploadRepository) DeclareLength(ctx context.Context, id string, fileSize int64) error {
+	return r.db.WithContext(ctx).Model(&domain.TusModulUpload{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"file_size":       fileSize,
+			"length_deferred": false,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
 func (r *tusModulUploadRepository) UpdateStatus(ctx context.Context, id, status string) error {
 	return r.db.WithContext(ctx).Model(&domain.TusModulUpload{}).
 		Where("id = ?", id).
@@ -94,13 +94,15 @@ func TestTusModulUploadRepository_UpdateOffset(t *testing.T) {
 	upload := newTusModulUpload("test-modul-upload-6", "user-1", domain.UploadStatusUploading, time.Now().Add(time.Hour))
 	require.NoError(t, db.Create(&upload).Error)
 
-	require.NoError(t, repository.UpdateOffset(context.Background(), "test-modul-upload-6", 1024, 50.0))
+	expiresAt := time.Now().Add(2 * time.Hour)
+	require.NoError(t, repository.UpdateOffset(context.Background(), "test-modul-upload-6", 1024, 50.0, expiresAt))
 
 	updated, err := repository.GetByID(context.Background(), "test-modul-upload-6")
 	require.NoError(t, err)
 	require.NotNil(t, updated)
 	assert.Equal(t, int64(1024), updated.CurrentOffset)
 	assert.Equal(t, 50.0, updated.Progress)
+	assert.WithinDuration(t, expiresAt, updated.ExpiresAt, time.Second)
 }
 
 func TestTusModulUploadRepository_UpdateStatus(t *testing.T) {
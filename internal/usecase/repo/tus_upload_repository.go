@@ -2,12 +2,16 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"invento-service/internal/domain"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// errPartialsTaken rolls back a partial claim that did not get every requested part.
+var errPartialsTaken = errors.New("partial uploads already claimed")
+
 type tusUploadRepository struct {
 	db *gorm.DB
 }
@@ -59,12 +63,24 @@ func (r *tusUploadRepository) CountActiveByUserID(ctx context.Context, userID st
 	return count, err
 }
 
-func (r *tusUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64) error {
+// UpdateOffset records a written chunk and slides the upload's expiry forward.
+func (r *tusUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64, expiresAt time.Time) error {
 	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"current_offset": offset,
 			"progress":       progress,
+			"expires_at":     expiresAt,
+		}).Error
+}
+
+// DeclareLength records the size of an upload that was started with a deferred length.
+func (r *tusUploadRepository) DeclareLength(ctx context.Context, id string, fileSize int64) error {
+	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"file_size":       fileSize,
+			"length_deferred": false,
 		}).Error
 }
 
@@ -76,6 +92,16 @@ func (r *tusUploadRepository) UpdateStatus(ctx context.Context, id, status strin
 		}).Error
 }
 
+// Promote hands a slot to a queued upload and restarts its idle expiry from now.
+func (r *tusUploadRepository) Promote(ctx context.Context, id string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     domain.UploadStatusPending,
+			"expires_at": expiresAt,
+		}).Error
+}
+
 func (r *tusUploadRepository) Complete(ctx context.Context, id string, projectID uint, filePath string) error {
 	now := time.Now()
 	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
@@ -89,6 +115,61 @@ func (r *tusUploadRepository) Complete(ctx context.Context, id string, projectID
 		}).Error
 }
 
+// CompletePartial marks a partial upload as fully received. expiresAt bounds how long
+// it waits for a final upload to consume it.
+func (r *tusUploadRepository) CompletePartial(ctx context.Context, id string, expiresAt time.Time) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       domain.UploadStatusCompleted,
+			"progress":     100.0,
+			"completed_at": &now,
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+// Fail marks an upload as failed and keeps reason for the client to read back.
+func (r *tusUploadRepository) Fail(ctx context.Context, id, reason string) error {
+	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":         domain.UploadStatusFailed,
+			"failure_reason": reason,
+		}).Error
+}
+
+// ClaimPartials reserves the given completed partial uploads of userID for the final
+// upload finalID. Either every part is claimed or none is, so two final uploads can
+// never assemble the same part; claimed reports whether the claim went through.
+func (r *tusUploadRepository) ClaimPartials(ctx context.Context, finalID, userID string, partIDs []string) (claimed bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.TusUpload{}).
+			Where("id IN (?) AND user_id = ? AND upload_type = ? AND status = ? AND final_upload_id IS NULL",
+				partIDs, userID, domain.UploadTypeProjectPartial, domain.UploadStatusCompleted).
+			Update("final_upload_id", finalID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(partIDs)) {
+			return errPartialsTaken
+		}
+		claimed = true
+		return nil
+	})
+	if errors.Is(err, errPartialsTaken) {
+		return false, nil
+	}
+	return claimed, err
+}
+
+// ReleasePartials hands the parts claimed by finalID back when assembling it failed.
+func (r *tusUploadRepository) ReleasePartials(ctx context.Context, finalID string) error {
+	return r.db.WithContext(ctx).Model(&domain.TusUpload{}).
+		Where("final_upload_id = ?", finalID).
+		Update("final_upload_id", nil).Error
+}
+
 func (r *tusUploadRepository) GetExpiredUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error) {
 	var uploads []domain.TusUpload
 	err := r.db.WithContext(ctx).Where("expires_at < ? AND status NOT IN (?)", before, []string{
@@ -103,13 +184,23 @@ func (r *tusUploadRepository) GetAbandonedUploads(ctx context.Context, timeout t
 	var uploads []domain.TusUpload
 	cutoffTime := time.Now().Add(-timeout)
 	err := r.db.WithContext(ctx).Where("updated_at < ? AND status IN (?)", cutoffTime, []string{
-		domain.UploadStatusQueued,
 		domain.UploadStatusUploading,
 		domain.UploadStatusPending,
 	}).Find(&uploads).Error
 	return uploads, err
 }
 
+// GetUnusedPartialUploads returns finished partial uploads that no final upload
+// claimed before their expiry.
+func (r *tusUploadRepository) GetUnusedPartialUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error) {
+	var uploads []domain.TusUpload
+	err := r.db.WithContext(ctx).
+		Where("upload_type = ? AND status = ? AND final_upload_id IS NULL AND expires_at < ?",
+			domain.UploadTypeProjectPartial, domain.UploadStatusCompleted, before).
+		Find(&uploads).Error
+	return uploads, err
+}
+
 func (r *tusUploadRepository) Delete(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.TusUpload{}).Error
 }
@@ -126,7 +217,17 @@ func (r *tusUploadRepository) ListActive(ctx context.Context) ([]domain.TusUploa
 func (r *tusUploadRepository) GetActiveUploadIDs(ctx context.Context) ([]string, error) {
 	var ids []string
 	err := r.db.WithContext(ctx).Model(&domain.TusUpload{}).
-		Where("status IN (?)", []string{domain.UploadStatusQueued, domain.UploadStatusPending, domain.UploadStatusUploading}).
+		Where("status IN (?) AND upload_type <> ?", []string{domain.UploadStatusPending, domain.UploadStatusUploading}, domain.UploadTypeProjectPartial).
+		Order("created_at ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *tusUploadRepository) GetQueuedUploadIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&domain.TusUpload{}).
+		Where("status = ?", domain.UploadStatusQueued).
+		Order("created_at ASC").
 		Pluck("id", &ids).Error
 	return ids, err
 }
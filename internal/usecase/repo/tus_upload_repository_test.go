@@ -113,13 +113,15 @@ func TestTusUploadRepository_UpdateOffset(t *testing.T) {
 	upload := newTusUpload("test-upload-6", "user-1", domain.UploadStatusUploading, time.Now().Add(time.Hour))
 	require.NoError(t, db.Create(&upload).Error)
 
-	require.NoError(t, repository.UpdateOffset(context.Background(), "test-upload-6", 512, 50.0))
+	expiresAt := time.Now().Add(2 * time.Hour)
+	require.NoError(t, repository.UpdateOffset(context.Background(), "test-upload-6", 512, 50.0, expiresAt))
 
 	updated, err := repository.GetByID(context.Background(), "test-upload-6")
 	require.NoError(t, err)
 	require.NotNil(t, updated)
 	assert.Equal(t, int64(512), updated.CurrentOffset)
 	assert.Equal(t, 50.0, updated.Progress)
+	assert.WithinDuration(t, expiresAt, updated.ExpiresAt, time.Second)
 }
 
 func TestTusUploadRepository_UpdateStatus(t *testing.T) {
@@ -161,6 +163,112 @@ func TestTusUploadRepository_Complete(t *testing.T) {
 	require.NotNil(t, updated.CompletedAt)
 }
 
+func TestTusUploadRepository_Fail(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	repository := NewTusUploadRepository(db)
+
+	upload := newTusUpload("test-upload-fail", "user-1", domain.UploadStatusUploading, time.Now().Add(time.Hour))
+	require.NoError(t, db.Create(&upload).Error)
+
+	require.NoError(t, repository.Fail(context.Background(), "test-upload-fail", "arsip ZIP berisi symlink: link"))
+
+	updated, err := repository.GetByID(context.Background(), "test-upload-fail")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusFailed, updated.Status)
+	assert.Equal(t, "arsip ZIP berisi symlink: link", updated.FailureReason)
+}
+
+func TestTusUploadRepository_Promote(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	repository := NewTusUploadRepository(db)
+
+	now := time.Now()
+	upload := newTusUpload("test-upload-promote", "user-1", domain.UploadStatusQueued, now.Add(-time.Hour))
+	require.NoError(t, db.Create(&upload).Error)
+
+	expiresAt := now.Add(10 * time.Minute)
+	require.NoError(t, repository.Promote(context.Background(), "test-upload-promote", expiresAt))
+
+	var updated domain.TusUpload
+	require.NoError(t, db.First(&updated, "id = ?", "test-upload-promote").Error)
+	assert.Equal(t, domain.UploadStatusPending, updated.Status)
+	assert.WithinDuration(t, expiresAt, updated.ExpiresAt, time.Second)
+}
+
+func TestTusUploadRepository_PartialUploads(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	repository := NewTusUploadRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	for _, id := range []string{"part-claimed", "part-unused", "part-fresh"} {
+		part := newTusUpload(id, "user-1", domain.UploadStatusUploading, now)
+		part.UploadType = domain.UploadTypeProjectPartial
+		require.NoError(t, db.Create(&part).Error)
+	}
+	regular := newTusUpload("regular", "user-1", domain.UploadStatusCompleted, now.Add(-time.Hour))
+	require.NoError(t, db.Create(&regular).Error)
+
+	require.NoError(t, repository.CompletePartial(ctx, "part-claimed", now.Add(-time.Minute)))
+	require.NoError(t, repository.CompletePartial(ctx, "part-unused", now.Add(-time.Minute)))
+	require.NoError(t, repository.CompletePartial(ctx, "part-fresh", now.Add(time.Hour)))
+	claimedAll, err := repository.ClaimPartials(ctx, "final-1", "user-1", []string{"part-claimed", "regular"})
+	require.NoError(t, err)
+	assert.False(t, claimedAll, "a regular upload cannot be claimed as a part")
+	claimedAll, err = repository.ClaimPartials(ctx, "final-1", "user-1", []string{"part-claimed"})
+	require.NoError(t, err)
+	assert.True(t, claimedAll)
+	claimedAll, err = repository.ClaimPartials(ctx, "final-2", "user-1", []string{"part-claimed", "part-fresh"})
+	require.NoError(t, err)
+	assert.False(t, claimedAll, "a claimed part cannot be assembled twice")
+
+	fresh, err := repository.GetByID(ctx, "part-fresh")
+	require.NoError(t, err)
+	assert.Nil(t, fresh.FinalUploadID, "a failed claim takes no part")
+
+	claimed, err := repository.GetByID(ctx, "part-claimed")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusCompleted, claimed.Status)
+	require.NotNil(t, claimed.FinalUploadID)
+	assert.Equal(t, "final-1", *claimed.FinalUploadID)
+
+	notPartial, err := repository.GetByID(ctx, "regular")
+	require.NoError(t, err)
+	assert.Nil(t, notPartial.FinalUploadID)
+
+	unused, err := repository.GetUnusedPartialUploads(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, unused, 1)
+	assert.Equal(t, "part-unused", unused[0].ID)
+
+	require.NoError(t, repository.ReleasePartials(ctx, "final-1"))
+	released, err := repository.GetByID(ctx, "part-claimed")
+	require.NoError(t, err)
+	assert.Nil(t, released.FinalUploadID)
+}
+
+func TestTusUploadRepository_DeclareLength(t *testing.T) {
+	t.Parallel()
+	db := setupTestDB(t)
+	repository := NewTusUploadRepository(db)
+	ctx := context.Background()
+
+	deferred := newTusUpload("deferred", "user-1", domain.UploadStatusUploading, time.Now().Add(time.Hour))
+	deferred.FileSize = 0
+	deferred.LengthDeferred = true
+	require.NoError(t, db.Create(&deferred).Error)
+
+	require.NoError(t, repository.DeclareLength(ctx, "deferred", 4096))
+
+	result, err := repository.GetByID(ctx, "deferred")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4096), result.FileSize)
+	assert.False(t, result.LengthDeferred)
+}
+
 func TestTusUploadRepository_GetExpiredUploads(t *testing.T) {
 	t.Parallel()
 	db := setupTestDB(t)
@@ -173,6 +281,7 @@ func TestTusUploadRepository_GetExpiredUploads(t *testing.T) {
 	expiredCompleted := newTusUpload("test-upload-11", "user-1", domain.UploadStatusCompleted, now.Add(-time.Hour))
 	expiredCancelled := newTusUpload("test-upload-12", "user-1", domain.UploadStatusCancelled, now.Add(-time.Hour))
 	expiredAlreadyExpired := newTusUpload("test-upload-13", "user-1", domain.UploadStatusExpired, now.Add(-time.Hour))
+	expiredQueued := newTusUpload("test-upload-14", "user-1", domain.UploadStatusQueued, now.Add(-time.Hour))
 
 	require.NoError(t, db.Create(&expiredPending).Error)
 	require.NoError(t, db.Create(&expiredUploading).Error)
@@ -180,6 +289,7 @@ func TestTusUploadRepository_GetExpiredUploads(t *testing.T) {
 	require.NoError(t, db.Create(&expiredCompleted).Error)
 	require.NoError(t, db.Create(&expiredCancelled).Error)
 	require.NoError(t, db.Create(&expiredAlreadyExpired).Error)
+	require.NoError(t, db.Create(&expiredQueued).Error)
 
 	uploads, err := repository.GetExpiredUploads(context.Background(), now)
 	require.NoError(t, err)
@@ -189,7 +299,7 @@ func TestTusUploadRepository_GetExpiredUploads(t *testing.T) {
 		ids = append(ids, item.ID)
 	}
 
-	assert.ElementsMatch(t, []string{"test-upload-8", "test-upload-9"}, ids)
+	assert.ElementsMatch(t, []string{"test-upload-8", "test-upload-9", "test-upload-14"}, ids)
 }
 
 func TestTusUploadRepository_GetAbandonedUploads(t *testing.T) {
@@ -199,7 +309,7 @@ func TestTusUploadRepository_GetAbandonedUploads(t *testing.T) {
 
 	now := time.Now()
 	records := []domain.TusUpload{
-		newTusUpload("abandoned-queued", "user-1", domain.UploadStatusQueued, now.Add(time.Hour)),
+		newTusUpload("waiting-queued", "user-1", domain.UploadStatusQueued, now.Add(time.Hour)),
 		newTusUpload("abandoned-uploading", "user-1", domain.UploadStatusUploading, now.Add(time.Hour)),
 		newTusUpload("abandoned-pending", "user-1", domain.UploadStatusPending, now.Add(time.Hour)),
 		newTusUpload("not-abandoned-completed", "user-1", domain.UploadStatusCompleted, now.Add(time.Hour)),
@@ -211,7 +321,7 @@ func TestTusUploadRepository_GetAbandonedUploads(t *testing.T) {
 	}
 
 	oldTime := now.Add(-20 * time.Minute)
-	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "abandoned-queued").Update("updated_at", oldTime).Error)
+	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "waiting-queued").Update("updated_at", oldTime).Error)
 	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "abandoned-uploading").Update("updated_at", oldTime).Error)
 	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "abandoned-pending").Update("updated_at", oldTime).Error)
 	require.NoError(t, db.Model(&domain.TusUpload{}).Where("id = ?", "not-abandoned-completed").Update("updated_at", oldTime).Error)
@@ -225,7 +335,8 @@ func TestTusUploadRepository_GetAbandonedUploads(t *testing.T) {
 		ids = append(ids, item.ID)
 	}
 
-	assert.ElementsMatch(t, []string{"abandoned-queued", "abandoned-uploading", "abandoned-pending"}, ids)
+	// Queued uploads are waiting for a slot, not idle, so they are never reported as abandoned.
+	assert.ElementsMatch(t, []string{"abandoned-uploading", "abandoned-pending"}, ids)
 }
 
 func TestTusUploadRepository_GetActiveByUserID(t *testing.T) {
@@ -338,14 +449,21 @@ func TestTusUploadRepository_GetActiveUploadIDs(t *testing.T) {
 		newTusUpload("test-upload-31", "user-1", domain.UploadStatusUploading, now.Add(time.Hour)),
 		newTusUpload("test-upload-32", "user-1", domain.UploadStatusQueued, now.Add(time.Hour)),
 		newTusUpload("test-upload-33", "user-1", domain.UploadStatusCompleted, now.Add(time.Hour)),
+		newTusUpload("test-upload-34", "user-1", domain.UploadStatusUploading, now.Add(time.Hour)),
 	}
+	// Partial uploads do not take a queue slot.
+	records[4].UploadType = domain.UploadTypeProjectPartial
 	for i := range records {
 		require.NoError(t, db.Create(&records[i]).Error)
 	}
 
 	ids, err := repository.GetActiveUploadIDs(context.Background())
 	require.NoError(t, err)
-	assert.ElementsMatch(t, []string{"test-upload-30", "test-upload-31", "test-upload-32"}, ids)
+	assert.ElementsMatch(t, []string{"test-upload-30", "test-upload-31"}, ids)
+
+	ids, err = repository.GetQueuedUploadIDs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-upload-32"}, ids)
 }
 
 func TestTusUploadRepository_ConcurrentGetByID(t *testing.T) {
@@ -253,12 +253,13 @@ func (r *userRepository) BulkUpdateRole(ctx context.Context, userIDs []string, r
 		Update("role_id", roleID).Error
 }
 
+// FindByEmails returns the users registered with one of emails, with their role.
 func (r *userRepository) FindByEmails(ctx context.Context, emails []string) ([]domain.User, error) {
 	var users []domain.User
 	if len(emails) == 0 {
 		return users, nil
 	}
-	err := r.db.WithContext(ctx).Where("email IN ?", emails).Find(&users).Error
+	err := r.db.WithContext(ctx).Preload("Role").Where("email IN ?", emails).Find(&users).Error
 	if err != nil {
 		return nil, err
 	}
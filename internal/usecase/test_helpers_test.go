@@ -1,6 +1,9 @@
 package usecase
 
-import "invento-service/config"
+import (
+	"invento-service/config"
+	"invento-service/internal/helper"
+)
 
 // Helper functions for tests
 func stringPtr(s string) *string {
@@ -37,3 +40,34 @@ func getTestTusUploadConfig() *config.Config {
 		},
 	}
 }
+
+// repoMocks holds one mock of each repository the class, assignment and project
+// review usecases are built on. Tests build the usecase they exercise from it and set
+// expectations on the mocks that usecase uses.
+type repoMocks struct {
+	assignment *MockAssignmentRepository
+	class      *MockClassRepository
+	project    *MockProjectRepository
+	user       *MockUserRepository
+}
+
+func newRepoMocks() *repoMocks {
+	return &repoMocks{
+		assignment: new(MockAssignmentRepository),
+		class:      new(MockClassRepository),
+		project:    new(MockProjectRepository),
+		user:       new(MockUserRepository),
+	}
+}
+
+func (m *repoMocks) assignmentUsecase() AssignmentUsecase {
+	return NewAssignmentUsecase(m.assignment, m.class)
+}
+
+func (m *repoMocks) classUsecase() ClassUsecase {
+	return NewClassUsecase(m.class, m.user, helper.NewExcelHelper())
+}
+
+func (m *repoMocks) projectReviewUsecase() ProjectReviewUsecase {
+	return NewProjectReviewUsecase(m.project, m.user)
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/base64"
@@ -15,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	dto "invento-service/internal/dto"
 
@@ -70,9 +72,19 @@ func setupTusIntegrationTest(t *testing.T) *tusIntegrationEnv {
 	err = db.AutoMigrate(
 		&domain.User{},
 		&domain.Project{},
+		&domain.ProjectManifest{},
+		&domain.ProjectVersion{},
+		&domain.ProjectReviewer{},
+		&domain.ProjectReviewEvent{},
 		&domain.Modul{},
+		&domain.ModulRevision{},
+		&domain.ModulAllowedRole{},
 		&domain.TusUpload{},
 		&domain.TusModulUpload{},
+		&domain.Class{},
+		&domain.ClassMember{},
+		&domain.Assignment{},
+		&domain.AssignmentSubmission{},
 	)
 	require.NoError(t, err)
 	userID := "11111111-1111-1111-1111-111111111111"
@@ -100,6 +112,8 @@ func setupTusIntegrationTest(t *testing.T) *tusIntegrationEnv {
 		nil,
 		projectManager,
 		fileManager,
+		nil,
+		nil,
 		cfg,
 	).(*tusUploadUsecase)
 	modulUsecase := NewTusModulUsecase(
@@ -107,6 +121,7 @@ func setupTusIntegrationTest(t *testing.T) *tusIntegrationEnv {
 		modulRepo,
 		modulManager,
 		fileManager,
+		nil,
 		cfg,
 	).(*tusModulUsecase)
 	return &tusIntegrationEnv{
@@ -131,6 +146,28 @@ func createTestChunk(size int) io.Reader {
 	return bytes.NewReader(data)
 }
 
+// createTestProjectZip builds a valid ZIP of exactly size bytes so project uploads pass
+// archive inspection while the tests keep their chunk arithmetic.
+func createTestProjectZip(t *testing.T, size int) []byte {
+	t.Helper()
+	build := func(content []byte) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: "src/main.go", Method: zip.Store})
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	overhead := len(build(nil))
+	require.Greater(t, size, overhead)
+	archive := build(bytes.Repeat([]byte("a"), size-overhead))
+	require.Len(t, archive, size)
+	return archive
+}
+
 func integrationModulMetadataHeader(judul, deskripsi string) string {
 	enc := func(v string) string {
 		return base64.StdEncoding.EncodeToString([]byte(v))
@@ -158,7 +195,8 @@ func TestTusProjectUploadFullFlowIntegration(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, domain.UploadStatusPending, info.Status)
 
-	offset, err := env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, createTestChunk(1024))
+	archive := createTestProjectZip(t, 3*1024)
+	offset, err := env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, bytes.NewReader(archive[:1024]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(1024), offset)
 
@@ -167,11 +205,11 @@ func TestTusProjectUploadFullFlowIntegration(t *testing.T) {
 	assert.Equal(t, domain.UploadStatusUploading, info.Status)
 	assert.Equal(t, int64(1024), info.Offset)
 
-	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, createTestChunk(1024))
+	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, bytes.NewReader(archive[1024:2048]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(2048), offset)
 
-	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, createTestChunk(1024))
+	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, bytes.NewReader(archive[2048:]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(3072), offset)
 
@@ -187,6 +225,141 @@ func TestTusProjectUploadFullFlowIntegration(t *testing.T) {
 	fileInfo, err := os.Stat(project.PathFile)
 	require.NoError(t, err)
 	assert.Equal(t, int64(3072), fileInfo.Size())
+
+	var manifest domain.ProjectManifest
+	require.NoError(t, env.db.First(&manifest, project.ID).Error)
+	require.Len(t, manifest.Entries, 1)
+	assert.Equal(t, "src/main.go", manifest.Entries[0].Path)
+
+	var versions []domain.ProjectVersion
+	require.NoError(t, env.db.Where("project_id = ?", project.ID).Find(&versions).Error)
+	require.Len(t, versions, 1)
+	assert.Equal(t, 1, versions[0].Version)
+	assert.Equal(t, project.PathFile, versions[0].PathFile)
+	assert.Len(t, versions[0].Checksum, 64)
+}
+
+func TestTusProjectAssignmentSubmissionIntegration(t *testing.T) {
+	t.Parallel()
+	env := setupTusIntegrationTest(t)
+	ctx := context.Background()
+	classRepo := repo.NewClassRepository(env.db, zerolog.Nop())
+	assignmentRepo := repo.NewAssignmentRepository(env.db, zerolog.Nop())
+	env.uploadUsecase.assignmentUsecase = NewAssignmentUsecase(assignmentRepo, classRepo)
+
+	class := &domain.Class{Kode: "TIF101", Nama: "Basis Data", Periode: "2025/2026-1", JoinCode: "ABCD2345", CreatedBy: "dosen-1"}
+	require.NoError(t, classRepo.Create(ctx, class, []string{"dosen-1"}))
+	require.NoError(t, classRepo.AddMembers(ctx, []domain.ClassMember{
+		{ClassID: class.ID, UserID: env.userID, Role: domain.ClassRoleMahasiswa, JoinedVia: domain.ClassJoinedJoinCode},
+	}))
+	assignment := &domain.Assignment{
+		ClassID:   class.ID,
+		Judul:     "Tugas ERD",
+		OpenAt:    time.Now().Add(-time.Hour),
+		DueAt:     time.Now().Add(time.Hour),
+		MaxSize:   2 * 1024,
+		CreatedBy: "dosen-1",
+	}
+	require.NoError(t, assignmentRepo.Create(ctx, assignment))
+
+	submit := func(size int) (*dto.TusUploadResponse, error) {
+		resp, err := env.uploadUsecase.InitiateUpload(ctx, env.userID, "integration@test.local", "mahasiswa", int64(size),
+			dto.TusUploadInitRequest{NamaProject: "ERD Perpustakaan", Kategori: "website", Semester: 3, AssignmentID: &assignment.ID})
+		if err != nil {
+			return nil, err
+		}
+		_, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, bytes.NewReader(createTestProjectZip(t, size)))
+		return resp, err
+	}
+
+	_, err := submit(3 * 1024)
+	require.Error(t, err, "larger than the assignment allows")
+
+	_, err = submit(1024)
+	require.NoError(t, err)
+
+	submission, err := assignmentRepo.GetSubmission(ctx, assignment.ID, env.userID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubmissionStatusOnTime, submission.Status)
+	assert.Equal(t, "ERD Perpustakaan", submission.Project.NamaProject)
+	assert.Equal(t, submission.Project.PathFile, submission.PathFile)
+	assert.NotEmpty(t, submission.Checksum)
+	firstProjectID := submission.ProjectID
+
+	// Past the due date but within the late window, a new submission replaces the first.
+	assignment.DueAt = time.Now().Add(-time.Minute)
+	assignment.LateWindowMinutes = 60
+	require.NoError(t, assignmentRepo.Update(ctx, assignment))
+	_, err = submit(1024)
+	require.NoError(t, err)
+
+	submissions, err := assignmentRepo.GetSubmissions(ctx, assignment.ID)
+	require.NoError(t, err)
+	require.Len(t, submissions, 1)
+	assert.Equal(t, domain.SubmissionStatusLate, submissions[0].Status)
+	assert.NotEqual(t, firstProjectID, submissions[0].ProjectID)
+
+	assignment.LateWindowMinutes = 0
+	require.NoError(t, assignmentRepo.Update(ctx, assignment))
+	_, err = submit(1024)
+	require.Error(t, err, "the assignment is closed")
+
+	// An upload whose submission cannot be recorded fails and leaves nothing behind.
+	assignment.LateWindowMinutes = 60
+	require.NoError(t, assignmentRepo.Update(ctx, assignment))
+	var projectsBefore, versionsBefore int64
+	require.NoError(t, env.db.Model(&domain.Project{}).Count(&projectsBefore).Error)
+	require.NoError(t, env.db.Model(&domain.ProjectVersion{}).Count(&versionsBefore).Error)
+	resp, err := env.uploadUsecase.InitiateUpload(ctx, env.userID, "integration@test.local", "mahasiswa", 1024,
+		dto.TusUploadInitRequest{NamaProject: "ERD Perpustakaan", Kategori: "website", Semester: 3, AssignmentID: &assignment.ID})
+	require.NoError(t, err)
+	require.NoError(t, assignmentRepo.Delete(ctx, assignment.ID))
+	_, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, bytes.NewReader(createTestProjectZip(t, 1024)))
+	require.Error(t, err)
+	var upload domain.TusUpload
+	require.NoError(t, env.db.Where("id = ?", resp.UploadID).First(&upload).Error)
+	assert.Equal(t, domain.UploadStatusFailed, upload.Status)
+	assert.NotEmpty(t, upload.FailureReason)
+	assert.False(t, env.projectManager.IsActiveUpload(resp.UploadID), "the slot is released")
+	assert.True(t, env.projectManager.CanAcceptUpload())
+
+	var projectsAfter, versionsAfter int64
+	require.NoError(t, env.db.Model(&domain.Project{}).Count(&projectsAfter).Error)
+	require.NoError(t, env.db.Model(&domain.ProjectVersion{}).Count(&versionsAfter).Error)
+	assert.Equal(t, projectsBefore, projectsAfter, "no orphaned project")
+	assert.Equal(t, versionsBefore, versionsAfter, "no orphaned version")
+}
+
+func TestTusProjectUploadRejectedArchiveIntegration(t *testing.T) {
+	t.Parallel()
+	env := setupTusIntegrationTest(t)
+	ctx := context.Background()
+
+	resp, err := env.uploadUsecase.InitiateUpload(
+		ctx,
+		env.userID,
+		"integration@test.local",
+		"mahasiswa",
+		1024,
+		dto.TusUploadInitRequest{NamaProject: "Bukan Zip", Kategori: "website", Semester: 2},
+	)
+	require.NoError(t, err)
+
+	_, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, createTestChunk(1024))
+	require.Error(t, err)
+
+	info, err := env.uploadUsecase.GetUploadInfo(ctx, resp.UploadID, env.userID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusFailed, info.Status)
+	assert.Equal(t, storage.ErrZipCorrupt.Error(), info.FailureReason)
+
+	_, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 1024, createTestChunk(1))
+	require.Error(t, err)
+
+	var count int64
+	require.NoError(t, env.db.Model(&domain.Project{}).Where("user_id = ?", env.userID).Count(&count).Error)
+	assert.Zero(t, count)
+	assert.True(t, env.projectManager.CanAcceptUpload(), "the slot is released")
 }
 
 func TestTusProjectUploadResumeAfterPauseIntegration(t *testing.T) {
@@ -204,26 +377,36 @@ func TestTusProjectUploadResumeAfterPauseIntegration(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	offset, err := env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, createTestChunk(1024))
+	archive := createTestProjectZip(t, 3*1024)
+	offset, err := env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, bytes.NewReader(archive[:1024]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(1024), offset)
 
-	pausedOffset, length, err := env.uploadUsecase.GetUploadStatus(ctx, resp.UploadID, env.userID)
+	status, err := env.uploadUsecase.GetUploadStatus(ctx, resp.UploadID, env.userID)
 	require.NoError(t, err)
+	pausedOffset := status.Offset
 	assert.Equal(t, int64(1024), pausedOffset)
-	assert.Equal(t, int64(3072), length)
+	assert.Equal(t, int64(3072), status.Length)
 
-	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, pausedOffset, createTestChunk(1024))
+	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, pausedOffset, bytes.NewReader(archive[1024:2048]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(2048), offset)
 
-	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, createTestChunk(1024))
+	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, bytes.NewReader(archive[2048:]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(3072), offset)
 
 	var upload domain.TusUpload
 	require.NoError(t, env.db.Where("id = ?", resp.UploadID).First(&upload).Error)
 	assert.Equal(t, domain.UploadStatusCompleted, upload.Status)
+
+	// The archive holds only a Go file, so nothing points to mobile; the kategori the
+	// user sent is kept while the detection is still recorded.
+	var project domain.Project
+	require.NoError(t, env.db.Where("user_id = ?", env.userID).First(&project).Error)
+	assert.Equal(t, domain.KategoriMobile, project.Kategori)
+	assert.Equal(t, domain.KategoriWebsite, project.KategoriTerdeteksi)
+	assert.NotNil(t, project.KategoriSignals)
 }
 
 func TestTusProjectUploadCancelIntegration(t *testing.T) {
@@ -299,12 +482,51 @@ func TestTusModulUploadFullFlowIntegration(t *testing.T) {
 	var modul domain.Modul
 	require.NoError(t, env.db.Where("id = ?", *upload.ModulID).First(&modul).Error)
 	assert.FileExists(t, modul.FilePath)
+	assert.Equal(t, domain.ModulStatusDraft, modul.Status, "new moduls start as drafts")
 
 	fileInfo, err := os.Stat(modul.FilePath)
 	require.NoError(t, err)
 	assert.Equal(t, int64(2048), fileInfo.Size())
 }
 
+func TestTusModulRevisionIntegration(t *testing.T) {
+	t.Parallel()
+	env := setupTusIntegrationTest(t)
+	ctx := context.Background()
+
+	resp, err := env.modulUsecase.InitiateModulUpload(ctx, env.userID, 1024, integrationModulMetadataHeader("modul-2024", "semester ganjil"))
+	require.NoError(t, err)
+	_, err = env.modulUsecase.HandleModulChunk(ctx, resp.UploadID, env.userID, 0, createTestChunk(1024))
+	require.NoError(t, err)
+
+	var first domain.Modul
+	require.NoError(t, env.db.Where("user_id = ?", env.userID).First(&first).Error)
+
+	update, err := env.modulUsecase.InitiateModulUpdateUpload(ctx, first.ID, env.userID, 2048, integrationModulMetadataHeader("modul-2025", "semester ganjil"))
+	require.NoError(t, err)
+	_, err = env.modulUsecase.HandleModulUpdateChunk(ctx, first.ID, update.UploadID, env.userID, 0, createTestChunk(2048))
+	require.NoError(t, err)
+
+	modulUC := NewModulUsecase(repo.NewModulRepository(env.db, zerolog.Nop()), 0)
+	revisions, err := modulUC.GetRevisions(ctx, first.ID, env.userID)
+	require.NoError(t, err)
+	require.Len(t, revisions.Items, 2)
+	assert.Equal(t, domain.ModulRevisionFile, revisions.Items[0].Action)
+	assert.True(t, revisions.Items[0].IsCurrent)
+	assert.Contains(t, revisions.Items[0].Changes, dto.ModulRevisionChange{Field: "judul", Before: "modul-2024", After: "modul-2025"})
+	assert.FileExists(t, first.FilePath, "the replaced file is kept for its revision")
+
+	rollback, err := modulUC.Rollback(ctx, first.ID, env.userID, 1, dto.RollbackModulRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, rollback.Revision)
+
+	var current domain.Modul
+	require.NoError(t, env.db.Where("id = ?", first.ID).First(&current).Error)
+	assert.Equal(t, "modul-2024", current.Judul)
+	assert.Equal(t, first.FilePath, current.FilePath)
+	assert.Equal(t, int64(1024), current.FileSize)
+}
+
 func TestTusModulUploadResumeAfterPauseIntegration(t *testing.T) {
 	t.Parallel()
 	env := setupTusIntegrationTest(t)
@@ -322,7 +544,7 @@ func TestTusModulUploadResumeAfterPauseIntegration(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(1024), offset)
 
-	pausedOffset, length, err := env.modulUsecase.GetModulUploadStatus(ctx, resp.UploadID, env.userID)
+	pausedOffset, length, _, err := env.modulUsecase.GetModulUploadStatus(ctx, resp.UploadID, env.userID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1024), pausedOffset)
 	assert.Equal(t, int64(3072), length)
@@ -377,24 +599,69 @@ func TestTusUploadConcurrentSlotsIntegration(t *testing.T) {
 
 	meta := dto.TusUploadInitRequest{NamaProject: "Concurrent", Kategori: "website", Semester: 1}
 	secondUserID := "22222222-2222-2222-2222-222222222222"
+	thirdUserID := "33333333-3333-3333-3333-333333333333"
 
 	first, err := env.uploadUsecase.InitiateUpload(ctx, env.userID, "integration@test.local", "mahasiswa", 1024, meta)
 	require.NoError(t, err)
 	second, err := env.uploadUsecase.InitiateUpload(ctx, secondUserID, "integration-2@test.local", "mahasiswa", 1024, meta)
 	require.NoError(t, err)
 
-	_, err = env.uploadUsecase.InitiateUpload(ctx, "33333333-3333-3333-3333-333333333333", "integration-3@test.local", "mahasiswa", 1024, meta)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "slot upload tidak tersedia")
-
-	queuedID := "manually-queued-upload"
-	env.projectQueue.Add(queuedID)
+	third, err := env.uploadUsecase.InitiateUpload(ctx, thirdUserID, "integration-3@test.local", "mahasiswa", 1024, meta)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusQueued, third.Status)
+	assert.Equal(t, 1, third.QueuePosition)
 	assert.Equal(t, 1, env.projectQueue.GetQueueLength())
 
-	promoted := env.projectQueue.FinishUpload(first.UploadID)
-	assert.Equal(t, queuedID, promoted)
-	assert.True(t, env.projectQueue.IsActiveUpload(queuedID))
+	info, err := env.uploadUsecase.GetUploadInfo(ctx, third.UploadID, thirdUserID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusQueued, info.Status)
+	assert.Equal(t, 1, info.QueuePosition)
+
+	_, err = env.uploadUsecase.HandleChunk(ctx, third.UploadID, thirdUserID, 0, bytes.NewReader([]byte("x")))
+	require.Error(t, err)
+
+	require.NoError(t, env.uploadUsecase.CancelUpload(ctx, first.UploadID, env.userID))
+	assert.True(t, env.projectQueue.IsActiveUpload(third.UploadID))
 	assert.True(t, env.projectQueue.IsActiveUpload(second.UploadID))
+
+	info, err = env.uploadUsecase.GetUploadInfo(ctx, third.UploadID, thirdUserID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusPending, info.Status)
+	assert.Equal(t, 0, info.QueuePosition)
+}
+
+func TestTusUploadDatabaseQueueIntegration(t *testing.T) {
+	t.Parallel()
+	env := setupTusIntegrationTest(t)
+	ctx := context.Background()
+
+	// Two usecases sharing one database stand in for two service instances.
+	newInstance := func() *tusUploadUsecase {
+		queue := upload.NewDBTusQueue(env.db, upload.TusQueueTableProject, 1, time.Duration(env.cfg.Upload.IdleTimeout)*time.Second, zerolog.Nop())
+		store := upload.NewTusStore(env.pathResolver, env.cfg.Upload.MaxSizeProject)
+		manager := upload.NewTusManager(store, queue, nil, env.cfg, zerolog.Nop())
+		return NewTusUploadUsecase(repo.NewTusUploadRepository(env.db), repo.NewProjectRepository(env.db), nil, manager, storage.NewFileManager(env.cfg), nil, nil, env.cfg).(*tusUploadUsecase)
+	}
+	instanceA := newInstance()
+	instanceB := newInstance()
+
+	meta := dto.TusUploadInitRequest{NamaProject: "Shared", Kategori: "website", Semester: 1}
+	secondUserID := "22222222-2222-2222-2222-222222222222"
+
+	first, err := instanceA.InitiateUpload(ctx, env.userID, "integration@test.local", "mahasiswa", 1024, meta)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusPending, first.Status)
+
+	second, err := instanceB.InitiateUpload(ctx, secondUserID, "integration-2@test.local", "mahasiswa", 1024, meta)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusQueued, second.Status)
+	assert.Equal(t, 1, second.QueuePosition)
+
+	require.NoError(t, instanceA.CancelUpload(ctx, first.UploadID, env.userID))
+
+	info, err := instanceB.GetUploadInfo(ctx, second.UploadID, secondUserID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusPending, info.Status)
 }
 
 func TestTusUploadInvalidFileSizeIntegration(t *testing.T) {
@@ -456,7 +723,8 @@ func TestTusUploadStatusTransitionsIntegration(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, domain.UploadStatusPending, info.Status)
 
-	offset, err := env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, createTestChunk(1024))
+	archive := createTestProjectZip(t, 2*1024)
+	offset, err := env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, 0, bytes.NewReader(archive[:1024]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(1024), offset)
 
@@ -464,7 +732,7 @@ func TestTusUploadStatusTransitionsIntegration(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, domain.UploadStatusUploading, info.Status)
 
-	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, createTestChunk(1024))
+	offset, err = env.uploadUsecase.HandleChunk(ctx, resp.UploadID, env.userID, offset, bytes.NewReader(archive[1024:]))
 	require.NoError(t, err)
 	assert.Equal(t, int64(2048), offset)
 
@@ -34,8 +34,13 @@ func (m *MockTusModulUploadRepository) GetByUserID(ctx context.Context, userID s
 	return args.Get(0).([]domain.TusModulUpload), args.Error(1)
 }
 
-func (m *MockTusModulUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64) error {
-	args := m.Called(ctx, id, offset, progress)
+func (m *MockTusModulUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64, expiresAt time.Time) error {
+	args := m.Called(ctx, id, offset, progress, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTusModulUploadRepository) DeclareLength(ctx context.Context, id string, fileSize int64) error {
+	args := m.Called(ctx, id, fileSize)
 	return args.Error(0)
 }
 
@@ -130,8 +135,13 @@ func (m *MockTusUploadRepository) CountActiveByUserID(ctx context.Context, userI
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockTusUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64) error {
-	args := m.Called(ctx, id, offset, progress)
+func (m *MockTusUploadRepository) UpdateOffset(ctx context.Context, id string, offset int64, progress float64, expiresAt time.Time) error {
+	args := m.Called(ctx, id, offset, progress, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTusUploadRepository) DeclareLength(ctx context.Context, id string, fileSize int64) error {
+	args := m.Called(ctx, id, fileSize)
 	return args.Error(0)
 }
 
@@ -140,6 +150,39 @@ func (m *MockTusUploadRepository) UpdateStatus(ctx context.Context, id, status s
 	return args.Error(0)
 }
 
+func (m *MockTusUploadRepository) Promote(ctx context.Context, id string, expiresAt time.Time) error {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTusUploadRepository) CompletePartial(ctx context.Context, id string, expiresAt time.Time) error {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTusUploadRepository) Fail(ctx context.Context, id, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+func (m *MockTusUploadRepository) ClaimPartials(ctx context.Context, finalID, userID string, partIDs []string) (bool, error) {
+	args := m.Called(ctx, finalID, userID, partIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTusUploadRepository) ReleasePartials(ctx context.Context, finalID string) error {
+	args := m.Called(ctx, finalID)
+	return args.Error(0)
+}
+
+func (m *MockTusUploadRepository) GetUnusedPartialUploads(ctx context.Context, before time.Time) ([]domain.TusUpload, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TusUpload), args.Error(1)
+}
+
 func (m *MockTusUploadRepository) Complete(ctx context.Context, id string, projectID uint, filePath string) error {
 	args := m.Called(ctx, id, projectID, filePath)
 	return args.Error(0)
@@ -181,3 +224,11 @@ func (m *MockTusUploadRepository) GetActiveUploadIDs(ctx context.Context) ([]str
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+
+func (m *MockTusUploadRepository) GetQueuedUploadIDs(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
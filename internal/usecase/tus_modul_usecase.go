@@ -12,27 +12,26 @@ import (
 	"invento-service/internal/usecase/repo"
 	"io"
 	"net/http"
-	"os"
 	"path/filepath"
 	"time"
 
 	apperrors "invento-service/internal/errors"
 
 	"github.com/google/uuid"
-	zlog "github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
 type TusModulUsecase interface {
 	InitiateModulUpload(ctx context.Context, userID string, fileSize int64, uploadMetadata string) (*dto.TusModulUploadResponse, error)
 	HandleModulChunk(ctx context.Context, uploadID, userID string, offset int64, chunk io.Reader) (int64, error)
+	DeclareModulUploadLength(ctx context.Context, uploadID, userID string, length int64) error
 	GetModulUploadInfo(ctx context.Context, uploadID, userID string) (*dto.TusModulUploadInfoResponse, error)
-	GetModulUploadStatus(ctx context.Context, uploadID, userID string) (int64, int64, error)
+	GetModulUploadStatus(ctx context.Context, uploadID, userID string) (int64, int64, time.Time, error)
 	CancelModulUpload(ctx context.Context, uploadID, userID string) error
 	CheckModulUploadSlot(ctx context.Context, userID string) (*dto.TusModulUploadSlotResponse, error)
 	InitiateModulUpdateUpload(ctx context.Context, modulID, userID string, fileSize int64, uploadMetadata string) (*dto.TusModulUploadResponse, error)
 	HandleModulUpdateChunk(ctx context.Context, modulID, uploadID, userID string, offset int64, chunk io.Reader) (int64, error)
-	GetModulUpdateUploadStatus(ctx context.Context, modulID, uploadID, userID string) (int64, int64, error)
+	GetModulUpdateUploadStatus(ctx context.Context, modulID, uploadID, userID string) (int64, int64, time.Time, error)
 	GetModulUpdateUploadInfo(ctx context.Context, modulID, uploadID, userID string) (*dto.TusModulUploadInfoResponse, error)
 	CancelModulUpdateUpload(ctx context.Context, modulID, uploadID, userID string) error
 }
@@ -42,14 +41,18 @@ type tusModulUsecase struct {
 	modulRepo          repo.ModulRepository
 	tusManager         *upload.TusManager
 	fileManager        *storage.FileManager
+	quotaUsecase       QuotaUsecase
 	config             *config.Config
 }
 
+// NewTusModulUsecase creates the modul upload usecase. A nil quotaUsecase admits
+// uploads without checking storage quotas.
 func NewTusModulUsecase(
 	tusModulUploadRepo repo.TusModulUploadRepository,
 	modulRepo repo.ModulRepository,
 	tusManager *upload.TusManager,
 	fileManager *storage.FileManager,
+	quotaUsecase QuotaUsecase,
 	config *config.Config,
 ) TusModulUsecase {
 	return &tusModulUsecase{
@@ -57,6 +60,7 @@ func NewTusModulUsecase(
 		modulRepo:          modulRepo,
 		tusManager:         tusManager,
 		fileManager:        fileManager,
+		quotaUsecase:       quotaUsecase,
 		config:             config,
 	}
 }
@@ -114,8 +118,11 @@ func (uc *tusModulUsecase) initiateUpload(ctx context.Context, userID string, fi
 		return nil, apperrors.NewConflictError(fmt.Sprintf("antrian penuh: %s", slotCheck.Message))
 	}
 
-	if err = uc.validateModulFileSize(fileSize); err != nil { //nolint:gocritic // sloppyReassign conflicts with govet shadow
-		return nil, err
+	deferred := fileSize == upload.DeferredLength
+	if !deferred {
+		if err = uc.validateModulFileSize(fileSize); err != nil { //nolint:gocritic // sloppyReassign conflicts with govet shadow
+			return nil, err
+		}
 	}
 
 	metadata, err := uc.parseModulMetadata(uploadMetadata)
@@ -123,6 +130,10 @@ func (uc *tusModulUsecase) initiateUpload(ctx context.Context, userID string, fi
 		return nil, err
 	}
 
+	if err = uc.checkQuota(ctx, userID, fileSize, modulID); err != nil { //nolint:gocritic // sloppyReassign conflicts with govet shadow
+		return nil, err
+	}
+
 	uploadID := uuid.New().String()
 	uploadURL := fmt.Sprintf("/modul/upload/%s", uploadID)
 	if uploadType == domain.UploadTypeModulUpdate && modulID != nil {
@@ -139,12 +150,14 @@ func (uc *tusModulUsecase) initiateUpload(ctx context.Context, userID string, fi
 		UploadMetadata: domain.TusModulUploadMetadata{
 			Judul:     metadata.Judul,
 			Deskripsi: metadata.Deskripsi,
+			Catatan:   metadata.Catatan,
 		},
-		FileSize:      fileSize,
-		CurrentOffset: 0,
-		Status:        domain.UploadStatusPending,
-		Progress:      0,
-		ExpiresAt:     expiresAt,
+		FileSize:       max(fileSize, 0),
+		LengthDeferred: deferred,
+		CurrentOffset:  0,
+		Status:         domain.UploadStatusPending,
+		Progress:       0,
+		ExpiresAt:      expiresAt,
 	}
 
 	if err := uc.tusModulUploadRepo.Create(ctx, tusUpload); err != nil {
@@ -165,14 +178,48 @@ func (uc *tusModulUsecase) initiateUpload(ctx context.Context, userID string, fi
 		return nil, apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.initiateUpload: init storage: %w", err))
 	}
 
+	uc.publishProgress(tusUpload)
+
 	return &dto.TusModulUploadResponse{
 		UploadID:  uploadID,
 		UploadURL: uploadURL,
 		Offset:    0,
-		Length:    fileSize,
+		Length:    tusUpload.FileSize,
+		ExpiresAt: tusUpload.ExpiresAt,
 	}, nil
 }
 
+// checkQuota applies the user's storage quota before an upload is created. An update
+// adds no file, and what its pruning deletes is not counted. A length-deferred upload
+// is checked again once its length is declared.
+func (uc *tusModulUsecase) checkQuota(ctx context.Context, userID string, fileSize int64, modulID *string) error {
+	return uc.checkQuotaReplacing(ctx, userID, dto.QuotaCheck{Bytes: max(fileSize, 0), NewFile: modulID == nil}, modulID)
+}
+
+// checkQuotaReplacing runs check against the user's quota. For an update of modulID it
+// leaves out the files that pruning will delete once the new revision is stored.
+func (uc *tusModulUsecase) checkQuotaReplacing(ctx context.Context, userID string, check dto.QuotaCheck, modulID *string) error {
+	if uc.quotaUsecase == nil {
+		return nil
+	}
+
+	if modulID != nil {
+		modul, err := uc.modulRepo.GetByID(ctx, *modulID)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrRecordNotFound) {
+				return apperrors.NewNotFoundError("Modul")
+			}
+			return apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.checkQuota: %w", err))
+		}
+		check.FreedBytes, err = prunedRevisionBytes(ctx, uc.modulRepo, modul, uc.config.Upload.ModulRevisionRetention)
+		if err != nil {
+			return apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.checkQuota: revisions: %w", err))
+		}
+	}
+
+	return uc.quotaUsecase.CheckUpload(ctx, userID, check)
+}
+
 func (uc *tusModulUsecase) HandleModulChunk(ctx context.Context, uploadID, userID string, offset int64, chunk io.Reader) (int64, error) {
 	return uc.handleChunk(ctx, uploadID, userID, offset, chunk, nil)
 }
@@ -205,27 +252,78 @@ func (uc *tusModulUsecase) handleChunk(ctx context.Context, uploadID, userID str
 		tusUpload.Status = domain.UploadStatusUploading
 	}
 
+	if tusUpload.LengthDeferred {
+		chunk = upload.NewLengthLimitReader(chunk, uc.config.Upload.MaxSizeModul-offset)
+	}
+
 	newOffset, err := uc.tusManager.HandleChunk(uploadID, offset, chunk)
 	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return tusUpload.CurrentOffset, err
+		}
 		return tusUpload.CurrentOffset, apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.handleChunk: write chunk: %w", err))
 	}
 
-	progress := float64(newOffset) / float64(tusUpload.FileSize) * 100
-	if err = uc.tusModulUploadRepo.UpdateOffset(ctx, uploadID, newOffset, progress); err != nil {
+	var progress float64
+	if !tusUpload.LengthDeferred {
+		progress = float64(newOffset) / float64(tusUpload.FileSize) * 100
+	}
+	expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
+	if err = uc.tusModulUploadRepo.UpdateOffset(ctx, uploadID, newOffset, progress, expiresAt); err != nil {
 		return newOffset, apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.handleChunk: update offset: %w", err))
 	}
 
 	tusUpload.CurrentOffset = newOffset
 	tusUpload.Progress = progress
-	if newOffset >= tusUpload.FileSize {
+	tusUpload.ExpiresAt = expiresAt
+	uc.publishProgress(tusUpload)
+	if !tusUpload.LengthDeferred && newOffset >= tusUpload.FileSize {
 		if err = uc.completeUpload(ctx, tusUpload, userID); err != nil { //nolint:gocritic // sloppyReassign conflicts with govet shadow
 			return newOffset, err
 		}
+		tusUpload.Status = domain.UploadStatusCompleted
+		uc.publishProgress(tusUpload)
 	}
 
 	return newOffset, nil
 }
 
+// DeclareModulUploadLength fixes the size of a modul upload started with
+// Upload-Defer-Length.
+func (uc *tusModulUsecase) DeclareModulUploadLength(ctx context.Context, uploadID, userID string, length int64) error {
+	tusUpload, err := uc.getOwnedUpload(ctx, uploadID, userID, nil)
+	if err != nil {
+		return err
+	}
+
+	if !tusUpload.LengthDeferred {
+		if length != tusUpload.FileSize {
+			return apperrors.NewValidationError("Upload-Length tidak sesuai dengan ukuran upload", nil)
+		}
+		return nil
+	}
+
+	if err = uc.validateModulFileSize(length); err != nil {
+		return err
+	}
+
+	// The upload already counts as a file; its bytes are what is new.
+	if err = uc.checkQuotaReplacing(ctx, userID, dto.QuotaCheck{Bytes: length}, tusUpload.ModulID); err != nil {
+		return err
+	}
+
+	if err = uc.tusManager.DeclareUploadLength(uploadID, length); err != nil {
+		return err
+	}
+
+	if err = uc.tusModulUploadRepo.DeclareLength(ctx, uploadID, length); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.DeclareModulUploadLength: %w", err))
+	}
+
+	return nil
+}
+
 func (uc *tusModulUsecase) completeUpload(ctx context.Context, tusUpload *domain.TusModulUpload, userID string) error {
 	dirPath, randomDir, err := uc.fileManager.CreateModulUploadDirectory(userID)
 	if err != nil {
@@ -270,8 +368,8 @@ func (uc *tusModulUsecase) completeModulCreate(ctx context.Context, tusUpload *d
 		FileName:  fileName,
 		FilePath:  finalPath,
 		FileSize:  tusUpload.FileSize,
-		MimeType:  detectMimeType(finalPath),
-		Status:    "completed",
+		MimeType:  detectMimeType(ctx, finalPath),
+		Status:    domain.ModulStatusDraft,
 	}
 
 	if err := uc.modulRepo.Create(ctx, modul); err != nil {
@@ -280,6 +378,8 @@ func (uc *tusModulUsecase) completeModulCreate(ctx context.Context, tusUpload *d
 		return "", apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.completeModulCreate: %w", err))
 	}
 
+	recordModulRevision(ctx, uc.modulRepo, &domain.Modul{}, modul, domain.ModulRevisionCreate, userID, tusUpload.UploadMetadata.Catatan, uc.config.Upload.ModulRevisionRetention)
+
 	return modul.ID, nil
 }
 
@@ -293,26 +393,29 @@ func (uc *tusModulUsecase) completeModulUpdate(ctx context.Context, tusUpload *d
 		return "", apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.completeModulUpdate: get modul: %w", err))
 	}
 
-	oldFilePath := modul.FilePath
+	if err := ensureModulBaseline(ctx, uc.modulRepo, modul); err != nil {
+		_ = storage.DeleteFile(finalPath)
+		_ = uc.fileManager.DeleteModulDirectory(userID, randomDir)
+		return "", apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.completeModulUpdate: baseline revision: %w", err))
+	}
+
+	before := *modul
 	modul.Judul = tusUpload.UploadMetadata.Judul
 	modul.Deskripsi = tusUpload.UploadMetadata.Deskripsi
 	modul.FilePath = finalPath
 	modul.FileName = fileName
 	modul.FileSize = tusUpload.FileSize
-	modul.MimeType = detectMimeType(finalPath)
+	modul.MimeType = detectMimeType(ctx, finalPath)
 
+	// The previous file is kept: it belongs to an earlier revision and stays
+	// downloadable and restorable from there until that revision is pruned.
 	if err := uc.modulRepo.Update(ctx, modul); err != nil {
 		_ = storage.DeleteFile(finalPath)
 		_ = uc.fileManager.DeleteModulDirectory(userID, randomDir)
 		return "", apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.completeModulUpdate: %w", err))
 	}
 
-	if oldFilePath != "" {
-		if err := storage.DeleteFile(oldFilePath); err != nil {
-			// Old file deletion after successful update is critical but non-blocking
-			zlog.Warn().Err(err).Str("file", oldFilePath).Msg("TusModulUsecase.completeModulUpdate: failed to delete old file")
-		}
-	}
+	recordModulRevision(ctx, uc.modulRepo, &before, modul, domain.ModulRevisionFile, userID, tusUpload.UploadMetadata.Catatan, uc.config.Upload.ModulRevisionRetention)
 	return modul.ID, nil
 }
 
@@ -349,21 +452,26 @@ func (uc *tusModulUsecase) getUploadInfo(ctx context.Context, uploadID, userID s
 	return response, nil
 }
 
-func (uc *tusModulUsecase) GetModulUploadStatus(ctx context.Context, uploadID, userID string) (offset, length int64, err error) {
+func (uc *tusModulUsecase) GetModulUploadStatus(ctx context.Context, uploadID, userID string) (offset, length int64, expiresAt time.Time, err error) {
 	return uc.getUploadStatus(ctx, uploadID, userID, nil)
 }
 
-func (uc *tusModulUsecase) GetModulUpdateUploadStatus(ctx context.Context, modulID, uploadID, userID string) (offset, length int64, err error) {
+func (uc *tusModulUsecase) GetModulUpdateUploadStatus(ctx context.Context, modulID, uploadID, userID string) (offset, length int64, expiresAt time.Time, err error) {
 	return uc.getUploadStatus(ctx, uploadID, userID, &modulID)
 }
 
-func (uc *tusModulUsecase) getUploadStatus(ctx context.Context, uploadID, userID string, modulID *string) (offset, length int64, err error) {
+func (uc *tusModulUsecase) getUploadStatus(ctx context.Context, uploadID, userID string, modulID *string) (offset, length int64, expiresAt time.Time, err error) {
 	tusUpload, err := uc.getOwnedUpload(ctx, uploadID, userID, modulID)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, time.Time{}, err
+	}
+
+	// A completed modul upload is kept, so it has no expiry to report.
+	if tusUpload.Status != domain.UploadStatusCompleted {
+		expiresAt = tusUpload.ExpiresAt
 	}
 
-	return tusUpload.CurrentOffset, tusUpload.FileSize, nil
+	return tusUpload.CurrentOffset, tusUpload.FileSize, expiresAt, nil
 }
 
 func (uc *tusModulUsecase) CancelModulUpload(ctx context.Context, uploadID, userID string) error {
@@ -392,9 +500,23 @@ func (uc *tusModulUsecase) cancelUpload(ctx context.Context, uploadID, userID st
 		return apperrors.NewInternalError(fmt.Errorf("TusModulUsecase.cancelUpload: update status: %w", err))
 	}
 
+	tusUpload.Status = domain.UploadStatusCancelled
+	uc.publishProgress(tusUpload)
 	return nil
 }
 
+func (uc *tusModulUsecase) publishProgress(tusUpload *domain.TusModulUpload) {
+	uc.tusManager.PublishProgress(dto.TusProgressEvent{
+		UploadID: tusUpload.ID,
+		UserID:   tusUpload.UserID,
+		Kind:     upload.ProgressKindModul,
+		Status:   tusUpload.Status,
+		Offset:   tusUpload.CurrentOffset,
+		Length:   tusUpload.FileSize,
+		Progress: tusUpload.Progress,
+	})
+}
+
 func (uc *tusModulUsecase) getOwnedUpload(ctx context.Context, uploadID, userID string, modulID *string) (*domain.TusModulUpload, error) {
 	tusUpload, err := uc.tusModulUploadRepo.GetByID(ctx, uploadID)
 	if err != nil {
@@ -408,6 +530,10 @@ func (uc *tusModulUsecase) getOwnedUpload(ctx context.Context, uploadID, userID
 		return nil, apperrors.NewForbiddenError("Anda tidak memiliki akses ke upload ini")
 	}
 
+	if tusUpload.Status == domain.UploadStatusExpired {
+		return nil, apperrors.NewTusExpiredError()
+	}
+
 	if modulID != nil {
 		if tusUpload.ModulID == nil || *tusUpload.ModulID != *modulID {
 			return nil, apperrors.NewValidationError("modul ID tidak cocok", nil)
@@ -432,9 +558,15 @@ func (uc *tusModulUsecase) parseModulMetadata(metadataHeader string) (*dto.TusMo
 		return nil, apperrors.NewValidationError("judul harus antara 3-255 karakter", nil)
 	}
 
+	catatan := metadataMap["catatan"]
+	if len(catatan) > 500 {
+		return nil, apperrors.NewValidationError("catatan maksimal 500 karakter", nil)
+	}
+
 	return &dto.TusModulUploadInitRequest{
 		Judul:     judul,
 		Deskripsi: metadataMap["deskripsi"],
+		Catatan:   catatan,
 	}, nil
 }
 
@@ -451,16 +583,16 @@ func (uc *tusModulUsecase) validateModulFileSize(fileSize int64) error {
 	return nil
 }
 
-func detectMimeType(filePath string) string {
-	file, err := os.Open(filePath)
+func detectMimeType(ctx context.Context, filePath string) string {
+	file, err := storage.DefaultBackend().Open(ctx, filePath)
 	if err != nil {
 		return "application/octet-stream"
 	}
 	defer file.Close()
 
 	buffer := make([]byte, 512)
-	n, err := file.Read(buffer)
-	if err != nil {
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
 		return "application/octet-stream"
 	}
 
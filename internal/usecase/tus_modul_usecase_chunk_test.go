@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"invento-service/internal/domain"
+	"invento-service/internal/upload"
 	"testing"
 	"time"
 
+	apperrors "invento-service/internal/errors"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -33,7 +36,7 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 				Status:         domain.UploadStatusPending,
 			}, nil).Once()
 			tusRepo.On("UpdateStatus", mock.Anything, uploadID, domain.UploadStatusUploading).Return(nil).Once()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 			seedTusModulStore(t, manager, uploadID, 10, map[string]string{"user_id": "u1"})
 			offset, err := uc.HandleModulChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader(chunk))
@@ -58,17 +61,31 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 			}
 
 			tusRepo.On("GetByID", mock.Anything, uploadID).Return(uploadObj, nil).Twice()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, fileSize, mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, fileSize, mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 			modulRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Modul")).Run(func(args mock.Arguments) {
 				m := args.Get(1).(*domain.Modul)
 				m.ID = "550e8400-e29b-41d4-a716-446655440077"
 			}).Return(nil).Once()
+			modulRepo.On("CreateRevision", mock.Anything, mock.MatchedBy(func(r *domain.ModulRevision) bool {
+				return r.ModulID == "550e8400-e29b-41d4-a716-446655440077" && r.Action == domain.ModulRevisionCreate && r.ChangedBy == "u1"
+			})).Return(nil).Once()
 			tusRepo.On("Complete", mock.Anything, uploadID, "550e8400-e29b-41d4-a716-446655440077", mock.MatchedBy(func(path string) bool { return path != "" })).Return(nil).Once()
 
+			bus := upload.NewProgressBus()
+			manager.SetProgressBus(bus)
+			events, cancel := bus.Subscribe("u1", uploadID)
+			defer cancel()
+
 			seedTusModulStore(t, manager, uploadID, fileSize, map[string]string{"user_id": "u1"})
 			offset, err := uc.HandleModulChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader([]byte("done")))
 			require.NoError(t, err)
 			assert.Equal(t, fileSize, offset)
+
+			require.Len(t, events, 2)
+			progress := <-events
+			assert.Equal(t, fileSize, progress.Offset)
+			assert.Equal(t, upload.ProgressKindModul, progress.Kind)
+			assert.Equal(t, domain.UploadStatusCompleted, (<-events).Status)
 		})
 
 		t.Run("not found", func(t *testing.T) {
@@ -100,6 +117,17 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), "sudah selesai")
 		})
+
+		t.Run("expired upload is gone", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, _ := newTusModulTestDeps(t)
+			tusRepo.On("GetByID", mock.Anything, "id").Return(&domain.TusModulUpload{ID: "id", UserID: "u1", Status: domain.UploadStatusExpired}, nil).Once()
+
+			_, err := uc.HandleModulChunk(context.Background(), "id", "u1", 0, bytes.NewReader([]byte("x")))
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.ErrTusExpired, appErr.Code)
+		})
 	})
 
 	t.Run("HandleModulUpdateChunk", func(t *testing.T) {
@@ -121,7 +149,7 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 				Status:         domain.UploadStatusPending,
 			}, nil).Once()
 			tusRepo.On("UpdateStatus", mock.Anything, uploadID, domain.UploadStatusUploading).Return(nil).Once()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(3), mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(3), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 			seedTusModulStore(t, manager, uploadID, 8, map[string]string{"user_id": "u1", "modul_id": modulID})
 			offset, err := uc.HandleModulUpdateChunk(context.Background(), modulID, uploadID, "u1", 0, bytes.NewReader([]byte("abc")))
@@ -132,6 +160,7 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 		t.Run("auto completion", func(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, modulRepo, manager := newTusModulTestDeps(t)
+			uc.config.Upload.ModulRevisionRetention = 1
 			modulID := "550e8400-e29b-41d4-a716-446655440011"
 			uploadID := "update-complete"
 
@@ -147,15 +176,23 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 			}
 
 			tusRepo.On("GetByID", mock.Anything, uploadID).Return(uploadObj, nil).Twice()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 			modulRepo.On("GetByID", mock.Anything, modulID).Return(&domain.Modul{ID: modulID, UserID: "u1", FilePath: ""}, nil).Once()
+			modulRepo.On("GetRevisions", mock.Anything, modulID).Return([]domain.ModulRevision{{ID: 1, Revision: 1}}, nil).Once()
 			modulRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Modul")).Return(nil).Once()
+			modulRepo.On("CreateRevision", mock.Anything, mock.MatchedBy(func(r *domain.ModulRevision) bool {
+				return r.Action == domain.ModulRevisionFile && r.Judul == "rev" && len(r.Changes) > 0
+			})).Return(nil).Once()
+			// Only the new revision is retained.
+			modulRepo.On("GetRevisions", mock.Anything, modulID).Return([]domain.ModulRevision{{ID: 2, Revision: 2}, {ID: 1, Revision: 1}}, nil).Once()
+			modulRepo.On("DeleteRevisions", mock.Anything, []uint{1}).Return(nil).Once()
 			tusRepo.On("Complete", mock.Anything, uploadID, modulID, mock.MatchedBy(func(path string) bool { return path != "" })).Return(nil).Once()
 
 			seedTusModulStore(t, manager, uploadID, 4, map[string]string{"user_id": "u1", "modul_id": modulID})
 			offset, err := uc.HandleModulUpdateChunk(context.Background(), modulID, uploadID, "u1", 0, bytes.NewReader([]byte("done")))
 			require.NoError(t, err)
 			assert.Equal(t, int64(4), offset)
+			modulRepo.AssertExpectations(t)
 		})
 
 		t.Run("not found", func(t *testing.T) {
@@ -240,7 +277,7 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 				FileSize:      14,
 			}, nil).Once()
 
-			offset, length, err := uc.GetModulUpdateUploadStatus(context.Background(), modulID, "update-status-id", "u1")
+			offset, length, _, err := uc.GetModulUpdateUploadStatus(context.Background(), modulID, "update-status-id", "u1")
 			require.NoError(t, err)
 			assert.Equal(t, int64(7), offset)
 			assert.Equal(t, int64(14), length)
@@ -251,7 +288,7 @@ func TestTusModulUsecase_ChunkAndUpdate(t *testing.T) {
 			uc, tusRepo, _, _ := newTusModulTestDeps(t)
 			tusRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
 
-			offset, length, err := uc.GetModulUpdateUploadStatus(context.Background(), "550e8400-e29b-41d4-a716-446655440032", "missing", "u1")
+			offset, length, _, err := uc.GetModulUpdateUploadStatus(context.Background(), "550e8400-e29b-41d4-a716-446655440032", "missing", "u1")
 			require.Error(t, err)
 			assert.Equal(t, int64(0), offset)
 			assert.Equal(t, int64(0), length)
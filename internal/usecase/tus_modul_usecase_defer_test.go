@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/upload"
+	"testing"
+
+	dto "invento-service/internal/dto"
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTusModulUsecase_DeferredLength(t *testing.T) {
+	t.Parallel()
+
+	t.Run("initiate without a length", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, manager := newTusModulTestDeps(t)
+		tusRepo.On("CountActiveByUserID", mock.Anything, "u1").Return(int64(0), nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.TusModulUpload) bool {
+			return u.LengthDeferred && u.FileSize == 0
+		})).Return(nil).Once()
+
+		res, err := uc.InitiateModulUpload(context.Background(), "u1", upload.DeferredLength, modulMetadataHeader("modul-a", "deskripsi modul"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), res.Length)
+
+		info, err := manager.GetUploadInfo(res.UploadID)
+		require.NoError(t, err)
+		assert.True(t, info.SizeIsDeferred)
+		tusRepo.AssertExpectations(t)
+	})
+
+	t.Run("declared length completes on the last chunk", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, modulRepo, manager := newTusModulTestDeps(t)
+		uploadID := "modul-deferred"
+		deferred := &domain.TusModulUpload{
+			ID:             uploadID,
+			UserID:         "u1",
+			UploadType:     domain.UploadTypeModulCreate,
+			UploadMetadata: domain.TusModulUploadMetadata{Judul: "filex", Deskripsi: "desc"},
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}
+		declared := *deferred
+		declared.LengthDeferred = false
+		declared.FileSize = 4
+
+		tusRepo.On("GetByID", mock.Anything, uploadID).Return(deferred, nil).Once()
+		tusRepo.On("DeclareLength", mock.Anything, uploadID, int64(4)).Return(nil).Once()
+		tusRepo.On("GetByID", mock.Anything, uploadID).Return(&declared, nil).Once()
+		tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), float64(100), mock.AnythingOfType("time.Time")).Return(nil).Once()
+		modulRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Modul")).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Modul).ID = "550e8400-e29b-41d4-a716-446655440078"
+		}).Return(nil).Once()
+		modulRepo.On("CreateRevision", mock.Anything, mock.AnythingOfType("*domain.ModulRevision")).Return(nil).Once()
+		tusRepo.On("Complete", mock.Anything, uploadID, "550e8400-e29b-41d4-a716-446655440078", mock.AnythingOfType("string")).Return(nil).Once()
+
+		seedTusModulStore(t, manager, uploadID, upload.DeferredLength, map[string]string{"user_id": "u1"})
+		require.NoError(t, uc.DeclareModulUploadLength(context.Background(), uploadID, "u1", 4))
+
+		offset, err := uc.HandleModulChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader([]byte("done")))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), offset)
+		tusRepo.AssertExpectations(t)
+		modulRepo.AssertExpectations(t)
+	})
+
+	t.Run("declare length over the modul limit", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusModulTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "modul-deferred").Return(&domain.TusModulUpload{
+			ID:             "modul-deferred",
+			UserID:         "u1",
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+
+		err := uc.DeclareModulUploadLength(context.Background(), "modul-deferred", "u1", uc.config.Upload.MaxSizeModul+1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ukuran file melebihi batas maksimal")
+		tusRepo.AssertNotCalled(t, "DeclareLength", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("declare length over the quota", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusModulTestDeps(t)
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		tusRepo.On("GetByID", mock.Anything, "modul-deferred").Return(&domain.TusModulUpload{
+			ID:             "modul-deferred",
+			UserID:         "u1",
+			UploadType:     domain.UploadTypeModulCreate,
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 64}).
+			Return(apperrors.NewQuotaExceededError("")).Once()
+
+		err := uc.DeclareModulUploadLength(context.Background(), "modul-deferred", "u1", 64)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrQuotaExceeded, appErr.Code)
+		tusRepo.AssertNotCalled(t, "DeclareLength", mock.Anything, mock.Anything, mock.Anything)
+		quota.AssertExpectations(t)
+	})
+}
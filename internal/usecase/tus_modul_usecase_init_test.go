@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"invento-service/internal/domain"
+	"invento-service/internal/dto"
 	"invento-service/internal/storage"
 	"invento-service/internal/upload"
 	"path/filepath"
@@ -46,7 +47,7 @@ func newTusModulTestDeps(t *testing.T) (*tusModulUsecase, *MockTusModulUploadRep
 	tusManager := upload.NewTusManager(tusStore, tusQueue, nil, cfg, zerolog.Nop())
 	fileManager := storage.NewFileManager(cfg)
 
-	uc := NewTusModulUsecase(mockTusModulRepo, mockModulRepo, tusManager, fileManager, cfg).(*tusModulUsecase)
+	uc := NewTusModulUsecase(mockTusModulRepo, mockModulRepo, tusManager, fileManager, nil, cfg).(*tusModulUsecase)
 	return uc, mockTusModulRepo, mockModulRepo, tusManager
 }
 
@@ -189,7 +190,7 @@ func TestTusModulUsecase_InitAndStatus(t *testing.T) {
 			uc, tusRepo, _, _ := newTusModulTestDeps(t)
 			tusRepo.On("GetByID", mock.Anything, "status-id").Return(&domain.TusModulUpload{ID: "status-id", UserID: "u1", CurrentOffset: 4, FileSize: 12}, nil).Once()
 
-			offset, length, err := uc.GetModulUploadStatus(context.Background(), "status-id", "u1")
+			offset, length, _, err := uc.GetModulUploadStatus(context.Background(), "status-id", "u1")
 			require.NoError(t, err)
 			assert.Equal(t, int64(4), offset)
 			assert.Equal(t, int64(12), length)
@@ -200,7 +201,7 @@ func TestTusModulUsecase_InitAndStatus(t *testing.T) {
 			uc, tusRepo, _, _ := newTusModulTestDeps(t)
 			tusRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
 
-			offset, length, err := uc.GetModulUploadStatus(context.Background(), "missing", "u1")
+			offset, length, _, err := uc.GetModulUploadStatus(context.Background(), "missing", "u1")
 			require.Error(t, err)
 			assert.Equal(t, int64(0), offset)
 			assert.Equal(t, int64(0), length)
@@ -310,6 +311,68 @@ func TestTusModulUsecase_InitiateModulUpload_RepoCreateError(t *testing.T) {
 	tusRepo.AssertExpectations(t)
 }
 
+func TestTusModulUsecase_InitiateModulUpload_Quota(t *testing.T) {
+	t.Parallel()
+	meta := modulMetadataHeader("modul-a", "deskripsi")
+
+	t.Run("payload larger than the quota", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusModulTestDeps(t)
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		tusRepo.On("CountActiveByUserID", mock.Anything, "u1").Return(int64(0), nil).Once()
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 1024, NewFile: true}).
+			Return(apperrors.NewPayloadTooLargeError("")).Once()
+
+		res, err := uc.InitiateModulUpload(context.Background(), "u1", 1024, meta)
+		assert.Nil(t, res)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrPayloadTooLarge, appErr.Code)
+		tusRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update counts the file it replaces while history keeps it", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, modulRepo, _ := newTusModulTestDeps(t)
+		uc.config.Upload.ModulRevisionRetention = 2
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		modulRepo.On("GetByID", mock.Anything, "m1").Return(&domain.Modul{ID: "m1", UserID: "u1", FilePath: "/m/b.pdf", FileSize: 2048}, nil)
+		modulRepo.On("GetRevisions", mock.Anything, "m1").Return([]domain.ModulRevision{}, nil)
+		tusRepo.On("CountActiveByUserID", mock.Anything, "u1").Return(int64(0), nil).Once()
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 1024}).Return(nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusModulUpload")).Return(nil).Once()
+
+		res, err := uc.InitiateModulUpdateUpload(context.Background(), "m1", "u1", 1024, meta)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		quota.AssertExpectations(t)
+	})
+
+	t.Run("update does not count the revisions it prunes", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, modulRepo, _ := newTusModulTestDeps(t)
+		uc.config.Upload.ModulRevisionRetention = 1
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		modulRepo.On("GetByID", mock.Anything, "m1").Return(&domain.Modul{ID: "m1", UserID: "u1", FilePath: "/m/b.pdf", FileSize: 2048}, nil)
+		modulRepo.On("GetRevisions", mock.Anything, "m1").Return([]domain.ModulRevision{
+			{FilePath: "/m/b.pdf", FileSize: 2048},
+			{FilePath: "/m/b.pdf", FileSize: 2048},
+			{FilePath: "/m/a.pdf", FileSize: 512},
+		}, nil)
+		tusRepo.On("CountActiveByUserID", mock.Anything, "u1").Return(int64(0), nil).Once()
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 1024, FreedBytes: 2560}).Return(nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusModulUpload")).Return(nil).Once()
+
+		res, err := uc.InitiateModulUpdateUpload(context.Background(), "m1", "u1", 1024, meta)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		quota.AssertExpectations(t)
+	})
+}
+
 func TestTusModulUsecase_CheckModulUploadSlot_RepoError(t *testing.T) {
 	t.Parallel()
 	uc, tusRepo, _, _ := newTusModulTestDeps(t)
@@ -24,40 +24,50 @@ type TusUploadUsecase interface {
 	ResetUploadQueue(ctx context.Context, userID string) error
 	InitiateUpload(ctx context.Context, userID, userEmail, userRole string, fileSize int64, metadata dto.TusUploadInitRequest) (*dto.TusUploadResponse, error)
 	HandleChunk(ctx context.Context, uploadID, userID string, offset int64, chunk io.Reader) (int64, error)
+	DeclareUploadLength(ctx context.Context, uploadID, userID string, length int64) error
 	GetUploadInfo(ctx context.Context, uploadID, userID string) (*dto.TusUploadInfoResponse, error)
 	CancelUpload(ctx context.Context, uploadID, userID string) error
-	GetUploadStatus(ctx context.Context, uploadID, userID string) (int64, int64, error)
+	GetUploadStatus(ctx context.Context, uploadID, userID string) (*dto.TusUploadStatus, error)
 	InitiateProjectUpdateUpload(ctx context.Context, projectID uint, userID string, fileSize int64, metadata dto.TusUploadInitRequest) (*dto.TusUploadResponse, error)
 	HandleProjectUpdateChunk(ctx context.Context, projectID uint, uploadID, userID string, offset int64, chunk io.Reader) (int64, error)
-	GetProjectUpdateUploadStatus(ctx context.Context, projectID uint, uploadID, userID string) (int64, int64, error)
+	GetProjectUpdateUploadStatus(ctx context.Context, projectID uint, uploadID, userID string) (*dto.TusUploadStatus, error)
 	GetProjectUpdateUploadInfo(ctx context.Context, projectID uint, uploadID, userID string) (*dto.TusUploadInfoResponse, error)
 	CancelProjectUpdateUpload(ctx context.Context, projectID uint, uploadID, userID string) error
 }
 
 type tusUploadUsecase struct {
-	tusUploadRepo  repo.TusUploadRepository
-	projectRepo    repo.ProjectRepository
-	projectUsecase ProjectUsecase
-	tusManager     *upload.TusManager
-	fileManager    *storage.FileManager
-	config         *config.Config
+	tusUploadRepo     repo.TusUploadRepository
+	projectRepo       repo.ProjectRepository
+	projectUsecase    ProjectUsecase
+	tusManager        *upload.TusManager
+	fileManager       *storage.FileManager
+	quotaUsecase      QuotaUsecase
+	assignmentUsecase AssignmentUsecase
+	config            *config.Config
 }
 
+// NewTusUploadUsecase creates the project upload usecase. A nil quotaUsecase admits
+// uploads without checking storage quotas, and a nil assignmentUsecase refuses uploads
+// that name an assignment.
 func NewTusUploadUsecase(
 	tusUploadRepo repo.TusUploadRepository,
 	projectRepo repo.ProjectRepository,
 	projectUsecase ProjectUsecase,
 	tusManager *upload.TusManager,
 	fileManager *storage.FileManager,
+	quotaUsecase QuotaUsecase,
+	assignmentUsecase AssignmentUsecase,
 	cfg *config.Config,
 ) TusUploadUsecase {
 	return &tusUploadUsecase{
-		tusUploadRepo:  tusUploadRepo,
-		projectRepo:    projectRepo,
-		projectUsecase: projectUsecase,
-		tusManager:     tusManager,
-		fileManager:    fileManager,
-		config:         cfg,
+		tusUploadRepo:     tusUploadRepo,
+		projectRepo:       projectRepo,
+		projectUsecase:    projectUsecase,
+		tusManager:        tusManager,
+		fileManager:       fileManager,
+		quotaUsecase:      quotaUsecase,
+		assignmentUsecase: assignmentUsecase,
+		config:            cfg,
 	}
 }
 
@@ -109,9 +119,6 @@ func (uc *tusUploadUsecase) InitiateProjectUpdateUpload(ctx context.Context, pro
 	if metadata.NamaProject == "" {
 		metadata.NamaProject = project.NamaProject
 	}
-	if metadata.Kategori == "" {
-		metadata.Kategori = project.Kategori
-	}
 	if metadata.Semester == 0 {
 		metadata.Semester = project.Semester
 	}
@@ -120,14 +127,30 @@ func (uc *tusUploadUsecase) InitiateProjectUpdateUpload(ctx context.Context, pro
 }
 
 func (uc *tusUploadUsecase) initiateUpload(ctx context.Context, userID string, fileSize int64, metadata dto.TusUploadInitRequest, uploadType string, projectID *uint) (*dto.TusUploadResponse, error) {
+	if err := uc.checkQuota(ctx, userID, fileSize, metadata, projectID); err != nil {
+		return nil, err
+	}
+
+	if metadata.ConcatPartial {
+		return uc.initiatePartialUpload(ctx, userID, fileSize)
+	}
+	if len(metadata.ConcatParts) > 0 {
+		return uc.concatenateUploads(ctx, userID, metadata, uploadType, projectID)
+	}
+
 	if fileSize > uc.config.Upload.MaxSizeProject {
 		return nil, apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas maksimal %d MB", uc.config.Upload.MaxSizeProject/(1024*1024)))
 	}
 
-	if fileSize <= 0 {
+	deferred := fileSize == upload.DeferredLength
+	if fileSize <= 0 && !deferred {
 		return nil, apperrors.NewValidationError("ukuran file tidak valid", nil)
 	}
 
+	if err := uc.checkAssignment(ctx, userID, metadata.AssignmentID, fileSize); err != nil {
+		return nil, err
+	}
+
 	existingUploads, err := uc.tusUploadRepo.GetActiveByUserID(ctx, userID)
 	if err == nil {
 		for _, existing := range existingUploads {
@@ -139,10 +162,6 @@ func (uc *tusUploadUsecase) initiateUpload(ctx context.Context, userID string, f
 		}
 	}
 
-	if !uc.tusManager.CanAcceptUpload() {
-		return nil, apperrors.NewConflictError("slot upload tidak tersedia, silakan coba lagi nanti")
-	}
-
 	uploadID := uuid.New().String()
 	expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
 	uploadURL := fmt.Sprintf("/project/upload/%s", uploadID)
@@ -157,21 +176,123 @@ func (uc *tusUploadUsecase) initiateUpload(ctx context.Context, userID string, f
 		UploadType: uploadType,
 		UploadURL:  uploadURL,
 		UploadMetadata: domain.TusUploadMetadata{
-			NamaProject: metadata.NamaProject,
-			Kategori:    metadata.Kategori,
-			Semester:    metadata.Semester,
+			NamaProject:  metadata.NamaProject,
+			Kategori:     metadata.Kategori,
+			Semester:     metadata.Semester,
+			Catatan:      metadata.Catatan,
+			AssignmentID: metadata.AssignmentID,
 		},
-		FileSize:      fileSize,
-		CurrentOffset: 0,
-		Status:        domain.UploadStatusPending,
-		Progress:      0,
-		ExpiresAt:     expiresAt,
+		FileSize:       max(fileSize, 0),
+		LengthDeferred: deferred,
+		CurrentOffset:  0,
+		Status:         domain.UploadStatusPending,
+		Progress:       0,
+		ExpiresAt:      expiresAt,
+	}
+
+	return uc.admitUpload(ctx, tusUpload, projectMetadataMap(userID, metadata, projectID))
+}
+
+// checkQuota applies the user's storage quota before an upload is created. A slice of
+// a concatenated upload adds bytes but no file, while the final concatenation adds a
+// file whose bytes were already counted in its slices. An update adds no file, and
+// what its pruning deletes is not counted. A length-deferred upload is only checked
+// against the space already used until its length is declared.
+func (uc *tusUploadUsecase) checkQuota(ctx context.Context, userID string, fileSize int64, metadata dto.TusUploadInitRequest, projectID *uint) error {
+	check := dto.QuotaCheck{Bytes: max(fileSize, 0), NewFile: projectID == nil}
+	switch {
+	case metadata.ConcatPartial:
+		check.NewFile = false
+	case len(metadata.ConcatParts) > 0:
+		check.Bytes = 0
+	}
+	return uc.checkQuotaReplacing(ctx, userID, check, projectID)
+}
+
+// checkQuotaReplacing runs check against the user's quota. For an update of projectID
+// it leaves out the files that pruning will delete once the new version is stored.
+func (uc *tusUploadUsecase) checkQuotaReplacing(ctx context.Context, userID string, check dto.QuotaCheck, projectID *uint) error {
+	if uc.quotaUsecase == nil {
+		return nil
+	}
+
+	if projectID != nil {
+		project, err := uc.projectRepo.GetByID(ctx, *projectID)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrRecordNotFound) {
+				return apperrors.NewNotFoundError("Project")
+			}
+			return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.checkQuota: %w", err))
+		}
+		check.FreedBytes, err = prunedVersionBytes(ctx, uc.projectRepo, project, uc.config.Upload.ProjectVersionRetention)
+		if err != nil {
+			return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.checkQuota: versions: %w", err))
+		}
+	}
+
+	return uc.quotaUsecase.CheckUpload(ctx, userID, check)
+}
+
+// checkAssignment admits the upload as a submission for assignmentID, when one is given.
+func (uc *tusUploadUsecase) checkAssignment(ctx context.Context, userID string, assignmentID *uint, fileSize int64) error {
+	if assignmentID == nil {
+		return nil
+	}
+	if uc.assignmentUsecase == nil {
+		return apperrors.NewValidationError("pengumpulan tugas tidak tersedia", nil)
+	}
+	return uc.assignmentUsecase.CheckSubmission(ctx, *assignmentID, userID, fileSize)
+}
+
+// admitUpload stores tusUpload, prepares its file and places it in the upload queue.
+func (uc *tusUploadUsecase) admitUpload(ctx context.Context, tusUpload *domain.TusUpload, metadataMap map[string]string) (*dto.TusUploadResponse, error) {
+	uploadID := tusUpload.ID
+	if !uc.tusManager.CanAcceptUpload() {
+		tusUpload.Status = domain.UploadStatusQueued
 	}
 
 	if err := uc.tusUploadRepo.Create(ctx, tusUpload); err != nil {
 		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiateUpload: create record: %w", err))
 	}
 
+	fileSize := tusUpload.FileSize
+	if tusUpload.LengthDeferred {
+		fileSize = upload.DeferredLength
+	}
+	if err := uc.tusManager.InitiateUpload(uploadID, fileSize, metadataMap); err != nil {
+		_ = uc.tusUploadRepo.Delete(ctx, uploadID)
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiateUpload: init storage: %w", err))
+	}
+
+	uc.tusManager.AddToQueue(uploadID)
+
+	// The slot check above can race with other requests, so the queue has the final say.
+	queuePosition := uc.tusManager.GetQueuePosition(uploadID)
+	status := domain.UploadStatusPending
+	if queuePosition > 0 {
+		status = domain.UploadStatusQueued
+	}
+	if status != tusUpload.Status {
+		if err := uc.tusUploadRepo.UpdateStatus(ctx, uploadID, status); err != nil {
+			return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiateUpload: sync queue status: %w", err))
+		}
+	}
+
+	tusUpload.Status = status
+	uc.publishProgress(tusUpload)
+
+	return &dto.TusUploadResponse{
+		UploadID:      uploadID,
+		UploadURL:     tusUpload.UploadURL,
+		Offset:        0,
+		Length:        tusUpload.FileSize,
+		Status:        status,
+		QueuePosition: max(queuePosition, 0),
+		ExpiresAt:     tusUpload.ExpiresAt,
+	}, nil
+}
+
+func projectMetadataMap(userID string, metadata dto.TusUploadInitRequest, projectID *uint) map[string]string {
 	metadataMap := map[string]string{
 		"nama_project": metadata.NamaProject,
 		"kategori":     metadata.Kategori,
@@ -181,19 +302,186 @@ func (uc *tusUploadUsecase) initiateUpload(ctx context.Context, userID string, f
 	if projectID != nil {
 		metadataMap["project_id"] = fmt.Sprintf("%d", *projectID)
 	}
+	return metadataMap
+}
 
-	if err := uc.tusManager.InitiateUpload(uploadID, fileSize, metadataMap); err != nil {
+// initiatePartialUpload opens one slice of a concatenated project upload. Partials
+// bypass the upload queue so the slices of one file can be sent in parallel; instead a
+// user may have at most upload.MaxConcatParts of them open, together within the project
+// size limit.
+func (uc *tusUploadUsecase) initiatePartialUpload(ctx context.Context, userID string, fileSize int64) (*dto.TusUploadResponse, error) {
+	if fileSize <= 0 {
+		return nil, apperrors.NewValidationError("ukuran file tidak valid", nil)
+	}
+
+	existingUploads, err := uc.tusUploadRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiatePartialUpload: %w", err))
+	}
+
+	openParts := 0
+	totalSize := fileSize
+	for _, existing := range existingUploads {
+		active := existing.Status == domain.UploadStatusPending ||
+			existing.Status == domain.UploadStatusUploading ||
+			existing.Status == domain.UploadStatusQueued
+		if existing.UploadType != domain.UploadTypeProjectPartial {
+			if active {
+				return nil, apperrors.NewConflictError("anda sudah memiliki upload yang sedang berjalan, selesaikan atau batalkan terlebih dahulu")
+			}
+			continue
+		}
+		if active || (existing.Status == domain.UploadStatusCompleted && existing.FinalUploadID == nil) {
+			openParts++
+			totalSize += existing.FileSize
+		}
+	}
+
+	if openParts >= upload.MaxConcatParts {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("maksimal %d partial upload dapat berjalan bersamaan", upload.MaxConcatParts))
+	}
+	if totalSize > uc.config.Upload.MaxSizeProject {
+		return nil, apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas maksimal %d MB", uc.config.Upload.MaxSizeProject/(1024*1024)))
+	}
+
+	uploadID := uuid.New().String()
+	tusUpload := &domain.TusUpload{
+		ID:         uploadID,
+		UserID:     userID,
+		UploadType: domain.UploadTypeProjectPartial,
+		UploadURL:  fmt.Sprintf("/project/upload/%s", uploadID),
+		FileSize:   fileSize,
+		Status:     domain.UploadStatusPending,
+		ExpiresAt:  time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second),
+	}
+
+	if err := uc.tusUploadRepo.Create(ctx, tusUpload); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiatePartialUpload: create record: %w", err))
+	}
+	if err := uc.tusManager.InitiateUpload(uploadID, fileSize, map[string]string{"user_id": userID}); err != nil {
 		_ = uc.tusUploadRepo.Delete(ctx, uploadID)
-		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiateUpload: init storage: %w", err))
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.initiatePartialUpload: init storage: %w", err))
+	}
+	uc.publishProgress(tusUpload)
+
+	return &dto.TusUploadResponse{
+		UploadID:  uploadID,
+		UploadURL: tusUpload.UploadURL,
+		Offset:    0,
+		Length:    fileSize,
+		Status:    tusUpload.Status,
+		ExpiresAt: tusUpload.ExpiresAt,
+	}, nil
+}
+
+// concatenateUploads assembles the completed partial uploads listed in metadata into
+// a final upload and completes it straight away, so the project is created or updated
+// in the same request.
+func (uc *tusUploadUsecase) concatenateUploads(ctx context.Context, userID string, metadata dto.TusUploadInitRequest, uploadType string, projectID *uint) (*dto.TusUploadResponse, error) {
+	if len(metadata.ConcatParts) > upload.MaxConcatParts {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("maksimal %d partial upload dapat digabungkan", upload.MaxConcatParts), nil)
+	}
+
+	var totalSize int64
+	for _, partID := range metadata.ConcatParts {
+		part, err := uc.tusUploadRepo.GetByID(ctx, partID)
+		if err != nil {
+			return nil, apperrors.NewNotFoundError("Upload")
+		}
+		if part.UserID != userID {
+			return nil, apperrors.NewForbiddenError("Anda tidak memiliki akses ke upload ini")
+		}
+		if part.UploadType != domain.UploadTypeProjectPartial {
+			return nil, apperrors.NewValidationError("upload "+partID+" bukan partial upload", nil)
+		}
+		if part.Status != domain.UploadStatusCompleted || part.FinalUploadID != nil {
+			return nil, apperrors.NewConflictError("partial upload " + partID + " belum selesai atau sudah digunakan")
+		}
+		totalSize += part.FileSize
+	}
+
+	if totalSize > uc.config.Upload.MaxSizeProject {
+		return nil, apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas maksimal %d MB", uc.config.Upload.MaxSizeProject/(1024*1024)))
+	}
+
+	if err := uc.checkAssignment(ctx, userID, metadata.AssignmentID, totalSize); err != nil {
+		return nil, err
+	}
+
+	uploadID := uuid.New().String()
+	uploadURL := fmt.Sprintf("/project/upload/%s", uploadID)
+	if uploadType == domain.UploadTypeProjectUpdate && projectID != nil {
+		uploadURL = fmt.Sprintf("/project/%d/update/%s", *projectID, uploadID)
+	}
+
+	tusUpload := &domain.TusUpload{
+		ID:         uploadID,
+		UserID:     userID,
+		ProjectID:  projectID,
+		UploadType: uploadType,
+		UploadURL:  uploadURL,
+		UploadMetadata: domain.TusUploadMetadata{
+			NamaProject:  metadata.NamaProject,
+			Kategori:     metadata.Kategori,
+			Semester:     metadata.Semester,
+			Catatan:      metadata.Catatan,
+			AssignmentID: metadata.AssignmentID,
+			ConcatParts:  metadata.ConcatParts,
+		},
+		FileSize:      totalSize,
+		CurrentOffset: totalSize,
+		Status:        domain.UploadStatusUploading,
+		Progress:      100,
+		ExpiresAt:     time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second),
 	}
 
+	if err := uc.tusUploadRepo.Create(ctx, tusUpload); err != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.concatenateUploads: create record: %w", err))
+	}
+
+	// The parts are combined right away, so the final upload needs a slot now rather
+	// than a place in line.
 	uc.tusManager.AddToQueue(uploadID)
+	if uc.tusManager.GetQueuePosition(uploadID) != 0 {
+		_ = uc.tusManager.RemoveFromQueue(uploadID)
+		_ = uc.tusUploadRepo.Delete(ctx, uploadID)
+		return nil, apperrors.NewConflictError("server sedang memproses upload lain, coba lagi beberapa saat lagi")
+	}
+	abandon := func() {
+		_ = uc.tusUploadRepo.Delete(ctx, uploadID)
+		uc.releaseSlot(ctx, uploadID)
+	}
+
+	// Another final upload may have taken the same parts since they were checked above.
+	claimed, err := uc.tusUploadRepo.ClaimPartials(ctx, uploadID, userID, metadata.ConcatParts)
+	if err != nil || !claimed {
+		abandon()
+		if err != nil {
+			return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.concatenateUploads: claim parts: %w", err))
+		}
+		return nil, apperrors.NewConflictError("partial upload sudah digunakan oleh upload lain")
+	}
+
+	if _, err := uc.tusManager.ConcatenateUploads(uploadID, metadata.ConcatParts, projectMetadataMap(userID, metadata, projectID)); err != nil {
+		if releaseErr := uc.tusUploadRepo.ReleasePartials(ctx, uploadID); releaseErr != nil {
+			zlog.Warn().Err(releaseErr).Str("upload_id", uploadID).Msg("TusUploadUsecase.concatenateUploads: failed to release partial uploads")
+		}
+		abandon()
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.concatenateUploads: %w", err))
+	}
+
+	if err := uc.completeUpload(ctx, tusUpload); err != nil {
+		return nil, err
+	}
+	tusUpload.Status = domain.UploadStatusCompleted
+	uc.publishProgress(tusUpload)
 
 	return &dto.TusUploadResponse{
 		UploadID:  uploadID,
 		UploadURL: uploadURL,
-		Offset:    0,
-		Length:    fileSize,
+		Offset:    totalSize,
+		Length:    totalSize,
+		Status:    domain.UploadStatusCompleted,
 	}, nil
 }
 
@@ -206,48 +494,124 @@ func (uc *tusUploadUsecase) HandleProjectUpdateChunk(ctx context.Context, projec
 }
 
 func (uc *tusUploadUsecase) handleChunk(ctx context.Context, uploadID, userID string, offset int64, chunk io.Reader, projectID *uint) (int64, error) {
-	upload, err := uc.getOwnedUpload(ctx, uploadID, userID, projectID)
+	tusUpload, err := uc.getOwnedUpload(ctx, uploadID, userID, projectID)
 	if err != nil {
 		return 0, err
 	}
 
-	if upload.Status == domain.UploadStatusCompleted {
-		return upload.FileSize, apperrors.NewTusCompletedError()
+	if tusUpload.Status == domain.UploadStatusCompleted {
+		return tusUpload.FileSize, apperrors.NewTusCompletedError()
 	}
 
-	if upload.Status == domain.UploadStatusCancelled || upload.Status == domain.UploadStatusFailed {
+	if tusUpload.Status == domain.UploadStatusCancelled || tusUpload.Status == domain.UploadStatusFailed {
 		return 0, apperrors.NewTusInactiveError()
 	}
 
+	if tusUpload.Status == domain.UploadStatusQueued {
+		if !uc.tusManager.IsActiveUpload(uploadID) {
+			return tusUpload.CurrentOffset, apperrors.NewTusInactiveError()
+		}
+		// The queue handed this upload a slot but marking it active in the database
+		// failed; catch the record up instead of leaving the slot unusable.
+		expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
+		if err := uc.tusUploadRepo.Promote(ctx, uploadID, expiresAt); err != nil {
+			return tusUpload.CurrentOffset, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.handleChunk: promote: %w", err))
+		}
+		tusUpload.Status = domain.UploadStatusPending
+		tusUpload.ExpiresAt = expiresAt
+	}
+
+	if tusUpload.LengthDeferred {
+		chunk = upload.NewLengthLimitReader(chunk, uc.config.Upload.MaxSizeProject-offset)
+	}
+
 	newOffset, err := uc.tusManager.HandleChunk(uploadID, offset, chunk)
 	if err != nil {
 		return offset, err
 	}
 
-	if upload.Status == domain.UploadStatusPending && newOffset > 0 {
+	if tusUpload.Status == domain.UploadStatusPending && newOffset > 0 {
 		if err := uc.tusUploadRepo.UpdateStatus(ctx, uploadID, domain.UploadStatusUploading); err != nil {
 			return newOffset, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.handleChunk: update status: %w", err))
 		}
-		upload.Status = domain.UploadStatusUploading
+		tusUpload.Status = domain.UploadStatusUploading
 	}
 
-	progress := (float64(newOffset) / float64(upload.FileSize)) * 100
-	if err := uc.tusUploadRepo.UpdateOffset(ctx, uploadID, newOffset, progress); err != nil {
+	var progress float64
+	if !tusUpload.LengthDeferred {
+		progress = (float64(newOffset) / float64(tusUpload.FileSize)) * 100
+	}
+	expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
+	if err := uc.tusUploadRepo.UpdateOffset(ctx, uploadID, newOffset, progress, expiresAt); err != nil {
 		return newOffset, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.handleChunk: update offset: %w", err))
 	}
 
-	upload.CurrentOffset = newOffset
-	upload.Progress = progress
-	if newOffset >= upload.FileSize {
-		if err := uc.completeUpload(ctx, upload); err != nil {
+	tusUpload.CurrentOffset = newOffset
+	tusUpload.Progress = progress
+	tusUpload.ExpiresAt = expiresAt
+	uc.publishProgress(tusUpload)
+	if !tusUpload.LengthDeferred && newOffset >= tusUpload.FileSize {
+		if err := uc.completeUpload(ctx, tusUpload); err != nil {
 			return newOffset, err
 		}
+		tusUpload.Status = domain.UploadStatusCompleted
+		uc.publishProgress(tusUpload)
 	}
 
 	return newOffset, nil
 }
 
+// DeclareUploadLength fixes the size of an upload started with Upload-Defer-Length. For
+// an upload whose size is already known it only checks that the sizes agree.
+func (uc *tusUploadUsecase) DeclareUploadLength(ctx context.Context, uploadID, userID string, length int64) error {
+	tusUpload, err := uc.getOwnedUpload(ctx, uploadID, userID, nil)
+	if err != nil {
+		return err
+	}
+
+	if !tusUpload.LengthDeferred {
+		if length != tusUpload.FileSize {
+			return apperrors.NewValidationError("Upload-Length tidak sesuai dengan ukuran upload", nil)
+		}
+		return nil
+	}
+
+	if length > uc.config.Upload.MaxSizeProject {
+		return apperrors.NewPayloadTooLargeError(fmt.Sprintf("ukuran file melebihi batas maksimal %d MB", uc.config.Upload.MaxSizeProject/(1024*1024)))
+	}
+
+	// The upload already counts as a file; its bytes are what is new.
+	if err := uc.checkQuotaReplacing(ctx, userID, dto.QuotaCheck{Bytes: length}, tusUpload.ProjectID); err != nil {
+		return err
+	}
+
+	if err := uc.tusManager.DeclareUploadLength(uploadID, length); err != nil {
+		return err
+	}
+
+	if err := uc.tusUploadRepo.DeclareLength(ctx, uploadID, length); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.DeclareUploadLength: %w", err))
+	}
+
+	return nil
+}
+
 func (uc *tusUploadUsecase) completeUpload(ctx context.Context, upload *domain.TusUpload) error {
+	if upload.UploadType == domain.UploadTypeProjectPartial {
+		return uc.completePartialUpload(ctx, upload)
+	}
+
+	inspection, err := uc.inspectArchive(ctx, upload)
+	if err != nil {
+		return err
+	}
+	detection := uc.classifyArchive(upload)
+
+	checksum, err := storage.FileSHA256(uc.tusManager.GetUploadFilePath(upload.ID))
+	if err != nil {
+		zlog.Warn().Err(err).Str("upload_id", upload.ID).Msg("TusUploadUsecase.completeUpload: failed to compute checksum")
+	}
+
 	randomDir, err := uc.fileManager.GenerateRandomDirectory()
 	if err != nil {
 		return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeUpload: generate dir: %w", err))
@@ -258,79 +622,299 @@ func (uc *tusUploadUsecase) completeUpload(ctx context.Context, upload *domain.T
 		return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeUpload: finalize: %w", err))
 	}
 
-	var projectID uint
+	var (
+		project *domain.Project
+		undo    func() error
+	)
 	switch upload.UploadType {
 	case domain.UploadTypeProjectCreate:
-		projectID, err = uc.completeProjectCreate(ctx, upload, finalFilePath)
-		if err != nil {
-			return err
-		}
+		project, undo, err = uc.completeProjectCreate(ctx, upload, finalFilePath, detection)
 	case domain.UploadTypeProjectUpdate:
-		projectID, err = uc.completeProjectUpdate(ctx, upload, finalFilePath)
-		if err != nil {
-			return err
-		}
+		project, undo, err = uc.completeProjectUpdate(ctx, upload, finalFilePath, detection)
 	default:
-		return apperrors.NewValidationError("tipe upload tidak didukung", nil)
+		err = apperrors.NewValidationError("tipe upload tidak didukung", nil)
+	}
+	if err != nil {
+		return uc.abandonCompletion(ctx, upload, finalFilePath, "gagal menyimpan project", err)
+	}
+	projectID := project.ID
+
+	version := &domain.ProjectVersion{
+		ProjectID:  projectID,
+		PathFile:   finalFilePath,
+		FileSize:   upload.FileSize,
+		Checksum:   checksum,
+		UploadedBy: upload.UserID,
+		Catatan:    upload.UploadMetadata.Catatan,
+	}
+
+	// The submission is recorded before anything else hangs off the project write, so
+	// undoing that write is all a failed submission has to take back.
+	if assignmentID := upload.UploadMetadata.AssignmentID; assignmentID != nil && uc.assignmentUsecase != nil {
+		if err := uc.assignmentUsecase.RecordSubmission(ctx, *assignmentID, upload.UserID, version); err != nil {
+			discard := finalFilePath
+			if undoErr := undo(); undoErr != nil {
+				// The project still points at the new file, so the file has to stay.
+				zlog.Error().Err(undoErr).Uint("project_id", projectID).Msg("TusUploadUsecase.completeUpload: failed to undo project write")
+				discard = ""
+			}
+			return uc.abandonCompletion(ctx, upload, discard, "gagal mencatat pengumpulan tugas",
+				apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeUpload: record submission: %w", err)))
+		}
+	}
+
+	if upload.UploadType == domain.UploadTypeProjectUpdate {
+		// The new file is stored either way; only the review status lags behind it.
+		if err := resubmitProjectReview(ctx, uc.projectRepo, project, upload.UserID); err != nil {
+			zlog.Error().Err(err).Uint("project_id", projectID).Msg("TusUploadUsecase.completeUpload: failed to resubmit project review")
+		}
+	}
+
+	manifest := &domain.ProjectManifest{
+		ProjectID:        projectID,
+		EntryCount:       len(inspection.Entries),
+		UncompressedSize: inspection.UncompressedSize,
+		Entries:          inspection.Entries,
+	}
+	if err := uc.projectRepo.SaveManifest(ctx, manifest); err != nil {
+		// The project itself is stored; a missing manifest only loses the entry listing.
+		zlog.Warn().Err(err).Uint("project_id", projectID).Msg("TusUploadUsecase.completeUpload: failed to save archive manifest")
+	}
+
+	if err := recordProjectVersion(ctx, uc.projectRepo, version, uc.config.Upload.ProjectVersionRetention); err != nil {
+		// The new file is already current; only its history entry or pruning is lost.
+		zlog.Warn().Err(err).Uint("project_id", projectID).Msg("TusUploadUsecase.completeUpload: failed to record project version")
 	}
 
 	if err := uc.tusUploadRepo.Complete(ctx, upload.ID, projectID, finalFilePath); err != nil {
 		return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeUpload: complete record: %w", err))
 	}
 
-	uc.tusManager.FinishUpload(upload.ID)
+	uc.releaseSlot(ctx, upload.ID)
+	return nil
+}
+
+// abandonCompletion fails an upload whose finalized file could not become a project
+// and removes that file, unless finalFilePath is empty. It returns cause for the caller
+// to pass on.
+func (uc *tusUploadUsecase) abandonCompletion(ctx context.Context, tusUpload *domain.TusUpload, finalFilePath, reason string, cause error) error {
+	zlog.Error().Err(cause).Str("upload_id", tusUpload.ID).Str("user_id", tusUpload.UserID).Msg("TusUploadUsecase.completeUpload: upload abandoned")
+
+	if finalFilePath != "" {
+		if err := storage.DeleteFile(finalFilePath); err != nil {
+			zlog.Warn().Err(err).Str("path", finalFilePath).Msg("TusUploadUsecase.abandonCompletion: failed to delete file")
+		}
+	}
+	if err := uc.failUpload(ctx, tusUpload, reason); err != nil {
+		zlog.Error().Err(err).Str("upload_id", tusUpload.ID).Msg("TusUploadUsecase.abandonCompletion: failed to mark upload failed")
+	}
+	return cause
+}
+
+// failUpload marks tusUpload failed with reason and hands its slot to the next upload
+// in the queue.
+func (uc *tusUploadUsecase) failUpload(ctx context.Context, tusUpload *domain.TusUpload, reason string) error {
+	if err := uc.tusUploadRepo.Fail(ctx, tusUpload.ID, reason); err != nil {
+		return err
+	}
+	uc.releaseSlot(ctx, tusUpload.ID)
+
+	tusUpload.Status = domain.UploadStatusFailed
+	tusUpload.FailureReason = reason
+	uc.publishProgress(tusUpload)
+	return nil
+}
+
+// inspectArchive checks that the received bytes form a safe ZIP before they become a
+// project. A rejected upload is marked failed with the reason, its data is discarded
+// and its slot goes to the next upload in the queue.
+func (uc *tusUploadUsecase) inspectArchive(ctx context.Context, tusUpload *domain.TusUpload) (*storage.ZipInspection, error) {
+	inspection, err := storage.InspectZip(uc.tusManager.GetUploadFilePath(tusUpload.ID), storage.ZipLimits{
+		MaxUncompressedSize: uc.config.Upload.ZipMaxUncompressedSize,
+		MaxCompressionRatio: uc.config.Upload.ZipMaxCompressionRatio,
+		MaxEntries:          uc.config.Upload.ZipMaxEntries,
+	})
+	if err == nil {
+		return inspection, nil
+	}
+
+	reason := err.Error()
+	zlog.Warn().Err(err).Str("upload_id", tusUpload.ID).Str("user_id", tusUpload.UserID).Msg("project archive rejected")
+
+	if rejectErr := uc.tusManager.RejectUpload(tusUpload.ID); rejectErr != nil {
+		zlog.Warn().Err(rejectErr).Str("upload_id", tusUpload.ID).Msg("TusUploadUsecase.inspectArchive: failed to discard upload data")
+	}
+	if failErr := uc.failUpload(ctx, tusUpload, reason); failErr != nil {
+		return nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.inspectArchive: mark failed: %w", failErr))
+	}
+
+	return nil, apperrors.NewArchiveRejectedError(reason)
+}
+
+// classifyArchive detects the project category from the received archive. A failure
+// only costs the detection, so it is logged and the upload goes ahead.
+func (uc *tusUploadUsecase) classifyArchive(tusUpload *domain.TusUpload) *storage.CategoryDetection {
+	detection, err := storage.ClassifyProjectZip(uc.tusManager.GetUploadFilePath(tusUpload.ID))
+	if err != nil {
+		zlog.Warn().Err(err).Str("upload_id", tusUpload.ID).Msg("TusUploadUsecase.classifyArchive: failed to detect project category")
+		return nil
+	}
+	return detection
+}
+
+// applyProjectCategory records detection on project and picks its Kategori. A kategori
+// sent with the upload always wins. Without one, a new project takes the detected
+// category, and an updated project follows the new detection only if its current
+// kategori was itself detected rather than chosen by the user.
+func applyProjectCategory(project *domain.Project, kategori string, detection *storage.CategoryDetection) {
+	followsDetection := project.Kategori == "" || project.Kategori == project.KategoriTerdeteksi
+
+	if detection != nil {
+		project.KategoriTerdeteksi = detection.Kategori
+		project.KategoriConfidence = detection.Confidence
+		project.KategoriSignals = detection.Signals
+	}
+
+	switch {
+	case kategori != "":
+		project.Kategori = kategori
+	case detection != nil && followsDetection:
+		project.Kategori = detection.Kategori
+	case project.Kategori == "":
+		project.Kategori = domain.KategoriWebsite
+	}
+}
+
+// completePartialUpload keeps the finished partial file in the store until a final
+// upload claims it; cleanup expires it if none does within the idle timeout.
+func (uc *tusUploadUsecase) completePartialUpload(ctx context.Context, tusUpload *domain.TusUpload) error {
+	expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
+	if err := uc.tusUploadRepo.CompletePartial(ctx, tusUpload.ID, expiresAt); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completePartialUpload: %w", err))
+	}
+
+	uc.releaseSlot(ctx, tusUpload.ID)
 	return nil
 }
 
-func (uc *tusUploadUsecase) completeProjectCreate(ctx context.Context, upload *domain.TusUpload, finalFilePath string) (uint, error) {
+// releaseSlot frees the queue slot held by uploadID and hands it to the next queued upload.
+func (uc *tusUploadUsecase) releaseSlot(ctx context.Context, uploadID string) {
+	nextID := uc.tusManager.FinishUpload(uploadID)
+	if nextID == "" {
+		return
+	}
+
+	expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
+	if err := uc.tusUploadRepo.Promote(ctx, nextID, expiresAt); err != nil {
+		zlog.Error().Err(err).Str("upload_id", nextID).Msg("TusUploadUsecase.releaseSlot: failed to promote queued upload")
+		return
+	}
+	zlog.Info().Str("upload_id", nextID).Str("released_by", uploadID).Msg("queued upload promoted")
+
+	// The promoted upload and everyone still waiting behind it have a new queue position.
+	for _, id := range append([]string{nextID}, uc.tusManager.GetCurrentQueue()...) {
+		if tusUpload, err := uc.tusUploadRepo.GetByID(ctx, id); err == nil {
+			uc.publishProgress(tusUpload)
+		}
+	}
+}
+
+// publishProgress pushes the current state of tusUpload to progress subscribers.
+func (uc *tusUploadUsecase) publishProgress(tusUpload *domain.TusUpload) {
+	event := dto.TusProgressEvent{
+		UploadID:      tusUpload.ID,
+		UserID:        tusUpload.UserID,
+		Kind:          upload.ProgressKindProject,
+		Status:        tusUpload.Status,
+		Offset:        tusUpload.CurrentOffset,
+		Length:        tusUpload.FileSize,
+		Progress:      tusUpload.Progress,
+		FailureReason: tusUpload.FailureReason,
+	}
+	if tusUpload.Status == domain.UploadStatusQueued {
+		event.QueuePosition = max(uc.tusManager.GetQueuePosition(tusUpload.ID), 0)
+	}
+	uc.tusManager.PublishProgress(event)
+}
+
+// completeProjectCreate stores the finished upload as a new project. The returned undo
+// removes that project again.
+func (uc *tusUploadUsecase) completeProjectCreate(ctx context.Context, upload *domain.TusUpload, finalFilePath string, detection *storage.CategoryDetection) (*domain.Project, func() error, error) {
 	project := &domain.Project{
 		UserID:      upload.UserID,
 		NamaProject: upload.UploadMetadata.NamaProject,
-		Kategori:    upload.UploadMetadata.Kategori,
 		Semester:    upload.UploadMetadata.Semester,
 		Ukuran:      storage.GetFileSizeFromPath(finalFilePath),
+		FileSize:    upload.FileSize,
 		PathFile:    finalFilePath,
 	}
+	applyProjectCategory(project, upload.UploadMetadata.Kategori, detection)
 
 	if err := uc.projectRepo.Create(ctx, project); err != nil {
-		return 0, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectCreate: %w", err))
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectCreate: %w", err))
 	}
 
-	return project.ID, nil
+	undo := func() error { return uc.projectRepo.Delete(ctx, project.ID) }
+	return project, undo, nil
 }
 
-func (uc *tusUploadUsecase) completeProjectUpdate(ctx context.Context, upload *domain.TusUpload, finalFilePath string) (uint, error) {
+// completeProjectUpdate makes the finished upload the current file of its project. The
+// returned undo puts the project's previous file and metadata back.
+func (uc *tusUploadUsecase) completeProjectUpdate(ctx context.Context, upload *domain.TusUpload, finalFilePath string, detection *storage.CategoryDetection) (*domain.Project, func() error, error) {
 	if upload.ProjectID == nil {
-		return 0, apperrors.NewValidationError("project ID tidak ditemukan", nil)
+		return nil, nil, apperrors.NewValidationError("project ID tidak ditemukan", nil)
 	}
 
 	project, err := uc.projectRepo.GetByID(ctx, *upload.ProjectID)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return 0, apperrors.NewNotFoundError("Project")
+			return nil, nil, apperrors.NewNotFoundError("Project")
 		}
-		return 0, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectUpdate: %w", err))
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectUpdate: %w", err))
+	}
+
+	if err := uc.recordBaselineVersion(ctx, project); err != nil {
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectUpdate: baseline version: %w", err))
 	}
 
-	oldFilePath := project.PathFile
+	previous := *project
 	project.NamaProject = upload.UploadMetadata.NamaProject
-	project.Kategori = upload.UploadMetadata.Kategori
+	applyProjectCategory(project, upload.UploadMetadata.Kategori, detection)
 	project.Semester = upload.UploadMetadata.Semester
 	project.Ukuran = storage.GetFileSizeFromPath(finalFilePath)
+	project.FileSize = upload.FileSize
 	project.PathFile = finalFilePath
 
+	// The previous file is not deleted here: it stays downloadable as an older
+	// version until retention prunes it.
 	if err := uc.projectRepo.Update(ctx, project); err != nil {
-		return 0, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectUpdate: %w", err))
+		return nil, nil, apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.completeProjectUpdate: %w", err))
 	}
 
-	if oldFilePath != "" && oldFilePath != finalFilePath {
-		if err := storage.DeleteFile(oldFilePath); err != nil {
-			// Old file deletion after successful update is critical but non-blocking
-			zlog.Warn().Err(err).Str("file", oldFilePath).Msg("TusUploadUsecase.completeProjectUpdate: failed to delete old file")
-		}
+	undo := func() error { return uc.projectRepo.Update(ctx, &previous) }
+	return project, undo, nil
+}
+
+// recordBaselineVersion gives a project stored before versions were kept a first
+// version for its current file, so that file is not lost from history once an
+// update replaces it.
+func (uc *tusUploadUsecase) recordBaselineVersion(ctx context.Context, project *domain.Project) error {
+	if project.PathFile == "" {
+		return nil
 	}
 
-	return project.ID, nil
+	versions, err := uc.projectRepo.GetVersions(ctx, project.ID)
+	if err != nil || len(versions) > 0 {
+		return err
+	}
+
+	return uc.projectRepo.CreateVersion(ctx, &domain.ProjectVersion{
+		ProjectID:  project.ID,
+		PathFile:   project.PathFile,
+		FileSize:   project.FileSize,
+		UploadedBy: project.UserID,
+		CreatedAt:  project.UpdatedAt,
+	})
 }
 
 func (uc *tusUploadUsecase) GetUploadInfo(ctx context.Context, uploadID, userID string) (*dto.TusUploadInfoResponse, error) {
@@ -342,45 +926,97 @@ func (uc *tusUploadUsecase) GetProjectUpdateUploadInfo(ctx context.Context, proj
 }
 
 func (uc *tusUploadUsecase) getUploadInfo(ctx context.Context, uploadID, userID string, projectID *uint) (*dto.TusUploadInfoResponse, error) {
-	upload, err := uc.getOwnedUpload(ctx, uploadID, userID, projectID)
+	upload, err := uc.getUploadRecord(ctx, uploadID, userID, projectID)
 	if err != nil {
 		return nil, err
 	}
+	// A failed upload stays readable so the client can show why it failed.
+	if upload.Status != domain.UploadStatusFailed {
+		if err := checkUploadUsable(upload); err != nil {
+			return nil, err
+		}
+	}
 
 	response := &dto.TusUploadInfoResponse{
-		UploadID:    upload.ID,
-		NamaProject: upload.UploadMetadata.NamaProject,
-		Kategori:    upload.UploadMetadata.Kategori,
-		Semester:    upload.UploadMetadata.Semester,
-		Status:      upload.Status,
-		Progress:    upload.Progress,
-		Offset:      upload.CurrentOffset,
-		Length:      upload.FileSize,
-		CreatedAt:   upload.CreatedAt,
-		UpdatedAt:   upload.UpdatedAt,
+		UploadID:      upload.ID,
+		NamaProject:   upload.UploadMetadata.NamaProject,
+		Kategori:      upload.UploadMetadata.Kategori,
+		Semester:      upload.UploadMetadata.Semester,
+		Status:        upload.Status,
+		Progress:      upload.Progress,
+		Offset:        upload.CurrentOffset,
+		Length:        upload.FileSize,
+		FailureReason: upload.FailureReason,
+		CreatedAt:     upload.CreatedAt,
+		UpdatedAt:     upload.UpdatedAt,
 	}
 
 	if upload.ProjectID != nil {
 		response.ProjectID = *upload.ProjectID
 	}
 
+	if upload.Status == domain.UploadStatusQueued {
+		if err := uc.refreshQueuedExpiry(ctx, upload); err != nil {
+			return nil, err
+		}
+		if position := uc.tusManager.GetQueuePosition(upload.ID); position > 0 {
+			response.QueuePosition = position
+		}
+	}
+
 	return response, nil
 }
 
-func (uc *tusUploadUsecase) GetUploadStatus(ctx context.Context, uploadID, userID string) (offset, length int64, err error) {
+func (uc *tusUploadUsecase) GetUploadStatus(ctx context.Context, uploadID, userID string) (*dto.TusUploadStatus, error) {
 	return uc.getUploadStatus(ctx, uploadID, userID, nil)
 }
 
-func (uc *tusUploadUsecase) GetProjectUpdateUploadStatus(ctx context.Context, projectID uint, uploadID, userID string) (offset, length int64, err error) {
+func (uc *tusUploadUsecase) GetProjectUpdateUploadStatus(ctx context.Context, projectID uint, uploadID, userID string) (*dto.TusUploadStatus, error) {
 	return uc.getUploadStatus(ctx, uploadID, userID, &projectID)
 }
 
-func (uc *tusUploadUsecase) getUploadStatus(ctx context.Context, uploadID, userID string, projectID *uint) (offset, length int64, err error) {
+func (uc *tusUploadUsecase) getUploadStatus(ctx context.Context, uploadID, userID string, projectID *uint) (*dto.TusUploadStatus, error) {
 	upload, err := uc.getOwnedUpload(ctx, uploadID, userID, projectID)
 	if err != nil {
-		return 0, 0, err
+		return nil, err
+	}
+	if upload.Status == domain.UploadStatusQueued {
+		if err := uc.refreshQueuedExpiry(ctx, upload); err != nil {
+			return nil, err
+		}
 	}
-	return upload.CurrentOffset, upload.FileSize, nil
+
+	status := &dto.TusUploadStatus{
+		Offset:        upload.CurrentOffset,
+		Length:        upload.FileSize,
+		ExpiresAt:     uploadExpiresAt(upload),
+		ConcatPartial: upload.UploadType == domain.UploadTypeProjectPartial,
+	}
+	for _, partID := range upload.UploadMetadata.ConcatParts {
+		status.ConcatParts = append(status.ConcatParts, fmt.Sprintf("/project/upload/%s", partID))
+	}
+	return status, nil
+}
+
+// refreshQueuedExpiry pushes back the expiry of a queued upload whose client still polls
+// for its turn, so cleanup only drops queued uploads nobody is waiting on anymore.
+func (uc *tusUploadUsecase) refreshQueuedExpiry(ctx context.Context, tusUpload *domain.TusUpload) error {
+	expiresAt := time.Now().Add(time.Duration(uc.config.Upload.IdleTimeout) * time.Second)
+	if err := uc.tusUploadRepo.UpdateOffset(ctx, tusUpload.ID, tusUpload.CurrentOffset, tusUpload.Progress, expiresAt); err != nil {
+		return apperrors.NewInternalError(fmt.Errorf("TusUploadUsecase.refreshQueuedExpiry: %w", err))
+	}
+	tusUpload.ExpiresAt = expiresAt
+	return nil
+}
+
+// uploadExpiresAt reports when the cleanup job removes an upload left idle. Completed
+// uploads are kept, except partial ones still waiting to be concatenated.
+func uploadExpiresAt(tusUpload *domain.TusUpload) time.Time {
+	if tusUpload.Status == domain.UploadStatusCompleted &&
+		(tusUpload.UploadType != domain.UploadTypeProjectPartial || tusUpload.FinalUploadID != nil) {
+		return time.Time{}
+	}
+	return tusUpload.ExpiresAt
 }
 
 func (uc *tusUploadUsecase) CancelUpload(ctx context.Context, uploadID, userID string) error {
@@ -409,11 +1045,28 @@ func (uc *tusUploadUsecase) cancelUpload(ctx context.Context, uploadID, userID s
 		zlog.Warn().Err(err).Str("upload_id", uploadID).Msg("failed to delete upload file")
 	}
 
-	uc.tusManager.FinishUpload(uploadID)
+	upload.Status = domain.UploadStatusCancelled
+	uc.publishProgress(upload)
+	uc.releaseSlot(ctx, uploadID)
 	return nil
 }
 
 func (uc *tusUploadUsecase) getOwnedUpload(ctx context.Context, uploadID, userID string, projectID *uint) (*domain.TusUpload, error) {
+	upload, err := uc.getUploadRecord(ctx, uploadID, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkUploadUsable(upload); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// getUploadRecord loads uploadID and checks that it belongs to userID (and projectID,
+// when given), whatever its status.
+func (uc *tusUploadUsecase) getUploadRecord(ctx context.Context, uploadID, userID string, projectID *uint) (*domain.TusUpload, error) {
 	upload, err := uc.tusUploadRepo.GetByID(ctx, uploadID)
 	if err != nil {
 		return nil, apperrors.NewNotFoundError("Upload")
@@ -423,10 +1076,6 @@ func (uc *tusUploadUsecase) getOwnedUpload(ctx context.Context, uploadID, userID
 		return nil, apperrors.NewForbiddenError("Anda tidak memiliki akses ke upload ini")
 	}
 
-	if upload.Status == domain.UploadStatusExpired || upload.Status == domain.UploadStatusCancelled || upload.Status == domain.UploadStatusFailed {
-		return nil, apperrors.NewConflictError("upload sudah " + upload.Status + ", tidak dapat dilanjutkan")
-	}
-
 	if projectID != nil {
 		if upload.ProjectID == nil || *upload.ProjectID != *projectID {
 			return nil, apperrors.NewValidationError("project ID tidak cocok", nil)
@@ -435,3 +1084,20 @@ func (uc *tusUploadUsecase) getOwnedUpload(ctx context.Context, uploadID, userID
 
 	return upload, nil
 }
+
+// checkUploadUsable rejects uploads that can no longer be resumed or queried.
+func checkUploadUsable(upload *domain.TusUpload) error {
+	if upload.Status == domain.UploadStatusExpired {
+		return apperrors.NewTusExpiredError()
+	}
+
+	if upload.Status == domain.UploadStatusFailed && upload.FailureReason != "" {
+		return apperrors.NewConflictError("upload gagal: " + upload.FailureReason)
+	}
+
+	if upload.Status == domain.UploadStatusCancelled || upload.Status == domain.UploadStatusFailed {
+		return apperrors.NewConflictError("upload sudah " + upload.Status + ", tidak dapat dilanjutkan")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"invento-service/internal/domain"
+	"invento-service/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProjectCategory(t *testing.T) {
+	t.Parallel()
+
+	mobile := &storage.CategoryDetection{
+		Kategori:   domain.KategoriMobile,
+		Confidence: 0.67,
+		Signals:    []string{"pubspec.yaml (pubspec.yaml)"},
+	}
+
+	tests := []struct {
+		name      string
+		project   domain.Project
+		kategori  string
+		detection *storage.CategoryDetection
+		want      string
+	}{
+		{name: "new project takes detection", detection: mobile, want: domain.KategoriMobile},
+		{name: "explicit kategori wins", kategori: domain.KategoriIoT, detection: mobile, want: domain.KategoriIoT},
+		{name: "new project without detection", want: domain.KategoriWebsite},
+		{
+			name:      "update follows detection when kategori was detected",
+			project:   domain.Project{Kategori: domain.KategoriWebsite, KategoriTerdeteksi: domain.KategoriWebsite},
+			detection: mobile,
+			want:      domain.KategoriMobile,
+		},
+		{
+			name:      "update keeps kategori the user chose",
+			project:   domain.Project{Kategori: domain.KategoriDeepLearning, KategoriTerdeteksi: domain.KategoriWebsite},
+			detection: mobile,
+			want:      domain.KategoriDeepLearning,
+		},
+		{
+			name:     "explicit kategori on update",
+			project:  domain.Project{Kategori: domain.KategoriWebsite, KategoriTerdeteksi: domain.KategoriWebsite},
+			kategori: domain.KategoriMachineLearning,
+			want:     domain.KategoriMachineLearning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			project := tt.project
+			applyProjectCategory(&project, tt.kategori, tt.detection)
+
+			assert.Equal(t, tt.want, project.Kategori)
+			if tt.detection != nil {
+				assert.Equal(t, tt.detection.Kategori, project.KategoriTerdeteksi)
+				assert.Equal(t, tt.detection.Confidence, project.KategoriConfidence)
+				assert.Equal(t, tt.detection.Signals, project.KategoriSignals)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"invento-service/internal/domain"
+	"invento-service/internal/storage"
+	"invento-service/internal/upload"
 	"testing"
 	"time"
 
@@ -36,6 +40,8 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			}, nil).Once()
 			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(len(chunk)), mock.MatchedBy(func(progress float64) bool {
 				return progress > 0 && progress < 100
+			}), mock.MatchedBy(func(expiresAt time.Time) bool {
+				return expiresAt.After(time.Now())
 			})).Return(nil).Once()
 
 			seedTusUploadStore(t, manager, uploadID, 10, map[string]string{"user_id": "u1"})
@@ -45,6 +51,35 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			tusRepo.AssertExpectations(t)
 		})
 
+		t.Run("checksum mismatch rejects chunk without moving offset", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+			uploadID := "upload-checksum"
+
+			tusRepo.On("GetByID", mock.Anything, uploadID).Return(&domain.TusUpload{
+				ID:            uploadID,
+				UserID:        "u1",
+				FileSize:      10,
+				CurrentOffset: 0,
+				Status:        domain.UploadStatusUploading,
+			}, nil).Once()
+
+			sum := sha256.Sum256([]byte("abcd"))
+			checksum, err := upload.ParseUploadChecksum("sha256 " + base64.StdEncoding.EncodeToString(sum[:]))
+			require.NoError(t, err)
+
+			seedTusUploadStore(t, manager, uploadID, 10, map[string]string{"user_id": "u1"})
+			newOffset, err := uc.HandleChunk(context.Background(), uploadID, "u1", 0, upload.NewChecksumReader(bytes.NewReader([]byte("abcX")), checksum))
+			require.Error(t, err)
+			assert.Equal(t, int64(0), newOffset)
+
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.ErrTusChecksumMismatch, appErr.Code)
+			assert.Equal(t, 460, appErr.HTTPStatus)
+			tusRepo.AssertNotCalled(t, "UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+
 		t.Run("pending transitions to uploading", func(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
@@ -58,7 +93,7 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 				Status:        domain.UploadStatusPending,
 			}, nil).Once()
 			tusRepo.On("UpdateStatus", mock.Anything, uploadID, domain.UploadStatusUploading).Return(nil).Once()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(3), mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(3), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 			seedTusUploadStore(t, manager, uploadID, 10, map[string]string{"user_id": "u1"})
 			newOffset, err := uc.HandleChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader([]byte("abc")))
@@ -71,28 +106,62 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
 			uploadID := "upload-complete"
+			archive := createTestProjectZip(t, 256)
 
 			tusRepo.On("GetByID", mock.Anything, uploadID).Return(&domain.TusUpload{
 				ID:             uploadID,
 				UserID:         "u1",
 				UploadType:     domain.UploadTypeProjectCreate,
 				UploadMetadata: domain.TusUploadMetadata{NamaProject: "Final", Kategori: "mobile", Semester: 3},
-				FileSize:       4,
+				FileSize:       256,
 				CurrentOffset:  0,
 				Status:         domain.UploadStatusUploading,
 			}, nil).Once()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.MatchedBy(func(progress float64) bool { return progress == 100 })).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(256), mock.MatchedBy(func(progress float64) bool { return progress == 100 }), mock.AnythingOfType("time.Time")).Return(nil).Once()
 			projectRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Project")).Return(nil).Once()
+			projectRepo.On("SaveManifest", mock.Anything, mock.AnythingOfType("*domain.ProjectManifest")).Return(nil).Once()
+			projectRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.ProjectVersion) bool {
+				return v.UploadedBy == "u1" && v.FileSize == 256 && len(v.Checksum) == 64
+			})).Return(nil).Once()
 			tusRepo.On("Complete", mock.Anything, uploadID, mock.AnythingOfType("uint"), mock.AnythingOfType("string")).Return(nil).Once()
 
-			seedTusUploadStore(t, manager, uploadID, 4, map[string]string{"user_id": "u1"})
-			newOffset, err := uc.HandleChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader([]byte("done")))
+			seedTusUploadStore(t, manager, uploadID, 256, map[string]string{"user_id": "u1"})
+			newOffset, err := uc.HandleChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader(archive))
 			require.NoError(t, err)
-			assert.Equal(t, int64(4), newOffset)
+			assert.Equal(t, int64(256), newOffset)
 			tusRepo.AssertExpectations(t)
 			projectRepo.AssertExpectations(t)
 		})
 
+		t.Run("completed upload that is not a zip is rejected", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
+			uploadID := "upload-not-zip"
+
+			tusRepo.On("GetByID", mock.Anything, uploadID).Return(&domain.TusUpload{
+				ID:             uploadID,
+				UserID:         "u1",
+				UploadType:     domain.UploadTypeProjectCreate,
+				UploadMetadata: domain.TusUploadMetadata{NamaProject: "Final", Kategori: "mobile", Semester: 3},
+				FileSize:       4,
+				Status:         domain.UploadStatusUploading,
+			}, nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+			tusRepo.On("Fail", mock.Anything, uploadID, storage.ErrZipCorrupt.Error()).Return(nil).Once()
+
+			seedTusUploadStore(t, manager, uploadID, 4, map[string]string{"user_id": "u1"})
+			_, err := uc.HandleChunk(context.Background(), uploadID, "u1", 0, bytes.NewReader([]byte("done")))
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.ErrArchiveRejected, appErr.Code)
+			assert.Equal(t, storage.ErrZipCorrupt.Error(), appErr.Message)
+
+			_, err = manager.GetUploadInfo(uploadID)
+			assert.Error(t, err, "rejected upload data is discarded")
+			tusRepo.AssertExpectations(t)
+			projectRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+
 		t.Run("upload not found", func(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
@@ -134,6 +203,46 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), "tidak dapat dilanjutkan")
 		})
+
+		t.Run("expired upload is gone", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+			tusRepo.On("GetByID", mock.Anything, "u").Return(&domain.TusUpload{ID: "u", UserID: "u1", FileSize: 10, Status: domain.UploadStatusExpired}, nil).Once()
+
+			_, err := uc.HandleChunk(context.Background(), "u", "u1", 0, bytes.NewReader([]byte("x")))
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.ErrTusExpired, appErr.Code)
+		})
+
+		t.Run("queued upload is locked until promoted", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+			seedTusUploadStore(t, manager, "u", 10, map[string]string{"user_id": "u1"})
+			tusRepo.On("GetByID", mock.Anything, "u").Return(&domain.TusUpload{ID: "u", UserID: "u1", FileSize: 10, Status: domain.UploadStatusQueued}, nil).Once()
+
+			offset, err := uc.HandleChunk(context.Background(), "u", "u1", 0, bytes.NewReader([]byte("x")))
+			require.Error(t, err)
+			assert.Equal(t, int64(0), offset)
+			assert.Contains(t, err.Error(), "tidak aktif")
+			tusRepo.AssertNotCalled(t, "UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		t.Run("queued upload holding a slot is promoted", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+			seedTusUploadStore(t, manager, "u", 10, map[string]string{"user_id": "u1"})
+			manager.AddToQueue("u")
+			tusRepo.On("GetByID", mock.Anything, "u").Return(&domain.TusUpload{ID: "u", UserID: "u1", FileSize: 10, Status: domain.UploadStatusQueued}, nil).Once()
+			tusRepo.On("Promote", mock.Anything, "u", mock.AnythingOfType("time.Time")).Return(nil).Once()
+			tusRepo.On("UpdateStatus", mock.Anything, "u", domain.UploadStatusUploading).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, "u", int64(1), mock.Anything, mock.Anything).Return(nil).Once()
+
+			offset, err := uc.HandleChunk(context.Background(), "u", "u1", 0, bytes.NewReader([]byte("x")))
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), offset)
+			tusRepo.AssertExpectations(t)
+		})
 	})
 
 	t.Run("CancelUpload", func(t *testing.T) {
@@ -152,6 +261,38 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			tusRepo.AssertExpectations(t)
 		})
 
+		t.Run("promotes next queued upload", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+			seedTusUploadStore(t, manager, "cancel-id", 8, map[string]string{"user_id": "u1"})
+			manager.AddToQueue("cancel-id")
+			manager.AddToQueue("waiting-id")
+
+			tusRepo.On("GetByID", mock.Anything, "cancel-id").Return(&domain.TusUpload{ID: "cancel-id", UserID: "u1", Status: domain.UploadStatusUploading}, nil).Once()
+			tusRepo.On("UpdateStatus", mock.Anything, "cancel-id", domain.UploadStatusCancelled).Return(nil).Once()
+			tusRepo.On("Promote", mock.Anything, "waiting-id", mock.AnythingOfType("time.Time")).Return(nil).Once()
+			tusRepo.On("GetByID", mock.Anything, "waiting-id").Return(&domain.TusUpload{ID: "waiting-id", UserID: "u2", Status: domain.UploadStatusPending}, nil).Once()
+
+			bus := upload.NewProgressBus()
+			manager.SetProgressBus(bus)
+			cancelled, stopCancelled := bus.Subscribe("u1", "cancel-id")
+			defer stopCancelled()
+			promoted, stopPromoted := bus.Subscribe("u2", "")
+			defer stopPromoted()
+
+			require.NoError(t, uc.CancelUpload(context.Background(), "cancel-id", "u1"))
+			assert.True(t, manager.IsActiveUpload("waiting-id"))
+			tusRepo.AssertExpectations(t)
+
+			require.Len(t, cancelled, 1)
+			assert.Equal(t, domain.UploadStatusCancelled, (<-cancelled).Status)
+			require.Len(t, promoted, 1)
+			event := <-promoted
+			assert.Equal(t, "waiting-id", event.UploadID)
+			assert.Equal(t, domain.UploadStatusPending, event.Status)
+			assert.Equal(t, upload.ProgressKindProject, event.Kind)
+		})
+
 		t.Run("not found", func(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
@@ -234,8 +375,9 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			}, nil).Once()
 			tusRepo.On("GetActiveByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil).Once()
 			tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(upload *domain.TusUpload) bool {
+				// Kategori is settled when the archive arrives, see applyProjectCategory.
 				return upload.UploadMetadata.NamaProject == "Existing Name" &&
-					upload.UploadMetadata.Kategori == "website" &&
+					upload.UploadMetadata.Kategori == "" &&
 					upload.UploadMetadata.Semester == 6 &&
 					upload.ProjectID != nil && *upload.ProjectID == 9 &&
 					upload.UploadType == domain.UploadTypeProjectUpdate
@@ -265,7 +407,7 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 				CurrentOffset: 0,
 				Status:        domain.UploadStatusUploading,
 			}, nil).Once()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(3), mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(3), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 			seedTusUploadStore(t, manager, uploadID, 10, map[string]string{"user_id": "u1", "project_id": "1"})
 			offset, err := uc.HandleProjectUpdateChunk(context.Background(), projectID, uploadID, "u1", 0, bytes.NewReader(chunk))
@@ -278,6 +420,7 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
 			projectID := uint(2)
 			uploadID := "proj-complete"
+			archive := createTestProjectZip(t, 256)
 			projectRepo.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID, UserID: "u1", PathFile: "", NamaProject: "Old", Kategori: "website", Semester: 1}, nil).Once()
 			projectRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Project")).Return(nil).Once()
 
@@ -287,16 +430,59 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 				ProjectID:      &projectID,
 				UploadType:     domain.UploadTypeProjectUpdate,
 				UploadMetadata: domain.TusUploadMetadata{NamaProject: "New", Kategori: "iot", Semester: 6},
-				FileSize:       4,
+				FileSize:       256,
 				Status:         domain.UploadStatusUploading,
 			}, nil).Once()
-			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(4), mock.AnythingOfType("float64")).Return(nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(256), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+			projectRepo.On("SaveManifest", mock.Anything, mock.AnythingOfType("*domain.ProjectManifest")).Return(nil).Once()
+			projectRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.ProjectVersion) bool {
+				return v.ProjectID == projectID && v.PathFile != ""
+			})).Return(nil).Once()
 			tusRepo.On("Complete", mock.Anything, uploadID, mock.AnythingOfType("uint"), mock.AnythingOfType("string")).Return(nil).Once()
 
-			seedTusUploadStore(t, manager, uploadID, 4, map[string]string{"user_id": "u1", "project_id": "2"})
-			offset, err := uc.HandleProjectUpdateChunk(context.Background(), projectID, uploadID, "u1", 0, bytes.NewReader([]byte("done")))
+			seedTusUploadStore(t, manager, uploadID, 256, map[string]string{"user_id": "u1", "project_id": "2"})
+			offset, err := uc.HandleProjectUpdateChunk(context.Background(), projectID, uploadID, "u1", 0, bytes.NewReader(archive))
 			require.NoError(t, err)
-			assert.Equal(t, int64(4), offset)
+			assert.Equal(t, int64(256), offset)
+		})
+
+		t.Run("completion resubmits a reviewed project", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
+			projectID := uint(3)
+			uploadID := "proj-resubmit"
+			archive := createTestProjectZip(t, 256)
+			projectRepo.On("GetByID", mock.Anything, projectID).Return(&domain.Project{
+				ID: projectID, UserID: "u1", NamaProject: "Old", Kategori: "website", Semester: 1, ReviewStatus: domain.ProjectReviewUnderReview,
+			}, nil).Once()
+			projectRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Project")).Return(nil).Once()
+			// A reviewer approved the project meanwhile; the resubmission starts over from there.
+			projectRepo.On("UpdateReviewStatus", mock.Anything, mock.Anything, mock.MatchedBy(func(e *domain.ProjectReviewEvent) bool {
+				return e.FromStatus == domain.ProjectReviewUnderReview
+			})).Return(false, nil).Once()
+			projectRepo.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID, ReviewStatus: domain.ProjectReviewApproved}, nil).Once()
+			projectRepo.On("UpdateReviewStatus", mock.Anything, mock.Anything, mock.MatchedBy(func(e *domain.ProjectReviewEvent) bool {
+				return e.ActorID == "u1" && e.FromStatus == domain.ProjectReviewApproved && e.ToStatus == domain.ProjectReviewSubmitted
+			})).Return(true, nil).Once()
+
+			tusRepo.On("GetByID", mock.Anything, uploadID).Return(&domain.TusUpload{
+				ID:             uploadID,
+				UserID:         "u1",
+				ProjectID:      &projectID,
+				UploadType:     domain.UploadTypeProjectUpdate,
+				UploadMetadata: domain.TusUploadMetadata{NamaProject: "New", Semester: 6},
+				FileSize:       256,
+				Status:         domain.UploadStatusUploading,
+			}, nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, uploadID, int64(256), mock.AnythingOfType("float64"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+			projectRepo.On("SaveManifest", mock.Anything, mock.AnythingOfType("*domain.ProjectManifest")).Return(nil).Once()
+			projectRepo.On("CreateVersion", mock.Anything, mock.AnythingOfType("*domain.ProjectVersion")).Return(nil).Once()
+			tusRepo.On("Complete", mock.Anything, uploadID, projectID, mock.AnythingOfType("string")).Return(nil).Once()
+
+			seedTusUploadStore(t, manager, uploadID, 256, map[string]string{"user_id": "u1", "project_id": "3"})
+			_, err := uc.HandleProjectUpdateChunk(context.Background(), projectID, uploadID, "u1", 0, bytes.NewReader(archive))
+			require.NoError(t, err)
+			projectRepo.AssertExpectations(t)
 		})
 
 		t.Run("upload not found", func(t *testing.T) {
@@ -443,10 +629,10 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 				FileSize:      18,
 			}, nil).Once()
 
-			offset, length, err := uc.GetProjectUpdateUploadStatus(context.Background(), projectID, "project-status-id", "u1")
+			status, err := uc.GetProjectUpdateUploadStatus(context.Background(), projectID, "project-status-id", "u1")
 			require.NoError(t, err)
-			assert.Equal(t, int64(9), offset)
-			assert.Equal(t, int64(18), length)
+			assert.Equal(t, int64(9), status.Offset)
+			assert.Equal(t, int64(18), status.Length)
 		})
 
 		t.Run("GetProjectUpdateUploadStatus not found", func(t *testing.T) {
@@ -454,10 +640,9 @@ func TestTusUploadUsecase_ChunkAndCancel(t *testing.T) {
 			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
 			tusRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
 
-			offset, length, err := uc.GetProjectUpdateUploadStatus(context.Background(), 12, "missing", "u1")
+			status, err := uc.GetProjectUpdateUploadStatus(context.Background(), 12, "missing", "u1")
 			require.Error(t, err)
-			assert.Equal(t, int64(0), offset)
-			assert.Equal(t, int64(0), length)
+			assert.Nil(t, status)
 		})
 	})
 }
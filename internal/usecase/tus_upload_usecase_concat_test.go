@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/upload"
+	"strconv"
+	"testing"
+
+	dto "invento-service/internal/dto"
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func seedCompletedPart(t *testing.T, manager *upload.TusManager, uploadID string, data []byte) {
+	t.Helper()
+	seedTusUploadStore(t, manager, uploadID, int64(len(data)), map[string]string{"user_id": "u1"})
+	_, err := manager.HandleChunk(uploadID, 0, bytes.NewReader(data))
+	require.NoError(t, err)
+}
+
+func TestTusUploadUsecase_Concatenation(t *testing.T) {
+	t.Parallel()
+	partialRequest := dto.TusUploadInitRequest{ConcatPartial: true}
+
+	t.Run("partial upload skips metadata and the queue", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+		tusRepo.On("GetByUserID", mock.Anything, "u1").Return([]domain.TusUpload{
+			{ID: "part-1", UploadType: domain.UploadTypeProjectPartial, Status: domain.UploadStatusUploading, FileSize: 64},
+		}, nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.TusUpload) bool {
+			return u.UploadType == domain.UploadTypeProjectPartial && u.ProjectID == nil && u.UploadMetadata.NamaProject == ""
+		})).Return(nil).Once()
+
+		res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 64, partialRequest)
+		require.NoError(t, err)
+		assert.Equal(t, "/project/upload/"+res.UploadID, res.UploadURL)
+		assert.Equal(t, domain.UploadStatusPending, res.Status)
+		assert.False(t, manager.IsActiveUpload(res.UploadID), "partials do not hold a queue slot")
+		tusRepo.AssertExpectations(t)
+	})
+
+	t.Run("partial upload starts while the queue is full", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+		manager.AddToQueue("someone-else")
+		tusRepo.On("GetByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.TusUpload) bool {
+			return u.Status == domain.UploadStatusPending
+		})).Return(nil).Once()
+
+		res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 64, partialRequest)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UploadStatusPending, res.Status)
+		assert.Zero(t, res.QueuePosition)
+		tusRepo.AssertExpectations(t)
+	})
+
+	t.Run("partial upload rejected while a regular upload runs", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByUserID", mock.Anything, "u1").Return([]domain.TusUpload{
+			{ID: "regular", UploadType: domain.UploadTypeProjectCreate, Status: domain.UploadStatusUploading},
+		}, nil).Once()
+
+		_, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 64, partialRequest)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+		tusRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("open partial uploads count towards the project size limit", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByUserID", mock.Anything, "u1").Return([]domain.TusUpload{
+			{ID: "part-1", UploadType: domain.UploadTypeProjectPartial, Status: domain.UploadStatusCompleted, FileSize: uc.config.Upload.MaxSizeProject},
+			{ID: "used", UploadType: domain.UploadTypeProjectPartial, Status: domain.UploadStatusCompleted, FileSize: 1 << 40, FinalUploadID: stringPtr("final")},
+		}, nil).Once()
+
+		_, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 1, partialRequest)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrPayloadTooLarge, appErr.Code)
+	})
+
+	t.Run("finished partial upload is kept for concatenation", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "part-1").Return(&domain.TusUpload{
+			ID:         "part-1",
+			UserID:     "u1",
+			UploadType: domain.UploadTypeProjectPartial,
+			FileSize:   4,
+			Status:     domain.UploadStatusUploading,
+		}, nil).Once()
+		tusRepo.On("UpdateOffset", mock.Anything, "part-1", int64(4), float64(100), mock.AnythingOfType("time.Time")).Return(nil).Once()
+		tusRepo.On("CompletePartial", mock.Anything, "part-1", mock.Anything).Return(nil).Once()
+
+		seedTusUploadStore(t, manager, "part-1", 4, map[string]string{"user_id": "u1"})
+		newOffset, err := uc.HandleChunk(context.Background(), "part-1", "u1", 0, bytes.NewReader([]byte("half")))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), newOffset)
+
+		complete, err := manager.IsUploadComplete("part-1")
+		require.NoError(t, err)
+		assert.True(t, complete)
+		tusRepo.AssertExpectations(t)
+		projectRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("final upload joins parts and creates the project", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
+		archive := createTestProjectZip(t, 512)
+		seedCompletedPart(t, manager, "part-1", archive[:300])
+		seedCompletedPart(t, manager, "part-2", archive[300:])
+		for id, size := range map[string]int64{"part-1": 300, "part-2": 212} {
+			tusRepo.On("GetByID", mock.Anything, id).Return(&domain.TusUpload{
+				ID:         id,
+				UserID:     "u1",
+				UploadType: domain.UploadTypeProjectPartial,
+				FileSize:   size,
+				Status:     domain.UploadStatusCompleted,
+			}, nil).Once()
+		}
+		tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.TusUpload) bool {
+			return u.UploadType == domain.UploadTypeProjectCreate && u.FileSize == 512 && u.CurrentOffset == 512 &&
+				assert.ObjectsAreEqual([]string{"part-1", "part-2"}, u.UploadMetadata.ConcatParts)
+		})).Return(nil).Once()
+		tusRepo.On("ClaimPartials", mock.Anything, mock.AnythingOfType("string"), "u1", []string{"part-1", "part-2"}).Return(true, nil).Once()
+		projectRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+			return p.NamaProject == "Project Alpha" && p.Ukuran != ""
+		})).Return(nil).Once()
+		projectRepo.On("SaveManifest", mock.Anything, mock.MatchedBy(func(m *domain.ProjectManifest) bool {
+			return m.EntryCount == 1 && m.Entries[0].Path == "src/main.go"
+		})).Return(nil).Once()
+		projectRepo.On("CreateVersion", mock.Anything, mock.AnythingOfType("*domain.ProjectVersion")).Return(nil).Once()
+		tusRepo.On("Complete", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("uint"), mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 0, dto.TusUploadInitRequest{
+			NamaProject: "Project Alpha",
+			Kategori:    "website",
+			Semester:    2,
+			ConcatParts: []string{"part-1", "part-2"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.UploadStatusCompleted, res.Status)
+		assert.Equal(t, int64(512), res.Offset)
+		assert.Equal(t, int64(512), res.Length)
+
+		_, err = manager.GetUploadInfo("part-1")
+		assert.Error(t, err, "partial files are consumed")
+		tusRepo.AssertExpectations(t)
+		projectRepo.AssertExpectations(t)
+	})
+
+	t.Run("status reports how the upload was concatenated", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "part-1").Return(&domain.TusUpload{
+			ID: "part-1", UserID: "u1", UploadType: domain.UploadTypeProjectPartial, Status: domain.UploadStatusUploading,
+		}, nil).Once()
+		tusRepo.On("GetByID", mock.Anything, "final").Return(&domain.TusUpload{
+			ID: "final", UserID: "u1", UploadType: domain.UploadTypeProjectCreate, Status: domain.UploadStatusCompleted,
+			UploadMetadata: domain.TusUploadMetadata{ConcatParts: []string{"part-1", "part-2"}},
+		}, nil).Once()
+
+		status, err := uc.GetUploadStatus(context.Background(), "part-1", "u1")
+		require.NoError(t, err)
+		assert.True(t, status.ConcatPartial)
+
+		status, err = uc.GetUploadStatus(context.Background(), "final", "u1")
+		require.NoError(t, err)
+		assert.False(t, status.ConcatPartial)
+		assert.Equal(t, []string{"/project/upload/part-1", "/project/upload/part-2"}, status.ConcatParts)
+	})
+
+	t.Run("final upload loses the parts to a concurrent final", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
+		seedCompletedPart(t, manager, "part-1", []byte("data"))
+		tusRepo.On("GetByID", mock.Anything, "part-1").Return(&domain.TusUpload{
+			ID:         "part-1",
+			UserID:     "u1",
+			UploadType: domain.UploadTypeProjectPartial,
+			FileSize:   4,
+			Status:     domain.UploadStatusCompleted,
+		}, nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusUpload")).Return(nil).Once()
+		tusRepo.On("ClaimPartials", mock.Anything, mock.AnythingOfType("string"), "u1", []string{"part-1"}).Return(false, nil).Once()
+		tusRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+
+		_, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 0, dto.TusUploadInitRequest{
+			NamaProject: "Project Alpha",
+			Kategori:    "website",
+			Semester:    2,
+			ConcatParts: []string{"part-1"},
+		})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+		_, err = manager.GetUploadInfo("part-1")
+		assert.NoError(t, err, "the losing final must not consume the part")
+		assert.True(t, manager.CanAcceptUpload(), "the losing final gives its slot back")
+		tusRepo.AssertExpectations(t)
+		projectRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("final upload needs a free slot", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+		for i := 0; manager.CanAcceptUpload(); i++ {
+			manager.AddToQueue("busy-" + strconv.Itoa(i))
+		}
+		seedCompletedPart(t, manager, "part-1", []byte("data"))
+		tusRepo.On("GetByID", mock.Anything, "part-1").Return(&domain.TusUpload{
+			ID:         "part-1",
+			UserID:     "u1",
+			UploadType: domain.UploadTypeProjectPartial,
+			FileSize:   4,
+			Status:     domain.UploadStatusCompleted,
+		}, nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusUpload")).Return(nil).Once()
+		tusRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+
+		_, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 0, dto.TusUploadInitRequest{
+			NamaProject: "Project Alpha",
+			Kategori:    "website",
+			Semester:    2,
+			ConcatParts: []string{"part-1"},
+		})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+		assert.Empty(t, manager.GetCurrentQueue(), "the final does not wait in line")
+		tusRepo.AssertExpectations(t)
+		tusRepo.AssertNotCalled(t, "ClaimPartials", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("final upload rejects parts that are not finished", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "part-1").Return(&domain.TusUpload{
+			ID:         "part-1",
+			UserID:     "u1",
+			UploadType: domain.UploadTypeProjectPartial,
+			Status:     domain.UploadStatusUploading,
+		}, nil).Once()
+
+		_, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 0, dto.TusUploadInitRequest{
+			NamaProject: "Project Alpha",
+			Kategori:    "website",
+			Semester:    2,
+			ConcatParts: []string{"part-1"},
+		})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrConflict, appErr.Code)
+		tusRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("final upload rejects parts owned by another user", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "part-1").Return(&domain.TusUpload{
+			ID:         "part-1",
+			UserID:     "u2",
+			UploadType: domain.UploadTypeProjectPartial,
+			Status:     domain.UploadStatusCompleted,
+		}, nil).Once()
+
+		_, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 0, dto.TusUploadInitRequest{
+			NamaProject: "Project Alpha",
+			Kategori:    "website",
+			Semester:    2,
+			ConcatParts: []string{"part-1"},
+		})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrForbidden, appErr.Code)
+	})
+}
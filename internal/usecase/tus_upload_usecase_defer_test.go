@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"invento-service/internal/domain"
+	"invento-service/internal/upload"
+	"testing"
+
+	dto "invento-service/internal/dto"
+	apperrors "invento-service/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTusUploadUsecase_DeferredLength(t *testing.T) {
+	t.Parallel()
+	metadata := dto.TusUploadInitRequest{NamaProject: "Project Alpha", Kategori: "website", Semester: 2}
+
+	t.Run("initiate without a length", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+		tusRepo.On("GetActiveByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil).Once()
+		tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.TusUpload) bool {
+			return u.LengthDeferred && u.FileSize == 0
+		})).Return(nil).Once()
+
+		res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", upload.DeferredLength, metadata)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), res.Length)
+
+		info, err := manager.GetUploadInfo(res.UploadID)
+		require.NoError(t, err)
+		assert.True(t, info.SizeIsDeferred)
+		tusRepo.AssertExpectations(t)
+	})
+
+	t.Run("chunk on a deferred upload does not complete it", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, manager := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "deferred").Return(&domain.TusUpload{
+			ID:             "deferred",
+			UserID:         "u1",
+			UploadType:     domain.UploadTypeProjectCreate,
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+		tusRepo.On("UpdateOffset", mock.Anything, "deferred", int64(4), float64(0), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		seedTusUploadStore(t, manager, "deferred", upload.DeferredLength, map[string]string{"user_id": "u1"})
+		newOffset, err := uc.HandleChunk(context.Background(), "deferred", "u1", 0, bytes.NewReader([]byte("data")))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), newOffset)
+		tusRepo.AssertExpectations(t)
+		projectRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("declare length fixes the size", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "deferred").Return(&domain.TusUpload{
+			ID:             "deferred",
+			UserID:         "u1",
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+		tusRepo.On("DeclareLength", mock.Anything, "deferred", int64(64)).Return(nil).Once()
+
+		seedTusUploadStore(t, manager, "deferred", upload.DeferredLength, map[string]string{"user_id": "u1"})
+		require.NoError(t, uc.DeclareUploadLength(context.Background(), "deferred", "u1", 64))
+
+		info, err := manager.GetUploadInfo("deferred")
+		require.NoError(t, err)
+		assert.False(t, info.SizeIsDeferred)
+		assert.Equal(t, int64(64), info.Size)
+		tusRepo.AssertExpectations(t)
+	})
+
+	t.Run("declare length rejects a different size once known", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "known").Return(&domain.TusUpload{
+			ID:       "known",
+			UserID:   "u1",
+			FileSize: 32,
+			Status:   domain.UploadStatusUploading,
+		}, nil).Twice()
+
+		assert.NoError(t, uc.DeclareUploadLength(context.Background(), "known", "u1", 32))
+
+		err := uc.DeclareUploadLength(context.Background(), "known", "u1", 64)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrValidation, appErr.Code)
+		tusRepo.AssertNotCalled(t, "DeclareLength", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("declare length over the project limit", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		tusRepo.On("GetByID", mock.Anything, "deferred").Return(&domain.TusUpload{
+			ID:             "deferred",
+			UserID:         "u1",
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+
+		err := uc.DeclareUploadLength(context.Background(), "deferred", "u1", uc.config.Upload.MaxSizeProject+1)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrPayloadTooLarge, appErr.Code)
+	})
+
+	t.Run("declare length over the quota", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		tusRepo.On("GetByID", mock.Anything, "deferred").Return(&domain.TusUpload{
+			ID:             "deferred",
+			UserID:         "u1",
+			UploadType:     domain.UploadTypeProjectCreate,
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 64}).
+			Return(apperrors.NewQuotaExceededError("")).Once()
+
+		err := uc.DeclareUploadLength(context.Background(), "deferred", "u1", 64)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrQuotaExceeded, appErr.Code)
+		tusRepo.AssertNotCalled(t, "DeclareLength", mock.Anything, mock.Anything, mock.Anything)
+		quota.AssertExpectations(t)
+	})
+
+	t.Run("declare length for an update of a deleted project", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, _ := newTusUploadTestDeps(t)
+		uc.quotaUsecase = new(MockQuotaUsecase)
+		tusRepo.On("GetByID", mock.Anything, "deferred").Return(&domain.TusUpload{
+			ID:             "deferred",
+			UserID:         "u1",
+			ProjectID:      uintPtr(7),
+			UploadType:     domain.UploadTypeProjectUpdate,
+			LengthDeferred: true,
+			Status:         domain.UploadStatusUploading,
+		}, nil).Once()
+		projectRepo.On("GetByID", mock.Anything, uint(7)).Return(nil, apperrors.ErrRecordNotFound).Once()
+
+		err := uc.DeclareUploadLength(context.Background(), "deferred", "u1", 64)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrNotFound, appErr.Code)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	dto "invento-service/internal/dto"
+	apperrors "invento-service/internal/errors"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -36,7 +37,7 @@ func newTusUploadTestDeps(t *testing.T) (*tusUploadUsecase, *MockTusUploadReposi
 	tusManager := upload.NewTusManager(tusStore, tusQueue, nil, cfg, zerolog.Nop())
 	fileManager := storage.NewFileManager(cfg)
 
-	uc := NewTusUploadUsecase(mockTusUploadRepo, mockProjectRepo, nil, tusManager, fileManager, cfg).(*tusUploadUsecase)
+	uc := NewTusUploadUsecase(mockTusUploadRepo, mockProjectRepo, nil, tusManager, fileManager, nil, nil, cfg).(*tusUploadUsecase)
 
 	return uc, mockTusUploadRepo, mockProjectRepo, tusManager
 }
@@ -113,6 +114,8 @@ func TestTusUploadUsecase_InitAndStatus(t *testing.T) {
 			require.NotNil(t, res)
 			assert.NotEmpty(t, res.UploadID)
 			assert.Equal(t, int64(1024), res.Length)
+			assert.Equal(t, domain.UploadStatusPending, res.Status)
+			assert.Equal(t, 0, res.QueuePosition)
 
 			_, _, err = manager.GetUploadStatus(res.UploadID)
 			assert.NoError(t, err)
@@ -131,17 +134,22 @@ func TestTusUploadUsecase_InitAndStatus(t *testing.T) {
 			tusRepo.AssertNotCalled(t, "Create", mock.Anything)
 		})
 
-		t.Run("no upload slot", func(t *testing.T) {
+		t.Run("no upload slot queues the upload", func(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
 			tusRepo.On("GetActiveByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil)
+			tusRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.TusUpload) bool {
+				return u.Status == domain.UploadStatusQueued
+			})).Return(nil).Once()
 			manager.AddToQueue("active")
 
 			res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 256, metadata)
-			require.Error(t, err)
-			assert.Nil(t, res)
-			assert.Contains(t, err.Error(), "slot upload tidak tersedia")
-			tusRepo.AssertNotCalled(t, "Create", mock.Anything)
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, domain.UploadStatusQueued, res.Status)
+			assert.Equal(t, 1, res.QueuePosition)
+			assert.Equal(t, 1, manager.GetQueuePosition(res.UploadID))
+			tusRepo.AssertExpectations(t)
 		})
 	})
 
@@ -172,6 +180,28 @@ func TestTusUploadUsecase_InitAndStatus(t *testing.T) {
 			assert.Equal(t, int64(5), info.Offset)
 		})
 
+		t.Run("GetUploadInfo reports live queue position", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, manager := newTusUploadTestDeps(t)
+			manager.AddToQueue("active-id")
+			manager.AddToQueue("ahead-id")
+			manager.AddToQueue("queued-id")
+			tusRepo.On("GetByID", mock.Anything, "queued-id").Return(&domain.TusUpload{
+				ID:       "queued-id",
+				UserID:   "u1",
+				Status:   domain.UploadStatusQueued,
+				FileSize: 10,
+			}, nil).Once()
+			// Polling keeps the queued upload from expiring.
+			tusRepo.On("UpdateOffset", mock.Anything, "queued-id", int64(0), float64(0), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+			info, err := uc.GetUploadInfo(context.Background(), "queued-id", "u1")
+			require.NoError(t, err)
+			assert.Equal(t, domain.UploadStatusQueued, info.Status)
+			assert.Equal(t, 2, info.QueuePosition)
+			tusRepo.AssertExpectations(t)
+		})
+
 		t.Run("GetUploadInfo not found", func(t *testing.T) {
 			t.Parallel()
 			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
@@ -188,10 +218,28 @@ func TestTusUploadUsecase_InitAndStatus(t *testing.T) {
 			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
 			tusRepo.On("GetByID", mock.Anything, "status-id").Return(&domain.TusUpload{ID: "status-id", UserID: "u1", CurrentOffset: 8, FileSize: 16}, nil).Once()
 
-			offset, length, err := uc.GetUploadStatus(context.Background(), "status-id", "u1")
+			status, err := uc.GetUploadStatus(context.Background(), "status-id", "u1")
 			require.NoError(t, err)
-			assert.Equal(t, int64(8), offset)
-			assert.Equal(t, int64(16), length)
+			assert.Equal(t, int64(8), status.Offset)
+			assert.Equal(t, int64(16), status.Length)
+			assert.False(t, status.ConcatPartial)
+			assert.Empty(t, status.ConcatParts)
+		})
+
+		t.Run("GetUploadStatus keeps a waiting queued upload alive", func(t *testing.T) {
+			t.Parallel()
+			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+			tusRepo.On("GetByID", mock.Anything, "queued-id").Return(&domain.TusUpload{
+				ID: "queued-id", UserID: "u1", FileSize: 16, Status: domain.UploadStatusQueued, ExpiresAt: time.Now().Add(time.Second),
+			}, nil).Once()
+			tusRepo.On("UpdateOffset", mock.Anything, "queued-id", int64(0), float64(0), mock.MatchedBy(func(expiresAt time.Time) bool {
+				return expiresAt.After(time.Now().Add(time.Minute))
+			})).Return(nil).Once()
+
+			status, err := uc.GetUploadStatus(context.Background(), "queued-id", "u1")
+			require.NoError(t, err)
+			assert.True(t, status.ExpiresAt.After(time.Now().Add(time.Minute)))
+			tusRepo.AssertExpectations(t)
 		})
 
 		t.Run("GetUploadStatus not found", func(t *testing.T) {
@@ -199,14 +247,105 @@ func TestTusUploadUsecase_InitAndStatus(t *testing.T) {
 			uc, tusRepo, _, _ := newTusUploadTestDeps(t)
 			tusRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
 
-			offset, length, err := uc.GetUploadStatus(context.Background(), "missing", "u1")
+			status, err := uc.GetUploadStatus(context.Background(), "missing", "u1")
 			require.Error(t, err)
-			assert.Equal(t, int64(0), offset)
-			assert.Equal(t, int64(0), length)
+			assert.Nil(t, status)
 		})
 	})
 }
 
+func TestTusUploadUsecase_InitiateUpload_Quota(t *testing.T) {
+	t.Parallel()
+	metadata := dto.TusUploadInitRequest{NamaProject: "Project Alpha", Kategori: "website", Semester: 2}
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 1024, NewFile: true}).
+			Return(apperrors.NewQuotaExceededError("")).Once()
+
+		res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 1024, metadata)
+		assert.Nil(t, res)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ErrQuotaExceeded, appErr.Code)
+		tusRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		quota.AssertExpectations(t)
+	})
+
+	t.Run("update counts the file it replaces while history keeps it", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, _ := newTusUploadTestDeps(t)
+		uc.config.Upload.ProjectVersionRetention = 2
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		projectRepo.On("GetByID", mock.Anything, uint(7)).Return(&domain.Project{ID: 7, UserID: "u1", PathFile: "/p/b.zip", Ukuran: "4.00KB"}, nil)
+		projectRepo.On("GetVersions", mock.Anything, uint(7)).Return([]domain.ProjectVersion{{PathFile: "/p/b.zip", FileSize: 4096}}, nil)
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 1024}).Return(nil).Once()
+		tusRepo.On("GetActiveByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil)
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusUpload")).Return(nil).Once()
+
+		res, err := uc.InitiateProjectUpdateUpload(context.Background(), 7, "u1", 1024, metadata)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		quota.AssertExpectations(t)
+	})
+
+	t.Run("update does not count the versions it prunes", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, _ := newTusUploadTestDeps(t)
+		uc.config.Upload.ProjectVersionRetention = 2
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		projectRepo.On("GetByID", mock.Anything, uint(7)).Return(&domain.Project{ID: 7, UserID: "u1", PathFile: "/p/c.zip", FileSize: 4096}, nil)
+		projectRepo.On("GetVersions", mock.Anything, uint(7)).Return([]domain.ProjectVersion{
+			{PathFile: "/p/c.zip", FileSize: 4096},
+			{PathFile: "/p/b.zip", FileSize: 2048},
+			{PathFile: "/p/a.zip", FileSize: 1024},
+		}, nil)
+		projectRepo.On("GetSubmittedPaths", mock.Anything, uint(7)).Return([]string{"/p/a.zip"}, nil)
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 1024, FreedBytes: 2048}).Return(nil).Once()
+		tusRepo.On("GetActiveByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil)
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusUpload")).Return(nil).Once()
+
+		res, err := uc.InitiateProjectUpdateUpload(context.Background(), 7, "u1", 1024, metadata)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		quota.AssertExpectations(t)
+	})
+
+	t.Run("update leaves the review until the upload completes", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, projectRepo, _ := newTusUploadTestDeps(t)
+		projectRepo.On("GetByID", mock.Anything, uint(7)).
+			Return(&domain.Project{ID: 7, UserID: "u1", Ukuran: "4.00KB", ReviewStatus: domain.ProjectReviewApproved}, nil)
+		tusRepo.On("GetActiveByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil)
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusUpload")).Return(nil).Once()
+
+		res, err := uc.InitiateProjectUpdateUpload(context.Background(), 7, "u1", 1024, metadata)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		projectRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("concatenation slice adds no file", func(t *testing.T) {
+		t.Parallel()
+		uc, tusRepo, _, _ := newTusUploadTestDeps(t)
+		quota := new(MockQuotaUsecase)
+		uc.quotaUsecase = quota
+		quota.On("CheckUpload", mock.Anything, "u1", dto.QuotaCheck{Bytes: 512}).Return(nil).Once()
+		tusRepo.On("GetByUserID", mock.Anything, "u1").Return([]domain.TusUpload{}, nil)
+		tusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TusUpload")).Return(nil).Once()
+
+		res, err := uc.InitiateUpload(context.Background(), "u1", "u1@mail.com", "mahasiswa", 512, dto.TusUploadInitRequest{ConcatPartial: true})
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		quota.AssertExpectations(t)
+	})
+}
+
 func TestUsecaseTestMocksHelpers(t *testing.T) {
 	t.Parallel()
 	t.Run("uintPtr returns pointer value", func(t *testing.T) {
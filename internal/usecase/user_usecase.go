@@ -34,7 +34,7 @@ type UserUsecase interface {
 	GetProfile(ctx context.Context, userID string) (*dto.ProfileData, error)
 	UpdateProfile(ctx context.Context, userID string, req dto.UpdateProfileRequest, fotoProfil *multipart.FileHeader) (*dto.ProfileData, error)
 	GetUserPermissions(ctx context.Context, userID string) ([]dto.UserPermissionItem, error)
-	DownloadUserFiles(ctx context.Context, ownerUserID string, projectIDs, modulIDs []string) (string, error)
+	DownloadUserFiles(ctx context.Context, ownerUserID string, projectIDs, modulIDs []string) (*storage.Download, error)
 	GetUsersForRole(ctx context.Context, roleID uint) ([]dto.UserListItem, error)
 	BulkAssignRole(ctx context.Context, userIDs []string, roleID uint) error
 	AdminCreateUser(ctx context.Context, req dto.CreateUserRequest) (*dto.CreateUserResponse, error)
@@ -179,7 +179,7 @@ func (uc *userUsecase) GetUserFiles(ctx context.Context, userID string, params d
 	}
 
 	for i := range items {
-		if normalizedPath := uc.pathResolver.ConvertToAPIPath(&items[i].DownloadURL); normalizedPath != nil {
+		if normalizedPath := uc.pathResolver.ConvertToAPIPath(&items[i].DownloadURL, userID); normalizedPath != nil {
 			items[i].DownloadURL = *normalizedPath
 		}
 	}
@@ -267,17 +267,17 @@ func (uc *userUsecase) GetUserPermissions(ctx context.Context, userID string) ([
 	return uc.userHelper.AggregateUserPermissions(permissions), nil
 }
 
-func (uc *userUsecase) DownloadUserFiles(ctx context.Context, ownerUserID string, projectIDs, modulIDs []string) (string, error) {
+func (uc *userUsecase) DownloadUserFiles(ctx context.Context, ownerUserID string, projectIDs, modulIDs []string) (*storage.Download, error) {
 	if err := uc.downloadHelper.ValidateDownloadRequest(projectIDs, modulIDs); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	_, err := uc.userRepo.GetByID(ctx, ownerUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", apperrors.NewNotFoundError("User")
+			return nil, apperrors.NewNotFoundError("User")
 		}
-		return "", apperrors.NewInternalError(err)
+		return nil, apperrors.NewInternalError(err)
 	}
 
 	// Convert string IDs to uint for repository calls
@@ -286,44 +286,43 @@ func (uc *userUsecase) DownloadUserFiles(ctx context.Context, ownerUserID string
 	for _, idStr := range projectIDs {
 		id, err = strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
-			return "", apperrors.NewValidationError("Format project ID tidak valid", err)
+			return nil, apperrors.NewValidationError("Format project ID tidak valid", err)
 		}
 		projectIDsUint = append(projectIDsUint, uint(id))
 	}
 
 	projects, err := uc.projectRepo.GetByIDs(ctx, projectIDsUint, ownerUserID)
 	if err != nil {
-		return "", apperrors.NewInternalError(err)
+		return nil, apperrors.NewInternalError(err)
 	}
 
 	moduls, err := uc.modulRepo.GetByIDs(ctx, modulIDs, ownerUserID)
 	if err != nil {
-		return "", apperrors.NewInternalError(err)
+		return nil, apperrors.NewInternalError(err)
 	}
 
 	if len(projects)+len(moduls) == 0 {
-		return "", apperrors.NewNotFoundError("File")
+		return nil, apperrors.NewNotFoundError("File")
 	}
 
-	filePaths, _, err := uc.downloadHelper.PrepareFilesForDownload(projects, moduls)
+	entries, missing, err := uc.downloadHelper.PrepareFilesForDownload(projects, moduls)
 	if err != nil {
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
-			return "", appErr
+			return nil, appErr
 		}
-		return "", apperrors.NewInternalError(err)
+		return nil, apperrors.NewInternalError(err)
 	}
 
-	zipPath, err := uc.downloadHelper.CreateDownloadZip(filePaths, ownerUserID)
+	download, err := uc.downloadHelper.CreateDownload(ctx, entries, missing, ownerUserID)
 	if err != nil {
-		var appErr *apperrors.AppError
-		if errors.As(err, &appErr) {
-			return "", appErr
+		if errors.Is(err, storage.ErrArchiveTooLarge) {
+			return nil, apperrors.NewPayloadTooLargeError("Ukuran total file melebihi batas download")
 		}
-		return "", apperrors.NewInternalError(err)
+		return nil, apperrors.NewInternalError(err)
 	}
 
-	return zipPath, nil
+	return download, nil
 }
 
 func (uc *userUsecase) GetUsersForRole(ctx context.Context, roleID uint) ([]dto.UserListItem, error) {
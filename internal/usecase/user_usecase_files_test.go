@@ -330,8 +330,8 @@ func TestUserUsecase_DownloadUserFiles_Success(t *testing.T) {
 	result, err := userUC.DownloadUserFiles(context.Background(), ownerUserID, projectIDs, modulIDs)
 
 	assert.NoError(t, err)
-	assert.NotEmpty(t, result)
-	assert.Equal(t, tmpFile.Name(), result)
+	assert.NotNil(t, result)
+	assert.Equal(t, tmpFile.Name(), result.Key)
 
 	mockUserRepo.AssertExpectations(t)
 	mockProjectRepo.AssertExpectations(t)
@@ -7,12 +7,14 @@ import (
 	"invento-service/internal/domain"
 	"invento-service/internal/dto"
 	"invento-service/internal/storage"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -273,6 +275,40 @@ func TestUserUsecase_GetUserFiles_Success(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestUserUsecase_GetUserFiles_SignedLinks(t *testing.T) {
+	t.Parallel()
+	mockUserRepo := new(MockUserRepository)
+
+	cfg := &config.Config{
+		App: config.AppConfig{
+			Env: "development",
+		},
+		Upload: config.UploadConfig{
+			PathDevelopment: "/data/uploads",
+		},
+	}
+	pathResolver := storage.NewPathResolver(cfg)
+	signer := storage.NewURLSigner("test-secret", 15*time.Minute)
+	pathResolver.SetURLSigner(signer)
+
+	userUC := NewUserUsecase(mockUserRepo, new(MockRoleRepository), new(MockProjectRepository), new(MockModulRepository), nil, nil, pathResolver, cfg, zerolog.Nop())
+
+	items := []dto.UserFileItem{
+		{ID: "project-1", NamaFile: "project-file.zip", Kategori: "project", DownloadURL: "/data/uploads/projects/user-1/abc/project-file.zip"},
+	}
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil)
+	mockUserRepo.On("GetUserFiles", mock.Anything, "user-1", "", 1, 10).Return(items, 1, nil)
+
+	result, err := userUC.GetUserFiles(context.Background(), "user-1", dto.UserFilesQueryParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+
+	link, err := url.Parse(result.Items[0].DownloadURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/projects/user-1/abc/project-file.zip", link.Path)
+	assert.NoError(t, signer.Verify("/projects/user-1/abc/project-file.zip", "user-1", link.Query().Get("expires"), link.Query().Get("signature"), time.Now()))
+}
+
 func TestUserUsecase_GetUserFiles_UserNotFound(t *testing.T) {
 	t.Parallel()
 	mockUserRepo := new(MockUserRepository)